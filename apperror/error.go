@@ -0,0 +1,276 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+)
+
+// Code is a machine-readable error code returned to the API clients.
+type Code string
+
+const (
+	// BadRequest means the request is malformed, e.g. the body cannot be parsed.
+	BadRequest Code = "bad_request"
+	// InvalidID means the ID in the request path is malformed.
+	InvalidID Code = "invalid_id"
+	// Unauthorized means the request requires the authentication.
+	Unauthorized Code = "unauthorized"
+	// InvalidCredentials means the login or the password is wrong.
+	InvalidCredentials Code = "invalid_credentials"
+	// Forbidden means the user is not allowed to perform the request.
+	Forbidden Code = "forbidden"
+	// NotFound means the requested record does not exist.
+	NotFound Code = "not_found"
+	// Conflict means the request conflicts with the existing records, e.g. a unique value is already taken.
+	Conflict Code = "conflict"
+	// ValidationFailed means one or more fields of the request are invalid.
+	ValidationFailed Code = "validation_failed"
+	// InvalidReference means the request refers to a record which does not exist.
+	InvalidReference Code = "invalid_reference"
+	// WrongPassword means the current password given for the password change is wrong.
+	WrongPassword Code = "wrong_password"
+	// InvalidState means the record is in a state which does not allow the requested operation.
+	InvalidState Code = "invalid_state"
+	// PreconditionFailed means the record was changed since the client fetched it, i.e. the If-Match header does not match.
+	PreconditionFailed Code = "precondition_failed"
+	// PreconditionRequired means the request must be conditional, i.e. the If-Match header is missing.
+	PreconditionRequired Code = "precondition_required"
+	// FileTooLarge means the uploaded file exceeds the size limit.
+	FileTooLarge Code = "file_too_large"
+	// UnsupportedMediaType means the type of the uploaded file is not accepted.
+	UnsupportedMediaType Code = "unsupported_media_type"
+	// TooManyRequests means the client exceeded the rate limit.
+	TooManyRequests Code = "too_many_requests"
+	// Unavailable means the request could not be completed in time.
+	Unavailable Code = "service_unavailable"
+	// Internal means an unexpected error occurred.
+	Internal Code = "internal_error"
+)
+
+// Error is a typed domain error. It carries the HTTP status, the code and the message returned to the client,
+// while the wrapped error is only logged.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Details []*FieldError
+	Err     error
+}
+
+// FieldError describes the validation error of a single field.
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Tag     string `json:"tag" example:"email"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message" example:"email must be a valid email address"`
+}
+
+// Response defines the body of an error response which is shared by all endpoints.
+type Response struct {
+	Code    Code          `json:"code" example:"not_found"`
+	Message string        `json:"message" example:"record not found"`
+	Details []*FieldError `json:"details,omitempty"`
+}
+
+// New is constructor.
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Wrap creates a new error which wraps the given error.
+func Wrap(err error, status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message, Err: err}
+}
+
+// Error returns the message and the wrapped error.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Response creates the body of the error response.
+func (e *Error) Response() *Response {
+	return &Response{Code: e.Code, Message: e.Message, Details: e.Details}
+}
+
+// NewInvalidID returns the error for a malformed ID in the request path.
+func NewInvalidID(id string) *Error {
+	return New(http.StatusBadRequest, InvalidID, fmt.Sprintf("invalid id: %s", id))
+}
+
+// NewInvalidReference returns the error for a field referring to a record which does not exist.
+// Errors other than gorm.ErrRecordNotFound are returned unchanged.
+func NewInvalidReference(field string, err error) error {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	e := Wrap(err, http.StatusUnprocessableEntity, InvalidReference, fmt.Sprintf("%s refers to a record which does not exist", field))
+	e.Details = []*FieldError{{Field: field, Tag: "exists", Message: e.Message}}
+	return e
+}
+
+// NewUnauthorized returns the error for the request which requires the authentication.
+func NewUnauthorized() *Error {
+	return New(http.StatusUnauthorized, Unauthorized, "authentication is required")
+}
+
+// NewForbidden returns the error for the request which the user is not allowed to perform.
+func NewForbidden() *Error {
+	return New(http.StatusForbidden, Forbidden, "access denied")
+}
+
+// NewInvalidCredentials returns the error for a failed login.
+func NewInvalidCredentials(err error) *Error {
+	return Wrap(err, http.StatusUnauthorized, InvalidCredentials, "invalid login or password")
+}
+
+// NewWrongPassword returns the error for a wrong current password given for the password change.
+func NewWrongPassword(err error) *Error {
+	return Wrap(err, http.StatusUnprocessableEntity, WrongPassword, "the old password is wrong")
+}
+
+// NewReferenced returns the error for the record which cannot be deleted permanently while other records refer to it.
+func NewReferenced() *Error {
+	return New(http.StatusConflict, Conflict, "record is referenced by other records")
+}
+
+// NewDuplicate returns the error for the request which repeats a pending one, e.g. a second booking with the same phone.
+func NewDuplicate(message string) *Error {
+	return New(http.StatusConflict, Conflict, message)
+}
+
+// NewInvalidState returns the error for the operation which is not allowed in the current state of the record.
+func NewInvalidState(message string) *Error {
+	return New(http.StatusConflict, InvalidState, message)
+}
+
+// NewPreconditionFailed returns the error for the record which was changed since the client fetched it.
+func NewPreconditionFailed() *Error {
+	return New(http.StatusPreconditionFailed, PreconditionFailed, "the record has been changed by another user")
+}
+
+// NewPreconditionRequired returns the error for the modification request without the If-Match header.
+func NewPreconditionRequired() *Error {
+	return New(http.StatusPreconditionRequired, PreconditionRequired, "the If-Match header is required")
+}
+
+// NewTooManyRequests returns the error for the request which exceeds the rate limit.
+func NewTooManyRequests() *Error {
+	return New(http.StatusTooManyRequests, TooManyRequests, "too many requests, try again later")
+}
+
+// NewFileTooLarge returns the error for the uploaded file which exceeds the size limit in bytes.
+func NewFileTooLarge(limit int64) *Error {
+	e := New(http.StatusRequestEntityTooLarge, FileTooLarge, fmt.Sprintf("the file must not be larger than %d bytes", limit))
+	e.Details = []*FieldError{{Field: "file", Tag: "max", Param: fmt.Sprint(limit), Message: e.Message}}
+	return e
+}
+
+// NewUnsupportedMediaType returns the error for the uploaded file of the type which is not accepted.
+func NewUnsupportedMediaType(contentType string) *Error {
+	e := New(http.StatusUnsupportedMediaType, UnsupportedMediaType, fmt.Sprintf("the file type %s is not allowed", contentType))
+	e.Details = []*FieldError{{Field: "file", Tag: "mime", Message: e.Message}}
+	return e
+}
+
+// FieldTranslator returns the message of the validation error of a field in the language of the user.
+type FieldTranslator func(fe validator.FieldError) string
+
+// NewValidationError returns the error for the fields which failed the validation.
+// The messages are translated by the given translator, or left untranslated if it is nil.
+func NewValidationError(errs validator.ValidationErrors, translate FieldTranslator) *Error {
+	e := Wrap(errs, http.StatusUnprocessableEntity, ValidationFailed, "validation failed")
+	for _, fe := range errs {
+		message := fmt.Sprintf("%s failed on the '%s' tag", fieldName(fe), fe.Tag())
+		if translate != nil {
+			message = translate(fe)
+		}
+		e.Details = append(e.Details, &FieldError{
+			Field:   fieldName(fe),
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: message,
+		})
+	}
+	return e
+}
+
+// fieldName returns the path of the field without the name of the top-level struct, e.g. "departments[0]".
+func fieldName(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+// From converts any error to Error.
+// Known errors of GORM, the validator, echo and the context are mapped to the corresponding status,
+// all other errors are treated as internal errors and their messages are not exposed.
+// The validation messages are translated by the given translator.
+func From(err error, translate FieldTranslator) *Error {
+	var appErr *Error
+	var validationErrs validator.ValidationErrors
+	var httpErr *echo.HTTPError
+
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.As(err, &validationErrs):
+		return NewValidationError(validationErrs, translate)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return Wrap(err, http.StatusNotFound, NotFound, "record not found")
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Wrap(err, http.StatusConflict, Conflict, "record with the same unique value already exists")
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return Wrap(err, http.StatusConflict, Conflict, "record is referenced by other records")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return Wrap(err, http.StatusServiceUnavailable, Unavailable, "request could not be completed in time")
+	case errors.As(err, &httpErr):
+		return Wrap(err, httpErr.Code, codeOf(httpErr.Code), fmt.Sprint(httpErr.Message))
+	default:
+		return Wrap(err, http.StatusInternalServerError, Internal, http.StatusText(http.StatusInternalServerError))
+	}
+}
+
+func codeOf(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusPreconditionFailed:
+		return PreconditionFailed
+	case http.StatusPreconditionRequired:
+		return PreconditionRequired
+	case http.StatusUnprocessableEntity:
+		return ValidationFailed
+	case http.StatusTooManyRequests:
+		return TooManyRequests
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return Internal
+	}
+	return Code(strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"))
+}
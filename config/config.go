@@ -4,11 +4,13 @@ import (
 	"embed"
 	"flag"
 	"fmt"
+	"github.com/creasty/defaults"
 	"go.uber.org/zap"
 	"gopkg.in/natefinch/lumberjack.v2"
 	"gopkg.in/yaml.v3"
 	"gorm.io/gorm/logger"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -20,6 +22,8 @@ type Config struct {
 		Username  string
 		Password  string
 		Migration bool `default:"false"`
+		// RequestTimeout is the deadline of each database statement run for a request, 0 disables it.
+		RequestTimeout time.Duration `yaml:"request_timeout" default:"0"`
 	}
 	Redis struct {
 		Enabled            bool `default:"false"`
@@ -32,6 +36,68 @@ type Config struct {
 		SecurityEnabled bool `yaml:"security_enabled" default:"false"`
 		CorsEnabled     bool `yaml:"cors_enabled" default:"false"`
 		CsrfEnabled     bool `yaml:"csrf_enabled" default:"false"`
+		// IfMatchRequired rejects the modification requests without the If-Match header.
+		IfMatchRequired bool `yaml:"if_match_required" default:"false"`
+	}
+	Retention struct {
+		Enabled bool `default:"false"`
+		// Period is how long the soft-deleted records are kept before they are purged.
+		Period time.Duration `default:"8760h"`
+		// Interval is how often the expired soft-deleted records are purged.
+		Interval time.Duration `default:"24h"`
+	}
+	Portal struct {
+		// OneTimeCodeTTL is how long the one-time login code sent to a client is valid.
+		OneTimeCodeTTL time.Duration `yaml:"one_time_code_ttl" default:"10m"`
+		// OneTimeCodeAttempts is the number of wrong codes after which the one-time code is discarded.
+		OneTimeCodeAttempts int `yaml:"one_time_code_attempts" default:"5"`
+	}
+	Booking struct {
+		// SlotDuration is the length of a visit booked online.
+		SlotDuration time.Duration `yaml:"slot_duration" default:"30m"`
+		// DayStart and DayEnd are the working hours of the doctors as offsets from midnight.
+		DayStart time.Duration `yaml:"day_start" default:"9h"`
+		DayEnd   time.Duration `yaml:"day_end" default:"18h"`
+		// Horizon is how far in advance the visits can be booked.
+		Horizon time.Duration `default:"336h"`
+	}
+	Lead struct {
+		// DefaultAssigneeID is the user the leads submitted by the public form are assigned to, 0 leaves them unassigned.
+		DefaultAssigneeID uint `yaml:"default_assignee_id" default:"0"`
+		// MinFillTime is the shortest time a human takes to fill in the form, faster submissions are rejected as spam.
+		MinFillTime time.Duration `yaml:"min_fill_time" default:"3s"`
+		// DuplicateWindow is the period in which another lead with the same phone or e-mail is rejected as a duplicate.
+		DuplicateWindow time.Duration `yaml:"duplicate_window" default:"24h"`
+		// FormSecret is the key signing the form tokens. If it is empty, a random key is generated at startup,
+		// so the tokens do not survive a restart and are not shared by several instances.
+		FormSecret string `yaml:"form_secret"`
+		// FormTokenTTL is how long the form token is valid after it has been issued.
+		FormTokenTTL time.Duration `yaml:"form_token_ttl" default:"2h"`
+		Captcha      struct {
+			Enabled bool `default:"false"`
+			// VerifyURL is the siteverify endpoint of the captcha provider, e.g. reCAPTCHA, hCaptcha or Turnstile.
+			VerifyURL string `yaml:"verify_url"`
+			Secret    string
+		}
+	}
+	Attachment struct {
+		// Storage keeps the attached files, only "local" is supported for now.
+		Storage string `default:"local"`
+		// Path is the directory of the local storage which keeps the attached files.
+		Path string `default:"attachments"`
+		// MaxSize is the largest file accepted in bytes.
+		MaxSize int64 `yaml:"max_size" default:"20971520"`
+		// AllowedTypes are the accepted MIME types, which are detected from the content of the file.
+		AllowedTypes []string `yaml:"allowed_types"`
+	}
+	RateLimit struct {
+		Enabled bool `default:"false"`
+		// Store keeps the token buckets, either "memory" or "redis". The redis store uses the Redis settings.
+		Store string `default:"memory"`
+		// TrustProxy takes the client IP address from the X-Forwarded-For header set by the reverse proxy.
+		TrustProxy bool `yaml:"trust_proxy" default:"false"`
+		// Groups defines the limit of each route group, e.g. "auth" and "public".
+		Groups map[string]RateLimitRule
 	}
 	StaticContents struct {
 		Enabled bool `default:"false"`
@@ -40,6 +106,15 @@ type Config struct {
 		Enabled bool `default:"false"`
 		Path    string
 	}
+	Tracing struct {
+		Enabled     bool `default:"false"`
+		Exporter    string
+		Endpoint    string
+		Insecure    bool    `default:"false"`
+		FilePath    string  `yaml:"file_path"`
+		SampleRatio float64 `yaml:"sample_ratio" default:"1"`
+		ServiceName string  `yaml:"service_name"`
+	}
 	Logger struct {
 		GormConfig logger.Config     `json:"gorm_config" yaml:"gorm_config"`
 		ZapConfig  zap.Config        `json:"zap_config" yaml:"zap_config"`
@@ -47,6 +122,13 @@ type Config struct {
 	}
 }
 
+// RateLimitRule defines a token bucket which allows Limit requests per Period, including bursts of up to Limit requests.
+type RateLimitRule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// LoadConfig reads the configuration of the environment, the settings missing from the file take the values of their default tags.
 func LoadConfig(configFile embed.FS) *Config {
 	var env *string
 	if value := os.Getenv("VET_CLINIC_ENV"); value != "" {
@@ -63,6 +145,10 @@ func LoadConfig(configFile embed.FS) *Config {
 	}
 
 	config := &Config{}
+	if err := defaults.Set(config); err != nil {
+		fmt.Printf("Failed to set the default configuration: %s", err)
+		os.Exit(ErrExitStatus)
+	}
 	if err := yaml.Unmarshal(file, config); err != nil {
 		fmt.Printf("Failed to read %s.yml: %s", *env, err)
 		os.Exit(ErrExitStatus)
@@ -27,26 +27,152 @@ const (
 	Users = "/users"
 	// UsersID represents the path to get user data using the id.
 	UsersID = Users + "/:id"
+	// UsersIDRestore represents the path to restore the soft-deleted user using the id.
+	UsersIDRestore = UsersID + "/restore"
+	// UsersIDPurge represents the path to permanently delete user data using the id.
+	UsersIDPurge = UsersID + "/purge"
+	// UsersIDWorkingHours represents the path to get and set the working hours of the user using the id.
+	UsersIDWorkingHours = UsersID + "/working-hours"
 	// Departments represents a group of department management paths.
 	Departments = "/departments"
 	// DepartmentsID represents the path to get department data using the id.
 	DepartmentsID = Departments + "/:id"
+	// Resources represents a group of resource management paths.
+	Resources = "/resources"
+	// ResourcesID represents the path to get resource data using the id.
+	ResourcesID = Resources + "/:id"
+	// ResourcesIDReservations represents the path to get the reservations of the resource using the id.
+	ResourcesIDReservations = ResourcesID + "/reservations"
 	// Categories represents a group of category management paths.
 	Categories = "/categories"
 	// CategoriesID represents the path to get category data using the id.
 	CategoriesID = Categories + "/:id"
+	// CategoriesTree represents the path to get the category tree.
+	CategoriesTree = Categories + "/tree"
 	// Services represents a group of service management paths.
 	Services = "/services"
 	// ServicesID represents the path to get service data using the id.
 	ServicesID = Services + "/:id"
+	// ServicesIDConsumables represents the path to get and set the consumables of the service using the id.
+	ServicesIDConsumables = ServicesID + "/consumables"
+	// ServicesIDResources represents the path to get and set the resources required by the service using the id.
+	ServicesIDResources = ServicesID + Resources
+	// ServicesIDPrices represents the path to get and add the prices of the service using the id.
+	ServicesIDPrices = ServicesID + "/prices"
+	// ServicesIDPrice represents the path to get the price of the service as of a date using the id.
+	ServicesIDPrice = ServicesID + "/price"
+	// ServicePricesID represents the path to delete the price of a service using the id.
+	ServicePricesID = "/service-prices/:id"
+	// PriceList represents the path to get the price list.
+	PriceList = "/price-list"
+	// PriceListExport represents the path to export the price list as a CSV file.
+	PriceListExport = PriceList + "/export"
 	// Clients represents a group of client management paths.
 	Clients = "/clients"
 	// ClientsID represents the path to get client data using the id.
 	ClientsID = Clients + "/:id"
+	// ClientsIDRestore represents the path to restore the soft-deleted client using the id.
+	ClientsIDRestore = ClientsID + "/restore"
+	// ClientsIDPurge represents the path to permanently delete client data using the id.
+	ClientsIDPurge = ClientsID + "/purge"
+	// ClientsIDAccount represents the path to manage the portal account of the client using the id.
+	ClientsIDAccount = ClientsID + "/account"
+	// ClientsIDDuplicates represents the path to find the likely duplicates of the client using the id.
+	ClientsIDDuplicates = ClientsID + "/duplicates"
+	// ClientsIDMerge represents the path to merge a duplicate client into the client using the id.
+	ClientsIDMerge = ClientsID + "/merge"
+	// ClientsIDMerges represents the path to get the merges of the client using the id.
+	ClientsIDMerges = ClientsID + "/merges"
+	// ClientsIDDiscount represents the path to get the discount of the client using the id.
+	ClientsIDDiscount = ClientsID + "/discount"
+	// ClientsIDBalance represents the path to get the balance of the client using the id.
+	ClientsIDBalance = ClientsID + "/balance"
+	// ClientsIDBalanceTopUp represents the path to top up the balance of the client using the id.
+	ClientsIDBalanceTopUp = ClientsIDBalance + "/top-up"
+	// ClientsIDBalanceRefund represents the path to refund the balance of the client using the id.
+	ClientsIDBalanceRefund = ClientsIDBalance + "/refund"
+	// ClientsIDInvoices represents the path to get the invoices of the client using the id.
+	ClientsIDInvoices = ClientsID + Invoices
+	// DiscountTiers represents a group of discount tier management paths.
+	DiscountTiers = "/discount-tiers"
+	// DiscountTiersID represents the path to get discount tier data using the id.
+	DiscountTiersID = DiscountTiers + "/:id"
+	// PromoCodes represents a group of promo code management paths.
+	PromoCodes = "/promo-codes"
+	// PromoCodesID represents the path to get promo code data using the id.
+	PromoCodesID = PromoCodes + "/:id"
+	// Invoices represents a group of invoice management paths.
+	Invoices = "/invoices"
+	// InvoicesID represents the path to get invoice data using the id.
+	InvoicesID = Invoices + "/:id"
+	// Species represents a group of species management paths.
+	Species = "/species"
+	// SpeciesID represents the path to get species data using the id.
+	SpeciesID = Species + "/:id"
+	// Breeds represents a group of breed management paths.
+	Breeds = "/breeds"
+	// BreedsID represents the path to get breed data using the id.
+	BreedsID = Breeds + "/:id"
+	// BreedsUnverified represents the path to get the breeds entered as free text for the pets.
+	BreedsUnverified = Breeds + "/unverified"
+	// Medications represents a group of medication management paths.
+	Medications = "/medications"
+	// MedicationsID represents the path to get medication data using the id.
+	MedicationsID = Medications + "/:id"
+	// Prescriptions represents a group of prescription management paths.
+	Prescriptions = "/prescriptions"
+	// PrescriptionsID represents the path to get prescription data using the id.
+	PrescriptionsID = Prescriptions + "/:id"
+	// PrescriptionsIDCancel represents the path to cancel the prescription using the id.
+	PrescriptionsIDCancel = PrescriptionsID + "/cancel"
+	// PrescriptionsIDPrint represents the path to get the printable prescription using the id.
+	PrescriptionsIDPrint = PrescriptionsID + "/print"
+	// Attachments represents a group of attachment management paths.
+	Attachments = "/attachments"
+	// AttachmentsID represents the path to get attachment data using the id.
+	AttachmentsID = Attachments + "/:id"
+	// AttachmentsIDDownload represents the path to download the attached file using the id.
+	AttachmentsIDDownload = AttachmentsID + "/download"
+	// LabResults represents a group of lab result management paths.
+	LabResults = "/lab-results"
+	// LabResultsID represents the path to get lab result data using the id.
+	LabResultsID = LabResults + "/:id"
+	// Products represents a group of product management paths.
+	Products = "/products"
+	// ProductsID represents the path to get product data using the id.
+	ProductsID = Products + "/:id"
+	// ProductsLowStock represents the path to get the products low in stock.
+	ProductsLowStock = Products + "/low-stock"
+	// ProductsIDBatches represents the path to get and receive the batches of the product using the id.
+	ProductsIDBatches = ProductsID + Batches
+	// ProductsIDMovements represents the path to get the stock movements of the product using the id.
+	ProductsIDMovements = ProductsID + "/movements"
+	// Batches represents a group of product batch management paths.
+	Batches = "/batches"
+	// BatchesExpiring represents the path to get the batches expiring soon.
+	BatchesExpiring = Batches + "/expiring"
+	// BatchesIDWriteOff represents the path to write off a quantity of the batch using the id.
+	BatchesIDWriteOff = Batches + "/:id/write-off"
 	// Pets represents a group of pet management paths.
 	Pets = "/pets"
 	// PetsID represents the path to get pet data using the id.
 	PetsID = Pets + "/:id"
+	// PetsIDRestore represents the path to restore the soft-deleted pet using the id.
+	PetsIDRestore = PetsID + "/restore"
+	// PetsIDPurge represents the path to permanently delete pet data using the id.
+	PetsIDPurge = PetsID + "/purge"
+	// PetsIDWeights represents the path to record and get the weight history of the pet using the id.
+	PetsIDWeights = PetsID + "/weights"
+	// PetsIDWeightsTrend represents the path to get the weight trend of the pet using the id.
+	PetsIDWeightsTrend = PetsIDWeights + "/trend"
+	// PetsIDPrescriptions represents the path to get the prescriptions of the pet using the id.
+	PetsIDPrescriptions = PetsID + Prescriptions
+	// PetsIDMedications represents the path to get the current medications of the pet using the id.
+	PetsIDMedications = PetsID + Medications
+	// PetsIDAttachments represents the path to get and upload the files attached to the pet using the id.
+	PetsIDAttachments = PetsID + Attachments
+	// PetsIDLabResults represents the path to get the lab results of the pet using the id.
+	PetsIDLabResults = PetsID + LabResults
 	// Records represents a group of record management paths.
 	Records = "/records"
 	// RecordsID represents the path to get record data using the id.
@@ -55,6 +181,24 @@ const (
 	Visits = "/visits"
 	// VisitsID represents the path to get visit data using the id.
 	VisitsID = Visits + "/:id"
+	// VisitsIDRestore represents the path to restore the soft-deleted visit using the id.
+	VisitsIDRestore = VisitsID + "/restore"
+	// VisitsIDPurge represents the path to permanently delete visit data using the id.
+	VisitsIDPurge = VisitsID + "/purge"
+	// VisitsIDComplete represents the path to complete the visit using the id.
+	VisitsIDComplete = VisitsID + "/complete"
+	// VisitsIDInvoice represents the path to invoice the visit using the id.
+	VisitsIDInvoice = VisitsID + "/invoice"
+	// VisitsIDAttachments represents the path to get and upload the files attached to the visit using the id.
+	VisitsIDAttachments = VisitsID + Attachments
+	// VisitsIDLabResults represents the path to get the lab results of the visit using the id.
+	VisitsIDLabResults = VisitsID + LabResults
+	// Waitlist represents a group of waitlist management paths.
+	Waitlist = "/waitlist"
+	// WaitlistID represents the path to get waitlist entry data using the id.
+	WaitlistID = Waitlist + "/:id"
+	// WaitlistMatches represents the path to get the freed slots which suit the waiting entries.
+	WaitlistMatches = Waitlist + "/matches"
 	// Leads represents a group of lead management paths.
 	Leads = "/leads"
 	// LeadsID represents the path to get lead data using the id.
@@ -63,6 +207,40 @@ const (
 	LeadsTypes = Leads + "/types"
 	// LeadsStatuses represents the path to get the list of lead statuses.
 	LeadsStatuses = Leads + "/statuses"
+	// LeadsForm represents the path to get the data for showing the public lead form.
+	LeadsForm = Leads + "/form"
+	// Booking represents a group of paths of the public online booking.
+	Booking = "/booking"
+	// BookingDepartments represents the path to get the departments for online booking.
+	BookingDepartments = Booking + Departments
+	// BookingSlots represents the path to get the free slots for online booking.
+	BookingSlots = Booking + "/slots"
+	// BookingVisits represents the path to book a visit online.
+	BookingVisits = Booking + Visits
+	// Search represents the path to search clients, pets, users and leads.
+	Search = "/search"
+	// Portal represents a group of paths of the client portal.
+	Portal = "/portal"
+	// PortalLogin represents the path for the client to log in to the portal with the password.
+	PortalLogin = Portal + Login
+	// PortalLoginCode represents the path for the client to log in to the portal with a one-time code.
+	PortalLoginCode = PortalLogin + "/code"
+	// PortalCode represents the path for the client to request a one-time login code.
+	PortalCode = Portal + "/code"
+	// PortalLogout represents the path for the client to log out of the portal.
+	PortalLogout = Portal + Logout
+	// PortalProfile represents the path to get the data of the logged-in client.
+	PortalProfile = Portal + Profile
+	// PortalPets represents the path to get the pets of the logged-in client.
+	PortalPets = Portal + Pets
+	// PortalVisits represents a group of paths for managing the visits of the logged-in client.
+	PortalVisits = Portal + Visits
+	// PortalVisitsIDCancel represents the path for the logged-in client to cancel the visit using the id.
+	PortalVisitsIDCancel = PortalVisits + "/:id/cancel"
+	// PortalInvoices represents the path to get the invoices of the logged-in client.
+	PortalInvoices = Portal + Invoices
+	// PortalPetsIDRecord represents the path to get the medical record of the pet of the logged-in client using the id.
+	PortalPetsIDRecord = PortalPets + "/:id/record"
 )
 
 // APIv1 represents the group of API v1.
@@ -85,26 +263,148 @@ const (
 	APIv1Users = APIv1 + Users
 	// APIv1UsersID represents the API v1 to get user data using id.
 	APIv1UsersID = APIv1 + UsersID
+	// APIv1UsersIDRestore represents the API v1 to restore the soft-deleted user using the id.
+	APIv1UsersIDRestore = APIv1 + UsersIDRestore
+	// APIv1UsersIDPurge represents the API v1 to permanently delete user data using the id.
+	APIv1UsersIDPurge = APIv1 + UsersIDPurge
+	// APIv1UsersIDWorkingHours represents the API v1 to get and set the working hours of the user using the id.
+	APIv1UsersIDWorkingHours = APIv1 + UsersIDWorkingHours
 	// APIv1Departments represents a group of department management API v1.
 	APIv1Departments = APIv1 + Departments
 	// APIv1DepartmentsID represents the API v1 to get department data using the id.
 	APIv1DepartmentsID = APIv1 + DepartmentsID
+	// APIv1Resources represents a group of resource management API v1.
+	APIv1Resources = APIv1 + Resources
+	// APIv1ResourcesID represents the API v1 to get resource data using the id.
+	APIv1ResourcesID = APIv1 + ResourcesID
+	// APIv1ResourcesIDReservations represents the API v1 to get the reservations of the resource using the id.
+	APIv1ResourcesIDReservations = APIv1 + ResourcesIDReservations
 	// APIv1Categories represents a group of category management API v1.
 	APIv1Categories = APIv1 + Categories
 	// APIv1CategoriesID represents the API v1 to get category data using the id.
 	APIv1CategoriesID = APIv1 + CategoriesID
+	// APIv1CategoriesTree represents the API v1 to get the category tree.
+	APIv1CategoriesTree = APIv1 + CategoriesTree
 	// APIv1Services represents a group of service management API v1.
 	APIv1Services = APIv1 + Services
 	// APIv1ServicesID represents the API v1 to get service data using the id.
 	APIv1ServicesID = APIv1 + ServicesID
+	// APIv1ServicesIDConsumables represents the API v1 to get and set the consumables of the service using the id.
+	APIv1ServicesIDConsumables = APIv1 + ServicesIDConsumables
+	// APIv1ServicesIDResources represents the API v1 to get and set the resources required by the service using the id.
+	APIv1ServicesIDResources = APIv1 + ServicesIDResources
+	// APIv1ServicesIDPrices represents the API v1 to get and add the prices of the service using the id.
+	APIv1ServicesIDPrices = APIv1 + ServicesIDPrices
+	// APIv1ServicesIDPrice represents the API v1 to get the price of the service as of a date using the id.
+	APIv1ServicesIDPrice = APIv1 + ServicesIDPrice
+	// APIv1ServicePricesID represents the API v1 to delete the price of a service using the id.
+	APIv1ServicePricesID = APIv1 + ServicePricesID
+	// APIv1PriceList represents the API v1 to get the price list.
+	APIv1PriceList = APIv1 + PriceList
+	// APIv1PriceListExport represents the API v1 to export the price list as a CSV file.
+	APIv1PriceListExport = APIv1 + PriceListExport
 	// APIv1Clients represents a group of client management API v1.
 	APIv1Clients = APIv1 + Clients
 	// APIv1ClientsID represents the API v1 to get client data using the id.
 	APIv1ClientsID = APIv1 + ClientsID
+	// APIv1ClientsIDRestore represents the API v1 to restore the soft-deleted client using the id.
+	APIv1ClientsIDRestore = APIv1 + ClientsIDRestore
+	// APIv1ClientsIDPurge represents the API v1 to permanently delete client data using the id.
+	APIv1ClientsIDPurge = APIv1 + ClientsIDPurge
+	// APIv1ClientsIDAccount represents the API v1 to manage the portal account of the client using the id.
+	APIv1ClientsIDAccount = APIv1 + ClientsIDAccount
+	// APIv1ClientsIDDuplicates represents the API v1 to find the likely duplicates of the client using the id.
+	APIv1ClientsIDDuplicates = APIv1 + ClientsIDDuplicates
+	// APIv1ClientsIDMerge represents the API v1 to merge a duplicate client into the client using the id.
+	APIv1ClientsIDMerge = APIv1 + ClientsIDMerge
+	// APIv1ClientsIDMerges represents the API v1 to get the merges of the client using the id.
+	APIv1ClientsIDMerges = APIv1 + ClientsIDMerges
+	// APIv1ClientsIDDiscount represents the API v1 to get the discount of the client using the id.
+	APIv1ClientsIDDiscount = APIv1 + ClientsIDDiscount
+	// APIv1ClientsIDBalance represents the API v1 to get the balance of the client using the id.
+	APIv1ClientsIDBalance = APIv1 + ClientsIDBalance
+	// APIv1ClientsIDBalanceTopUp represents the API v1 to top up the balance of the client using the id.
+	APIv1ClientsIDBalanceTopUp = APIv1 + ClientsIDBalanceTopUp
+	// APIv1ClientsIDBalanceRefund represents the API v1 to refund the balance of the client using the id.
+	APIv1ClientsIDBalanceRefund = APIv1 + ClientsIDBalanceRefund
+	// APIv1ClientsIDInvoices represents the API v1 to get the invoices of the client using the id.
+	APIv1ClientsIDInvoices = APIv1 + ClientsIDInvoices
+	// APIv1DiscountTiers represents a group of discount tier management API v1.
+	APIv1DiscountTiers = APIv1 + DiscountTiers
+	// APIv1DiscountTiersID represents the API v1 to get discount tier data using the id.
+	APIv1DiscountTiersID = APIv1 + DiscountTiersID
+	// APIv1PromoCodes represents a group of promo code management API v1.
+	APIv1PromoCodes = APIv1 + PromoCodes
+	// APIv1PromoCodesID represents the API v1 to get promo code data using the id.
+	APIv1PromoCodesID = APIv1 + PromoCodesID
+	// APIv1InvoicesID represents the API v1 to get invoice data using the id.
+	APIv1InvoicesID = APIv1 + InvoicesID
+	// APIv1Species represents a group of species management API v1.
+	APIv1Species = APIv1 + Species
+	// APIv1SpeciesID represents the API v1 to get species data using the id.
+	APIv1SpeciesID = APIv1 + SpeciesID
+	// APIv1Breeds represents a group of breed management API v1.
+	APIv1Breeds = APIv1 + Breeds
+	// APIv1BreedsID represents the API v1 to get breed data using the id.
+	APIv1BreedsID = APIv1 + BreedsID
+	// APIv1BreedsUnverified represents the API v1 to get the breeds entered as free text for the pets.
+	APIv1BreedsUnverified = APIv1 + BreedsUnverified
+	// APIv1Medications represents a group of medication management API v1.
+	APIv1Medications = APIv1 + Medications
+	// APIv1MedicationsID represents the API v1 to get medication data using the id.
+	APIv1MedicationsID = APIv1 + MedicationsID
+	// APIv1Prescriptions represents a group of prescription management API v1.
+	APIv1Prescriptions = APIv1 + Prescriptions
+	// APIv1PrescriptionsID represents the API v1 to get prescription data using the id.
+	APIv1PrescriptionsID = APIv1 + PrescriptionsID
+	// APIv1PrescriptionsIDCancel represents the API v1 to cancel the prescription using the id.
+	APIv1PrescriptionsIDCancel = APIv1 + PrescriptionsIDCancel
+	// APIv1PrescriptionsIDPrint represents the API v1 to get the printable prescription using the id.
+	APIv1PrescriptionsIDPrint = APIv1 + PrescriptionsIDPrint
+	// APIv1Attachments represents a group of attachment management API v1.
+	APIv1Attachments = APIv1 + Attachments
+	// APIv1AttachmentsID represents the API v1 to get attachment data using the id.
+	APIv1AttachmentsID = APIv1 + AttachmentsID
+	// APIv1AttachmentsIDDownload represents the API v1 to download the attached file using the id.
+	APIv1AttachmentsIDDownload = APIv1 + AttachmentsIDDownload
+	// APIv1LabResults represents a group of lab result management API v1.
+	APIv1LabResults = APIv1 + LabResults
+	// APIv1LabResultsID represents the API v1 to get lab result data using the id.
+	APIv1LabResultsID = APIv1 + LabResultsID
+	// APIv1Products represents a group of product management API v1.
+	APIv1Products = APIv1 + Products
+	// APIv1ProductsID represents the API v1 to get product data using the id.
+	APIv1ProductsID = APIv1 + ProductsID
+	// APIv1ProductsLowStock represents the API v1 to get the products low in stock.
+	APIv1ProductsLowStock = APIv1 + ProductsLowStock
+	// APIv1ProductsIDBatches represents the API v1 to get and receive the batches of the product using the id.
+	APIv1ProductsIDBatches = APIv1 + ProductsIDBatches
+	// APIv1ProductsIDMovements represents the API v1 to get the stock movements of the product using the id.
+	APIv1ProductsIDMovements = APIv1 + ProductsIDMovements
+	// APIv1BatchesExpiring represents the API v1 to get the batches expiring soon.
+	APIv1BatchesExpiring = APIv1 + BatchesExpiring
+	// APIv1BatchesIDWriteOff represents the API v1 to write off a quantity of the batch using the id.
+	APIv1BatchesIDWriteOff = APIv1 + BatchesIDWriteOff
 	// APIv1Pets represents a group of pet management API v1.
 	APIv1Pets = APIv1 + Pets
 	// APIv1PetsID represents the API v1 to get pet data using the id.
 	APIv1PetsID = APIv1 + PetsID
+	// APIv1PetsIDRestore represents the API v1 to restore the soft-deleted pet using the id.
+	APIv1PetsIDRestore = APIv1 + PetsIDRestore
+	// APIv1PetsIDPurge represents the API v1 to permanently delete pet data using the id.
+	APIv1PetsIDPurge = APIv1 + PetsIDPurge
+	// APIv1PetsIDWeights represents the API v1 to record and get the weight history of the pet using the id.
+	APIv1PetsIDWeights = APIv1 + PetsIDWeights
+	// APIv1PetsIDWeightsTrend represents the API v1 to get the weight trend of the pet using the id.
+	APIv1PetsIDWeightsTrend = APIv1 + PetsIDWeightsTrend
+	// APIv1PetsIDPrescriptions represents the API v1 to get the prescriptions of the pet using the id.
+	APIv1PetsIDPrescriptions = APIv1 + PetsIDPrescriptions
+	// APIv1PetsIDMedications represents the API v1 to get the current medications of the pet using the id.
+	APIv1PetsIDMedications = APIv1 + PetsIDMedications
+	// APIv1PetsIDAttachments represents the API v1 to get and upload the files attached to the pet using the id.
+	APIv1PetsIDAttachments = APIv1 + PetsIDAttachments
+	// APIv1PetsIDLabResults represents the API v1 to get the lab results of the pet using the id.
+	APIv1PetsIDLabResults = APIv1 + PetsIDLabResults
 	// APIv1Records represents a group of record management API v1.
 	APIv1Records = APIv1 + Records
 	// APIv1RecordsID represents the API v1 to get record data using the id.
@@ -113,6 +413,24 @@ const (
 	APIv1Visits = APIv1 + Visits
 	// APIv1VisitsID represents the API v1 to get visit data using the id.
 	APIv1VisitsID = APIv1 + VisitsID
+	// APIv1VisitsIDRestore represents the API v1 to restore the soft-deleted visit using the id.
+	APIv1VisitsIDRestore = APIv1 + VisitsIDRestore
+	// APIv1VisitsIDPurge represents the API v1 to permanently delete visit data using the id.
+	APIv1VisitsIDPurge = APIv1 + VisitsIDPurge
+	// APIv1VisitsIDComplete represents the API v1 to complete the visit using the id.
+	APIv1VisitsIDComplete = APIv1 + VisitsIDComplete
+	// APIv1VisitsIDInvoice represents the API v1 to invoice the visit using the id.
+	APIv1VisitsIDInvoice = APIv1 + VisitsIDInvoice
+	// APIv1VisitsIDAttachments represents the API v1 to get and upload the files attached to the visit using the id.
+	APIv1VisitsIDAttachments = APIv1 + VisitsIDAttachments
+	// APIv1VisitsIDLabResults represents the API v1 to get the lab results of the visit using the id.
+	APIv1VisitsIDLabResults = APIv1 + VisitsIDLabResults
+	// APIv1Waitlist represents a group of waitlist management API v1.
+	APIv1Waitlist = APIv1 + Waitlist
+	// APIv1WaitlistID represents the API v1 to get waitlist entry data using the id.
+	APIv1WaitlistID = APIv1 + WaitlistID
+	// APIv1WaitlistMatches represents the API v1 to get the freed slots which suit the waiting entries.
+	APIv1WaitlistMatches = APIv1 + WaitlistMatches
 	// APIv1Leads represents a group of lead management API v1.
 	APIv1Leads = APIv1 + Leads
 	// APIv1LeadsID represents the API v1 to get lead data using the id.
@@ -121,6 +439,36 @@ const (
 	APIv1LeadsTypes = APIv1 + LeadsTypes
 	// APIv1LeadsStatuses represents the API v1 to get the list of lead statuses.
 	APIv1LeadsStatuses = APIv1 + LeadsStatuses
+	// APIv1LeadsForm represents the API v1 to get the data for showing the public lead form.
+	APIv1LeadsForm = APIv1 + LeadsForm
+	// APIv1BookingDepartments represents the API v1 to get the departments for online booking.
+	APIv1BookingDepartments = APIv1 + BookingDepartments
+	// APIv1BookingSlots represents the API v1 to get the free slots for online booking.
+	APIv1BookingSlots = APIv1 + BookingSlots
+	// APIv1BookingVisits represents the API v1 to book a visit online.
+	APIv1BookingVisits = APIv1 + BookingVisits
+	// APIv1Search represents the API v1 to search clients, pets, users and leads.
+	APIv1Search = APIv1 + Search
+	// APIv1PortalLogin represents the API v1 for the client to log in to the portal with the password.
+	APIv1PortalLogin = APIv1 + PortalLogin
+	// APIv1PortalLoginCode represents the API v1 for the client to log in to the portal with a one-time code.
+	APIv1PortalLoginCode = APIv1 + PortalLoginCode
+	// APIv1PortalCode represents the API v1 for the client to request a one-time login code.
+	APIv1PortalCode = APIv1 + PortalCode
+	// APIv1PortalLogout represents the API v1 for the client to log out of the portal.
+	APIv1PortalLogout = APIv1 + PortalLogout
+	// APIv1PortalProfile represents the API v1 to get the data of the logged-in client.
+	APIv1PortalProfile = APIv1 + PortalProfile
+	// APIv1PortalPets represents the API v1 to get the pets of the logged-in client.
+	APIv1PortalPets = APIv1 + PortalPets
+	// APIv1PortalVisits represents the API v1 for managing the visits of the logged-in client.
+	APIv1PortalVisits = APIv1 + PortalVisits
+	// APIv1PortalVisitsIDCancel represents the API v1 for the logged-in client to cancel the visit using the id.
+	APIv1PortalVisitsIDCancel = APIv1 + PortalVisitsIDCancel
+	// APIv1PortalInvoices represents the API v1 to get the invoices of the logged-in client.
+	APIv1PortalInvoices = APIv1 + PortalInvoices
+	// APIv1PortalPetsIDRecord represents the API v1 to get the medical record of the pet of the logged-in client using the id.
+	APIv1PortalPetsIDRecord = APIv1 + PortalPetsIDRecord
 )
 
 const (
@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type AttachmentController struct {
+	container container.Container
+	service   *service.AttachmentService
+}
+
+// NewAttachmentController is constructor.
+func NewAttachmentController(container container.Container) *AttachmentController {
+	return &AttachmentController{container: container, service: service.NewAttachmentService(container)}
+}
+
+// NewAttachmentControllerWithService is constructor which uses the given service, e.g. with another storage.
+func NewAttachmentControllerWithService(container container.Container, service *service.AttachmentService) *AttachmentController {
+	return &AttachmentController{container: container, service: service}
+}
+
+// Get returns one record matched attachment's id.
+//
+// @Summary Get an attachment.
+// @Description Returns the description of the attached file. Use the download endpoint to get its content.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Attachment ID"
+// @Success 200 {object} models.Attachment "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The attachment does not exist."
+// @Router /attachments/{id} [get]
+func (r *AttachmentController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	attachment, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, attachment)
+}
+
+// GetAllByPet returns the files attached to the pet.
+//
+// @Summary Get the attachments of the pet.
+// @Description Returns the files attached to the pet and its visits, the latest first.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} []models.Attachment "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/attachments [get]
+func (r *AttachmentController) GetAllByPet(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	attachments, err := r.service.GetAllByPet(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, attachments)
+}
+
+// GetAllByVisit returns the files attached to the visit.
+//
+// @Summary Get the attachments of the visit.
+// @Description Returns the files attached to the visit, the latest first.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Success 200 {object} []models.Attachment "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Router /visits/{id}/attachments [get]
+func (r *AttachmentController) GetAllByVisit(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	attachments, err := r.service.GetAllByVisit(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, attachments)
+}
+
+// CreateForPet attaches the uploaded file to the pet.
+//
+// @Summary Attach a file to the pet.
+// @Description Upload a file, e.g. a report or an image, and attach it to the pet. The type of the file is detected from its content and must be one of the configured types.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Param file formData file true "The file to attach."
+// @Param description formData string false "The description of the file."
+// @Success 200 {object} models.Attachment "Success to upload."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Failure 413 {object} apperror.Response "The file is too large."
+// @Failure 415 {object} apperror.Response "The type of the file is not allowed."
+// @Failure 422 {object} apperror.Response "The file is missing or empty."
+// @Router /pets/{id}/attachments [post]
+func (r *AttachmentController) CreateForPet(c echo.Context) error {
+	return r.create(c, r.service.CreateForPet)
+}
+
+// CreateForVisit attaches the uploaded file to the visit.
+//
+// @Summary Attach a file to the visit.
+// @Description Upload a file, e.g. a report or an image of a diagnostic service, and attach it to the visit and the pet of the visit. The type of the file is detected from its content and must be one of the configured types.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Param file formData file true "The file to attach."
+// @Param description formData string false "The description of the file."
+// @Success 200 {object} models.Attachment "Success to upload."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Failure 413 {object} apperror.Response "The file is too large."
+// @Failure 415 {object} apperror.Response "The type of the file is not allowed."
+// @Failure 422 {object} apperror.Response "The file is missing or empty."
+// @Router /visits/{id}/attachments [post]
+func (r *AttachmentController) CreateForVisit(c echo.Context) error {
+	return r.create(c, r.service.CreateForVisit)
+}
+
+func (r *AttachmentController) create(c echo.Context, create func(ctx context.Context, id string,
+	dto *dto.AttachmentDto, content io.Reader, uploaderID uint) (*models.Attachment, error)) error {
+	user := getUser(c, r.container)
+	if user == nil || !util.Staff.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.AttachmentDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		e := apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed, "the file is required")
+		e.Details = []*apperror.FieldError{{Field: "file", Tag: "required", Message: e.Message}}
+		return e
+	}
+	data.FileName = file.Filename
+	data.Size = file.Size
+	if err = c.Validate(data); err != nil {
+		return err
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	attachment, err := create(c.Request().Context(), c.Param("id"), data, content, user.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, attachment)
+}
+
+// Download returns the content of the attached file.
+//
+// @Summary Download the attached file.
+// @Description Returns the content of the attached file with its type and name.
+// @Tags Attachments
+// @Produce octet-stream
+// @Security ApiKeyAuth
+// @Param id path string true "Attachment ID"
+// @Success 200 {file} file "The content of the file."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The attachment does not exist."
+// @Router /attachments/{id}/download [get]
+func (r *AttachmentController) Download(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	attachment, content, err := r.service.Download(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Stream(http.StatusOK, attachment.ContentType, content)
+}
+
+// Delete deletes the attachment along with the file.
+//
+// @Summary Delete the attachment. Required user's role: Admin
+// @Description Delete the attachment and the file permanently.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Attachment ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Attachment "Success to delete."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The attachment does not exist."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /attachments/{id} [delete]
+func (r *AttachmentController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	attachment, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, attachment)
+}
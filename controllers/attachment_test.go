@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/storage"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+const testPDF = "%PDF-1.4\n% Холтеровское мониторирование\n"
+
+func TestCreateAttachmentForVisit_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	attachment := newAttachmentControllerForTest(t, cont)
+	e.POST(config.APIv1VisitsIDAttachments, func(c echo.Context) error { return attachment.CreateForVisit(c) })
+
+	req := test.NewMultipartRequest("POST", config.APIv1Visits+"/1"+config.Attachments, "holter.pdf", testPDF,
+		map[string]string{"description": "Холтеровское мониторирование"})
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Attachment{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "holter.pdf", data.FileName)
+	assert.Equal(t, "application/pdf", data.ContentType)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), data.StorageKey)
+}
+
+func TestCreateAttachmentForPet_NoFile(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	attachment := newAttachmentControllerForTest(t, cont)
+	e.POST(config.APIv1PetsIDAttachments, func(c echo.Context) error { return attachment.CreateForPet(c) })
+
+	req := test.NewMultipartRequest("POST", config.APIv1Pets+"/1"+config.Attachments, "", "",
+		map[string]string{"description": "ЭхоКГ скрининг"})
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"file"`)
+}
+
+func TestCreateAttachmentForPet_UnsupportedType(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	attachment := newAttachmentControllerForTest(t, cont)
+	e.POST(config.APIv1PetsIDAttachments, func(c echo.Context) error { return attachment.CreateForPet(c) })
+
+	req := test.NewMultipartRequest("POST", config.APIv1Pets+"/1"+config.Attachments, "echo.pdf",
+		"MZ\x90\x00\x03\x00\x00\x00", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"unsupported_media_type"`)
+}
+
+func TestDownloadAttachment_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	s := service.NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	attachment := NewAttachmentControllerWithService(cont, s)
+	e.GET(config.APIv1AttachmentsIDDownload, func(c echo.Context) error { return attachment.Download(c) })
+
+	_, _ = s.CreateForPet(context.Background(), "1", &dto.AttachmentDto{FileName: "холтер.pdf"},
+		strings.NewReader(testPDF), 1)
+
+	req := httptest.NewRequest("GET", config.APIv1Attachments+"/1/download", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "attachment; filename*=utf-8''%D1%85%D0%BE%D0%BB%D1%82%D0%B5%D1%80.pdf",
+		rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, testPDF, rec.Body.String())
+}
+
+func TestDownloadAttachment_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	attachment := newAttachmentControllerForTest(t, cont)
+	e.GET(config.APIv1AttachmentsIDDownload, func(c echo.Context) error { return attachment.Download(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Attachments+"/1/download", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGetAttachmentsByPet_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	s := service.NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	attachment := NewAttachmentControllerWithService(cont, s)
+	e.GET(config.APIv1PetsIDAttachments, func(c echo.Context) error { return attachment.GetAllByPet(c) })
+
+	_, _ = s.CreateForVisit(context.Background(), "1", &dto.AttachmentDto{FileName: "echo.pdf"},
+		strings.NewReader(testPDF), 1)
+
+	req := httptest.NewRequest("GET", config.APIv1Pets+"/1"+config.Attachments, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Attachment{}
+	data, _ := m.GetAllByPet(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func newAttachmentControllerForTest(t *testing.T, cont container.Container) *AttachmentController {
+	return NewAttachmentControllerWithService(cont,
+		service.NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir())))
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type BalanceController struct {
+	container container.Container
+	service   *service.BalanceService
+}
+
+// NewBalanceController is constructor.
+func NewBalanceController(container container.Container) *BalanceController {
+	return &BalanceController{container: container, service: service.NewBalanceService(container)}
+}
+
+// Get returns the balance of the client.
+//
+// @Summary Get the balance of the client.
+// @Description Returns the balance of the client along with its ledger, the latest entry first.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.ClientBalance "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Router /clients/{id}/balance [get]
+func (r *BalanceController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	balance, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, balance)
+}
+
+// TopUp records the prepayment made by the client.
+//
+// @Summary Top up the balance of the client.
+// @Description Records the prepayment made by the client, it can be used to pay the invoices.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Param data body dto.BalanceEntryDto true "The amount of the prepayment."
+// @Success 200 {object} models.ClientBalance "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Router /clients/{id}/balance/top-up [post]
+func (r *BalanceController) TopUp(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil || !util.Staff.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.BalanceEntryDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	balance, err := r.service.TopUp(c.Request().Context(), data, c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, balance)
+}
+
+// Refund records the money returned to the client.
+//
+// @Summary Refund the balance of the client. Required user's role: Admin
+// @Description Records the money returned to the client, e.g. the deposit for a returned device. The amount cannot exceed the balance.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Param data body dto.BalanceEntryDto true "The amount of the refund."
+// @Success 200 {object} models.ClientBalance "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Failure 409 {object} apperror.Response "The amount exceeds the balance of the client."
+// @Router /clients/{id}/balance/refund [post]
+func (r *BalanceController) Refund(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil || !util.Staff.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(util.ToAccessLevel(user.Role.Name)) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.BalanceEntryDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	balance, err := r.service.Refund(c.Request().Context(), data, c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, balance)
+}
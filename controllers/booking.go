@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+)
+
+// BookingController serves the public online booking, its endpoints do not require the authentication.
+type BookingController struct {
+	container container.Container
+	service   *service.BookingService
+}
+
+// NewBookingController is constructor.
+func NewBookingController(container container.Container) *BookingController {
+	return &BookingController{container: container, service: service.NewBookingService(container)}
+}
+
+// GetDepartments returns the departments along with their bookable services and doctors.
+//
+// @Summary Get the departments for online booking.
+// @Description Returns the list of departments along with their services and doctors who can be booked online.
+// @Tags Booking
+// @Accept json
+// @Produce json
+// @Success 200 {object} []models.BookableDepartment "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Router /booking/departments [get]
+func (b *BookingController) GetDepartments(c echo.Context) error {
+	departments, err := b.service.GetDepartments(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, departments)
+}
+
+// GetSlots returns the free slots of the doctors.
+//
+// @Summary Get the free slots for online booking.
+// @Description Returns the free slots on the given date of the doctors who provide the given service, within the working hours of each doctor and while the resources required by the service are free.
+// @Tags Booking
+// @Accept json
+// @Produce json
+// @Param serviceId query string true "Service ID"
+// @Param date query string true "Date" format(date)
+// @Param departmentId query string false "Department ID"
+// @Param doctorId query string false "Doctor ID"
+// @Success 200 {object} []models.Slot "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Router /booking/slots [get]
+func (b *BookingController) GetSlots(c echo.Context) error {
+	slots, err := b.service.GetSlots(c.Request().Context(), c.QueryParam("departmentId"),
+		c.QueryParam("serviceId"), c.QueryParam("doctorId"), c.QueryParam("date"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, slots)
+}
+
+// Book reserves a free slot.
+//
+// @Summary Book a visit online.
+// @Description Reserve a free slot with the contact details. It creates a tentative visit, which the clinic confirms
+// @Description by changing its status to scheduled. The client is created as a tentative client, which the staff reconciles with the existing clients.
+// @Tags Booking
+// @Accept json
+// @Produce json
+// @Param data body dto.BookingDto true "The booking data."
+// @Success 200 {object} models.Booking "Success to book."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 409 {object} apperror.Response "The slot or a required resource is taken, or a booking with the same phone or e-mail is pending."
+// @Failure 422 {object} apperror.Response "The time is not a free slot or the doctor does not provide the service."
+// @Failure 429 {object} apperror.Response "Too many bookings."
+// @Router /booking/visits [post]
+func (b *BookingController) Book(c echo.Context) error {
+	data := &dto.BookingDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	booking, err := b.service.Book(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, booking)
+}
@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/config"
+	"vet-clinic/middleware"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/test"
+)
+
+func TestGetBookingDepartments_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.GET(config.APIv1BookingDepartments, func(c echo.Context) error { return booking.GetDepartments(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1BookingDepartments, nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Department{}
+	data, _ := m.GetBookable(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Len(t, data[0].Doctors, 1)
+	assert.NotContains(t, rec.Body.String(), "test1@test.com")
+}
+
+func TestGetBookingSlots_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.GET(config.APIv1BookingSlots, func(c echo.Context) error { return booking.GetSlots(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1BookingSlots+"?serviceId=1&departmentId=1&date="+tomorrow(), nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	var slots []*models.Slot
+	_ = json.Unmarshal(rec.Body.Bytes(), &slots)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, slots, 18)
+	assert.Equal(t, uint(1), slots[0].DoctorID)
+}
+
+func TestGetBookingSlots_ExcludesBooked(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit, client := createBookingForBook(10).ToModel()
+	_, _ = visit.Book(cont.Repository(), client, "Барсик", 30*time.Minute, time.Now())
+
+	booking := NewBookingController(cont)
+	e.GET(config.APIv1BookingSlots, func(c echo.Context) error { return booking.GetSlots(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1BookingSlots+"?serviceId=1&date="+tomorrow(), nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	var slots []*models.Slot
+	_ = json.Unmarshal(rec.Body.Bytes(), &slots)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, slots, 17)
+	for _, slot := range slots {
+		assert.False(t, slot.Start.Hour() == 10 && slot.Start.Minute() == 0)
+	}
+}
+
+func TestGetBookingSlots_InvalidDate(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.GET(config.APIv1BookingSlots, func(c echo.Context) error { return booking.GetSlots(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1BookingSlots+"?serviceId=1&date=tomorrow", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.BadRequest, Message: "invalid date: tomorrow"}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestBook_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(10)
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data.ToBooking()), rec.Body.String())
+	assert.Equal(t, models.VisitTentative, data.Status)
+	assert.Equal(t, uint(2), data.ClientID)
+	assert.Equal(t, "Барсик", data.Pet.Name)
+}
+
+func TestBook_ExistingClientUnchanged(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(10)
+	param.Phone = "+78888888888"
+	param.PetName = "Китти"
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 2)
+	existing, _ := (&models.Client{}).Get(cont.Repository(), 1)
+	pets, _ := (&models.Pet{}).GetAllByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, uint(2), data.ClientID)
+	assert.True(t, data.Client.Tentative)
+	assert.Equal(t, "Китти", data.Pet.Name)
+	assert.False(t, existing.Tentative)
+	assert.Len(t, pets, 1)
+}
+
+func TestBook_SlotTaken(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit, client := createBookingForBook(10).ToModel()
+	_, _ = visit.Book(cont.Repository(), client, "Барсик", 30*time.Minute, time.Now())
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(10)
+	param.Phone = "+79990000000"
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.InvalidState, Message: "the slot is already taken"}
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestBook_Duplicate(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit, client := createBookingForBook(10).ToModel()
+	_, _ = visit.Book(cont.Repository(), client, "Барсик", 30*time.Minute, time.Now())
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(12)
+	param.Phone = "+79990000000"
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.Conflict, Message: "a booking with the same phone or e-mail is already pending"}
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestBook_DuplicateEmailCase(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit, client := createBookingForBook(10).ToModel()
+	_, _ = visit.Book(cont.Repository(), client, "Барсик", 30*time.Minute, time.Now())
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(12)
+	param.Phone = "+79990000000"
+	param.Email = "IVAN@Test.com"
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "a booking with the same phone or e-mail is already pending")
+}
+
+func TestBook_NotSlot(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) })
+
+	param := createBookingForBook(10)
+	param.DateTime = param.DateTime.Add(10 * time.Minute)
+	req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "dateTime is not a bookable slot")
+}
+
+func TestBook_RateLimited(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+	cont.Config().RateLimit.Enabled = true
+	cont.Config().RateLimit.Groups["public"] = config.RateLimitRule{Limit: 1, Period: time.Minute}
+
+	booking := NewBookingController(cont)
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) },
+		middleware.NewRateLimiter(cont).Group("public"))
+
+	for i, expected := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		param := createBookingForBook(10 + i)
+		req := test.NewJSONRequest("POST", config.APIv1BookingVisits, param)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, expected, rec.Code)
+	}
+}
+
+func tomorrow() string {
+	return time.Now().AddDate(0, 0, 1).Format(service.BookingDateFormat)
+}
+
+func createBookingForBook(hour int) *dto.BookingDto {
+	day := time.Now().AddDate(0, 0, 1)
+	return &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 1,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		Email:     "ivan@test.com",
+		PetName:   "Барсик",
+		Comment:   "Осмотр",
+	}
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type BreedController struct {
+	container container.Container
+	service   *service.BreedService
+}
+
+// NewBreedController is constructor.
+func NewBreedController(container container.Container) *BreedController {
+	return &BreedController{container: container, service: service.NewBreedService(container)}
+}
+
+// Get returns one record matched breed's id.
+//
+// @Summary Get a breed.
+// @Description Returns one record matched breed's id.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Breed ID"
+// @Success 200 {object} models.Breed "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /breeds/{id} [get]
+func (r *BreedController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	breed, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, breed)
+}
+
+// GetAll returns the list of breeds.
+//
+// @Summary Get a breed list.
+// @Description Returns the list of breeds.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param speciesId query string false "Return only the breeds of the species."
+// @Success 200 {object} []models.Breed "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /breeds [get]
+func (r *BreedController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	breeds, err := r.service.GetAll(c.Request().Context(), c.QueryParam("speciesId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, breeds)
+}
+
+// GetUnverified returns the breeds entered as free text for the pets.
+//
+// @Summary Get the breeds to be reviewed.
+// @Description Returns the breeds entered as free text for the pets along with the number of the pets.
+// @Description Creating the breed in the catalogue links the pets to it.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.UnverifiedBreed "Success to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /breeds/unverified [get]
+func (r *BreedController) GetUnverified(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	breeds, err := r.service.GetUnverified(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, breeds)
+}
+
+// Create creates a new breed.
+//
+// @Summary Create a new breed. Required user's role: Admin
+// @Description Create a new breed.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.BreedDto true "A new breed data for creating."
+// @Success 200 {object} models.Breed "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The breed with the same name already exists."
+// @Router /breeds [post]
+func (r *BreedController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.BreedDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	breed, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, breed)
+}
+
+// Update updates the existing breed.
+//
+// @Summary Update the existing breed. Required user's role: Admin
+// @Description Update the existing breed.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Breed ID"
+// @Param data body dto.BreedDto true "Service data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Breed "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The breed with the same name already exists or pets refer to the breed of another species."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /breeds/{id} [put]
+func (r *BreedController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.BreedDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	breed, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, breed)
+}
+
+// Delete deletes the existing breed.
+//
+// @Summary Delete the existing breed. Required user's role: Admin
+// @Description Delete the existing breed. It fails while pets, including the soft-deleted ones, refer to the breed.
+// @Tags Breeds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Breed ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Breed "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The breed is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /breeds/{id} [delete]
+func (r *BreedController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	breed, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, breed)
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetBreedList_BySpecies(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	breed := NewBreedController(cont)
+	e.GET(config.APIv1Breeds, func(c echo.Context) error { return breed.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Breeds+"?speciesId=1", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Breed{}
+	data, _ := m.GetAll(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 4)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestGetUnverifiedBreeds_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	breed := NewBreedController(cont)
+	e.GET(config.APIv1BreedsUnverified, func(c echo.Context) error { return breed.GetUnverified(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1BreedsUnverified, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := []*models.UnverifiedBreed{{SpeciesID: 1, Breed: "Дворняга", Count: 1}}
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestCreateBreed_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	breed := NewBreedController(cont)
+	e.POST(config.APIv1Breeds, func(c echo.Context) error { return breed.Create(c) })
+
+	param := &dto.BreedDto{Name: "Корги", SpeciesID: 2}
+	req := test.NewJSONRequest("POST", config.APIv1Breeds, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Breed{}
+	data, _ := m.Get(cont.Repository(), 9)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateBreed_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	breed := NewBreedController(cont)
+	e.POST(config.APIv1Breeds, func(c echo.Context) error { return breed.Create(c) })
+
+	param := &dto.BreedDto{Name: "Корги", SpeciesID: 2}
+	req := test.NewJSONRequest("POST", config.APIv1Breeds, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -22,25 +23,38 @@ func NewCategoryController(container container.Container) *CategoryController {
 func (r *CategoryController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	category, err := r.service.Get(c.Param("id"))
+	category, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, category)
+	return jsonWithETag(c, category)
 }
 
 func (r *CategoryController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	categories, err := r.service.GetAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, categories)
+}
+
+func (r *CategoryController) GetTree(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
 	}
 
-	categories, err := r.service.GetAll()
+	categories, err := r.service.GetTree(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, categories)
 }
@@ -48,63 +62,71 @@ func (r *CategoryController) GetAll(c echo.Context) error {
 func (r *CategoryController) Create(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.CategoryDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	category, err := r.service.Create(data)
+	category, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, category)
+	return jsonWithETag(c, category)
 }
 
 func (r *CategoryController) Update(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.CategoryDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	category, err := r.service.Update(data, c.Param("id"))
+	category, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, category)
+	return jsonWithETag(c, category)
 }
 
 func (r *CategoryController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	category, err := r.service.Delete(c.Param("id"))
+	category, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, category)
 }
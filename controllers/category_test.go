@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
@@ -48,8 +49,8 @@ func TestGetCategory_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -88,6 +89,30 @@ func TestGetCategoryList_Success(t *testing.T) {
 	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
 }
 
+func TestGetCategoryTree_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	category := NewCategoryController(cont)
+	e.GET(config.APIv1CategoriesTree, func(c echo.Context) error { return category.GetTree(c) })
+
+	_, _ = (&models.Category{Name: "Ультразвуковая диагностика", ParentID: 4}).Create(cont.Repository())
+
+	req := httptest.NewRequest("GET", config.APIv1CategoriesTree, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Category{}
+	data, _ := m.GetTree(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 4)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
 func TestGetCategoryList_Unauthorized(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -139,10 +164,10 @@ func TestCreateCategory_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "CategoryDto.Name")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestCreateCategory_Unauthorized(t *testing.T) {
@@ -215,10 +240,10 @@ func TestUpdateCategory_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "CategoryDto.Name")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateCategory_Unauthorized(t *testing.T) {
@@ -289,8 +314,8 @@ func TestDeleteCategory_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -3,6 +3,8 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -12,11 +14,16 @@ import (
 type ClientController struct {
 	container container.Container
 	service   *service.ClientService
+	accounts  *service.ClientAccountService
 }
 
 // NewClientController is constructor.
 func NewClientController(container container.Container) *ClientController {
-	return &ClientController{container: container, service: service.NewClientService(container)}
+	return &ClientController{
+		container: container,
+		service:   service.NewClientService(container),
+		accounts:  service.NewClientAccountService(container),
+	}
 }
 
 // Get returns one record matched client's id.
@@ -29,20 +36,21 @@ func NewClientController(container container.Container) *ClientController {
 // @Security ApiKeyAuth
 // @Param id path string true "Client ID"
 // @Success 200 {object} models.Client "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /clients/{id} [get]
 func (r *ClientController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	client, err := r.service.Get(c.Param("id"))
+	client, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, client)
+	return jsonWithETag(c, client)
 }
 
 // GetAll returns the list of clients.
@@ -53,19 +61,26 @@ func (r *ClientController) Get(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param includeDeleted query bool false "Include the soft-deleted clients. Required user's role: Admin"
 // @Success 200 {object} []models.Client "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /clients [get]
 func (r *ClientController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	includeDeleted, _ := strconv.ParseBool(c.QueryParam("includeDeleted"))
+	if includeDeleted && !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
 	}
 
-	clients, err := r.service.GetAll()
+	clients, err := r.service.GetAll(c.Request().Context(), includeDeleted)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, clients)
 }
@@ -80,33 +95,33 @@ func (r *ClientController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.ClientDto true "A new client data for creating."
 // @Success 200 {object} models.Client "Success to fetch data."
-// @Failure 400 {object} dto.ClientDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /clients [post]
 func (r *ClientController) Create(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Administrator.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.ClientDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	client, err := r.service.Create(data)
+	client, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, client)
+	return jsonWithETag(c, client)
 }
 
 // Update updates the existing client.
@@ -119,34 +134,85 @@ func (r *ClientController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Client ID"
 // @Param data body dto.ClientDto true "Client data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Client "Success to fetch data."
-// @Failure 400 {object} dto.ClientDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /clients/{id} [put]
 func (r *ClientController) Update(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Administrator.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.ClientDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	client, err := r.service.Update(data, c.Param("id"))
+	client, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, client)
+	return jsonWithETag(c, client)
+}
+
+// Patch partially updates the existing client.
+//
+// @Summary Partially update the existing client. Required user's role: Admin
+// @Description Partially update the existing client by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Clients
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Param data body dto.ClientDto true "Client fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Client "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /clients/{id} [patch]
+func (r *ClientController) Patch(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.ClientDto{})
+	if err != nil {
+		return err
+	}
+
+	client, err := r.service.Patch(c.Request().Context(), patch, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, client)
 }
 
 // Delete deletes the existing client.
@@ -158,23 +224,286 @@ func (r *ClientController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Client ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Client "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /clients/{id} [delete]
 func (r *ClientController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	client, err := r.service.Delete(c.Param("id"))
+	client, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, client)
 }
+
+// Restore restores the soft-deleted client.
+//
+// @Summary Restore the soft-deleted client. Required user's role: Superuser
+// @Description Restore the soft-deleted client.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.Client "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Router /clients/{id}/restore [post]
+func (r *ClientController) Restore(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	client, err := r.service.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, client)
+}
+
+// Purge permanently deletes the client.
+//
+// @Summary Permanently delete the client, including the soft-deleted one. Required user's role: Superuser
+// @Description Permanently delete the client, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the client.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.Client "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Failure 409 {object} apperror.Response "The client is referenced by other records."
+// @Router /clients/{id}/purge [delete]
+func (r *ClientController) Purge(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	client, err := r.service.Purge(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, client)
+}
+
+// GetAccount returns the portal account of the client.
+//
+// @Summary Get the portal account of the client.
+// @Description Returns the portal account of the client.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.ClientAccount "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client has no portal account."
+// @Router /clients/{id}/account [get]
+func (r *ClientController) GetAccount(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	account, err := r.accounts.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, account)
+}
+
+// CreateAccount creates the portal account of the client.
+//
+// @Summary Create the portal account of the client. Required user's role: Admin
+// @Description Create the portal account of the client. Without the password the client can log in only with one-time codes.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Param data body dto.ClientAccountDto true "The account data."
+// @Success 200 {object} models.ClientAccount "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The client already has a portal account."
+// @Router /clients/{id}/account [post]
+func (r *ClientController) CreateAccount(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ClientAccountDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	account, err := r.accounts.Create(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, account)
+}
+
+// DeleteAccount deletes the portal account of the client.
+//
+// @Summary Delete the portal account of the client. Required user's role: Admin
+// @Description Delete the portal account of the client, the client can no longer log in to the portal.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.ClientAccount "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The client has no portal account."
+// @Router /clients/{id}/account [delete]
+func (r *ClientController) DeleteAccount(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	account, err := r.accounts.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, account)
+}
+
+// GetDuplicates returns the likely duplicates of the client.
+//
+// @Summary Get the likely duplicates of the client.
+// @Description Returns the other clients with the same phone number or e-mail, or with a similar full name.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} []models.DuplicateClient "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Router /clients/{id}/duplicates [get]
+func (r *ClientController) GetDuplicates(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	duplicates, err := r.service.FindDuplicates(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, duplicates)
+}
+
+// Merge merges the duplicate client into the client.
+//
+// @Summary Merge the duplicate client into the client. Required user's role: Admin
+// @Description Move the pets, the visits and the portal account of the duplicate client to the client in one transaction,
+// @Description fill in the empty fields of the client and soft-delete the duplicate. The merge is recorded for the audit.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "ID of the surviving client"
+// @Param data body dto.ClientMergeDto true "The duplicate client."
+// @Success 200 {object} models.ClientMerge "Success to merge."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Failure 422 {object} apperror.Response "The duplicate client does not exist."
+// @Router /clients/{id}/merge [post]
+func (r *ClientController) Merge(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ClientMergeDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	merge, err := r.service.Merge(c.Request().Context(), data, c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, merge)
+}
+
+// GetMerges returns the audit records of the merges of the client.
+//
+// @Summary Get the merges of the client.
+// @Description Returns the audit records of the merges into and from the client.
+// @Tags Clients
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} []models.ClientMerge "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /clients/{id}/merges [get]
+func (r *ClientController) GetMerges(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	merges, err := r.service.GetMerges(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, merges)
+}
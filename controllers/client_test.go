@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
@@ -52,8 +53,8 @@ func TestGetClient_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -108,6 +109,48 @@ func TestGetClientList_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestGetClientList_IncludeDeleted(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	m := &models.Client{}
+	_, _ = m.Delete(cont.Repository(), 2)
+
+	client := NewClientController(cont)
+	e.GET(config.APIv1Clients, func(c echo.Context) error { return client.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Clients+"?includeDeleted=true", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := m.GetAllWithDeleted(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 2)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestGetClientList_IncludeDeletedForbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.GET(config.APIv1Clients, func(c echo.Context) error { return client.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Clients+"?includeDeleted=true", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
 func TestCreateClient_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -145,21 +188,21 @@ func TestCreateClient_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ClientDto.Surname")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Patronymic")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Sex")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Phone")
-	assert.Contains(t, rec.Body.String(), "'e164'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Email")
-	assert.Contains(t, rec.Body.String(), "'email'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Info")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"surname"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"patronymic"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"sex"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"phone"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"e164"`)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"email"`)
+	assert.Contains(t, rec.Body.String(), `"field":"info"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestCreateClient_Unauthorized(t *testing.T) {
@@ -234,21 +277,21 @@ func TestUpdateClient_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ClientDto.Surname")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Patronymic")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Sex")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Phone")
-	assert.Contains(t, rec.Body.String(), "'e164'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Email")
-	assert.Contains(t, rec.Body.String(), "'email'")
-	assert.Contains(t, rec.Body.String(), "ClientDto.Info")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"surname"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"patronymic"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"sex"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"phone"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"e164"`)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"email"`)
+	assert.Contains(t, rec.Body.String(), `"field":"info"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateClient_Unauthorized(t *testing.T) {
@@ -284,6 +327,95 @@ func TestUpdateClient_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+func TestPatchClient_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+
+	client := NewClientController(cont)
+	e.PATCH(config.APIv1ClientsID, func(c echo.Context) error { return client.Patch(c) })
+
+	param := map[string]interface{}{"phone": "+71234560000", "info": nil}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1ClientsID, "2"), param)
+	req.Header.Set("Content-Type", util.MergePatchMIMEType)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Client{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, "+71234560000", data.Phone)
+	assert.Equal(t, "", data.Info)
+	assert.Equal(t, createClientForCreate().Email, data.Email)
+}
+
+func TestPatchClient_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+
+	client := NewClientController(cont)
+	e.PATCH(config.APIv1ClientsID, func(c echo.Context) error { return client.Patch(c) })
+
+	param := map[string]interface{}{"email": "mail"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1ClientsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.NotContains(t, rec.Body.String(), `"field":"phone"`)
+}
+
+func TestPatchClient_UnsupportedMediaType(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+
+	client := NewClientController(cont)
+	e.PATCH(config.APIv1ClientsID, func(c echo.Context) error { return client.Patch(c) })
+
+	param := map[string]interface{}{"phone": "+71234560000"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1ClientsID, "2"), param)
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestPatchClient_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.PATCH(config.APIv1ClientsID, func(c echo.Context) error { return client.Patch(c) })
+
+	param := map[string]interface{}{"phone": "+71234560000"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1ClientsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
 func TestDeleteClient_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -321,8 +453,8 @@ func TestDeleteClient_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -357,6 +489,256 @@ func TestDeleteClient_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+func TestRestoreClient_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	m := &models.Client{}
+	_, _ = m.Delete(cont.Repository(), 2)
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDRestore, func(c echo.Context) error { return client.Restore(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDRestore, "2"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, err := m.Get(cont.Repository(), 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestRestoreClient_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDRestore, func(c echo.Context) error { return client.Restore(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDRestore, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestPurgeClient_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	m := &models.Client{}
+	_, _ = m.Delete(cont.Repository(), 2)
+
+	client := NewClientController(cont)
+	e.DELETE(config.APIv1ClientsIDPurge, func(c echo.Context) error { return client.Purge(c) })
+
+	req := test.NewJSONRequest("DELETE", test.SetParam(config.APIv1ClientsIDPurge, "2"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := m.GetAllWithDeleted(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+}
+
+func TestPurgeClient_Referenced(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.DELETE(config.APIv1ClientsIDPurge, func(c echo.Context) error { return client.Purge(c) })
+
+	req := test.NewJSONRequest("DELETE", test.SetParam(config.APIv1ClientsIDPurge, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.Conflict, Message: "record is referenced by other records"}
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestCreateClientAccount_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.CreateAccount(c) })
+
+	param := &dto.ClientAccountDto{Password: "Password1!"}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDAccount, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ClientAccount{}
+	data, _ := m.GetByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateClientAccount_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.CreateAccount(c) })
+
+	param := &dto.ClientAccountDto{Password: "Password1!"}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDAccount, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestDeleteClientAccount_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := models.NewClientAccount(1, "")
+	account, _ = account.Create(cont.Repository())
+
+	client := NewClientController(cont)
+	e.DELETE(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.DeleteAccount(c) })
+
+	req := test.NewJSONRequest("DELETE", test.SetParam(config.APIv1ClientsIDAccount, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	_, err := account.GetByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(account), rec.Body.String())
+	assert.Error(t, err)
+}
+
+func TestGetClientDuplicates_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+
+	client := NewClientController(cont)
+	e.GET(config.APIv1ClientsIDDuplicates, func(c echo.Context) error { return client.GetDuplicates(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1ClientsIDDuplicates, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Client{}
+	data, _ := m.FindDuplicates(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"reasons":["name"]`)
+}
+
+func TestMergeClient_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDMerge, func(c echo.Context) error { return client.Merge(c) })
+
+	param := &dto.ClientMergeDto{SourceID: 2}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDMerge, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ClientMerge{}
+	data, _ := m.GetAllByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data[0]), rec.Body.String())
+}
+
+func TestMergeClient_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDMerge, func(c echo.Context) error { return client.Merge(c) })
+
+	param := &dto.ClientMergeDto{}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDMerge, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"sourceId"`)
+}
+
+func TestMergeClient_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.POST(config.APIv1ClientsIDMerge, func(c echo.Context) error { return client.Merge(c) })
+
+	param := &dto.ClientMergeDto{SourceID: 2}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDMerge, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestGetClientMerges_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	client := NewClientController(cont)
+	e.GET(config.APIv1ClientsIDMerges, func(c echo.Context) error { return client.GetMerges(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1ClientsIDMerges, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
 func setUpClientTestData(container container.Container) {
 	rep := container.Repository()
 	client := createClientForCreate().ToModel()
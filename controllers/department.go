@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -29,20 +30,21 @@ func NewDepartmentController(container container.Container) *DepartmentControlle
 // @Security ApiKeyAuth
 // @Param id_or_slug path string true "Department ID"
 // @Success 200 {object} models.Department "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /departments/{id_or_slug} [get]
 func (u *DepartmentController) Get(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	department, err := u.service.Get(c.Param("id"))
+	department, err := u.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, department)
+	return jsonWithETag(c, department)
 }
 
 // GetAll returns the list of departments.
@@ -54,18 +56,18 @@ func (u *DepartmentController) Get(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} []models.Department "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /departments [get]
 func (u *DepartmentController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	departments, err := u.service.GetAll()
+	departments, err := u.service.GetAll(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, departments)
 }
@@ -80,33 +82,33 @@ func (u *DepartmentController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.DepartmentDto true "A new department data for creating."
 // @Success 200 {object} models.Department "Success to fetch data."
-// @Failure 400 {object} dto.DepartmentDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /departments [post]
 func (u *DepartmentController) Create(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.DepartmentDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	department, err := u.service.Create(data)
+	department, err := u.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, department)
+	return jsonWithETag(c, department)
 }
 
 // Update updates the existing department.
@@ -119,34 +121,41 @@ func (u *DepartmentController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Department ID"
 // @Param data body dto.DepartmentDto true "Department data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Department "Success to fetch data."
-// @Failure 400 {object} dto.DepartmentDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /departments/{id} [put]
 func (u *DepartmentController) Update(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.DepartmentDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	result, err := u.service.Update(data, c.Param("id"))
+	result, err := u.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, result)
+	return jsonWithETag(c, result)
 }
 
 // Delete deletes the existing department.
@@ -158,23 +167,30 @@ func (u *DepartmentController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Department ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Department "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /departments/{id} [delete]
 func (u *DepartmentController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	department, err := u.service.Delete(c.Param("id"))
+	department, err := u.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, department)
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
@@ -80,8 +81,8 @@ func TestGetDepartment_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -173,9 +174,8 @@ func TestCreateDepartment_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultDepartmentForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreateDepartment_ValidationError(t *testing.T) {
@@ -193,9 +193,9 @@ func TestCreateDepartment_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "DepartmentDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
 }
 
 func TestCreateDepartment_Unauthorized(t *testing.T) {
@@ -270,9 +270,8 @@ func TestUpdateDepartment_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultDepartmentForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdateDepartment_ValidationError(t *testing.T) {
@@ -290,9 +289,9 @@ func TestUpdateDepartment_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "DepartmentDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
 }
 
 func TestUpdateDepartment_Unauthorized(t *testing.T) {
@@ -365,8 +364,8 @@ func TestDeleteDepartment_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -421,13 +420,6 @@ func createDepartmentForBindError() *DepartmentDtoForBindError {
 	}
 }
 
-func createResultDepartmentForBindError() *dto.DepartmentDto {
-	return &dto.DepartmentDto{
-		Name:     "Хирургия",
-		Services: nil,
-	}
-}
-
 func createDepartmentForValidationError() *dto.DepartmentDto {
 	return &dto.DepartmentDto{
 		Name:     "Хирургия2",
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type DiscountTierController struct {
+	container container.Container
+	service   *service.DiscountTierService
+}
+
+// NewDiscountTierController is constructor.
+func NewDiscountTierController(container container.Container) *DiscountTierController {
+	return &DiscountTierController{container: container, service: service.NewDiscountTierService(container)}
+}
+
+// Get returns one record matched discount tier's id.
+//
+// @Summary Get a discount tier.
+// @Description Returns one record matched discount tier's id.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Discount tier ID"
+// @Success 200 {object} models.DiscountTier "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /discount-tiers/{id} [get]
+func (r *DiscountTierController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	tier, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, tier)
+}
+
+// GetAll returns the list of discount tiers.
+//
+// @Summary Get a discount tier list.
+// @Description Returns the list of discount tiers.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.DiscountTier "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /discount-tiers [get]
+func (r *DiscountTierController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	tiers, err := r.service.GetAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, tiers)
+}
+
+// Create creates a new discount tier.
+//
+// @Summary Create a new discount tier. Required user's role: Owner
+// @Description Create a new discount tier.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.DiscountTierDto true "A new discount tier data for creating."
+// @Success 200 {object} models.DiscountTier "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The discount tier with the same name already exists."
+// @Router /discount-tiers [post]
+func (r *DiscountTierController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.DiscountTierDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	tier, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, tier)
+}
+
+// Update updates the existing discount tier.
+//
+// @Summary Update the existing discount tier. Required user's role: Owner
+// @Description Update the existing discount tier.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Discount tier ID"
+// @Param data body dto.DiscountTierDto true "Discount tier data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.DiscountTier "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The discount tier with the same name already exists."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /discount-tiers/{id} [put]
+func (r *DiscountTierController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.DiscountTierDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	tier, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, tier)
+}
+
+// Delete deletes the existing discount tier.
+//
+// @Summary Delete the existing discount tier. Required user's role: Owner
+// @Description Delete the existing discount tier. It fails while invoices, including the soft-deleted ones, refer to the discount tier.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Discount tier ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.DiscountTier "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The discount tier is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /discount-tiers/{id} [delete]
+func (r *DiscountTierController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	tier, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, tier)
+}
+
+// GetForClient returns the loyalty programme status of the client.
+//
+// @Summary Get the discount of the client.
+// @Description Returns the total spent by the client and the discount tier reached by the client.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} models.ClientDiscount "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client does not exist."
+// @Router /clients/{id}/discount [get]
+func (r *DiscountTierController) GetForClient(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	discount, err := r.service.GetForClient(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, discount)
+}
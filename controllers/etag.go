@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/repository"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// etagged is implemented by all models through models.BaseModel.
+type etagged interface {
+	ETag() string
+	Version() map[string]interface{}
+}
+
+// jsonWithETag sends the record as JSON along with its ETag.
+// A GET request whose If-None-Match header matches the ETag gets 304 Not Modified instead.
+func jsonWithETag(c echo.Context, m etagged) error {
+	etag := m.ETag()
+	c.Response().Header().Set(headerETag, etag)
+	if c.Request().Method == http.MethodGet && matchETag(c.Request().Header.Get(headerIfNoneMatch), etag, true) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// checkIfMatch compares the If-Match header of the request with the current ETag of the record fetched by get.
+// It fails with 412 if the record has been changed since the client fetched it,
+// and with 428 if the header is missing while it is required by the configuration.
+// The matched version becomes the precondition of the request context, so the transaction which writes
+// the record fails with 412 as well if the record is changed in the meantime.
+func checkIfMatch[T etagged](c echo.Context, container container.Container,
+	get func(ctx context.Context, id string) (T, error), id string) error {
+	ifMatch := c.Request().Header.Get(headerIfMatch)
+	if ifMatch == "" {
+		if container.Config().Extension.IfMatchRequired {
+			return apperror.NewPreconditionRequired()
+		}
+		return nil
+	}
+
+	current, err := get(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	if !matchETag(ifMatch, current.ETag(), false) {
+		return apperror.NewPreconditionFailed()
+	}
+	version := current.Version()
+	if strings.TrimSpace(ifMatch) == "*" {
+		version = map[string]interface{}{"id": version["id"]}
+	}
+	c.SetRequest(c.Request().WithContext(repository.WithPrecondition(c.Request().Context(), current, version)))
+	return nil
+}
+
+// matchETag reports whether the list of entity tags in the header contains the given ETag or "*".
+// The weak comparison ignores the "W/" prefix, as required for If-None-Match.
+func matchETag(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
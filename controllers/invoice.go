@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type InvoiceController struct {
+	container container.Container
+	service   *service.InvoiceService
+}
+
+// NewInvoiceController is constructor.
+func NewInvoiceController(container container.Container) *InvoiceController {
+	return &InvoiceController{container: container, service: service.NewInvoiceService(container)}
+}
+
+// Get returns one record matched invoice's id.
+//
+// @Summary Get an invoice.
+// @Description Returns one record matched invoice's id.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} models.Invoice "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The invoice does not exist."
+// @Router /invoices/{id} [get]
+func (r *InvoiceController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	invoice, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, invoice)
+}
+
+// GetAllByClient returns the invoices of the client.
+//
+// @Summary Get the invoices of the client.
+// @Description Returns the invoices of the client, the latest first.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Client ID"
+// @Success 200 {object} []models.Invoice "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /clients/{id}/invoices [get]
+func (r *InvoiceController) GetAllByClient(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	invoices, err := r.service.GetAllByClient(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, invoices)
+}
+
+// Issue issues the invoice of the completed visit.
+//
+// @Summary Invoice the visit.
+// @Description Issues the invoice of the completed visit at the price of the service as of the date of the visit. The best of the loyalty discount of the client and the promo code discount applies. If useBalance is set, the invoice is paid from the balance of the client as far as it suffices. The invoice of a deposit service has no discount and is credited to the balance of the client.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Param data body dto.InvoiceDto true "The promo code and the payment from the balance."
+// @Success 200 {object} models.Invoice "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Failure 409 {object} apperror.Response "The visit is not completed, has already been invoiced or the promo code is not valid."
+// @Failure 422 {object} apperror.Response "The promo code does not exist."
+// @Router /visits/{id}/invoice [post]
+func (r *InvoiceController) Issue(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.InvoiceDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	invoice, err := r.service.Issue(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, invoice)
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestIssueInvoice_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	invoice := NewInvoiceController(cont)
+	e.POST(config.APIv1VisitsIDInvoice, func(c echo.Context) error { return invoice.Issue(c) })
+
+	_, _ = (&models.Visit{}).Complete(cont.Repository(), 1, 1, time.Now())
+
+	param := &dto.InvoiceDto{PromoCode: "WELCOME10"}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1VisitsIDInvoice, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Invoice{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestIssueInvoice_NotCompleted(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	invoice := NewInvoiceController(cont)
+	e.POST(config.APIv1VisitsIDInvoice, func(c echo.Context) error { return invoice.Issue(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1VisitsIDInvoice, "1"), &dto.InvoiceDto{})
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestRefundBalance_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	balance := NewBalanceController(cont)
+	e.POST(config.APIv1ClientsIDBalanceRefund, func(c echo.Context) error { return balance.Refund(c) })
+
+	param := &dto.BalanceEntryDto{Amount: 100}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDBalanceRefund, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestTopUpBalance_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	balance := NewBalanceController(cont)
+	e.POST(config.APIv1ClientsIDBalanceTopUp, func(c echo.Context) error { return balance.TopUp(c) })
+
+	param := &dto.BalanceEntryDto{Amount: 1000, Note: "Наличные"}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ClientsIDBalanceTopUp, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.BalanceEntry{}
+	data, _ := m.GetBalance(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, float64(1000), data.Balance)
+}
+
+func TestCreatePromoCode_InvalidPeriod(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	promo := NewPromoCodeController(cont)
+	e.POST(config.APIv1PromoCodes, func(c echo.Context) error { return promo.Create(c) })
+
+	param := &dto.PromoCodeDto{Code: "SUMMER", Percent: 15,
+		ValidFrom: time.Date(2030, time.June, 1, 0, 0, 0, 0, time.Local),
+		ValidTo:   time.Date(2030, time.May, 1, 0, 0, 0, 0, time.Local)}
+	req := test.NewJSONRequest("POST", config.APIv1PromoCodes, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestGetAllDiscountTiers_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	tier := NewDiscountTierController(cont)
+	e.GET(config.APIv1DiscountTiers, func(c echo.Context) error { return tier.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1DiscountTiers, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.DiscountTier{}
+	data, _ := m.GetAll(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type LabResultController struct {
+	container container.Container
+	service   *service.LabResultService
+}
+
+// NewLabResultController is constructor.
+func NewLabResultController(container container.Container) *LabResultController {
+	return &LabResultController{container: container, service: service.NewLabResultService(container)}
+}
+
+// Get returns one record matched lab result's id.
+//
+// @Summary Get a lab result.
+// @Description Returns one record matched lab result's id.
+// @Tags LabResults
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Lab result ID"
+// @Success 200 {object} models.LabResult "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The lab result does not exist."
+// @Router /lab-results/{id} [get]
+func (r *LabResultController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	result, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetAllByVisit returns the lab results of the visit.
+//
+// @Summary Get the lab results of the visit.
+// @Description Returns the lab results of the visit ordered by the test.
+// @Tags LabResults
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Success 200 {object} []models.LabResult "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Router /visits/{id}/lab-results [get]
+func (r *LabResultController) GetAllByVisit(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	results, err := r.service.GetAllByVisit(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, results)
+}
+
+// GetAllByPet returns the lab results of the pet.
+//
+// @Summary Get the lab results of the pet.
+// @Description Returns the lab results of the pet in the order of the visits. The test parameter narrows them down to the history of the test.
+// @Tags LabResults
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Param test query string false "The name of the test regardless of the case, e.g. Гемоглобин"
+// @Success 200 {object} []models.LabResult "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/lab-results [get]
+func (r *LabResultController) GetAllByPet(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	results, err := r.service.GetAllByPet(c.Request().Context(), c.Param("id"), c.QueryParam("test"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, results)
+}
+
+// Create creates a new lab result.
+//
+// @Summary Create a new lab result.
+// @Description Record a value measured by a laboratory test for the pet of the visit. The value is flagged as normal, low or high according to the reference range.
+// @Tags LabResults
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.LabResultDto true "A new lab result data for creating."
+// @Success 200 {object} models.LabResult "Success to create."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The visit does not exist or the reference range is invalid."
+// @Router /lab-results [post]
+func (r *LabResultController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.LabResultDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	result, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// Delete deletes the lab result.
+//
+// @Summary Delete the lab result. Required user's role: Admin
+// @Description Delete the lab result entered by mistake permanently.
+// @Tags LabResults
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Lab result ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.LabResult "Success to delete."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The lab result does not exist."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /lab-results/{id} [delete]
+func (r *LabResultController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	result, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestCreateLabResult_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	result := NewLabResultController(cont)
+	e.POST(config.APIv1LabResults, func(c echo.Context) error { return result.Create(c) })
+
+	param := createLabResultForCreate()
+	param.Value = 170
+	req := test.NewJSONRequest("POST", config.APIv1LabResults, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.LabResult{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, models.LabResultHigh, data.Flag)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateLabResult_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	result := NewLabResultController(cont)
+	e.POST(config.APIv1LabResults, func(c echo.Context) error { return result.Create(c) })
+
+	param := createLabResultForCreate()
+	param.Test = ""
+	req := test.NewJSONRequest("POST", config.APIv1LabResults, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"test"`)
+}
+
+func TestGetLabResultsByPet_Test(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	result := NewLabResultController(cont)
+	e.GET(config.APIv1PetsIDLabResults, func(c echo.Context) error { return result.GetAllByPet(c) })
+
+	s := service.NewLabResultService(cont)
+	_, _ = s.Create(context.Background(), createLabResultForCreate())
+	other := createLabResultForCreate()
+	other.Test = "Глюкоза"
+	_, _ = s.Create(context.Background(), other)
+
+	req := httptest.NewRequest("GET", config.APIv1Pets+"/1"+config.LabResults+"?test="+url.QueryEscape("Глюкоза"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.LabResult{}
+	data, _ := m.GetAllByPet(cont.Repository(), 1, "Глюкоза")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestDeleteLabResult_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	result := NewLabResultController(cont)
+	e.DELETE(config.APIv1LabResultsID, func(c echo.Context) error { return result.Delete(c) })
+
+	_, _ = service.NewLabResultService(cont).Create(context.Background(), createLabResultForCreate())
+
+	req := httptest.NewRequest("DELETE", config.APIv1LabResults+"/1", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func createLabResultForCreate() *dto.LabResultDto {
+	low, high := 80.0, 150.0
+	return &dto.LabResultDto{
+		VisitID:      1,
+		Test:         "Гемоглобин",
+		Value:        132,
+		Unit:         "г/л",
+		ReferenceMin: &low,
+		ReferenceMax: &high,
+	}
+}
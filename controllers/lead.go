@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -19,6 +20,11 @@ func NewLeadController(container container.Container) *LeadController {
 	return &LeadController{container: container, service: service.NewLeadService(container)}
 }
 
+// NewLeadControllerWithCaptcha is constructor which uses the given captcha verifier, nil disables the captcha.
+func NewLeadControllerWithCaptcha(container container.Container, captcha service.CaptchaVerifier) *LeadController {
+	return &LeadController{container: container, service: service.NewLeadServiceWithCaptcha(container, captcha)}
+}
+
 // Get returns one record matched lead's id.
 //
 // @Summary Get a lead.
@@ -29,20 +35,21 @@ func NewLeadController(container container.Container) *LeadController {
 // @Security ApiKeyAuth
 // @Param id path string true "Lead ID"
 // @Success 200 {object} models.Lead "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /leads/{id} [get]
 func (r *LeadController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	lead, err := r.service.Get(c.Param("id"))
+	lead, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, lead)
+	return jsonWithETag(c, lead)
 }
 
 // GetAll returns the list of leads.
@@ -54,48 +61,66 @@ func (r *LeadController) Get(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} []models.Lead "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /leads [get]
 func (r *LeadController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	leads, err := r.service.GetAll()
+	leads, err := r.service.GetAll(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, leads)
 }
 
-// Create creates a new lead.
+// GetForm returns the data for showing the public lead form.
+//
+// @Summary Get the data for showing the lead form.
+// @Description Returns a new form token, which is submitted along with the lead. It records when the form was shown.
+// @Tags Leads
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.LeadForm "Success to fetch data."
+// @Failure 429 {object} apperror.Response "Too many requests."
+// @Router /leads/form [get]
+func (r *LeadController) GetForm(c echo.Context) error {
+	return c.JSON(http.StatusOK, r.service.GetForm(c.Request().Context()))
+}
+
+// Create creates a new lead submitted by the public form.
 //
 // @Summary Create a new lead.
-// @Description Create a new lead.
+// @Description Create a new lead submitted by the public form. The lead is assigned to the default assignee.
+// @Description The honeypot field must be empty, the form token must be valid, the form must not be submitted too fast
+// @Description and the captcha must be solved if it is enabled. A lead with the same phone or e-mail as a recent one is rejected.
 // @Tags Leads
 // @Accept json
 // @Produce json
-// @Param data body dto.LeadDto true "A new lead data for creating."
+// @Param data body dto.LeadRequestDto true "A new lead data for creating."
 // @Success 200 {object} models.Lead "Success to fetch data."
-// @Failure 400 {object} dto.LeadDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 422 {object} apperror.Response "The lead has been rejected or the captcha is not valid."
+// @Failure 429 {object} apperror.Response "Too many requests."
 // @Router /leads [post]
 func (r *LeadController) Create(c echo.Context) error {
-	data := &dto.LeadDto{}
+	data := &dto.LeadRequestDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	lead, err := r.service.Create(data)
+	lead, err := r.service.Create(c.Request().Context(), data, c.RealIP())
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, lead)
+	return jsonWithETag(c, lead)
 }
 
 // Update updates the existing lead.
@@ -108,31 +133,78 @@ func (r *LeadController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Lead ID"
 // @Param data body dto.LeadDto true "Lead data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Lead "Success to fetch data."
-// @Failure 400 {object} dto.LeadDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /leads/{id} [put]
 func (r *LeadController) Update(c echo.Context) error {
 	user := getUser(c, r.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.LeadDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
 	data.LastUpdatedByID = user.ID
-	lead, err := r.service.Update(data, c.Param("id"))
+	lead, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, lead)
+	return jsonWithETag(c, lead)
+}
+
+// Patch partially updates the existing lead.
+//
+// @Summary Partially update the existing lead.
+// @Description Partially update the existing lead by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Leads
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Lead ID"
+// @Param data body dto.LeadDto true "Lead fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Lead "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /leads/{id} [patch]
+func (r *LeadController) Patch(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.LeadDto{})
+	if err != nil {
+		return err
+	}
+
+	lead, err := r.service.Patch(c.Request().Context(), patch, c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, lead)
 }
 
 // Delete deletes the existing lead.
@@ -144,23 +216,30 @@ func (r *LeadController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Lead ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Lead "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /leads/{id} [delete]
 func (r *LeadController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	lead, err := r.service.Delete(c.Param("id"))
+	lead, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, lead)
 }
@@ -1,15 +1,19 @@
 package controllers
 
 import (
+	"encoding/json"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/service"
 	"vet-clinic/test"
 	"vet-clinic/util"
 )
@@ -24,6 +28,12 @@ type LeadDtoForBindError struct {
 	DoctorID string
 }
 
+type LeadRequestDtoForBindError struct {
+	Name      string
+	Phone     string
+	FormToken int
+}
+
 func TestGetLeadByID_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -61,8 +71,8 @@ func TestGetLead_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -123,7 +133,7 @@ func TestCreateLead_Success(t *testing.T) {
 	lead := NewLeadController(cont)
 	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
 
-	param := createLeadForCreate()
+	param := createLeadRequestForCreate(cont)
 	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
 	rec := httptest.NewRecorder()
 
@@ -134,6 +144,7 @@ func TestCreateLead_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, uint(1), data.DoctorID)
 }
 
 func TestCreateLead_BindError(t *testing.T) {
@@ -142,15 +153,14 @@ func TestCreateLead_BindError(t *testing.T) {
 	lead := NewLeadController(cont)
 	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
 
-	param := createLeadForBindError()
+	param := createLeadRequestForBindError()
 	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
 	rec := httptest.NewRecorder()
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultLeadForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreateLead_ValidationError(t *testing.T) {
@@ -159,25 +169,156 @@ func TestCreateLead_ValidationError(t *testing.T) {
 	lead := NewLeadController(cont)
 	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
 
-	param := createLeadForValidationError()
+	param := createLeadRequestForValidationError()
 	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
 	rec := httptest.NewRecorder()
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "LeadDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Phone")
-	assert.Contains(t, rec.Body.String(), "'e164'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Email")
-	assert.Contains(t, rec.Body.String(), "'email'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Comment")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Type")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Status")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"phone"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"e164"`)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"email"`)
+	assert.Contains(t, rec.Body.String(), `"field":"comment"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"type"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+}
+
+func TestCreateLead_Honeypot(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadController(cont)
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	param := createLeadRequestForCreate(cont)
+	param.Website = "http://spam.example.com"
+	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Lead{}
+	leads, _ := m.GetAll(cont.Repository())
+
+	expected := apperror.Response{Code: apperror.ValidationFailed, Message: "the lead has been rejected"}
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+	assert.Len(t, leads, 1)
+}
+
+func TestCreateLead_TooFast(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadController(cont)
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	param := createLeadRequestForCreate(cont)
+	param.FormToken = service.NewLeadService(cont).IssueFormToken(time.Now().Add(-time.Second))
+	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the lead has been rejected")
+}
+
+func TestGetLeadForm_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadController(cont)
+	e.GET(config.APIv1LeadsForm, func(c echo.Context) error { return lead.GetForm(c) })
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1LeadsForm, nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	form := &models.LeadForm{}
+	_ = json.Unmarshal(rec.Body.Bytes(), form)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, form.FormToken)
+
+	// The form has just been shown, so it is submitted too fast.
+	param := createLeadRequestForCreate(cont)
+	param.FormToken = form.FormToken
+	req = test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec = httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	cont.Config().Lead.MinFillTime = 0
+	req = test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec = httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestCreateLead_Duplicate(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadController(cont)
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	for i, expected := range []int{http.StatusOK, http.StatusUnprocessableEntity} {
+		param := createLeadRequestForCreate(cont)
+		if i > 0 {
+			param.Phone = "+79990000000"
+		}
+		req := test.NewJSONRequest("POST", config.APIv1Leads, param)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, expected, rec.Code)
+		if i > 0 {
+			assert.Contains(t, rec.Body.String(), `"message":"the lead has been rejected"`)
+		}
+	}
+}
+
+func TestCreateLead_CaptchaFailure(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadControllerWithCaptcha(cont, &test.FakeCaptchaVerifier{Token: "valid"})
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	param := createLeadRequestForCreate(cont)
+	param.CaptchaToken = "invalid"
+	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"captchaToken"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"captcha"`)
+}
+
+func TestCreateLead_CaptchaSuccess(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	lead := NewLeadControllerWithCaptcha(cont, &test.FakeCaptchaVerifier{Token: "valid"})
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+
+	param := createLeadRequestForCreate(cont)
+	param.CaptchaToken = "valid"
+	req := test.NewJSONRequest("POST", config.APIv1Leads, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
 func TestUpdateLead_Success(t *testing.T) {
@@ -220,9 +361,8 @@ func TestUpdateLead_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultLeadForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdateLead_ValidationError(t *testing.T) {
@@ -240,19 +380,19 @@ func TestUpdateLead_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "LeadDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Phone")
-	assert.Contains(t, rec.Body.String(), "'e164'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Email")
-	assert.Contains(t, rec.Body.String(), "'email'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Comment")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Type")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "LeadDto.Status")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"phone"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"e164"`)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"email"`)
+	assert.Contains(t, rec.Body.String(), `"field":"comment"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"type"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"status"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateLead_Unauthorized(t *testing.T) {
@@ -270,6 +410,33 @@ func TestUpdateLead_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestPatchLead_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpLeadTestData(cont)
+
+	lead := NewLeadController(cont)
+	e.PATCH(config.APIv1LeadsID, func(c echo.Context) error { return lead.Patch(c) })
+
+	param := map[string]interface{}{"status": "Закрыт"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1LeadsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Lead{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, "Закрыт", data.Status)
+	assert.Equal(t, createLeadForCreate().Phone, data.Phone)
+}
+
 func TestDeleteLead_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -307,8 +474,8 @@ func TestDeleteLead_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -361,27 +528,44 @@ func createLeadForCreate() *dto.LeadDto {
 	}
 }
 
-func createLeadForBindError() *LeadDtoForBindError {
-	return &LeadDtoForBindError{
-		Name:     "Клиент",
-		Phone:    "+79998887766",
-		Email:    "client@test.com",
-		Comment:  "Комментарий",
-		Type:     "consult-online",
-		Status:   "in-progress",
-		DoctorID: "Doctor",
+func createLeadRequestForCreate(cont container.Container) *dto.LeadRequestDto {
+	return &dto.LeadRequestDto{
+		Name:      "Клиент",
+		Phone:     "+79998887766",
+		Email:     "client@test.com",
+		Comment:   "Комментарий",
+		Type:      "consult-online",
+		FormToken: service.NewLeadService(cont).IssueFormToken(time.Now().Add(-time.Minute)),
 	}
 }
 
-func createResultLeadForBindError() *dto.LeadDto {
-	return &dto.LeadDto{
+func createLeadRequestForBindError() *LeadRequestDtoForBindError {
+	return &LeadRequestDtoForBindError{
+		Name:      "Клиент",
+		Phone:     "+79998887766",
+		FormToken: 1,
+	}
+}
+
+func createLeadRequestForValidationError() *dto.LeadRequestDto {
+	return &dto.LeadRequestDto{
+		Name:    "Клиент2",
+		Phone:   "2",
+		Email:   "client2test.com",
+		Comment: "Комментарий\n",
+		Type:    "consult-online\n",
+	}
+}
+
+func createLeadForBindError() *LeadDtoForBindError {
+	return &LeadDtoForBindError{
 		Name:     "Клиент",
 		Phone:    "+79998887766",
 		Email:    "client@test.com",
 		Comment:  "Комментарий",
 		Type:     "consult-online",
 		Status:   "in-progress",
-		DoctorID: 0,
+		DoctorID: "Doctor",
 	}
 }
 
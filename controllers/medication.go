@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type MedicationController struct {
+	container container.Container
+	service   *service.MedicationService
+}
+
+// NewMedicationController is constructor.
+func NewMedicationController(container container.Container) *MedicationController {
+	return &MedicationController{container: container, service: service.NewMedicationService(container)}
+}
+
+// Get returns one record matched medication's id.
+//
+// @Summary Get a medication.
+// @Description Returns one record matched medication's id.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Medication ID"
+// @Success 200 {object} models.Medication "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /medications/{id} [get]
+func (r *MedicationController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	medication, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, medication)
+}
+
+// GetAll returns the list of medications.
+//
+// @Summary Get a medication list.
+// @Description Returns the list of medications.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.Medication "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /medications [get]
+func (r *MedicationController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	medications, err := r.service.GetAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, medications)
+}
+
+// Create creates a new medication.
+//
+// @Summary Create a new medication. Required user's role: Admin
+// @Description Create a new medication.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.MedicationDto true "A new medication data for creating."
+// @Success 200 {object} models.Medication "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The medication with the same name, form and strength already exists."
+// @Router /medications [post]
+func (r *MedicationController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.MedicationDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	medication, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, medication)
+}
+
+// Update updates the existing medication.
+//
+// @Summary Update the existing medication. Required user's role: Admin
+// @Description Update the existing medication.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Medication ID"
+// @Param data body dto.MedicationDto true "Service data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Medication "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The medication with the same name, form and strength already exists."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /medications/{id} [put]
+func (r *MedicationController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.MedicationDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	medication, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, medication)
+}
+
+// Delete deletes the existing medication.
+//
+// @Summary Delete the existing medication. Required user's role: Admin
+// @Description Delete the existing medication. It fails while prescriptions, including the soft-deleted ones, refer to the medication.
+// @Tags Medications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Medication ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Medication "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The medication is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /medications/{id} [delete]
+func (r *MedicationController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	medication, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, medication)
+}
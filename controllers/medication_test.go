@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetMedicationList_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	medication := NewMedicationController(cont)
+	e.GET(config.APIv1Medications, func(c echo.Context) error { return medication.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Medications, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Medication{}
+	data, _ := m.GetAll(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateMedication_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	medication := NewMedicationController(cont)
+	e.POST(config.APIv1Medications, func(c echo.Context) error { return medication.Create(c) })
+
+	param := &dto.MedicationDto{Name: "Фуросемид", Form: "таблетки", Strength: 40, Unit: "мг"}
+	req := test.NewJSONRequest("POST", config.APIv1Medications, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Medication{}
+	data, _ := m.Get(cont.Repository(), 4)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateMedication_Duplicate(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	medication := NewMedicationController(cont)
+	e.POST(config.APIv1Medications, func(c echo.Context) error { return medication.Create(c) })
+
+	param := &dto.MedicationDto{Name: "Синулокс", Form: "таблетки", Strength: 50, Unit: "мг"}
+	req := test.NewJSONRequest("POST", config.APIv1Medications, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateMedication_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	medication := NewMedicationController(cont)
+	e.POST(config.APIv1Medications, func(c echo.Context) error { return medication.Create(c) })
+
+	param := &dto.MedicationDto{Name: "Фуросемид", Form: "таблетки", Strength: 40, Unit: "мг"}
+	req := test.NewJSONRequest("POST", config.APIv1Medications, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+	"vet-clinic/apperror"
+	"vet-clinic/util"
+)
+
+type partialValidator interface {
+	ValidatePartial(i interface{}, fields ...string) error
+}
+
+// bindMergePatch reads the JSON Merge Patch from the request body
+// and validates only the fields present in it against the rules of the given DTO.
+func bindMergePatch(c echo.Context, data interface{}) ([]byte, error) {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, util.MergePatchMIMEType) &&
+		!strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return nil, echo.ErrUnsupportedMediaType
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := util.ApplyMergePatch(data, patch)
+	if err != nil {
+		return nil, apperror.Wrap(err, http.StatusBadRequest, apperror.BadRequest, err.Error())
+	}
+
+	if v, ok := c.Echo().Validator.(partialValidator); ok {
+		if err := v.ValidatePartial(data, fields...); err != nil {
+			return nil, err
+		}
+	}
+	return patch, nil
+}
@@ -3,6 +3,8 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -29,20 +31,21 @@ func NewPetController(container container.Container) *PetController {
 // @Security ApiKeyAuth
 // @Param id path string true "Pet ID"
 // @Success 200 {object} models.Pet "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /pets/{id} [get]
 func (r *PetController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	pet, err := r.service.Get(c.Param("id"))
+	pet, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, pet)
+	return jsonWithETag(c, pet)
 }
 
 // GetAll returns the list of pets.
@@ -53,19 +56,26 @@ func (r *PetController) Get(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param includeDeleted query bool false "Include the soft-deleted pets. Required user's role: Admin"
 // @Success 200 {object} []models.Pet "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /pets [get]
 func (r *PetController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	includeDeleted, _ := strconv.ParseBool(c.QueryParam("includeDeleted"))
+	if includeDeleted && !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
 	}
 
-	pets, err := r.service.GetAll()
+	pets, err := r.service.GetAll(c.Request().Context(), includeDeleted)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, pets)
 }
@@ -80,29 +90,29 @@ func (r *PetController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.PetDto true "A new pet data for creating."
 // @Success 200 {object} models.Pet "Success to fetch data."
-// @Failure 400 {object} dto.PetDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /pets [post]
 func (r *PetController) Create(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
 	data := &dto.PetDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	pet, err := r.service.Create(data)
+	pet, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, pet)
+	return jsonWithETag(c, pet)
 }
 
 // Update updates the existing pet.
@@ -115,30 +125,77 @@ func (r *PetController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Pet ID"
 // @Param data body dto.PetDto true "Pet data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Pet "Success to fetch data."
-// @Failure 400 {object} dto.PetDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /pets/{id} [put]
 func (r *PetController) Update(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.PetDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	pet, err := r.service.Update(data, c.Param("id"))
+	pet, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, pet)
+	return jsonWithETag(c, pet)
+}
+
+// Patch partially updates the existing pet.
+//
+// @Summary Partially update the existing pet.
+// @Description Partially update the existing pet by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Pets
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Param data body dto.PetDto true "Pet fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Pet "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /pets/{id} [patch]
+func (r *PetController) Patch(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.PetDto{})
+	if err != nil {
+		return err
+	}
+
+	pet, err := r.service.Patch(c.Request().Context(), patch, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, pet)
 }
 
 // Delete deletes the existing pet.
@@ -150,23 +207,184 @@ func (r *PetController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Pet ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Pet "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /pets/{id} [delete]
 func (r *PetController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	pet, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, pet)
+}
+
+// Restore restores the soft-deleted pet.
+//
+// @Summary Restore the soft-deleted pet. Required user's role: Superuser
+// @Description Restore the soft-deleted pet.
+// @Tags Pets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} models.Pet "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/restore [post]
+func (r *PetController) Restore(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	pet, err := r.service.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, pet)
+}
+
+// Purge permanently deletes the pet.
+//
+// @Summary Permanently delete the pet, including the soft-deleted one. Required user's role: Superuser
+// @Description Permanently delete the pet, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the pet.
+// @Tags Pets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} models.Pet "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Failure 409 {object} apperror.Response "The pet is referenced by other records."
+// @Router /pets/{id}/purge [delete]
+func (r *PetController) Purge(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
-	pet, err := r.service.Delete(c.Param("id"))
+	pet, err := r.service.Purge(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, pet)
 }
+
+// GetWeights returns the weight history of the pet.
+//
+// @Summary Get the weight history of the pet.
+// @Description Returns the weight measurements of the pet ordered by the measurement time.
+// @Tags Pets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} []models.PetWeight "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/weights [get]
+func (r *PetController) GetWeights(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	weights, err := r.service.GetWeights(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, weights)
+}
+
+// RecordWeight records the weight measurement of the pet.
+//
+// @Summary Record the weight of the pet.
+// @Description Record the weight measurement of the pet. The weight recorded at the same visit is replaced.
+// @Tags Pets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Param data body dto.PetWeightDto true "The weight measurement."
+// @Success 200 {object} models.PetWeight "Success to record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Failure 422 {object} apperror.Response "The visit of the pet does not exist."
+// @Router /pets/{id}/weights [post]
+func (r *PetController) RecordWeight(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.PetWeightDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	weight, err := r.service.RecordWeight(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, weight)
+}
+
+// GetWeightTrend returns the summary of the weight history of the pet.
+//
+// @Summary Get the weight trend of the pet.
+// @Description Returns the first, the latest, the minimum and the maximum weight of the pet, the change and the direction of the trend.
+// @Tags Pets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} models.WeightTrend "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/weights/trend [get]
+func (r *PetController) GetWeightTrend(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	trend, err := r.service.GetWeightTrend(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, trend)
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
@@ -60,8 +62,8 @@ func TestGetPet_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -153,9 +155,8 @@ func TestCreatePet_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultPetForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreatePet_ValidationError(t *testing.T) {
@@ -173,17 +174,38 @@ func TestCreatePet_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "PetDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Type")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Breed")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Colour")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Sex")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"type"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"breed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"colour"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"sex"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+}
+
+func TestCreatePet_OwnerValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	pet := NewPetController(cont)
+	e.POST(config.APIv1Pets, func(c echo.Context) error { return pet.Create(c) })
+
+	param := createPetForCreate()
+	param.Owners = []*dto.PetOwnerDto{{ClientID: 1, Role: models.PetOwnerPrimary}}
+	req := test.NewJSONRequest("POST", config.APIv1Pets, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"owners[0].role"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"oneof"`)
 }
 
 func TestCreatePet_Unauthorized(t *testing.T) {
@@ -240,9 +262,8 @@ func TestUpdatePet_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultPetForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdatePet_ValidationError(t *testing.T) {
@@ -260,17 +281,17 @@ func TestUpdatePet_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "PetDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Type")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Breed")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Colour")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "PetDto.Sex")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"type"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"breed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"colour"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"sex"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
 }
 
 func TestUpdatePet_Unauthorized(t *testing.T) {
@@ -288,6 +309,32 @@ func TestUpdatePet_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestPatchPet_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpPetTestData(cont)
+
+	pet := NewPetController(cont)
+	e.PATCH(config.APIv1PetsID, func(c echo.Context) error { return pet.Patch(c) })
+
+	param := map[string]interface{}{"colour": "Рыжий"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1PetsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Pet{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, "Рыжий", data.Colour)
+	assert.Equal(t, createPetForCreate().Name, data.Name)
+}
+
 func TestDeletePet_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -325,8 +372,8 @@ func TestDeletePet_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -361,6 +408,86 @@ func TestDeletePet_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+func TestRecordPetWeight_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	pet := NewPetController(cont)
+	e.POST(config.APIv1PetsIDWeights, func(c echo.Context) error { return pet.RecordWeight(c) })
+
+	param := &dto.PetWeightDto{Weight: 4.5, VisitID: 1}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1PetsIDWeights, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	weights, _ := (&models.PetWeight{}).GetAllByPet(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, weights, 1) {
+		assert.JSONEq(t, test.ConvertToJSON(weights[0]), rec.Body.String())
+	}
+}
+
+func TestRecordPetWeight_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	pet := NewPetController(cont)
+	e.POST(config.APIv1PetsIDWeights, func(c echo.Context) error { return pet.RecordWeight(c) })
+
+	param := &dto.PetWeightDto{Weight: -1}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1PetsIDWeights, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"weight"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"gt"`)
+}
+
+func TestGetPetWeightTrend_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	pet := NewPetController(cont)
+	e.GET(config.APIv1PetsIDWeightsTrend, func(c echo.Context) error { return pet.GetWeightTrend(c) })
+
+	weight := &models.PetWeight{Weight: 4.5}
+	_, _ = weight.Record(cont.Repository(), 1, time.Now())
+
+	req := test.NewJSONRequest("GET", test.SetParam(config.APIv1PetsIDWeightsTrend, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	trend, _ := weight.GetTrend(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(trend), rec.Body.String())
+}
+
+func TestGetPetWeightTrend_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	pet := NewPetController(cont)
+	e.GET(config.APIv1PetsIDWeightsTrend, func(c echo.Context) error { return pet.GetWeightTrend(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1PetsIDWeightsTrend, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
 func setUpPetTestData(container container.Container) {
 	rep := container.Repository()
 	pet := createPetForCreate().ToModel()
@@ -389,17 +516,6 @@ func createPetForBindError() *PetDtoForBindError {
 	}
 }
 
-func createResultPetForBindError() *dto.PetDto {
-	return &dto.PetDto{
-		Name:     "Шарик",
-		Type:     "Пёс",
-		Breed:    "Дворняга",
-		Colour:   "Коричневый",
-		Sex:      "Самец",
-		ClientID: 0,
-	}
-}
-
 func createPetForValidationError() *dto.PetDto {
 	return &dto.PetDto{
 		Name:     "Шарик2",
@@ -414,8 +530,8 @@ func createPetForValidationError() *dto.PetDto {
 func createPetForUpdate() *dto.PetDto {
 	return &dto.PetDto{
 		Name:     "Ракета",
-		Type:     "Енот",
-		Breed:    "Полоскун",
+		Type:     "Хорёк",
+		Breed:    "Хонорик",
 		Colour:   "Буровато-серый",
 		Sex:      "Самец",
 		ClientID: 1,
@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+)
+
+// PortalController serves the client portal. Its endpoints are available only to the clients logged in to the portal,
+// the staff users cannot access them and the clients cannot access the staff endpoints.
+type PortalController struct {
+	container container.Container
+	accounts  *service.ClientAccountService
+	service   *service.PortalService
+}
+
+// NewPortalController is constructor.
+func NewPortalController(container container.Container) *PortalController {
+	return &PortalController{
+		container: container,
+		accounts:  service.NewClientAccountService(container),
+		service:   service.NewPortalService(container),
+	}
+}
+
+// Login logs the client in to the portal with the password.
+//
+// @Summary Log in to the client portal with the password.
+// @Description Login using the phone or the e-mail of the client along with the password.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param data body dto.PortalLoginDto true "Login and Password for logged-in."
+// @Success 200 {object} models.ClientAccount "Success to the authentication."
+// @Header 200 {string} Cookie "Authorization"
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/login [post]
+func (p *PortalController) Login(c echo.Context) error {
+	data := &dto.PortalLoginDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	account, err := p.accounts.Login(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return p.saveAccount(c, account)
+}
+
+// RequestCode sends a one-time login code to the client.
+//
+// @Summary Request a one-time code to log in to the client portal.
+// @Description Send a one-time login code to the client with the given phone or e-mail.
+// @Description The response does not reveal whether the client has a portal account.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param data body dto.PortalCodeRequestDto true "Login of the client."
+// @Success 204 "The code has been sent if the account exists."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Router /portal/code [post]
+func (p *PortalController) RequestCode(c echo.Context) error {
+	data := &dto.PortalCodeRequestDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	if err := p.accounts.RequestCode(c.Request().Context(), data); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LoginWithCode logs the client in to the portal with the one-time code.
+//
+// @Summary Log in to the client portal with a one-time code.
+// @Description Login using the phone or the e-mail of the client along with the one-time code sent to the client.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param data body dto.PortalCodeLoginDto true "Login and one-time code for logged-in."
+// @Success 200 {object} models.ClientAccount "Success to the authentication."
+// @Header 200 {string} Cookie "Authorization"
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/login/code [post]
+func (p *PortalController) LoginWithCode(c echo.Context) error {
+	data := &dto.PortalCodeLoginDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	account, err := p.accounts.LoginWithCode(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return p.saveAccount(c, account)
+}
+
+// saveAccount saves the client account in the session instead of the staff user, if any.
+func (p *PortalController) saveAccount(c echo.Context, account *models.ClientAccount) error {
+	sess := p.container.Session()
+	_ = sess.SetUser(c, nil)
+	_ = sess.SetClientAccount(c, account)
+	_ = sess.Save(c)
+	return c.JSON(http.StatusOK, account)
+}
+
+// Logout logs the client out of the portal.
+//
+// @Summary Log out of the client portal.
+// @Description Logout the client by invalidating the current session.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Success 200 "Successfully logged out."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/logout [post]
+func (p *PortalController) Logout(c echo.Context) error {
+	if getClientAccount(c, p.container) == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	sess := p.container.Session()
+	_ = sess.SetClientAccount(c, nil)
+	_ = sess.Delete(c)
+	return c.NoContent(http.StatusOK)
+}
+
+// GetProfile returns the data of the logged-in client.
+//
+// @Summary Get the data of the logged-in client.
+// @Description Returns the data of the client logged in to the portal.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Client "Success to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/profile [get]
+func (p *PortalController) GetProfile(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	client, err := p.service.GetProfile(c.Request().Context(), account.ClientID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, client)
+}
+
+// GetPets returns the pets of the logged-in client.
+//
+// @Summary Get the pets of the logged-in client.
+// @Description Returns the list of the pets of the client logged in to the portal.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Success 200 {object} []models.Pet "Success to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/pets [get]
+func (p *PortalController) GetPets(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	pets, err := p.service.GetPets(c.Request().Context(), account.ClientID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, pets)
+}
+
+// GetVisits returns the visits of the logged-in client.
+//
+// @Summary Get the visits of the logged-in client.
+// @Description Returns the list of the visits of the client logged in to the portal.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param period query string false "Only the upcoming or the past visits." Enums(upcoming, past)
+// @Success 200 {object} []models.PortalVisit "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/visits [get]
+func (p *PortalController) GetVisits(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	visits, err := p.service.GetVisits(c.Request().Context(), account.ClientID, c.QueryParam("period"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, visits)
+}
+
+// RequestVisit requests a visit for the logged-in client.
+//
+// @Summary Request a visit.
+// @Description Request a visit for a pet of the client logged in to the portal. The clinic schedules it later.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param data body dto.PortalVisitDto true "The requested visit."
+// @Success 200 {object} models.PortalVisit "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The pet does not belong to the client or the service does not exist."
+// @Router /portal/visits [post]
+func (p *PortalController) RequestVisit(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.PortalVisitDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	visit, err := p.service.RequestVisit(c.Request().Context(), account.ClientID, data)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, visit)
+}
+
+// CancelVisit cancels the upcoming visit of the logged-in client.
+//
+// @Summary Cancel a visit.
+// @Description Cancel the upcoming visit of the client logged in to the portal.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param id path string true "Visit ID"
+// @Success 200 {object} models.PortalVisit "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client has no such visit."
+// @Failure 409 {object} apperror.Response "The visit has taken place or is already cancelled."
+// @Router /portal/visits/{id}/cancel [post]
+func (p *PortalController) CancelVisit(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	visit, err := p.service.CancelVisit(c.Request().Context(), account.ClientID, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, visit)
+}
+
+// GetInvoices returns the invoices of the logged-in client.
+//
+// @Summary Get the invoices of the logged-in client.
+// @Description Returns the list of the invoices of the client logged in to the portal, the latest first.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Success 200 {object} []models.PortalInvoice "Success to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /portal/invoices [get]
+func (p *PortalController) GetInvoices(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	invoices, err := p.service.GetInvoices(c.Request().Context(), account.ClientID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, invoices)
+}
+
+// GetRecord returns the medical record of the pet of the logged-in client.
+//
+// @Summary Get the medical record of the pet.
+// @Description Returns the completed visits, the prescriptions, the lab results and the weights of the pet of the client logged in to the portal.
+// @Tags Portal
+// @Accept json
+// @Produce json
+// @Param id path string true "Pet ID"
+// @Success 200 {object} models.PortalRecord "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The client has no such pet."
+// @Router /portal/pets/{id}/record [get]
+func (p *PortalController) GetRecord(c echo.Context) error {
+	account := getClientAccount(c, p.container)
+	if account == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	record, err := p.service.GetRecord(c.Request().Context(), account.ClientID, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, record)
+}
+
+func getClientAccount(c echo.Context, container container.Container) *models.ClientAccount {
+	var account *models.ClientAccount
+	if account = container.Session().GetClientAccount(c); account != nil {
+		_ = container.Session().Save(c)
+		return account
+	}
+	return nil
+}
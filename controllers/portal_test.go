@@ -0,0 +1,406 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/config"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestPortalLogin_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalLogin, func(c echo.Context) error { return portal.Login(c) })
+
+	param := &dto.PortalLoginDto{Login: "+78888888888", Password: "Password1!"}
+	req := test.NewJSONRequest("POST", config.APIv1PortalLogin, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(account), rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Set-Cookie"))
+}
+
+func TestPortalLogin_WrongPassword(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalLogin, func(c echo.Context) error { return portal.Login(c) })
+
+	param := &dto.PortalLoginDto{Login: "mail@mail.su", Password: "Password2!"}
+	req := test.NewJSONRequest("POST", config.APIv1PortalLogin, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.InvalidCredentials, Message: "invalid login or password"}
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestPortalLoginWithCode_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+	_ = account.SetOneTimeCode(cont.Repository(), account.ID, "123456", time.Now().Add(time.Minute))
+	account, _ = account.Get(cont.Repository(), account.ID)
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalLoginCode, func(c echo.Context) error { return portal.LoginWithCode(c) })
+
+	param := &dto.PortalCodeLoginDto{Login: "+78888888888", Code: "123456"}
+	req := test.NewJSONRequest("POST", config.APIv1PortalLoginCode, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(account), rec.Body.String())
+}
+
+func TestPortalLoginWithCode_Expired(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+	_ = account.SetOneTimeCode(cont.Repository(), account.ID, "123456", time.Now().Add(-time.Minute))
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalLoginCode, func(c echo.Context) error { return portal.LoginWithCode(c) })
+
+	param := &dto.PortalCodeLoginDto{Login: "+78888888888", Code: "123456"}
+	req := test.NewJSONRequest("POST", config.APIv1PortalLoginCode, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestPortalRequestCode_UnknownLogin(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalCode, func(c echo.Context) error { return portal.RequestCode(c) })
+
+	param := &dto.PortalCodeRequestDto{Login: "+70000000000"}
+	req := test.NewJSONRequest("POST", config.APIv1PortalCode, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestPortalGetPets_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalPets, func(c echo.Context) error { return portal.GetPets(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PortalPets, nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Pet{}
+	data, _ := m.GetAllByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestPortalGetPets_StaffUnauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalPets, func(c echo.Context) error { return portal.GetPets(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PortalPets, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestPortalStaffEndpoint_ClientUnauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	client := NewClientController(cont)
+	e.GET(config.APIv1Clients, func(c echo.Context) error { return client.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Clients, nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestPortalGetVisits_Past(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalVisits, func(c echo.Context) error { return portal.GetVisits(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PortalVisits+"?period=past", nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	upcoming := false
+	m := &models.Visit{}
+	data, _ := m.GetAllByClient(cont.Repository(), 1, &upcoming, time.Now())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(models.NewPortalVisits(data)), rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), data[0].Doctor.Username)
+}
+
+func TestPortalGetVisits_InvalidPeriod(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalVisits, func(c echo.Context) error { return portal.GetVisits(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PortalVisits+"?period=tomorrow", nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.BadRequest, Message: "invalid period: tomorrow"}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestPortalRequestVisit_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalVisits, func(c echo.Context) error { return portal.RequestVisit(c) })
+
+	param := createPortalVisitForRequest()
+	req := test.NewJSONRequest("POST", config.APIv1PortalVisits, param)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, models.VisitRequested, data.Status)
+	assert.Equal(t, uint(1), data.ClientID)
+	assert.JSONEq(t, test.ConvertToJSON(models.NewPortalVisit(data)), rec.Body.String())
+}
+
+func TestPortalRequestVisit_ForeignPet(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	account := models.NewClientAccount(2, "")
+	account, _ = account.Create(cont.Repository())
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalVisits, func(c echo.Context) error { return portal.RequestVisit(c) })
+
+	param := createPortalVisitForRequest()
+	req := test.NewJSONRequest("POST", config.APIv1PortalVisits, param)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), apperror.InvalidReference)
+}
+
+func TestPortalCancelVisit_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+	visit := createPortalVisitForRequest().ToModel(1)
+	visit, _ = visit.Request(cont.Repository())
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalVisitsIDCancel, func(c echo.Context) error { return portal.CancelVisit(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1PortalVisitsIDCancel, "2"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := visit.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, models.VisitCancelled, data.Status)
+	assert.JSONEq(t, test.ConvertToJSON(models.NewPortalVisit(data)), rec.Body.String())
+}
+
+func TestPortalCancelVisit_Past(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalVisitsIDCancel, func(c echo.Context) error { return portal.CancelVisit(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1PortalVisitsIDCancel, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.InvalidState, Message: "only upcoming visits can be cancelled"}
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestPortalCancelVisit_OtherClient(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	account := models.NewClientAccount(2, "")
+	account, _ = account.Create(cont.Repository())
+
+	portal := NewPortalController(cont)
+	e.POST(config.APIv1PortalVisitsIDCancel, func(c echo.Context) error { return portal.CancelVisit(c) })
+
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1PortalVisitsIDCancel, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPortalGetInvoices_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+	_, _ = (&models.Visit{}).Complete(cont.Repository(), 1, 1, time.Now())
+	_, _ = (&models.Invoice{}).Issue(cont.Repository(), 1, "", false, time.Now())
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalInvoices, func(c echo.Context) error { return portal.GetInvoices(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PortalInvoices, nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := (&models.Invoice{}).GetAllByClient(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, data, 1) {
+		assert.JSONEq(t, test.ConvertToJSON(models.NewPortalInvoices(data)), rec.Body.String())
+		assert.NotContains(t, rec.Body.String(), data[0].Visit.Doctor.Username)
+	}
+}
+
+func TestPortalGetRecord_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	account := setUpClientAccountTestData(cont)
+	_, _ = (&models.Visit{}).Complete(cont.Repository(), 1, 1, time.Now())
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalPetsIDRecord, func(c echo.Context) error { return portal.GetRecord(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1PortalPetsIDRecord, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := (&models.Pet{}).GetPortalRecord(cont.Repository(), 1, 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data.Visits, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestPortalGetRecord_OtherClient(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpClientTestData(cont)
+	account := models.NewClientAccount(2, "")
+	account, _ = account.Create(cont.Repository())
+
+	portal := NewPortalController(cont)
+	e.GET(config.APIv1PortalPetsIDRecord, func(c echo.Context) error { return portal.GetRecord(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1PortalPetsIDRecord, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginClient(e, cont, req, rec, account)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func setUpClientAccountTestData(container container.Container) *models.ClientAccount {
+	account := models.NewClientAccount(1, "Password1!")
+	account, _ = account.Create(container.Repository())
+	return account
+}
+
+func createPortalVisitForRequest() *dto.PortalVisitDto {
+	return &dto.PortalVisitDto{
+		DateTime:  time.Now().Add(24 * time.Hour).Truncate(time.Second),
+		Info:      "Осмотр",
+		PetID:     1,
+		ServiceID: 1,
+	}
+}
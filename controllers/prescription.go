@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type PrescriptionController struct {
+	container container.Container
+	service   *service.PrescriptionService
+}
+
+// NewPrescriptionController is constructor.
+func NewPrescriptionController(container container.Container) *PrescriptionController {
+	return &PrescriptionController{container: container, service: service.NewPrescriptionService(container)}
+}
+
+// Get returns one record matched prescription's id.
+//
+// @Summary Get a prescription.
+// @Description Returns one record matched prescription's id.
+// @Tags Prescriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Prescription ID"
+// @Success 200 {object} models.Prescription "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The prescription does not exist."
+// @Router /prescriptions/{id} [get]
+func (r *PrescriptionController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	prescription, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, prescription)
+}
+
+// GetAllByPet returns the prescriptions of the pet.
+//
+// @Summary Get the prescriptions of the pet.
+// @Description Returns the prescriptions of the pet, the latest first.
+// @Tags Prescriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} []models.Prescription "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/prescriptions [get]
+func (r *PrescriptionController) GetAllByPet(c echo.Context) error {
+	return r.getAllByPet(c, false)
+}
+
+// GetCurrentByPet returns the medications the pet is to take now.
+//
+// @Summary Get the current medications of the pet.
+// @Description Returns the active prescriptions of the pet whose course includes the current time.
+// @Tags Prescriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Pet ID"
+// @Success 200 {object} []models.Prescription "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The pet does not exist."
+// @Router /pets/{id}/medications [get]
+func (r *PrescriptionController) GetCurrentByPet(c echo.Context) error {
+	return r.getAllByPet(c, true)
+}
+
+func (r *PrescriptionController) getAllByPet(c echo.Context, current bool) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	prescriptions, err := r.service.GetAllByPet(c.Request().Context(), c.Param("id"), current)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, prescriptions)
+}
+
+// Create prescribes a medication for the pet of the visit.
+//
+// @Summary Create a new prescription.
+// @Description Prescribe a medication for the pet of the visit. The logged-in user is the prescriber.
+// @Tags Prescriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.PrescriptionDto true "A new prescription data for creating."
+// @Success 200 {object} models.Prescription "Success to create."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The visit or the medication does not exist."
+// @Router /prescriptions [post]
+func (r *PrescriptionController) Create(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil || !util.Staff.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.PrescriptionDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	prescription, err := r.service.Create(c.Request().Context(), data, user.ID)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, prescription)
+}
+
+// Cancel cancels the prescription.
+//
+// @Summary Cancel the prescription.
+// @Description Cancel the prescription, so the medication is no longer current.
+// @Tags Prescriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Prescription ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Prescription "Success to cancel."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The prescription does not exist."
+// @Failure 409 {object} apperror.Response "The prescription has already been cancelled."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /prescriptions/{id}/cancel [post]
+func (r *PrescriptionController) Cancel(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	prescription, err := r.service.Cancel(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, prescription)
+}
+
+// Print returns the printable prescription.
+//
+// @Summary Print the prescription.
+// @Description Returns the prescription as an HTML document to be printed.
+// @Tags Prescriptions
+// @Produce html
+// @Security ApiKeyAuth
+// @Param id path string true "Prescription ID"
+// @Success 200 {string} string "The printable prescription."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The prescription does not exist."
+// @Router /prescriptions/{id}/print [get]
+func (r *PrescriptionController) Print(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	document, err := r.service.Print(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.HTMLBlob(http.StatusOK, document)
+}
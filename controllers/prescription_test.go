@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestCreatePrescription_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.POST(config.APIv1Prescriptions, func(c echo.Context) error { return prescription.Create(c) })
+
+	param := createPrescriptionForCreate()
+	req := test.NewJSONRequest("POST", config.APIv1Prescriptions, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Prescription{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, uint(1), data.PrescriberID)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreatePrescription_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.POST(config.APIv1Prescriptions, func(c echo.Context) error { return prescription.Create(c) })
+
+	param := createPrescriptionForCreate()
+	param.DurationDays = -1
+	req := test.NewJSONRequest("POST", config.APIv1Prescriptions, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"durationDays"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"gte"`)
+}
+
+func TestGetCurrentMedications_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.GET(config.APIv1PetsIDMedications, func(c echo.Context) error { return prescription.GetCurrentByPet(c) })
+
+	s := service.NewPrescriptionService(cont)
+	_, _ = s.Create(context.Background(), createPrescriptionForCreate(), 1)
+	param := createPrescriptionForCreate()
+	param.StartDate = time.Now().AddDate(0, 0, -1)
+	_, _ = s.Create(context.Background(), param, 1)
+
+	req := httptest.NewRequest("GET", config.APIv1Pets+"/1"+config.Medications, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Prescription{}
+	data, _ := m.GetCurrentByPet(cont.Repository(), 1, time.Now())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.Len(t, data, 1) {
+		assert.Equal(t, uint(2), data[0].ID)
+	}
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCancelPrescription_AlreadyCancelled(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.POST(config.APIv1PrescriptionsIDCancel, func(c echo.Context) error { return prescription.Cancel(c) })
+
+	s := service.NewPrescriptionService(cont)
+	_, _ = s.Create(context.Background(), createPrescriptionForCreate(), 1)
+	_, _ = s.Cancel(context.Background(), "1")
+
+	req := httptest.NewRequest("POST", config.APIv1Prescriptions+"/1/cancel", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the prescription has already been cancelled")
+}
+
+func TestCancelPrescription_IfMatch(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.POST(config.APIv1PrescriptionsIDCancel, func(c echo.Context) error { return prescription.Cancel(c) })
+
+	s := service.NewPrescriptionService(cont)
+	before, _ := s.Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	req := httptest.NewRequest("POST", config.APIv1Prescriptions+"/1/cancel", nil)
+	req.Header.Set("If-Match", before.ETag())
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := s.Get(context.Background(), "1")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, data.ETag(), rec.Header().Get("ETag"))
+	assert.Equal(t, models.PrescriptionCancelled, data.Status)
+}
+
+func TestCancelPrescription_PreconditionFailed(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.POST(config.APIv1PrescriptionsIDCancel, func(c echo.Context) error { return prescription.Cancel(c) })
+
+	_, _ = service.NewPrescriptionService(cont).Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	req := httptest.NewRequest("POST", config.APIv1Prescriptions+"/1/cancel", nil)
+	req.Header.Set("If-Match", `"1-0"`)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
+
+func TestPrintPrescription_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.GET(config.APIv1PrescriptionsIDPrint, func(c echo.Context) error { return prescription.Print(c) })
+
+	_, _ = service.NewPrescriptionService(cont).Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	req := httptest.NewRequest("GET", config.APIv1Prescriptions+"/1/print", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+	assert.Contains(t, rec.Body.String(), "Синулокс")
+}
+
+func TestPrintPrescription_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	prescription := NewPrescriptionController(cont)
+	e.GET(config.APIv1PrescriptionsIDPrint, func(c echo.Context) error { return prescription.Print(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Prescriptions+"/1/print", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func createPrescriptionForCreate() *dto.PrescriptionDto {
+	return &dto.PrescriptionDto{
+		VisitID:      1,
+		MedicationID: 1,
+		Dosage:       "1 таблетка",
+		Frequency:    "2 раза в день",
+		DurationDays: 7,
+	}
+}
@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type ProductController struct {
+	container container.Container
+	service   *service.ProductService
+}
+
+// NewProductController is constructor.
+func NewProductController(container container.Container) *ProductController {
+	return &ProductController{container: container, service: service.NewProductService(container)}
+}
+
+// Get returns one record matched product's id.
+//
+// @Summary Get a product.
+// @Description Returns one record matched product's id.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} models.Product "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /products/{id} [get]
+func (r *ProductController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	product, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, product)
+}
+
+// GetAll returns the list of products.
+//
+// @Summary Get a product list.
+// @Description Returns the list of products.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.Product "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /products [get]
+func (r *ProductController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	products, err := r.service.GetAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, products)
+}
+
+// Create creates a new product.
+//
+// @Summary Create a new product. Required user's role: Admin
+// @Description Create a new product.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.ProductDto true "A new product data for creating."
+// @Success 200 {object} models.Product "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The product with the same name already exists."
+// @Router /products [post]
+func (r *ProductController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ProductDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	product, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, product)
+}
+
+// Update updates the existing product.
+//
+// @Summary Update the existing product. Required user's role: Admin
+// @Description Update the existing product.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Param data body dto.ProductDto true "Service data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Product "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The product with the same name already exists."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /products/{id} [put]
+func (r *ProductController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.ProductDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	product, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, product)
+}
+
+// Delete deletes the existing product.
+//
+// @Summary Delete the existing product. Required user's role: Admin
+// @Description Delete the existing product. It fails while batches or services, including the soft-deleted ones, refer to the product.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Product "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The product is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /products/{id} [delete]
+func (r *ProductController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	product, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, product)
+}
+
+// GetLowStock returns the products whose quantity in stock is below the minimum.
+//
+// @Summary Get the products low in stock.
+// @Description Returns the products whose quantity in the non-expired batches is below the minimum stock.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.ProductStock "Success to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /products/low-stock [get]
+func (r *ProductController) GetLowStock(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	products, err := r.service.GetLowStock(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, products)
+}
+
+// GetBatches returns the batches of the product.
+//
+// @Summary Get the batches of the product.
+// @Description Returns the batches of the product ordered by the expiry date.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} []models.ProductBatch "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /products/{id}/batches [get]
+func (r *ProductController) GetBatches(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	batches, err := r.service.GetBatches(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, batches)
+}
+
+// ReceiveBatch registers a batch of the product received in stock.
+//
+// @Summary Receive a batch of the product.
+// @Description Registers a batch of the product received in stock.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Param data body dto.ProductBatchDto true "A new batch data for receiving."
+// @Success 200 {object} models.ProductBatch "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 409 {object} apperror.Response "The batch with the same number already exists for the product."
+// @Failure 422 {object} apperror.Response "The product does not exist."
+// @Router /products/{id}/batches [post]
+func (r *ProductController) ReceiveBatch(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.ProductBatchDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	batch, err := r.service.ReceiveBatch(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, batch)
+}
+
+// GetMovements returns the stock movements of the product.
+//
+// @Summary Get the stock movements of the product.
+// @Description Returns the receipts, usages and write-offs of the product, the latest first.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} []models.StockMovement "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /products/{id}/movements [get]
+func (r *ProductController) GetMovements(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	movements, err := r.service.GetMovements(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, movements)
+}
+
+// GetExpiring returns the batches expiring soon.
+//
+// @Summary Get the batches expiring soon.
+// @Description Returns the batches left in stock which expire within the given number of days, including the expired ones.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Number of days, 30 by default."
+// @Success 200 {object} []models.ProductBatch "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /batches/expiring [get]
+func (r *ProductController) GetExpiring(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	batches, err := r.service.GetExpiring(c.Request().Context(), c.QueryParam("days"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, batches)
+}
+
+// WriteOff writes off a quantity of the batch.
+//
+// @Summary Write off a quantity of the batch.
+// @Description Decreases the quantity of the batch, e.g. for damaged or expired goods.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Batch ID"
+// @Param data body dto.WriteOffDto true "Write-off data."
+// @Success 200 {object} models.ProductBatch "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The batch does not exist."
+// @Failure 409 {object} apperror.Response "The quantity exceeds the stock of the batch."
+// @Router /batches/{id}/write-off [post]
+func (r *ProductController) WriteOff(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.WriteOffDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	batch, err := r.service.WriteOff(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, batch)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetLowStock_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	product := NewProductController(cont)
+	e.GET(config.APIv1ProductsLowStock, func(c echo.Context) error { return product.GetLowStock(c) })
+	e.GET(config.APIv1ProductsID, func(c echo.Context) error { return product.Get(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1ProductsLowStock, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Product{}
+	data, _ := m.GetLowStock(cont.Repository(), time.Now())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestReceiveBatch_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	product := NewProductController(cont)
+	e.POST(config.APIv1ProductsIDBatches, func(c echo.Context) error { return product.ReceiveBatch(c) })
+
+	param := &dto.ProductBatchDto{BatchNumber: "G-1", ExpiryDate: time.Date(2030, time.March, 31, 0, 0, 0, 0, time.UTC), Quantity: 1000}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ProductsIDBatches, "3"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ProductBatch{}
+	data, _ := m.Get(cont.Repository(), 3)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestReceiveBatch_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	product := NewProductController(cont)
+	e.POST(config.APIv1ProductsIDBatches, func(c echo.Context) error { return product.ReceiveBatch(c) })
+
+	param := &dto.ProductBatchDto{BatchNumber: "G-1", ExpiryDate: time.Date(2030, time.March, 31, 0, 0, 0, 0, time.UTC)}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ProductsIDBatches, "3"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"quantity"`)
+}
+
+func TestWriteOff_ExceedsStock(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	product := NewProductController(cont)
+	e.POST(config.APIv1BatchesIDWriteOff, func(c echo.Context) error { return product.WriteOff(c) })
+
+	param := &dto.WriteOffDto{Quantity: 100}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1BatchesIDWriteOff, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateProduct_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	product := NewProductController(cont)
+	e.POST(config.APIv1Products, func(c echo.Context) error { return product.Create(c) })
+
+	param := &dto.ProductDto{Name: "Бинт", Unit: "шт", Price: 50, MinStock: 10}
+	req := test.NewJSONRequest("POST", config.APIv1Products, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
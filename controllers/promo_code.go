@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type PromoCodeController struct {
+	container container.Container
+	service   *service.PromoCodeService
+}
+
+// NewPromoCodeController is constructor.
+func NewPromoCodeController(container container.Container) *PromoCodeController {
+	return &PromoCodeController{container: container, service: service.NewPromoCodeService(container)}
+}
+
+// Get returns one record matched promo code's id.
+//
+// @Summary Get a promo code.
+// @Description Returns one record matched promo code's id.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Promo code ID"
+// @Success 200 {object} models.PromoCode "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /promo-codes/{id} [get]
+func (r *PromoCodeController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	code, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, code)
+}
+
+// GetAll returns the list of promo codes.
+//
+// @Summary Get a promo code list.
+// @Description Returns the list of promo codes.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.PromoCode "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /promo-codes [get]
+func (r *PromoCodeController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	codes, err := r.service.GetAll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, codes)
+}
+
+// Create creates a new promo code.
+//
+// @Summary Create a new promo code. Required user's role: Owner
+// @Description Create a new promo code.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.PromoCodeDto true "A new promo code data for creating."
+// @Success 200 {object} models.PromoCode "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The promo code already exists."
+// @Router /promo-codes [post]
+func (r *PromoCodeController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.PromoCodeDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	code, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, code)
+}
+
+// Update updates the existing promo code.
+//
+// @Summary Update the existing promo code. Required user's role: Owner
+// @Description Update the existing promo code.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Promo code ID"
+// @Param data body dto.PromoCodeDto true "Promo code data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.PromoCode "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The promo code already exists."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /promo-codes/{id} [put]
+func (r *PromoCodeController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.PromoCodeDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	code, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, code)
+}
+
+// Delete deletes the existing promo code.
+//
+// @Summary Delete the existing promo code. Required user's role: Owner
+// @Description Delete the existing promo code. It fails while invoices, including the soft-deleted ones, refer to the promo code.
+// @Tags Discounts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Promo code ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.PromoCode "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The promo code is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /promo-codes/{id} [delete]
+func (r *PromoCodeController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	code, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, code)
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type ResourceController struct {
+	container container.Container
+	service   *service.ResourceService
+}
+
+// NewResourceController is constructor.
+func NewResourceController(container container.Container) *ResourceController {
+	return &ResourceController{container: container, service: service.NewResourceService(container)}
+}
+
+// Get returns one record matched resource's id.
+//
+// @Summary Get a resource.
+// @Description Returns one record matched resource's id.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Resource ID"
+// @Success 200 {object} models.Resource "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /resources/{id} [get]
+func (r *ResourceController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	resource, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, resource)
+}
+
+// GetAll returns the list of resources.
+//
+// @Summary Get a resource list.
+// @Description Returns the list of resources ordered by name.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param departmentId query string false "Return only the resources of the department."
+// @Success 200 {object} []models.Resource "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /resources [get]
+func (r *ResourceController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	resources, err := r.service.GetAll(c.Request().Context(), c.QueryParam("departmentId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resources)
+}
+
+// Create creates a new resource.
+//
+// @Summary Create a new resource. Required user's role: Owner
+// @Description Create a new room or piece of equipment of the department.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.ResourceDto true "A new resource data for creating."
+// @Success 200 {object} models.Resource "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The department already has a resource with the same name."
+// @Failure 422 {object} apperror.Response "The department does not exist."
+// @Router /resources [post]
+func (r *ResourceController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ResourceDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	resource, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, resource)
+}
+
+// Update updates the existing resource.
+//
+// @Summary Update the existing resource. Required user's role: Owner
+// @Description Update the existing resource.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Resource ID"
+// @Param data body dto.ResourceDto true "Service data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Resource "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The department already has a resource with the same name."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /resources/{id} [put]
+func (r *ResourceController) Update(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.ResourceDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	resource, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, resource)
+}
+
+// Delete deletes the existing resource.
+//
+// @Summary Delete the existing resource. Required user's role: Owner
+// @Description Delete the existing resource. It fails while a service requires the resource or a visit has reserved it.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Resource ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Resource "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The resource is referenced by other records."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /resources/{id} [delete]
+func (r *ResourceController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	resource, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resource)
+}
+
+// GetReservations returns the reservations of the resource on the date.
+//
+// @Summary Get the reservations of the resource.
+// @Description Returns the reservations of the resource held by the tentative and scheduled visits on the given date.
+// @Tags Resources
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Resource ID"
+// @Param date query string false "Date in the format YYYY-MM-DD, today by default."
+// @Success 200 {object} []models.Reservation "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The resource does not exist."
+// @Router /resources/{id}/reservations [get]
+func (r *ResourceController) GetReservations(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	reservations, err := r.service.GetReservations(c.Request().Context(), c.Param("id"), c.QueryParam("date"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, reservations)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetResourceList_Department(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	resource := NewResourceController(cont)
+	e.GET(config.APIv1Resources, func(c echo.Context) error { return resource.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Resources+"?departmentId=2", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Resource{}
+	data, _ := m.GetAll(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateResource_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	resource := NewResourceController(cont)
+	e.POST(config.APIv1Resources, func(c echo.Context) error { return resource.Create(c) })
+
+	param := &dto.ResourceDto{Name: "Рентген", Type: models.ResourceEquipment, DepartmentID: 1}
+	req := test.NewJSONRequest("POST", config.APIv1Resources, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Resource{}
+	data, _ := m.Get(cont.Repository(), 4)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateResource_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	resource := NewResourceController(cont)
+	e.POST(config.APIv1Resources, func(c echo.Context) error { return resource.Create(c) })
+
+	param := &dto.ResourceDto{Name: "Рентген", Type: "device", DepartmentID: 1}
+	req := test.NewJSONRequest("POST", config.APIv1Resources, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestCreateResource_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	resource := NewResourceController(cont)
+	e.POST(config.APIv1Resources, func(c echo.Context) error { return resource.Create(c) })
+
+	param := &dto.ResourceDto{Name: "Рентген", Type: models.ResourceEquipment, DepartmentID: 1}
+	req := test.NewJSONRequest("POST", config.APIv1Resources, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestGetResourceReservations_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	resource := NewResourceController(cont)
+	e.GET(config.APIv1ResourcesIDReservations, func(c echo.Context) error { return resource.GetReservations(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1ResourcesIDReservations, "3")+"?date=2024-01-01", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+}
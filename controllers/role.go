@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -29,24 +30,25 @@ func NewRoleController(container container.Container) *RoleController {
 // @Security ApiKeyAuth
 // @Param id path string true "Role ID"
 // @Success 200 {object} models.Role "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /roles/{id} [get]
 func (r *RoleController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
-	role, err := r.service.Get(c.Param("id"))
+	role, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, role)
+	return jsonWithETag(c, role)
 }
 
 // GetAll returns the list of roles.
@@ -58,22 +60,22 @@ func (r *RoleController) Get(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} []models.Role "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /roles [get]
 func (r *RoleController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
-	roles, err := r.service.GetAll()
+	roles, err := r.service.GetAll(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, roles)
 }
@@ -88,33 +90,33 @@ func (r *RoleController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.RoleDto true "A new role data for creating."
 // @Success 200 {object} models.Role "Success to fetch data."
-// @Failure 400 {object} dto.RoleDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /roles [post]
 func (r *RoleController) Create(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.RoleDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	role, err := r.service.Create(data)
+	role, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, role)
+	return jsonWithETag(c, role)
 }
 
 // Update updates the existing role.
@@ -127,34 +129,41 @@ func (r *RoleController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Role ID"
 // @Param data body dto.RoleDto true "Role data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Role "Success to fetch data."
-// @Failure 400 {object} dto.RoleDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /roles/{id} [put]
 func (r *RoleController) Update(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.RoleDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	role, err := r.service.Update(data, c.Param("id"))
+	role, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, role)
+	return jsonWithETag(c, role)
 }
 
 // Delete deletes the existing role.
@@ -166,23 +175,30 @@ func (r *RoleController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Role ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Role "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /roles/{id} [delete]
 func (r *RoleController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	role, err := r.service.Delete(c.Param("id"))
+	role, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, role)
 }
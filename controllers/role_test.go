@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
@@ -48,8 +49,8 @@ func TestGetRole_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -173,10 +174,29 @@ func TestCreateRole_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "RoleDto.Name")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+}
+
+func TestCreateRole_Conflict(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	role := NewRoleController(cont)
+	e.POST(config.APIv1Roles, func(c echo.Context) error { return role.Create(c) })
+
+	param := &dto.RoleDto{Name: util.Staff.ToString()}
+	req := test.NewJSONRequest("POST", config.APIv1Roles, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"conflict"`)
 }
 
 func TestCreateRole_Unauthorized(t *testing.T) {
@@ -249,10 +269,10 @@ func TestUpdateRole_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "RoleDto.Name")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
 }
 
 func TestUpdateRole_Unauthorized(t *testing.T) {
@@ -288,6 +308,32 @@ func TestUpdateRole_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+func TestUpdateRole_PreconditionFailed(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	role := NewRoleController(cont)
+	e.PUT(config.APIv1RolesID, func(c echo.Context) error { return role.Update(c) })
+
+	m := &models.Role{}
+	before, _ := m.Get(cont.Repository(), 4)
+	_, _ = models.NewRole("Changed").Update(cont.Repository(), 4)
+
+	param := createRoleForUpdate()
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1RolesID, "4"), param)
+	req.Header.Set("If-Match", before.ETag())
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := m.Get(cont.Repository(), 4)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, "Changed", data.Name)
+}
+
 func TestDeleteRole_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -323,8 +369,8 @@ func TestDeleteRole_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
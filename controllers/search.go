@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+// SearchController searches clients, pets, users and leads for the front desk.
+type SearchController struct {
+	container container.Container
+	service   *service.SearchService
+}
+
+// NewSearchController is constructor.
+func NewSearchController(container container.Container) *SearchController {
+	return &SearchController{container: container, service: service.NewSearchService(container)}
+}
+
+// Search returns the records matching the query.
+//
+// @Summary Search clients, pets, users and leads.
+// @Description Returns the clients, pets, users and leads whose names, phones or e-mails start with the query.
+// @Description Every word of the query is matched case-insensitively against the beginnings of the words.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "The query of at least 2 characters, e.g. a name or a phone number."
+// @Param types query string false "Comma-separated types of the results, all types by default." example(client,pet)
+// @Param limit query int false "The largest number of the results of each type, 10 by default and 50 at most."
+// @Success 200 {object} []models.SearchResult "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /search [get]
+func (r *SearchController) Search(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	results, err := r.service.Search(c.Request().Context(), c.QueryParam("q"), c.QueryParam("types"), c.QueryParam("limit"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, results)
+}
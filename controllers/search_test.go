@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestSearch_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	search := NewSearchController(cont)
+	e.GET(config.APIv1Search, func(c echo.Context) error { return search.Search(c) })
+
+	query := url.Values{"q": {"кит"}, "types": {"client,pet"}}
+	req := httptest.NewRequest("GET", config.APIv1Search+"?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := []*models.SearchResult{
+		{Type: models.SearchPet, ID: 1, Title: "Китти", Subtitle: "Кошка, Дворняга, Фамилия Имя"},
+	}
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestSearch_QueryTooShort(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	search := NewSearchController(cont)
+	e.GET(config.APIv1Search, func(c echo.Context) error { return search.Search(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Search+"?q=a", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the query must be at least 2 characters long")
+}
+
+func TestSearch_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	search := NewSearchController(cont)
+	e.GET(config.APIv1Search, func(c echo.Context) error { return search.Search(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Search+"?q=kit", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
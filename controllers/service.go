@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -29,43 +30,49 @@ func NewServiceController(container container.Container) *ServiceController {
 // @Security ApiKeyAuth
 // @Param id path string true "Service ID"
 // @Success 200 {object} models.Service "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /services/{id} [get]
 func (r *ServiceController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	serv, err := r.service.Get(c.Param("id"))
+	serv, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, serv)
+	return jsonWithETag(c, serv)
 }
 
 // GetAll returns the list of services.
 //
 // @Summary Get a service list.
-// @Description Returns the list of services.
+// @Description Returns the list of services, optionally filtered by the category, the department and the archived flag.
 // @Tags Services
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param categoryId query string false "Return only the services of the category and its subcategories."
+// @Param departmentId query string false "Return only the services of the department."
+// @Param archived query bool false "Return only the archived services if true, or only the active ones if false."
 // @Success 200 {object} []models.Service "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The category or the department does not exist."
 // @Router /services [get]
 func (r *ServiceController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	serv, err := r.service.GetAll()
+	serv, err := r.service.GetAll(c.Request().Context(), c.QueryParam("categoryId"),
+		c.QueryParam("departmentId"), c.QueryParam("archived"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, serv)
 }
@@ -80,33 +87,33 @@ func (r *ServiceController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.ServiceDto true "A new service data for creating."
 // @Success 200 {object} models.Service "Success to fetch data."
-// @Failure 400 {object} dto.ServiceDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /services [post]
 func (r *ServiceController) Create(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.ServiceDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	serv, err := r.service.Create(data)
+	serv, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, serv)
+	return jsonWithETag(c, serv)
 }
 
 // Update updates the existing service.
@@ -119,34 +126,41 @@ func (r *ServiceController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Service ID"
 // @Param data body dto.ServiceDto true "Service data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Service "Success to fetch data."
-// @Failure 400 {object} dto.ServiceDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /services/{id} [put]
 func (r *ServiceController) Update(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.ServiceDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	serv, err := r.service.Update(data, c.Param("id"))
+	serv, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, serv)
+	return jsonWithETag(c, serv)
 }
 
 // Delete deletes the existing service.
@@ -158,23 +172,164 @@ func (r *ServiceController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Service ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Service "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /services/{id} [delete]
 func (r *ServiceController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
-	serv, err := r.service.Delete(c.Param("id"))
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	serv, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, serv)
 }
+
+// GetConsumables returns the consumables used in the service.
+//
+// @Summary Get the consumables of the service.
+// @Description Returns the products used in the service with their quantities.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Success 200 {object} []models.ServiceConsumable "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /services/{id}/consumables [get]
+func (r *ServiceController) GetConsumables(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	consumables, err := r.service.GetConsumables(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, consumables)
+}
+
+// SetConsumables replaces the consumables used in the service.
+//
+// @Summary Set the consumables of the service. Required user's role: Owner
+// @Description Replaces the products used in the service, they are taken from stock when a visit for the service is completed.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Param data body dto.ServiceConsumablesDto true "The consumables of the service."
+// @Success 200 {object} []models.ServiceConsumable "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The product is listed more than once."
+// @Failure 422 {object} apperror.Response "The service or the product does not exist."
+// @Router /services/{id}/consumables [put]
+func (r *ServiceController) SetConsumables(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ServiceConsumablesDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	consumables, err := r.service.SetConsumables(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, consumables)
+}
+
+// GetResources returns the resources required by the service.
+//
+// @Summary Get the resources of the service.
+// @Description Returns the rooms and the equipment required by the service.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Success 200 {object} []models.ServiceResource "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /services/{id}/resources [get]
+func (r *ServiceController) GetResources(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	resources, err := r.service.GetResources(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resources)
+}
+
+// SetResources replaces the resources required by the service.
+//
+// @Summary Set the resources of the service. Required user's role: Owner
+// @Description Replaces the rooms and the equipment required by the service, they are reserved for the visits for the service.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Param data body dto.ServiceResourcesDto true "The resources of the service."
+// @Success 200 {object} []models.ServiceResource "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 409 {object} apperror.Response "The resource is listed more than once."
+// @Failure 422 {object} apperror.Response "The service or the resource does not exist."
+// @Router /services/{id}/resources [put]
+func (r *ServiceController) SetResources(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ServiceResourcesDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	resources, err := r.service.SetResources(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resources)
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"mime"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type ServicePriceController struct {
+	container container.Container
+	service   *service.ServicePriceService
+}
+
+// NewServicePriceController is constructor.
+func NewServicePriceController(container container.Container) *ServicePriceController {
+	return &ServicePriceController{container: container, service: service.NewServicePriceService(container)}
+}
+
+// GetAllByService returns the price history of the service.
+//
+// @Summary Get the price history of the service.
+// @Description Returns the base and the department prices of the service, the latest effective date first.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Success 200 {object} []models.ServicePrice "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /services/{id}/prices [get]
+func (r *ServicePriceController) GetAllByService(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	prices, err := r.service.GetAllByService(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, prices)
+}
+
+// Create adds the price of the service effective from the given date.
+//
+// @Summary Add a price of the service. Required user's role: Owner
+// @Description Adds the base price of the service, or the price overridden for the department, effective from the given date. The prices of the past visits are not affected.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Param data body dto.ServicePriceDto true "A new price data."
+// @Success 200 {object} models.ServicePrice "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The service does not exist."
+// @Failure 409 {object} apperror.Response "The price effective from the same date already exists."
+// @Failure 422 {object} apperror.Response "The department does not exist."
+// @Router /services/{id}/prices [post]
+func (r *ServicePriceController) Create(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.ServicePriceDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	price, err := r.service.Create(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, price)
+}
+
+// Delete deletes the price which is not yet effective.
+//
+// @Summary Delete the scheduled price of the service. Required user's role: Owner
+// @Description Deletes the price which is not yet effective, the effective prices are kept as the history.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service price ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.ServicePrice "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The price does not exist."
+// @Failure 409 {object} apperror.Response "The price is already effective."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /service-prices/{id} [delete]
+func (r *ServicePriceController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	price, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, price)
+}
+
+// Resolve returns the price of the service as of the given date.
+//
+// @Summary Get the price of the service as of a date.
+// @Description Returns the price of the service in the department as of the given date. The price overridden for the department takes precedence over the base price.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID"
+// @Param departmentId query string false "Department ID, the base price is returned without it."
+// @Param date query string false "Date in the format YYYY-MM-DD, today by default."
+// @Success 200 {object} models.ResolvedPrice "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The service does not exist."
+// @Failure 422 {object} apperror.Response "The department does not exist."
+// @Router /services/{id}/price [get]
+func (r *ServicePriceController) Resolve(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	price, err := r.service.Resolve(c.Request().Context(), c.Param("id"),
+		c.QueryParam("departmentId"), c.QueryParam("date"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, price)
+}
+
+// GetPriceList returns the price list.
+//
+// @Summary Get the price list.
+// @Description Returns the prices of the services as of the given date ordered by category and name.
+// @Tags Services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param categoryId query string false "Category ID."
+// @Param departmentId query string false "Department ID, only the services of the department are listed with its prices."
+// @Param date query string false "Date in the format YYYY-MM-DD, today by default."
+// @Success 200 {object} []models.PriceListItem "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The category or the department does not exist."
+// @Router /price-list [get]
+func (r *ServicePriceController) GetPriceList(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	items, err := r.service.GetPriceList(c.Request().Context(), c.QueryParam("categoryId"),
+		c.QueryParam("departmentId"), c.QueryParam("date"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// ExportPriceList returns the price list as a CSV file.
+//
+// @Summary Export the price list.
+// @Description Returns the price list as a CSV file with the category, the service and the price columns.
+// @Tags Services
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param categoryId query string false "Category ID."
+// @Param departmentId query string false "Department ID, only the services of the department are listed with its prices."
+// @Param date query string false "Date in the format YYYY-MM-DD, today by default."
+// @Success 200 {string} string "The price list."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 422 {object} apperror.Response "The category or the department does not exist."
+// @Router /price-list/export [get]
+func (r *ServicePriceController) ExportPriceList(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	document, err := r.service.ExportPriceList(c.Request().Context(), c.QueryParam("categoryId"),
+		c.QueryParam("departmentId"), c.QueryParam("date"))
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": "price-list.csv"}))
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", document)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetPriceList_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	price := NewServicePriceController(cont)
+	e.GET(config.APIv1PriceList, func(c echo.Context) error { return price.GetPriceList(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PriceList+"?departmentId=2&date=2024-01-01", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ServicePrice{}
+	data, _ := m.GetPriceList(cont.Repository(), 0, 2, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestExportPriceList_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	price := NewServicePriceController(cont)
+	e.GET(config.APIv1PriceListExport, func(c echo.Context) error { return price.ExportPriceList(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PriceListExport+"?categoryId=4", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename=price-list.csv`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "Категория,Услуга,Цена\n"))
+}
+
+func TestGetPriceList_InvalidCategory(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	price := NewServicePriceController(cont)
+	e.GET(config.APIv1PriceList, func(c echo.Context) error { return price.GetPriceList(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1PriceList+"?categoryId=99", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestCreateServicePrice_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	price := NewServicePriceController(cont)
+	e.POST(config.APIv1ServicesIDPrices, func(c echo.Context) error { return price.Create(c) })
+
+	param := &dto.ServicePriceDto{DepartmentID: 1, Price: 900, EffectiveFrom: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.Local)}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ServicesIDPrices, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ServicePrice{}
+	prices, _ := m.GetAllByService(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(prices[0]), rec.Body.String())
+}
+
+func TestCreateServicePrice_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	price := NewServicePriceController(cont)
+	e.POST(config.APIv1ServicesIDPrices, func(c echo.Context) error { return price.Create(c) })
+
+	param := &dto.ServicePriceDto{Price: 900, EffectiveFrom: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.Local)}
+	req := test.NewJSONRequest("POST", test.SetParam(config.APIv1ServicesIDPrices, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
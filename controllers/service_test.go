@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
@@ -57,8 +58,8 @@ func TestGetService_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -93,12 +94,52 @@ func TestGetServiceList_Success(t *testing.T) {
 	e.ServeHTTP(rec, req)
 
 	m := &models.Service{}
-	data, _ := m.GetAll(cont.Repository())
+	data, _ := m.GetAll(cont.Repository(), 0, 0, nil)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
 }
 
+func TestGetServiceList_Filtered(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.GET(config.APIv1Services, func(c echo.Context) error { return service.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Services+"?categoryId=4&departmentId=2&archived=false", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	archived := false
+	m := &models.Service{}
+	data, _ := m.GetAll(cont.Repository(), 4, 2, &archived)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 2)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestGetServiceList_InvalidDepartment(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.GET(config.APIv1Services, func(c echo.Context) error { return service.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Services+"?departmentId=99", nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
 func TestGetServiceList_Unauthorized(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -150,9 +191,8 @@ func TestCreateService_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultServiceForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreateService_ValidationError(t *testing.T) {
@@ -170,9 +210,9 @@ func TestCreateService_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ServiceDto.Name")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestCreateService_Unauthorized(t *testing.T) {
@@ -247,9 +287,8 @@ func TestUpdateService_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultServiceForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdateService_ValidationError(t *testing.T) {
@@ -267,9 +306,9 @@ func TestUpdateService_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "ServiceDto.Name")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateService_Unauthorized(t *testing.T) {
@@ -342,8 +381,8 @@ func TestDeleteService_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -400,14 +439,6 @@ func createServiceForBindError() *ServiceDtoForBindError {
 	}
 }
 
-func createResultServiceForBindError() *dto.ServiceDto {
-	return &dto.ServiceDto{
-		Name:       "Общий анализ крови",
-		Price:      0,
-		CategoryID: 0,
-	}
-}
-
 func createServiceForValidationError() *dto.ServiceDto {
 	return &dto.ServiceDto{
 		Name:       "Общий анализ крови\n",
@@ -423,3 +454,83 @@ func createServiceForUpdate() *dto.ServiceDto {
 		CategoryID: 3,
 	}
 }
+
+func TestSetConsumables_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.PUT(config.APIv1ServicesIDConsumables, func(c echo.Context) error { return service.SetConsumables(c) })
+
+	param := &dto.ServiceConsumablesDto{Consumables: []*dto.ServiceConsumableDto{{ProductID: 2, Quantity: 2}}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1ServicesIDConsumables, "5"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ServiceConsumable{}
+	data, _ := m.GetAllByService(cont.Repository(), 5)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestSetConsumables_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.PUT(config.APIv1ServicesIDConsumables, func(c echo.Context) error { return service.SetConsumables(c) })
+
+	param := &dto.ServiceConsumablesDto{Consumables: []*dto.ServiceConsumableDto{{ProductID: 2}}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1ServicesIDConsumables, "5"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestSetResources_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.PUT(config.APIv1ServicesIDResources, func(c echo.Context) error { return service.SetResources(c) })
+
+	param := &dto.ServiceResourcesDto{ResourceIDs: []uint{2, 3}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1ServicesIDResources, "8"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.ServiceResource{}
+	data, _ := m.GetAllByService(cont.Repository(), 8)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestSetResources_InvalidResource(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	service := NewServiceController(cont)
+	e.PUT(config.APIv1ServicesIDResources, func(c echo.Context) error { return service.SetResources(c) })
+
+	param := &dto.ServiceResourcesDto{ResourceIDs: []uint{9}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1ServicesIDResources, "8"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"vet-clinic/apperror"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestGetSpeciesList_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	species := NewSpeciesController(cont)
+	e.GET(config.APIv1Species, func(c echo.Context) error { return species.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Species, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Species{}
+	data, _ := m.GetAll(cont.Repository())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateSpecies_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	species := NewSpeciesController(cont)
+	e.POST(config.APIv1Species, func(c echo.Context) error { return species.Create(c) })
+
+	param := &dto.SpeciesDto{Name: "Черепаха", Aliases: "turtle"}
+	req := test.NewJSONRequest("POST", config.APIv1Species, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Species{}
+	data, _ := m.Get(cont.Repository(), 8)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateSpecies_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	species := NewSpeciesController(cont)
+	e.POST(config.APIv1Species, func(c echo.Context) error { return species.Create(c) })
+
+	param := &dto.SpeciesDto{Name: ""}
+	req := test.NewJSONRequest("POST", config.APIv1Species, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"required"`)
+}
+
+func TestCreateSpecies_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	species := NewSpeciesController(cont)
+	e.POST(config.APIv1Species, func(c echo.Context) error { return species.Create(c) })
+
+	param := &dto.SpeciesDto{Name: "Черепаха"}
+	req := test.NewJSONRequest("POST", config.APIv1Species, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestDeleteSpecies_Referenced(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	species := NewSpeciesController(cont)
+	e.DELETE(config.APIv1SpeciesID, func(c echo.Context) error { return species.Delete(c) })
+
+	req := test.NewJSONRequest("DELETE", test.SetParam(config.APIv1SpeciesID, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.Conflict, Message: "record is referenced by other records"}
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
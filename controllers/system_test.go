@@ -8,6 +8,7 @@ import (
 	"testing"
 	"vet-clinic/config"
 	"vet-clinic/test"
+	"vet-clinic/util"
 )
 
 func TestGetHealthCheck(t *testing.T) {
@@ -27,3 +28,54 @@ func TestGetHealthCheck(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
+
+func TestRequestID_Generated(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	System := NewSystemController(cont)
+	e.GET(config.APIv1Health, func(c echo.Context) error { return System.GetHealthCheck(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Health, nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestID_FromRequest(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	System := NewSystemController(cont)
+	e.GET(config.APIv1Health, func(c echo.Context) error { return System.GetHealthCheck(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Health, nil)
+	req.Header.Set(echo.HeaderXRequestID, "test-request-id")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "test-request-id", rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestLogger_ErrorStatus(t *testing.T) {
+	e, cont, logs := test.PrepareForLoggingTest()
+
+	visit := NewVisitController(cont)
+	e.GET(config.APIv1VisitsID, func(c echo.Context) error { return visit.Get(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1VisitsID, "999"), nil)
+	rec := httptest.NewRecorder()
+
+	test.LoginUser(e, cont, req, rec, userWithAccessLevel(util.Staff))
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	entries := logs.FilterMessage("Request completed").All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, int64(http.StatusNotFound), entries[0].ContextMap()["status"])
+	}
+}
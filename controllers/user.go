@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
@@ -31,20 +32,21 @@ func NewUserController(container container.Container) *UserController {
 // @Security ApiKeyAuth
 // @Param id_or_slug path string true "User ID"
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /users/{id_or_slug} [get]
 func (u *UserController) Get(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	user, err := u.service.Get(c.Param("id"))
+	user, err := u.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, user)
+	return jsonWithETag(c, user)
 }
 
 // GetSelf retrieves and returns the user's own profile based on the session.
@@ -56,15 +58,20 @@ func (u *UserController) Get(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /profile [get]
 func (u *UserController) GetSelf(c echo.Context) error {
 	user := getUser(c, u.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	return c.JSON(http.StatusOK, user)
+	result, err := u.service.Get(c.Request().Context(), strconv.Itoa(int(user.ID)))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, result)
 }
 
 // GetAll returns the list of users.
@@ -75,19 +82,26 @@ func (u *UserController) GetSelf(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param includeDeleted query bool false "Include the soft-deleted users. Required user's role: Admin"
 // @Success 200 {object} []models.User "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /users [get]
 func (u *UserController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	users, err := u.service.GetAll()
+	includeDeleted, _ := strconv.ParseBool(c.QueryParam("includeDeleted"))
+	if includeDeleted && !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	users, err := u.service.GetAll(c.Request().Context(), includeDeleted)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, users)
 }
@@ -102,33 +116,33 @@ func (u *UserController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.UserCreateDto true "A new user data for creating."
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} dto.UserCreateDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /users [post]
 func (u *UserController) Create(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.UserCreateDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	user, err := u.service.Create(data)
+	user, err := u.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, user)
+	return jsonWithETag(c, user)
 }
 
 // Update updates the existing user.
@@ -141,34 +155,41 @@ func (u *UserController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "User ID"
 // @Param data body dto.UserUpdateDto true "User data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} dto.UserUpdateDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /users/{id} [put]
 func (u *UserController) Update(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Owner.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.UserUpdateDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	result, err := u.service.Update(data, c.Param("id"), true)
+	result, err := u.service.Update(c.Request().Context(), data, c.Param("id"), true)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, result)
+	return jsonWithETag(c, result)
 }
 
 // UpdateSelf updates and returns the user's own profile based on the session.
@@ -180,30 +201,120 @@ func (u *UserController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param data body dto.UserUpdateDto true "User data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} dto.UserUpdateDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /profile [put]
 func (u *UserController) UpdateSelf(c echo.Context) error {
 	user := getUser(c, u.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, strconv.Itoa(int(user.ID))); err != nil {
+		return err
 	}
 
 	data := &dto.UserUpdateDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
+	}
+
+	result, err := u.service.Update(c.Request().Context(), data, strconv.Itoa(int(user.ID)), false)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, result)
+}
+
+// Patch partially updates the existing user.
+//
+// @Summary Partially update the existing user. Required user's role: Owner
+// @Description Partially update the existing user by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Users
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param data body dto.UserUpdateDto true "User fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.User "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /users/{id} [patch]
+func (u *UserController) Patch(c echo.Context) error {
+	level := getAccessLevel(c, u.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.UserUpdateDto{})
+	if err != nil {
+		return err
 	}
 
-	result, err := u.service.Update(data, strconv.Itoa(int(user.ID)), false)
+	result, err := u.service.Patch(c.Request().Context(), patch, c.Param("id"), true)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, result)
+	return jsonWithETag(c, result)
+}
+
+// PatchSelf partially updates and returns the user's own profile based on the session.
+//
+// @Summary Partially update user's profile.
+// @Description Partially updates the user's own profile by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Users
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.UserUpdateDto true "User fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.User "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /profile [patch]
+func (u *UserController) PatchSelf(c echo.Context) error {
+	user := getUser(c, u.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, strconv.Itoa(int(user.ID))); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.UserUpdateDto{})
+	if err != nil {
+		return err
+	}
+
+	result, err := u.service.Patch(c.Request().Context(), patch, strconv.Itoa(int(user.ID)), false)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, result)
 }
 
 // UpdatePassword updates the user's password based on the provided data.
@@ -216,27 +327,26 @@ func (u *UserController) UpdateSelf(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.UpdatePasswordDto true "Old password and new password for update."
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} dto.UpdatePasswordDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /profile/password [put]
 func (u *UserController) UpdatePassword(c echo.Context) error {
 	user := getUser(c, u.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
 	data := &dto.UpdatePasswordDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
-	err := u.service.UpdatePassword(data, strconv.Itoa(int(user.ID)))
+	err := u.service.UpdatePassword(c.Request().Context(), data, strconv.Itoa(int(user.ID)))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, user)
 }
@@ -250,23 +360,93 @@ func (u *UserController) UpdatePassword(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.User "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /users/{id} [delete]
 func (u *UserController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, u.container, u.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	user, err := u.service.Delete(c.Param("id"))
+	user, err := u.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
+// Restore restores the soft-deleted user.
+//
+// @Summary Restore the soft-deleted user. Required user's role: Superuser
+// @Description Restore the soft-deleted user.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The user does not exist."
+// @Router /users/{id}/restore [post]
+func (u *UserController) Restore(c echo.Context) error {
+	level := getAccessLevel(c, u.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	user, err := u.service.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, user)
+}
+
+// Purge permanently deletes the user.
+//
+// @Summary Permanently delete the user, including the soft-deleted one. Required user's role: Superuser
+// @Description Permanently delete the user, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the user.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The user does not exist."
+// @Failure 409 {object} apperror.Response "The user is referenced by other records."
+// @Router /users/{id}/purge [delete]
+func (u *UserController) Purge(c echo.Context) error {
+	level := getAccessLevel(c, u.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	user, err := u.service.Purge(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, user)
 }
@@ -281,16 +461,16 @@ func (u *UserController) Delete(c echo.Context) error {
 // @Param data body dto.LoginDto true "Login and Password for logged-in."
 // @Success 200 {object} models.User "Success to the authentication."
 // @Header 200 {string} Cookie "Authorization"
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /login [post]
 func (u *UserController) Login(c echo.Context) error {
 	data := &dto.LoginDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
 	sess := u.container.Session()
@@ -298,13 +478,14 @@ func (u *UserController) Login(c echo.Context) error {
 		return c.JSON(http.StatusOK, user)
 	}
 
-	if user, err := u.service.Login(data); err == nil {
-		_ = sess.SetUser(c, user)
-		_ = sess.Save(c)
-		return c.JSON(http.StatusOK, user)
-	} else {
-		return c.JSON(http.StatusUnauthorized, Error(err))
+	user, err := u.service.Login(c.Request().Context(), data)
+	if err != nil {
+		return err
 	}
+	_ = sess.SetClientAccount(c, nil)
+	_ = sess.SetUser(c, user)
+	_ = sess.Save(c)
+	return c.JSON(http.StatusOK, user)
 }
 
 // Logout logs the user out by invalidating the current session.
@@ -315,12 +496,12 @@ func (u *UserController) Login(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Success 200 "Successfully logged out."
-// @Failure 401 "Failed to the authentication."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /logout [post]
 func (u *UserController) Logout(c echo.Context) error {
 	level := getAccessLevel(c, u.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
 	sess := u.container.Session()
@@ -345,3 +526,72 @@ func getUser(c echo.Context, container container.Container) *models.User {
 	}
 	return nil
 }
+
+// GetWorkingHours returns the working hours of the user.
+//
+// @Summary Get the working hours of the user.
+// @Description Returns the working hours of the user by the day of the week. A user without working hours works the default hours of the clinic every day.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} []models.WorkingHours "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The user does not exist."
+// @Router /users/{id}/working-hours [get]
+func (u *UserController) GetWorkingHours(c echo.Context) error {
+	level := getAccessLevel(c, u.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	hours, err := u.service.GetWorkingHours(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, hours)
+}
+
+// SetWorkingHours replaces the working hours of the user.
+//
+// @Summary Set the working hours of the user. Required user's role: Owner
+// @Description Replaces the working hours of the user, the online booking offers the slots only within them. The user does not work on the days which are not listed, an empty list restores the default hours of the clinic.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param data body dto.WorkingScheduleDto true "The working hours of the user."
+// @Success 200 {object} []models.WorkingHours "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The user does not exist."
+// @Failure 409 {object} apperror.Response "A day is listed more than once or the hours end before they start."
+// @Failure 422 {object} apperror.Response "Validation failed."
+// @Router /users/{id}/working-hours [put]
+func (u *UserController) SetWorkingHours(c echo.Context) error {
+	level := getAccessLevel(c, u.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Owner.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	data := &dto.WorkingScheduleDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	hours, err := u.service.SetWorkingHours(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, hours)
+}
@@ -10,8 +10,10 @@ import (
 	"strconv"
 	"testing"
 	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
+	"vet-clinic/middleware"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
 	"vet-clinic/test"
@@ -117,10 +119,10 @@ func TestLogin_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "LoginDto.Login")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'username|email|e164'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"login"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"username|email|e164"`)
 }
 
 func TestLogin_WrongPassword(t *testing.T) {
@@ -137,7 +139,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "crypto/bcrypt: hashedPassword is not the hash of the given password"}
+	expected := apperror.Response{Code: apperror.InvalidCredentials, Message: "invalid login or password"}
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
@@ -156,11 +158,88 @@ func TestLogin_EntityNotFound(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
+	expected := apperror.Response{Code: apperror.InvalidCredentials, Message: "invalid login or password"}
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
+func TestLogin_RateLimitHeaders(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+	cont.Config().RateLimit.Enabled = true
+	cont.Config().RateLimit.Groups["auth"] = config.RateLimitRule{Limit: 2, Period: time.Minute}
+
+	user := NewUserController(cont)
+	e.POST(config.APIv1Login, func(c echo.Context) error { return user.Login(c) },
+		middleware.NewRateLimiter(cont).Group("auth"))
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := createLoginDtoForWrongPassword()
+	req := test.NewJSONRequest("POST", config.APIv1Login, param)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(middleware.HeaderRateLimitLimit))
+	assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(middleware.HeaderRateLimitReset))
+	assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+}
+
+func TestLogin_RateLimitExceeded(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+	cont.Config().RateLimit.Enabled = true
+	cont.Config().RateLimit.Groups["auth"] = config.RateLimitRule{Limit: 2, Period: time.Minute}
+
+	user := NewUserController(cont)
+	e.POST(config.APIv1Login, func(c echo.Context) error { return user.Login(c) },
+		middleware.NewRateLimiter(cont).Group("auth"))
+
+	setUpUserTestData(cont, util.Staff)
+
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		req := test.NewJSONRequest("POST", config.APIv1Login, createLoginDtoForWrongPassword())
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+	}
+
+	expected := apperror.Response{Code: apperror.TooManyRequests, Message: "too many requests, try again later"}
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+	assert.Equal(t, "0", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+
+	req := test.NewJSONRequest("POST", config.APIv1Login, createLoginDtoForWrongPassword())
+	req.RemoteAddr = "192.0.2.10:1234"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestLogin_RateLimitDisabled(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+	cont.Config().RateLimit.Groups["auth"] = config.RateLimitRule{Limit: 1, Period: time.Minute}
+
+	user := NewUserController(cont)
+	e.POST(config.APIv1Login, func(c echo.Context) error { return user.Login(c) },
+		middleware.NewRateLimiter(cont).Group("auth"))
+
+	setUpUserTestData(cont, util.Staff)
+
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := test.NewJSONRequest("POST", config.APIv1Login, createLoginDtoForWrongPassword())
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, rec.Header().Get(middleware.HeaderRateLimitLimit))
+}
+
 func TestGetUserByID_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -221,8 +300,8 @@ func TestGetUser_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -353,9 +432,8 @@ func TestCreateUser_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultUserForCreateBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreateUser_ValidationNameError(t *testing.T) {
@@ -373,11 +451,11 @@ func TestCreateUser_ValidationNameError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "UserCreateDto.Username")
-	assert.Contains(t, rec.Body.String(), "'username'")
-	assert.Contains(t, rec.Body.String(), "UserCreateDto.Password")
-	assert.Contains(t, rec.Body.String(), "'password'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"username"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"username"`)
+	assert.Contains(t, rec.Body.String(), `"field":"password"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"password"`)
 }
 
 func TestCreateUser_ValidationMinError(t *testing.T) {
@@ -395,10 +473,10 @@ func TestCreateUser_ValidationMinError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "UserCreateDto.Password")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'min'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"password"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"min"`)
 }
 
 func TestCreateUser_ValidationMaxError(t *testing.T) {
@@ -416,10 +494,10 @@ func TestCreateUser_ValidationMaxError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "UserCreateDto.Password")
-	assert.Contains(t, rec.Body.String(), "Error:Field validation")
-	assert.Contains(t, rec.Body.String(), "'max'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"password"`)
+	assert.Contains(t, rec.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"max"`)
 }
 
 func TestCreateUser_Unauthorized(t *testing.T) {
@@ -495,9 +573,8 @@ func TestUpdateUser_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultUserForUpdateBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdateUser_ValidationError(t *testing.T) {
@@ -515,23 +592,23 @@ func TestUpdateUser_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Email")
-	assert.Contains(t, rec.Body.String(), "'email'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Phone")
-	assert.Contains(t, rec.Body.String(), "'e164'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Surname")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Name")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Patronymic")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Sex")
-	assert.Contains(t, rec.Body.String(), "'rualpha'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Profession")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
-	assert.Contains(t, rec.Body.String(), "UserUpdateDto.Info")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"email"`)
+	assert.Contains(t, rec.Body.String(), `"field":"phone"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"e164"`)
+	assert.Contains(t, rec.Body.String(), `"field":"surname"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"name"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"patronymic"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"sex"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"rualpha"`)
+	assert.Contains(t, rec.Body.String(), `"field":"profession"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+	assert.Contains(t, rec.Body.String(), `"field":"info"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateUser_Unauthorized(t *testing.T) {
@@ -607,6 +684,81 @@ func TestUpdateSelf_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestPatchUser_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PATCH(config.APIv1UsersID, func(c echo.Context) error { return user.Patch(c) })
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := map[string]interface{}{"active": false}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1UsersID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.User{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.False(t, data.Active)
+	assert.Equal(t, "Test2", data.Username)
+}
+
+func TestPatchSelf_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PATCH(config.APIv1Profile, func(c echo.Context) error { return user.PatchSelf(c) })
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := map[string]interface{}{"surname": "Фамилия"}
+	req := test.NewJSONRequest("PATCH", config.APIv1Profile, param)
+	rec := httptest.NewRecorder()
+
+	m := &models.User{}
+	userForLogin, _ := m.Get(cont.Repository(), 2)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, "Фамилия", data.Surname)
+	assert.True(t, data.Active)
+	assert.Equal(t, makeSlugForUser(data), data.Slug)
+}
+
+func TestPatchSelf_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PATCH(config.APIv1Profile, func(c echo.Context) error { return user.PatchSelf(c) })
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := map[string]interface{}{"email": "mail"}
+	req := test.NewJSONRequest("PATCH", config.APIv1Profile, param)
+	rec := httptest.NewRecorder()
+
+	m := &models.User{}
+	userForLogin, _ := m.Get(cont.Repository(), 2)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"email"`)
+}
+
 func TestUpdatePassword_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -646,8 +798,17 @@ func TestUpdatePassword_NotEqualConfirmPassword(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "Key: 'UpdatePasswordDto.NewPassword' Error:Field validation for 'NewPassword' failed on the 'eqfield' tag"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{
+		Code:    apperror.ValidationFailed,
+		Message: "validation failed",
+		Details: []*apperror.FieldError{{
+			Field:   "newPassword",
+			Tag:     "eqfield",
+			Param:   "ConfirmPassword",
+			Message: "newPassword must be equal to confirmPassword",
+		}},
+	}
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -669,8 +830,64 @@ func TestUpdatePassword_EqualOldPassword(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "Key: 'UpdatePasswordDto.NewPassword' Error:Field validation for 'NewPassword' failed on the 'nefield' tag"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{
+		Code:    apperror.ValidationFailed,
+		Message: "validation failed",
+		Details: []*apperror.FieldError{{
+			Field:   "newPassword",
+			Tag:     "nefield",
+			Param:   "OldPassword",
+			Message: "newPassword cannot be equal to oldPassword",
+		}},
+	}
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestUpdatePassword_EqualOldPasswordRu(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PUT(config.APIv1Password, func(c echo.Context) error { return user.UpdatePassword(c) })
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := createPasswordForEqualOldPassword()
+	req := test.NewJSONRequest("PUT", config.APIv1Password, param)
+	req.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+	rec := httptest.NewRecorder()
+
+	m := &models.User{}
+	userForLogin, _ := m.Get(cont.Repository(), 2)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"message":"newPassword не должно совпадать с oldPassword"`)
+}
+
+func TestUpdatePassword_WrongOldPassword(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PUT(config.APIv1Password, func(c echo.Context) error { return user.UpdatePassword(c) })
+
+	setUpUserTestData(cont, util.Staff)
+
+	param := createPasswordForUpdate()
+	param.OldPassword = "Password9!"
+	req := test.NewJSONRequest("PUT", config.APIv1Password, param)
+	rec := httptest.NewRecorder()
+
+	m := &models.User{}
+	userForLogin, _ := m.Get(cont.Repository(), 2)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.WrongPassword, Message: "the old password is wrong"}
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -711,8 +928,8 @@ func TestDeleteUser_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -728,6 +945,7 @@ func TestDeleteUser_Unauthorized(t *testing.T) {
 	e.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"code":"unauthorized","message":"authentication is required"}`, rec.Body.String())
 }
 
 func TestDeleteUser_Forbidden(t *testing.T) {
@@ -744,6 +962,65 @@ func TestDeleteUser_Forbidden(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"code":"forbidden","message":"access denied"}`, rec.Body.String())
+}
+
+func TestSetWorkingHours_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PUT(config.APIv1UsersIDWorkingHours, func(c echo.Context) error { return user.SetWorkingHours(c) })
+
+	param := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{{Weekday: 1, Start: "09:00", End: "18:00"}}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1UsersIDWorkingHours, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.WorkingHours{}
+	data, _ := m.GetAllByUser(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestSetWorkingHours_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PUT(config.APIv1UsersIDWorkingHours, func(c echo.Context) error { return user.SetWorkingHours(c) })
+
+	param := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{{Weekday: 7, Start: "9:00", End: "18:00"}}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1UsersIDWorkingHours, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Owner)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestSetWorkingHours_Forbidden(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	user := NewUserController(cont)
+	e.PUT(config.APIv1UsersIDWorkingHours, func(c echo.Context) error { return user.SetWorkingHours(c) })
+
+	param := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{{Weekday: 1, Start: "09:00", End: "18:00"}}}
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1UsersIDWorkingHours, "1"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
@@ -805,14 +1082,6 @@ func createUserForCreateBindError() *UserDtoForCreateBindError {
 	}
 }
 
-func createResultUserForCreateBindError() *dto.UserCreateDto {
-	return &dto.UserCreateDto{
-		Username: "Test2",
-		Password: "Password2!",
-		RoleID:   0,
-	}
-}
-
 func createUserForCreateValidationError() *dto.UserCreateDto {
 	return &dto.UserCreateDto{
 		Username: "1startDigit",
@@ -873,22 +1142,6 @@ func createUserForUpdateBindError() *UserDtoForUpdateBindError {
 	}
 }
 
-func createResultUserForUpdateBindError() *dto.UserUpdateDto {
-	return &dto.UserUpdateDto{
-		Email:      "test@test.com",
-		Phone:      "+79999999999",
-		Active:     false,
-		Surname:    "Тестов",
-		Name:       "Тест",
-		Patronymic: "Тестович",
-		Sex:        "Man",
-		BirthDate:  time.Date(1990, time.January, 1, 0, 0, 0, 0, time.Local),
-		Profession: "Кардиолог",
-		Info:       "Информация ABCD0",
-		RoleID:     0,
-	}
-}
-
 func createUserForUpdateValidationError() *dto.UserUpdateDto {
 	return &dto.UserUpdateDto{
 		Email:      "test1com",
@@ -3,6 +3,8 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models/dto"
 	"vet-clinic/service"
@@ -29,20 +31,21 @@ func NewVisitController(container container.Container) *VisitController {
 // @Security ApiKeyAuth
 // @Param id path string true "Visit ID"
 // @Success 200 {object} models.Visit "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
 // @Router /visits/{id} [get]
 func (r *VisitController) Get(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 
-	visit, err := r.service.Get(c.Param("id"))
+	visit, err := r.service.Get(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, visit)
+	return jsonWithETag(c, visit)
 }
 
 // GetAll returns the list of visits.
@@ -53,19 +56,26 @@ func (r *VisitController) Get(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param includeDeleted query bool false "Include the soft-deleted visits. Required user's role: Admin"
 // @Success 200 {object} []models.Visit "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to fetch data."
-// @Failure 401 "Failed to the authentication."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /visits [get]
 func (r *VisitController) GetAll(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
+	}
+
+	includeDeleted, _ := strconv.ParseBool(c.QueryParam("includeDeleted"))
+	if includeDeleted && !util.Administrator.AccessAllowed(level) {
+		return apperror.NewForbidden()
 	}
 
-	visits, err := r.service.GetAll()
+	visits, err := r.service.GetAll(c.Request().Context(), includeDeleted)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, visits)
 }
@@ -80,34 +90,34 @@ func (r *VisitController) GetAll(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param data body dto.VisitDto true "A new visit data for creating."
 // @Success 200 {object} models.Visit "Success to fetch data."
-// @Failure 400 {object} dto.VisitDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
 // @Router /visits [post]
 func (r *VisitController) Create(c echo.Context) error {
 	user := getUser(c, r.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Administrator.AccessAllowed(util.ToAccessLevel(user.Role.Name)) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
 	}
 
 	data := &dto.VisitDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
 	data.LastUpdatedByID = user.ID
-	visit, err := r.service.Create(data)
+	visit, err := r.service.Create(c.Request().Context(), data)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, visit)
+	return jsonWithETag(c, visit)
 }
 
 // Update updates the existing visit.
@@ -120,35 +130,86 @@ func (r *VisitController) Create(c echo.Context) error {
 // @Security ApiKeyAuth
 // @Param id path string true "Visit ID"
 // @Param data body dto.VisitDto true "Visit data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Visit "Success to fetch data."
-// @Failure 400 {object} dto.VisitDto "Failed to the registration."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /visits/{id} [put]
 func (r *VisitController) Update(c echo.Context) error {
 	user := getUser(c, r.container)
 	if user == nil {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Administrator.AccessAllowed(util.ToAccessLevel(user.Role.Name)) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
 	data := &dto.VisitDto{}
 	if err := c.Bind(data); err != nil {
-		return c.JSON(http.StatusBadRequest, data)
+		return err
 	}
 	if err := c.Validate(data); err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 
 	data.LastUpdatedByID = user.ID
-	visit, err := r.service.Update(data, c.Param("id"))
+	visit, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
-	return c.JSON(http.StatusOK, visit)
+	return jsonWithETag(c, visit)
+}
+
+// Patch partially updates the existing visit.
+//
+// @Summary Partially update the existing visit. Required user's role: Admin
+// @Description Partially update the existing visit by using JSON Merge Patch. Only the fields present in the patch are validated and updated.
+// @Tags Visits
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Param data body dto.VisitDto true "Visit fields to update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.Visit "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /visits/{id} [patch]
+func (r *VisitController) Patch(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Administrator.AccessAllowed(util.ToAccessLevel(user.Role.Name)) {
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	patch, err := bindMergePatch(c, &dto.VisitDto{})
+	if err != nil {
+		return err
+	}
+
+	visit, err := r.service.Patch(c.Request().Context(), patch, c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, visit)
 }
 
 // Delete deletes the existing visit.
@@ -160,23 +221,122 @@ func (r *VisitController) Update(c echo.Context) error {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Visit ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
 // @Success 200 {object} models.Visit "Success to fetch data."
-// @Failure 400 {object} ErrorResponse "Failed to the registration."
-// @Failure 401 "Failed to the authentication."
-// @Failure 403 "Access denied."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
 // @Router /visits/{id} [delete]
 func (r *VisitController) Delete(c echo.Context) error {
 	level := getAccessLevel(c, r.container)
 	if !util.Staff.AccessAllowed(level) {
-		return c.NoContent(http.StatusUnauthorized)
+		return apperror.NewUnauthorized()
 	}
 	if !util.Superuser.AccessAllowed(level) {
-		return c.NoContent(http.StatusForbidden)
+		return apperror.NewForbidden()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
 	}
 
-	visit, err := r.service.Delete(c.Param("id"))
+	visit, err := r.service.Delete(c.Request().Context(), c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, Error(err))
+		return err
 	}
 	return c.JSON(http.StatusOK, visit)
 }
+
+// Restore restores the soft-deleted visit.
+//
+// @Summary Restore the soft-deleted visit. Required user's role: Superuser
+// @Description Restore the soft-deleted visit.
+// @Tags Visits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Success 200 {object} models.Visit "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Router /visits/{id}/restore [post]
+func (r *VisitController) Restore(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	visit, err := r.service.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, visit)
+}
+
+// Purge permanently deletes the visit.
+//
+// @Summary Permanently delete the visit, including the soft-deleted one. Required user's role: Superuser
+// @Description Permanently delete the visit, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the visit.
+// @Tags Visits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Success 200 {object} models.Visit "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 403 {object} apperror.Response "Access denied."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Failure 409 {object} apperror.Response "The visit is referenced by other records."
+// @Router /visits/{id}/purge [delete]
+func (r *VisitController) Purge(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+	if !util.Superuser.AccessAllowed(level) {
+		return apperror.NewForbidden()
+	}
+
+	visit, err := r.service.Purge(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, visit)
+}
+
+// Complete completes the scheduled visit.
+//
+// @Summary Complete the scheduled visit.
+// @Description Marks the scheduled visit as completed and takes the consumables of its service from stock, the batches expiring first are used first. A completed visit cannot be reopened.
+// @Tags Visits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Visit ID"
+// @Success 200 {object} models.Visit "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 404 {object} apperror.Response "The visit does not exist."
+// @Failure 409 {object} apperror.Response "The visit is not scheduled or the consumables are out of stock."
+// @Router /visits/{id}/complete [post]
+func (r *VisitController) Complete(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil || !util.Staff.AccessAllowed(getAccessLevel(c, r.container)) {
+		return apperror.NewUnauthorized()
+	}
+
+	visit, err := r.service.Complete(c.Request().Context(), c.Param("id"), user.ID)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, visit)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/models"
@@ -48,6 +49,39 @@ func TestGetVisitByID_Success(t *testing.T) {
 	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
 }
 
+func TestGetVisit_ETag(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.GET(config.APIv1VisitsID, func(c echo.Context) error { return visit.Get(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1VisitsID, "2"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, data.ETag(), rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest("GET", test.SetParam(config.APIv1VisitsID, "2"), nil)
+	req.Header.Set("If-None-Match", data.ETag())
+	rec = httptest.NewRecorder()
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
 func TestGetVisit_Failure(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -62,11 +96,29 @@ func TestGetVisit_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
+func TestGetVisit_InvalidID(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit := NewVisitController(cont)
+	e.GET(config.APIv1VisitsID, func(c echo.Context) error { return visit.Get(c) })
+
+	req := httptest.NewRequest("GET", test.SetParam(config.APIv1VisitsID, "abc"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"invalid_id"`)
+}
+
 func TestGetVisit_Unauthorized(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -156,9 +208,8 @@ func TestCreateVisit_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultVisitForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestCreateVisit_ValidationError(t *testing.T) {
@@ -176,9 +227,31 @@ func TestCreateVisit_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "VisitDto.Info")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"info"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
+}
+
+func TestCreateVisit_InvalidReference(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit := NewVisitController(cont)
+	e.POST(config.APIv1Visits, func(c echo.Context) error { return visit.Create(c) })
+
+	param := createVisitForCreate()
+	param.ClientID = 9999
+	req := test.NewJSONRequest("POST", config.APIv1Visits, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"invalid_reference"`)
+	assert.Contains(t, rec.Body.String(), `"field":"clientId"`)
 }
 
 func TestCreateVisit_Unauthorized(t *testing.T) {
@@ -254,9 +327,8 @@ func TestUpdateVisit_BindError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	result := createResultVisitForBindError()
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.JSONEq(t, test.ConvertToJSON(result), rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":"bad_request"`)
 }
 
 func TestUpdateVisit_ValidationError(t *testing.T) {
@@ -274,9 +346,9 @@ func TestUpdateVisit_ValidationError(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
-	assert.Contains(t, rec.Body.String(), "VisitDto.Info")
-	assert.Contains(t, rec.Body.String(), "'ruprintascii'")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"info"`)
+	assert.Contains(t, rec.Body.String(), `"tag":"ruprintascii"`)
 }
 
 func TestUpdateVisit_Unauthorized(t *testing.T) {
@@ -312,6 +384,139 @@ func TestUpdateVisit_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+func TestPatchVisit_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.PATCH(config.APIv1VisitsID, func(c echo.Context) error { return visit.Patch(c) })
+
+	param := map[string]interface{}{"info": "Новая информация"}
+	req := test.NewJSONRequest("PATCH", test.SetParam(config.APIv1VisitsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, "Новая информация", data.Info)
+	assert.Equal(t, createVisitForCreate().ServiceID, data.ServiceID)
+}
+
+func TestUpdateVisit_IfMatch(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.PUT(config.APIv1VisitsID, func(c echo.Context) error { return visit.Update(c) })
+
+	m := &models.Visit{}
+	before, _ := m.Get(cont.Repository(), 2)
+
+	param := createVisitForUpdate()
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1VisitsID, "2"), param)
+	req.Header.Set("If-Match", before.ETag())
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	data, _ := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, data.ETag(), rec.Header().Get("ETag"))
+	assert.NotEqual(t, before.ETag(), data.ETag())
+}
+
+func TestUpdateVisit_PreconditionFailed(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.PUT(config.APIv1VisitsID, func(c echo.Context) error { return visit.Update(c) })
+
+	m := &models.Visit{}
+	before, _ := m.Get(cont.Repository(), 2)
+	changed := createVisitForUpdate().ToModel()
+	changed.LastUpdatedByID = 1
+	_, _ = changed.Update(cont.Repository(), 2)
+
+	param := createVisitForUpdate()
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1VisitsID, "2"), param)
+	req.Header.Set("If-Match", before.ETag())
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	expected := apperror.Response{Code: apperror.PreconditionFailed, Message: "the record has been changed by another user"}
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
+}
+
+func TestUpdateVisit_PreconditionRequired(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+	cont.Config().Extension.IfMatchRequired = true
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.PUT(config.APIv1VisitsID, func(c echo.Context) error { return visit.Update(c) })
+
+	param := createVisitForUpdate()
+	req := test.NewJSONRequest("PUT", test.SetParam(config.APIv1VisitsID, "2"), param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Administrator)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"precondition_required"`)
+}
+
+func TestDeleteVisit_PreconditionFailed(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	setUpVisitTestData(cont)
+
+	visit := NewVisitController(cont)
+	e.DELETE(config.APIv1VisitsID, func(c echo.Context) error { return visit.Delete(c) })
+
+	req := httptest.NewRequest("DELETE", test.SetParam(config.APIv1VisitsID, "2"), nil)
+	req.Header.Set("If-Match", `"2-0"`)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Superuser)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	_, err := m.Get(cont.Repository(), 2)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.NoError(t, err)
+}
+
 func TestDeleteVisit_Success(t *testing.T) {
 	e, cont := test.PrepareForControllerTest()
 
@@ -349,8 +554,8 @@ func TestDeleteVisit_Failure(t *testing.T) {
 
 	e.ServeHTTP(rec, req)
 
-	expected := ErrorResponse{Message: "record not found"}
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	expected := apperror.Response{Code: apperror.NotFound, Message: "record not found"}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 	assert.JSONEq(t, test.ConvertToJSON(expected), rec.Body.String())
 }
 
@@ -414,17 +619,6 @@ func createVisitForBindError() *VisitDtoForBindError {
 	}
 }
 
-func createResultVisitForBindError() *dto.VisitDto {
-	return &dto.VisitDto{
-		DateTime:  time.Date(2024, time.January, 2, 0, 0, 0, 0, time.Local),
-		Info:      "Информация",
-		ClientID:  0,
-		PetID:     0,
-		DoctorID:  0,
-		ServiceID: 0,
-	}
-}
-
 func createVisitForValidationError() *dto.VisitDto {
 	return &dto.VisitDto{
 		DateTime:  time.Date(2024, time.January, 2, 0, 0, 0, 0, time.Local),
@@ -446,3 +640,40 @@ func createVisitForUpdate() *dto.VisitDto {
 		ServiceID: 1,
 	}
 }
+
+func TestCompleteVisit_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit := NewVisitController(cont)
+	e.POST(config.APIv1VisitsIDComplete, func(c echo.Context) error { return visit.Complete(c) })
+
+	req := httptest.NewRequest("POST", test.SetParam(config.APIv1VisitsIDComplete, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.Visit{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+	assert.Equal(t, models.VisitCompleted, data.Status)
+}
+
+func TestCompleteVisit_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	visit := NewVisitController(cont)
+	e.POST(config.APIv1VisitsIDComplete, func(c echo.Context) error { return visit.Complete(c) })
+
+	req := httptest.NewRequest("POST", test.SetParam(config.APIv1VisitsIDComplete, "1"), nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models/dto"
+	"vet-clinic/service"
+	"vet-clinic/util"
+)
+
+type WaitlistController struct {
+	container container.Container
+	service   *service.WaitlistService
+}
+
+// NewWaitlistController is constructor.
+func NewWaitlistController(container container.Container) *WaitlistController {
+	return &WaitlistController{container: container, service: service.NewWaitlistService(container)}
+}
+
+// Get returns one record matched waitlist entry's id.
+//
+// @Summary Get a waitlist entry.
+// @Description Returns one record matched waitlist entry's id.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Waitlist entry ID"
+// @Success 200 {object} models.WaitlistEntry "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /waitlist/{id} [get]
+func (r *WaitlistController) Get(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	entry, err := r.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, entry)
+}
+
+// GetAll returns the list of waitlist entries.
+//
+// @Summary Get the waitlist.
+// @Description Returns the list of waitlist entries in the order they were added.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Return only the entries with the status: waiting, booked or cancelled."
+// @Success 200 {object} []models.WaitlistEntry "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /waitlist [get]
+func (r *WaitlistController) GetAll(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	entries, err := r.service.GetAll(c.Request().Context(), c.QueryParam("status"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GetMatches returns the freed slots which suit the waiting entries.
+//
+// @Summary Get the candidates for the freed slots.
+// @Description Returns the upcoming slots freed by the cancelled, deleted or moved visits, each with a waiting entry whose preferred doctor, service and time windows it suits.
+// @Description A slot is returned only while it is still free, the earliest slot first and the entries in the order they were added.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.WaitlistMatch "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to fetch data."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Router /waitlist/matches [get]
+func (r *WaitlistController) GetMatches(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	matches, err := r.service.GetMatches(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, matches)
+}
+
+// Create creates a new waitlist entry.
+//
+// @Summary Create a new waitlist entry.
+// @Description Create a new entry of the client waiting for a slot of the service in one of the given time windows.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body dto.WaitlistEntryDto true "A new waitlist entry data for creating."
+// @Success 200 {object} models.WaitlistEntry "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 409 {object} apperror.Response "The service cannot be provided by the doctor or a time window ends before it starts."
+// @Failure 422 {object} apperror.Response "Validation failed or the pet does not belong to the client."
+// @Router /waitlist [post]
+func (r *WaitlistController) Create(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	data := &dto.WaitlistEntryDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	data.LastUpdatedByID = user.ID
+	entry, err := r.service.Create(c.Request().Context(), data)
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, entry)
+}
+
+// Update updates the existing waitlist entry.
+//
+// @Summary Update the existing waitlist entry.
+// @Description Update the existing waitlist entry, the time windows are replaced. The matches of an entry which is no longer waiting are not returned.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Waitlist entry ID"
+// @Param data body dto.WaitlistEntryDto true "Waitlist entry data for update."
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.WaitlistEntry "Success to fetch data."
+// @Header 200 {string} ETag "Entity tag of the record."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 409 {object} apperror.Response "The service cannot be provided by the doctor or a time window ends before it starts."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 422 {object} apperror.Response "Validation failed or the pet does not belong to the client."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /waitlist/{id} [put]
+func (r *WaitlistController) Update(c echo.Context) error {
+	user := getUser(c, r.container)
+	if user == nil {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	data := &dto.WaitlistEntryDto{}
+	if err := c.Bind(data); err != nil {
+		return err
+	}
+	if err := c.Validate(data); err != nil {
+		return err
+	}
+
+	data.LastUpdatedByID = user.ID
+	entry, err := r.service.Update(c.Request().Context(), data, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return jsonWithETag(c, entry)
+}
+
+// Delete deletes the existing waitlist entry.
+//
+// @Summary Delete the existing waitlist entry.
+// @Description Permanently delete the existing waitlist entry with its time windows and matches.
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Waitlist entry ID"
+// @Param If-Match header string false "ETag of the record, the request fails if the record has been changed since it was fetched."
+// @Success 200 {object} models.WaitlistEntry "Success to fetch data."
+// @Failure 400 {object} apperror.Response "Failed to the registration."
+// @Failure 401 {object} apperror.Response "Failed to the authentication."
+// @Failure 412 {object} apperror.Response "The record has been changed by another user."
+// @Failure 428 {object} apperror.Response "The If-Match header is required."
+// @Router /waitlist/{id} [delete]
+func (r *WaitlistController) Delete(c echo.Context) error {
+	level := getAccessLevel(c, r.container)
+	if !util.Staff.AccessAllowed(level) {
+		return apperror.NewUnauthorized()
+	}
+
+	if err := checkIfMatch(c, r.container, r.service.Get, c.Param("id")); err != nil {
+		return err
+	}
+
+	entry, err := r.service.Delete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, entry)
+}
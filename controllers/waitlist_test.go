@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+	"vet-clinic/util"
+)
+
+func TestCreateWaitlistEntry_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	waitlist := NewWaitlistController(cont)
+	e.POST(config.APIv1Waitlist, func(c echo.Context) error { return waitlist.Create(c) })
+
+	param := createWaitlistEntryForCreate()
+	req := test.NewJSONRequest("POST", config.APIv1Waitlist, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.WaitlistEntry{}
+	data, _ := m.Get(cont.Repository(), 1)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func TestCreateWaitlistEntry_ValidationError(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	waitlist := NewWaitlistController(cont)
+	e.POST(config.APIv1Waitlist, func(c echo.Context) error { return waitlist.Create(c) })
+
+	param := createWaitlistEntryForCreate()
+	param.Windows = nil
+	req := test.NewJSONRequest("POST", config.APIv1Waitlist, param)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	userForLogin.BaseModel = &models.BaseModel{ID: 1}
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestGetWaitlistList_Unauthorized(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	waitlist := NewWaitlistController(cont)
+	e.GET(config.APIv1Waitlist, func(c echo.Context) error { return waitlist.GetAll(c) })
+
+	req := httptest.NewRequest("GET", config.APIv1Waitlist, nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGetWaitlistMatches_Success(t *testing.T) {
+	e, cont := test.PrepareForControllerTest()
+
+	waitlist := NewWaitlistController(cont)
+	e.GET(config.APIv1WaitlistMatches, func(c echo.Context) error { return waitlist.GetMatches(c) })
+
+	visit := createVisitForCreate()
+	visit.DateTime = time.Now().AddDate(0, 0, 1).Truncate(time.Hour)
+	visit.ServiceID = 1
+	visit.LastUpdatedByID = 1
+	created, _ := visit.ToModel().Create(cont.Repository())
+	_, _ = createWaitlistEntryForCreate().ToModel().Create(cont.Repository())
+	_, _ = created.Delete(cont.Repository(), created.ID)
+
+	req := httptest.NewRequest("GET", config.APIv1WaitlistMatches, nil)
+	rec := httptest.NewRecorder()
+
+	userForLogin := userWithAccessLevel(util.Staff)
+	test.LoginUser(e, cont, req, rec, userForLogin)
+
+	e.ServeHTTP(rec, req)
+
+	m := &models.WaitlistMatch{}
+	data, _ := m.GetOpen(cont.Repository(), time.Now())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, data, 1)
+	assert.JSONEq(t, test.ConvertToJSON(data), rec.Body.String())
+}
+
+func createWaitlistEntryForCreate() *dto.WaitlistEntryDto {
+	from := time.Now().AddDate(0, 0, 1).Truncate(time.Hour).Add(-time.Hour)
+	return &dto.WaitlistEntryDto{
+		ClientID:        1,
+		PetID:           1,
+		ServiceID:       1,
+		Comment:         "Позвонить, если освободится время",
+		Windows:         []*dto.WaitlistWindowDto{{StartTime: from, EndTime: from.Add(3 * time.Hour)}},
+		LastUpdatedByID: 1,
+	}
+}
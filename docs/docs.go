@@ -22,14 +22,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/clients": {
+        "/attachments/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of clients.",
+                "description": "Returns the description of the attached file. Use the download endpoint to get its content.",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,37 +37,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Attachments"
+                ],
+                "summary": "Get an attachment.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a client list.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Client"
-                            }
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The attachment does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new client.",
+                "description": "Delete the attachment and the file permanently.",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,64 +90,89 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Attachments"
                 ],
-                "summary": "Create a new client. Required user's role: Admin",
+                "summary": "Delete the attachment. Required user's role: Admin",
                 "parameters": [
                     {
-                        "description": "A new client data for creating.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ClientDto"
-                        }
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success to fetch data.",
+                        "description": "Success to delete.",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The attachment does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/clients/{id}": {
+        "/attachments/{id}/download": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched client's id.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the content of the attached file with its type and name.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Clients"
+                    "Attachments"
                 ],
-                "summary": "Get a client.",
+                "summary": "Download the attached file.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -140,29 +180,40 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success to fetch data.",
+                        "description": "The content of the file.",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The attachment does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/batches/expiring": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing client.",
+                "description": "Returns the batches left in stock which expire within the given number of days, including the expired ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -170,55 +221,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Products"
                 ],
-                "summary": "Update the existing client. Required user's role: Admin",
+                "summary": "Get the batches expiring soon.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Client ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Client data for update.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ClientDto"
-                        }
+                        "type": "integer",
+                        "description": "Number of days, 30 by default.",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductBatch"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
-                    },
-                    "403": {
-                        "description": "Access denied."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/batches/{id}/write-off": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing client.",
+                "description": "Decreases the quantity of the batch, e.g. for damaged or expired goods.",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,48 +272,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Products"
                 ],
-                "summary": "Delete the existing client. Required user's role: Superuser",
+                "summary": "Write off a quantity of the batch.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID",
+                        "description": "Batch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Write-off data.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WriteOffDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Client"
+                            "$ref": "#/definitions/models.ProductBatch"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
-                    "403": {
-                        "description": "Access denied."
+                    "404": {
+                        "description": "The batch does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The quantity exceeds the stock of the batch.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/departments": {
+        "/booking/departments": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Returns the list of departments.",
+                "description": "Returns the list of departments along with their services and doctors who can be booked online.",
                 "consumes": [
                     "application/json"
                 ],
@@ -275,37 +343,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departments"
+                    "Booking"
                 ],
-                "summary": "Get a department list.",
+                "summary": "Get the departments for online booking.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Department"
+                                "$ref": "#/definitions/models.BookableDepartment"
                             }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
-                    },
-                    "401": {
-                        "description": "Failed to the authentication."
                     }
                 }
-            },
-            "post": {
-                "security": [
+            }
+        },
+        "/booking/slots": {
+            "get": {
+                "description": "Returns the free slots on the given date of the doctors who provide the given service, within the working hours of each doctor and while the resources required by the service are free.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Booking"
+                ],
+                "summary": "Get the free slots for online booking.",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "serviceId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Date",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department ID",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Doctor ID",
+                        "name": "doctorId",
+                        "in": "query"
                     }
                 ],
-                "description": "Create a new department.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Slot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/booking/visits": {
+            "post": {
+                "description": "Reserve a free slot with the contact details. It creates a tentative visit, which the clinic confirms\nby changing its status to scheduled. The client is created as a tentative client, which the staff reconciles with the existing clients.",
                 "consumes": [
                     "application/json"
                 ],
@@ -313,50 +436,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departments"
+                    "Booking"
                 ],
-                "summary": "Create a new department. Required user's role: Owner",
+                "summary": "Book a visit online.",
                 "parameters": [
                     {
-                        "description": "A new department data for creating.",
+                        "description": "The booking data.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DepartmentDto"
+                            "$ref": "#/definitions/dto.BookingDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success to fetch data.",
+                        "description": "Success to book.",
                         "schema": {
-                            "$ref": "#/definitions/models.Department"
+                            "$ref": "#/definitions/models.Booking"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "401": {
-                        "description": "Failed to the authentication."
+                    "409": {
+                        "description": "The slot or a required resource is taken, or a booking with the same phone or e-mail is pending.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
-                    "403": {
-                        "description": "Access denied."
+                    "422": {
+                        "description": "The time is not a free slot or the doctor does not provide the service.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many bookings.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/departments/{id_or_slug}": {
+        "/breeds": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched department's id.",
+                "description": "Returns the list of breeds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,45 +499,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departments"
+                    "Breeds"
                 ],
-                "summary": "Get a department.",
+                "summary": "Get a breed list.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Department ID",
-                        "name": "id_or_slug",
-                        "in": "path",
-                        "required": true
+                        "description": "Return only the breeds of the species.",
+                        "name": "speciesId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Department"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Breed"
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            }
-        },
-        "/departments/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing department.",
+                "description": "Create a new breed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -410,24 +548,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Departments"
+                    "Breeds"
                 ],
-                "summary": "Update the existing department. Required user's role: Owner",
+                "summary": "Create a new breed. Required user's role: Admin",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Department ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Department data for update.",
+                        "description": "A new breed data for creating.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DepartmentDto"
+                            "$ref": "#/definitions/dto.BreedDto"
                         }
                     }
                 ],
@@ -435,102 +566,50 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Department"
+                            "$ref": "#/definitions/models.Breed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete the existing department.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Departments"
-                ],
-                "summary": "Delete the existing department. Required user's role: Owner",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Department ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Success to fetch data.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Department"
-                        }
-                    },
-                    "400": {
-                        "description": "Failed to the registration.",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Failed to the authentication."
-                    },
-                    "403": {
-                        "description": "Access denied."
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Returns the health status of the system.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System"
-                ],
-                "summary": "Get the health status.",
-                "responses": {
-                    "200": {
-                        "description": "Success to fetch health status.",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.HealthResult"
-                        }
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The breed with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/leads": {
+        "/breeds/unverified": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of leads.",
+                "description": "Returns the breeds entered as free text for the pets along with the number of the pets.\nCreating the breed in the catalogue links the pets to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -538,77 +617,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Leads"
+                    "Breeds"
                 ],
-                "summary": "Get a lead list.",
+                "summary": "Get the breeds to be reviewed.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Lead"
+                                "$ref": "#/definitions/models.UnverifiedBreed"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Failed to fetch data.",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
-                        }
-                    },
                     "401": {
-                        "description": "Failed to the authentication."
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new lead.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Leads"
-                ],
-                "summary": "Create a new lead.",
-                "parameters": [
-                    {
-                        "description": "A new lead data for creating.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LeadDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Success to fetch data.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Lead"
-                        }
-                    },
-                    "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to the authentication.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/leads/{id}": {
+        "/breeds/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched lead's id.",
+                "description": "Returns one record matched breed's id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -616,13 +654,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Leads"
+                    "Breeds"
                 ],
-                "summary": "Get a lead.",
+                "summary": "Get a breed.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lead ID",
+                        "description": "Breed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -632,17 +670,26 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Lead"
+                            "$ref": "#/definitions/models.Breed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -652,7 +699,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing lead.",
+                "description": "Update the existing breed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -660,42 +707,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Leads"
+                    "Breeds"
                 ],
-                "summary": "Update the existing lead.",
+                "summary": "Update the existing breed. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lead ID",
+                        "description": "Breed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Lead data for update.",
+                        "description": "Service data for update.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LeadDto"
+                            "$ref": "#/definitions/dto.BreedDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Lead"
+                            "$ref": "#/definitions/models.Breed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The breed with the same name already exists or pets refer to the breed of another species.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -705,7 +791,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing lead.",
+                "description": "Delete the existing breed. It fails while pets, including the soft-deleted ones, refer to the breed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -713,120 +799,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Leads"
+                    "Breeds"
                 ],
-                "summary": "Delete the existing lead. Required user's role: Superuser",
+                "summary": "Delete the existing breed. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lead ID",
+                        "description": "Breed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Lead"
+                            "$ref": "#/definitions/models.Breed"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Login using username, e-mail, or phone along with the password.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Login with credentials.",
-                "parameters": [
-                    {
-                        "description": "Login and Password for logged-in.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
+                        "description": "Access denied.",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginDto"
+                            "$ref": "#/definitions/apperror.Response"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Success to the authentication.",
+                    },
+                    "409": {
+                        "description": "The breed is referenced by other records.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
-                        },
-                        "headers": {
-                            "Cookie": {
-                                "type": "string",
-                                "description": "Authorization"
-                            }
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "400": {
-                        "description": "Failed to fetch data.",
+                    "412": {
+                        "description": "The record has been changed by another user.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "401": {
-                        "description": "Failed to the authentication."
-                    }
-                }
-            }
-        },
-        "/logout": {
-            "post": {
-                "description": "Logout the user by invalidating the current session.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Logout user.",
-                "responses": {
-                    "200": {
-                        "description": "Successfully logged out."
-                    },
-                    "401": {
-                        "description": "Failed to the authentication."
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/pets": {
+        "/clients": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of pets.",
+                "description": "Returns the list of clients.",
                 "consumes": [
                     "application/json"
                 ],
@@ -834,27 +878,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pets"
+                    "Clients"
+                ],
+                "summary": "Get a client list.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the soft-deleted clients. Required user's role: Admin",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get a pet list.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Pet"
+                                "$ref": "#/definitions/models.Client"
                             }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -864,7 +925,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new pet.",
+                "description": "Create a new client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -872,17 +933,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pets"
+                    "Clients"
                 ],
-                "summary": "Create a new pet.",
+                "summary": "Create a new client. Required user's role: Admin",
                 "parameters": [
                     {
-                        "description": "A new pet data for creating.",
+                        "description": "A new client data for creating.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.PetDto"
+                            "$ref": "#/definitions/dto.ClientDto"
                         }
                     }
                 ],
@@ -890,29 +951,44 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Pet"
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/pets/{id}": {
+        "/clients/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched pet's id.",
+                "description": "Returns one record matched client's id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -920,13 +996,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pets"
+                    "Clients"
                 ],
-                "summary": "Get a pet.",
+                "summary": "Get a client.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pet ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -936,17 +1012,26 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Pet"
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -956,7 +1041,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing pet.",
+                "description": "Update the existing client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -964,42 +1049,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pets"
+                    "Clients"
                 ],
-                "summary": "Update the existing pet.",
+                "summary": "Update the existing client. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pet ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Pet data for update.",
+                        "description": "Client data for update.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.PetDto"
+                            "$ref": "#/definitions/dto.ClientDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Pet"
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -1009,7 +1127,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing pet.",
+                "description": "Delete the existing client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1017,173 +1135,159 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pets"
+                    "Clients"
                 ],
-                "summary": "Delete the existing pet. Required user's role: Superuser",
+                "summary": "Delete the existing client. Required user's role: Superuser",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pet ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Pet"
+                            "$ref": "#/definitions/models.Client"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
-                    }
-                }
-            }
-        },
-        "/profile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves and returns the user's own profile based on the session.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get user's profile.",
-                "responses": {
-                    "200": {
-                        "description": "Success to fetch data.",
+                        "description": "Access denied.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "401": {
-                        "description": "Failed to the authentication."
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
-            "put": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates and returns the user's own profile based on the session.",
+                "description": "Partially update the existing client by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
                 "consumes": [
+                    "application/merge-patch+json",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Clients"
                 ],
-                "summary": "Update user's profile.",
+                "summary": "Partially update the existing client. Required user's role: Admin",
                 "parameters": [
                     {
-                        "description": "User data for update.",
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Client fields to update.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserUpdateDto"
+                            "$ref": "#/definitions/dto.ClientDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Client"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
-                    }
-                }
-            }
-        },
-        "/profile/password": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Updates the user's password based on the provided data.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Update user's password.",
-                "parameters": [
-                    {
-                        "description": "Old password and new password for update.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
+                        "description": "Failed to the authentication.",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePasswordDto"
+                            "$ref": "#/definitions/apperror.Response"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Success to fetch data.",
+                    },
+                    "403": {
+                        "description": "Access denied.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "400": {
-                        "description": "Failed to the registration.",
+                    "412": {
+                        "description": "The record has been changed by another user.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
-                    "401": {
-                        "description": "Failed to the authentication."
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/roles": {
+        "/clients/{id}/account": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of roles.",
+                "description": "Returns the portal account of the client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1191,30 +1295,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clients"
+                ],
+                "summary": "Get the portal account of the client.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a role list. Required user's role: Owner",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Role"
-                            }
+                            "$ref": "#/definitions/models.ClientAccount"
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
-                    "403": {
-                        "description": "Access denied."
+                    "404": {
+                        "description": "The client has no portal account.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -1224,7 +1340,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new role.",
+                "description": "Create the portal account of the client. Without the password the client can log in only with one-time codes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1232,17 +1348,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clients"
                 ],
-                "summary": "Create a new role. Required user's role: Superuser",
+                "summary": "Create the portal account of the client. Required user's role: Admin",
                 "parameters": [
                     {
-                        "description": "A new role data for creating.",
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The account data.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RoleDto"
+                            "$ref": "#/definitions/dto.ClientAccountDto"
                         }
                     }
                 ],
@@ -1250,32 +1373,42 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/models.ClientAccount"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The client already has a portal account.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            }
-        },
-        "/roles/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched role's id.",
+                "description": "Delete the portal account of the client, the client can no longer log in to the portal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1283,13 +1416,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clients"
                 ],
-                "summary": "Get a role. Required user's role: Owner",
+                "summary": "Delete the portal account of the client. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1299,30 +1432,44 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/models.ClientAccount"
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client has no portal account.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/clients/{id}/balance": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing role.",
+                "description": "Returns the balance of the client along with its ledger, the latest entry first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1330,55 +1477,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clients"
                 ],
-                "summary": "Update the existing role. Required user's role: Superuser",
+                "summary": "Get the balance of the client.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Role data for update.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RoleDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/models.ClientBalance"
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
-                    "403": {
-                        "description": "Access denied."
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/clients/{id}/balance/refund": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing role.",
+                "description": "Records the money returned to the client, e.g. the deposit for a returned device. The amount cannot exceed the balance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1386,48 +1532,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clients"
                 ],
-                "summary": "Delete the existing role. Required user's role: Superuser",
+                "summary": "Refund the balance of the client. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The amount of the refund.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceEntryDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Role"
+                            "$ref": "#/definitions/models.ClientBalance"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The amount exceeds the balance of the client.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/services": {
-            "get": {
+        "/clients/{id}/balance/top-up": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of services.",
+                "description": "Records the prepayment made by the client, it can be used to pay the invoices.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1435,37 +1608,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Clients"
+                ],
+                "summary": "Top up the balance of the client.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The amount of the prepayment.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceEntryDto"
+                        }
+                    }
                 ],
-                "summary": "Get a service list.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Service"
-                            }
+                            "$ref": "#/definitions/models.ClientBalance"
                         }
                     },
                     "400": {
-                        "description": "Failed to fetch data.",
+                        "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/clients/{id}/discount": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new service.",
+                "description": "Returns the total spent by the client and the discount tier reached by the client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1473,50 +1672,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Discounts"
                 ],
-                "summary": "Create a new service. Required user's role: Owner",
+                "summary": "Get the discount of the client.",
                 "parameters": [
                     {
-                        "description": "A new service data for creating.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ServiceDto"
-                        }
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "$ref": "#/definitions/models.ClientDiscount"
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
-                    "403": {
-                        "description": "Access denied."
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/services/{id}": {
+        "/clients/{id}/duplicates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched service's id.",
+                "description": "Returns the other clients with the same phone number or e-mail, or with a similar full name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1524,13 +1727,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Clients"
                 ],
-                "summary": "Get a service.",
+                "summary": "Get the likely duplicates of the client.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1540,27 +1743,41 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DuplicateClient"
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/clients/{id}/invoices": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing service.",
+                "description": "Returns the invoices of the client, the latest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1568,55 +1785,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Invoices"
                 ],
-                "summary": "Update the existing service. Required user's role: Owner",
+                "summary": "Get the invoices of the client.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Service data for update.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ServiceDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Invoice"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
-                    },
-                    "403": {
-                        "description": "Access denied."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/clients/{id}/merge": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing service.",
+                "description": "Move the pets, the visits and the portal account of the duplicate client to the client in one transaction,\nfill in the empty fields of the client and soft-delete the duplicate. The merge is recorded for the audit.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1624,48 +1837,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Clients"
                 ],
-                "summary": "Delete the existing service. Required user's role: Owner",
+                "summary": "Merge the duplicate client into the client. Required user's role: Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "ID of the surviving client",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The duplicate client.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClientMergeDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success to fetch data.",
+                        "description": "Success to merge.",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "$ref": "#/definitions/models.ClientMerge"
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The duplicate client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/users": {
+        "/clients/{id}/merges": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of users.",
+                "description": "Returns the audit records of the merges into and from the client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1673,37 +1913,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Clients"
+                ],
+                "summary": "Get the merges of the client.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a user list.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.User"
+                                "$ref": "#/definitions/models.ClientMerge"
                             }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/clients/{id}/purge": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new user.",
+                "description": "Permanently delete the client, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1711,50 +1965,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Clients"
                 ],
-                "summary": "Create a new user. Required user's role: Owner",
+                "summary": "Permanently delete the client, including the soft-deleted one. Required user's role: Superuser",
                 "parameters": [
                     {
-                        "description": "A new user data for creating.",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserCreateDto"
-                        }
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Client"
                         }
                     },
                     "400": {
-                        "description": "Failed to the registration.",
+                        "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The client is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/users/{id_or_slug}": {
-            "get": {
+        "/clients/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched user's id or slug.",
+                "description": "Restore the soft-deleted client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1762,14 +2032,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Clients"
                 ],
-                "summary": "Get a user.",
+                "summary": "Restore the soft-deleted client. Required user's role: Superuser",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id_or_slug",
+                        "description": "Client ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1778,29 +2048,44 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Client"
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "put": {
+        "/departments": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing user.",
+                "description": "Returns the list of departments.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1808,45 +2093,234 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Departments"
                 ],
-                "summary": "Update the existing user. Required user's role: Owner",
+                "summary": "Get a department list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Department"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new department.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Departments"
+                ],
+                "summary": "Create a new department. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "description": "A new department data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepartmentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/departments/{id_or_slug}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched department's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Departments"
+                ],
+                "summary": "Get a department.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Department ID",
+                        "name": "id_or_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/departments/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing department.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Departments"
+                ],
+                "summary": "Update the existing department. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User data for update.",
+                        "description": "Department data for update.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserUpdateDto"
+                            "$ref": "#/definitions/dto.DepartmentDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Department"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -1856,7 +2330,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing user.",
+                "description": "Delete the existing department.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1864,48 +2338,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Departments"
                 ],
-                "summary": "Delete the existing user. Required user's role: Superuser",
+                "summary": "Delete the existing department. Required user's role: Owner",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Department ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Department"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/visits": {
+        "/discount-tiers": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns the list of visits.",
+                "description": "Returns the list of discount tiers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1913,27 +2411,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Visits"
+                    "Discounts"
                 ],
-                "summary": "Get a visit list.",
+                "summary": "Get a discount tier list.",
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Visit"
+                                "$ref": "#/definitions/models.DiscountTier"
                             }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -1943,7 +2444,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new visit.",
+                "description": "Create a new discount tier.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1951,17 +2452,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Visits"
+                    "Discounts"
                 ],
-                "summary": "Create a new visit. Required user's role: Admin",
+                "summary": "Create a new discount tier. Required user's role: Owner",
                 "parameters": [
                     {
-                        "description": "A new visit data for creating.",
+                        "description": "A new discount tier data for creating.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VisitDto"
+                            "$ref": "#/definitions/dto.DiscountTierDto"
                         }
                     }
                 ],
@@ -1969,32 +2470,50 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Visit"
+                            "$ref": "#/definitions/models.DiscountTier"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The discount tier with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/visits/{id}": {
+        "/discount-tiers/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns one record matched visit's id.",
+                "description": "Returns one record matched discount tier's id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2002,13 +2521,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Visits"
+                    "Discounts"
                 ],
-                "summary": "Get a visit.",
+                "summary": "Get a discount tier.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Visit ID",
+                        "description": "Discount tier ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2018,17 +2537,26 @@ const docTemplate = `{
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Visit"
+                            "$ref": "#/definitions/models.DiscountTier"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             },
@@ -2038,7 +2566,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update the existing visit.",
+                "description": "Update the existing discount tier.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2046,55 +2574,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Visits"
+                    "Discounts"
                 ],
-                "summary": "Update the existing visit. Required user's role: Admin",
+                "summary": "Update the existing discount tier. Required user's role: Owner",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Visit ID",
+                        "description": "Discount tier ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Visit data for update.",
+                        "description": "Discount tier data for update.",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VisitDto"
+                            "$ref": "#/definitions/dto.DiscountTierDto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Visit"
+                            "$ref": "#/definitions/models.DiscountTier"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The discount tier with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete the existing visit.",
+                "description": "Delete the existing discount tier. It fails while invoices, including the soft-deleted ones, refer to the discount tier.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2102,472 +2666,10824 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Visits"
+                    "Discounts"
                 ],
-                "summary": "Delete the existing visit. Required user's role: Superuser",
+                "summary": "Delete the existing discount tier. Required user's role: Owner",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Visit ID",
+                        "description": "Discount tier ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success to fetch data.",
                         "schema": {
-                            "$ref": "#/definitions/models.Visit"
+                            "$ref": "#/definitions/models.DiscountTier"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The discount tier is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the system.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get the health status.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch health status.",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HealthResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched invoice's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invoices"
+                ],
+                "summary": "Get an invoice.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invoice ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The invoice does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/lab-results": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record a value measured by a laboratory test for the pet of the visit. The value is flagged as normal, low or high according to the reference range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabResults"
+                ],
+                "summary": "Create a new lab result.",
+                "parameters": [
+                    {
+                        "description": "A new lab result data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LabResultDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to create.",
+                        "schema": {
+                            "$ref": "#/definitions/models.LabResult"
                         }
                     },
                     "400": {
                         "description": "Failed to the registration.",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ErrorResponse"
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The visit does not exist or the reference range is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/lab-results/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched lab result's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabResults"
+                ],
+                "summary": "Get a lab result.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lab result ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.LabResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     },
                     "401": {
-                        "description": "Failed to the authentication."
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The lab result does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the lab result entered by mistake permanently.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabResults"
+                ],
+                "summary": "Delete the lab result. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lab result ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to delete.",
+                        "schema": {
+                            "$ref": "#/definitions/models.LabResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
                     "403": {
-                        "description": "Access denied."
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The lab result does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.ErrorResponse": {
+        },
+        "/leads": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of leads.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Get a lead list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Lead"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new lead submitted by the public form. The lead is assigned to the default assignee.\nThe honeypot field must be empty, the form token must be valid, the form must not be submitted too fast\nand the captcha must be solved if it is enabled. A lead with the same phone or e-mail as a recent one is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Create a new lead.",
+                "parameters": [
+                    {
+                        "description": "A new lead data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeadRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lead"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The lead has been rejected or the captcha is not valid.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/leads/form": {
+            "get": {
+                "description": "Returns a new form token, which is submitted along with the lead. It records when the form was shown.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Get the data for showing the lead form.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeadForm"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/leads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched lead's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Get a lead.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lead ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lead"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing lead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Update the existing lead.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lead ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lead data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeadDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lead"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing lead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Delete the existing lead. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lead ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lead"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update the existing lead by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leads"
+                ],
+                "summary": "Partially update the existing lead.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lead ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lead fields to update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeadDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lead"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login using username, e-mail, or phone along with the password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Login with credentials.",
+                "parameters": [
+                    {
+                        "description": "Login and Password for logged-in.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "Cookie": {
+                                "type": "string",
+                                "description": "Authorization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "Logout the user by invalidating the current session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Logout user.",
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged out."
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/medications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of medications.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Get a medication list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Medication"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new medication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Create a new medication. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "description": "A new medication data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MedicationDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Medication"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The medication with the same name, form and strength already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/medications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched medication's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Get a medication.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medication ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Medication"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing medication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Update the existing medication. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medication ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MedicationDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Medication"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The medication with the same name, form and strength already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing medication. It fails while prescriptions, including the soft-deleted ones, refer to the medication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medications"
+                ],
+                "summary": "Delete the existing medication. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medication ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Medication"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The medication is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of pets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Get a pet list.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the soft-deleted pets. Required user's role: Admin",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pet"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new pet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Create a new pet.",
+                "parameters": [
+                    {
+                        "description": "A new pet data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PetDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched pet's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Get a pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing pet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Update the existing pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pet data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PetDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing pet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Delete the existing pet. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update the existing pet by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Partially update the existing pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pet fields to update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PetDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the files attached to the pet and its visits, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get the attachments of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload a file, e.g. a report or an image, and attach it to the pet. The type of the file is detected from its content and must be one of the configured types.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Attach a file to the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file to attach.",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The description of the file.",
+                        "name": "description",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to upload.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "The file is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The type of the file is not allowed.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The file is missing or empty.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/lab-results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the lab results of the pet in the order of the visits. The test parameter narrows them down to the history of the test.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabResults"
+                ],
+                "summary": "Get the lab results of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the test regardless of the case, e.g. Гемоглобин",
+                        "name": "test",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LabResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/medications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the active prescriptions of the pet whose course includes the current time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Get the current medications of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Prescription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/prescriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the prescriptions of the pet, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Get the prescriptions of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Prescription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete the pet, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the pet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Permanently delete the pet, including the soft-deleted one. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The pet is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore the soft-deleted pet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Restore the soft-deleted pet. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Pet"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/weights": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the weight measurements of the pet ordered by the measurement time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Get the weight history of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PetWeight"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record the weight measurement of the pet. The weight recorded at the same visit is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Record the weight of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The weight measurement.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PetWeightDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to record.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PetWeight"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The visit of the pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/weights/trend": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the first, the latest, the minimum and the maximum weight of the pet, the change and the direction of the trend.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pets"
+                ],
+                "summary": "Get the weight trend of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.WeightTrend"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The pet does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/code": {
+            "post": {
+                "description": "Send a one-time login code to the client with the given phone or e-mail.\nThe response does not reveal whether the client has a portal account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Request a one-time code to log in to the client portal.",
+                "parameters": [
+                    {
+                        "description": "Login of the client.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortalCodeRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "The code has been sent if the account exists."
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/invoices": {
+            "get": {
+                "description": "Returns the list of the invoices of the client logged in to the portal, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Get the invoices of the logged-in client.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PortalInvoice"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/login": {
+            "post": {
+                "description": "Login using the phone or the e-mail of the client along with the password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Log in to the client portal with the password.",
+                "parameters": [
+                    {
+                        "description": "Login and Password for logged-in.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortalLoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ClientAccount"
+                        },
+                        "headers": {
+                            "Cookie": {
+                                "type": "string",
+                                "description": "Authorization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/login/code": {
+            "post": {
+                "description": "Login using the phone or the e-mail of the client along with the one-time code sent to the client.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Log in to the client portal with a one-time code.",
+                "parameters": [
+                    {
+                        "description": "Login and one-time code for logged-in.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortalCodeLoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ClientAccount"
+                        },
+                        "headers": {
+                            "Cookie": {
+                                "type": "string",
+                                "description": "Authorization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/logout": {
+            "post": {
+                "description": "Logout the client by invalidating the current session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Log out of the client portal.",
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged out."
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/pets": {
+            "get": {
+                "description": "Returns the list of the pets of the client logged in to the portal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Get the pets of the logged-in client.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Pet"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/pets/{id}/record": {
+            "get": {
+                "description": "Returns the completed visits, the prescriptions, the lab results and the weights of the pet of the client logged in to the portal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Get the medical record of the pet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PortalRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client has no such pet.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/profile": {
+            "get": {
+                "description": "Returns the data of the client logged in to the portal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Get the data of the logged-in client.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Client"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/visits": {
+            "get": {
+                "description": "Returns the list of the visits of the client logged in to the portal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Get the visits of the logged-in client.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "upcoming",
+                            "past"
+                        ],
+                        "type": "string",
+                        "description": "Only the upcoming or the past visits.",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PortalVisit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Request a visit for a pet of the client logged in to the portal. The clinic schedules it later.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Request a visit.",
+                "parameters": [
+                    {
+                        "description": "The requested visit.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PortalVisitDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PortalVisit"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The pet does not belong to the client or the service does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/portal/visits/{id}/cancel": {
+            "post": {
+                "description": "Cancel the upcoming visit of the client logged in to the portal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Portal"
+                ],
+                "summary": "Cancel a visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PortalVisit"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The client has no such visit.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The visit has taken place or is already cancelled.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prescriptions": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Prescribe a medication for the pet of the visit. The logged-in user is the prescriber.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Create a new prescription.",
+                "parameters": [
+                    {
+                        "description": "A new prescription data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PrescriptionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to create.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Prescription"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The visit or the medication does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prescriptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched prescription's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Get a prescription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prescription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Prescription"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The prescription does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prescriptions/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel the prescription, so the medication is no longer current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Cancel the prescription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prescription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to cancel.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Prescription"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The prescription does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The prescription has already been cancelled.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prescriptions/{id}/print": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the prescription as an HTML document to be printed.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Prescriptions"
+                ],
+                "summary": "Print the prescription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prescription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The printable prescription.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The prescription does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the prices of the services as of the given date ordered by category and name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get the price list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID.",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department ID, only the services of the department are listed with its prices.",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date in the format YYYY-MM-DD, today by default.",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PriceListItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The category or the department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-list/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the price list as a CSV file with the category, the service and the price columns.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Export the price list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID.",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department ID, only the services of the department are listed with its prices.",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date in the format YYYY-MM-DD, today by default.",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The price list.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The category or the department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new product.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "description": "A new product data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The product with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the products whose quantity in the non-expired batches is below the minimum stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get the products low in stock.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductStock"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched product's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing product.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update the existing product. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The product with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing product. It fails while batches or services, including the soft-deleted ones, refer to the product.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete the existing product. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The product is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/batches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the batches of the product ordered by the expiry date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get the batches of the product.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductBatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers a batch of the product received in stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Receive a batch of the product.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "A new batch data for receiving.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductBatchDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductBatch"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The batch with the same number already exists for the product.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The product does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the receipts, usages and write-offs of the product, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get the stock movements of the product.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves and returns the user's own profile based on the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user's profile.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates and returns the user's own profile based on the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user's profile.",
+                "parameters": [
+                    {
+                        "description": "User data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserUpdateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially updates the user's own profile by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Partially update user's profile.",
+                "parameters": [
+                    {
+                        "description": "User fields to update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserUpdateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the user's password based on the provided data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user's password.",
+                "parameters": [
+                    {
+                        "description": "Old password and new password for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePasswordDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of promo codes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discounts"
+                ],
+                "summary": "Get a promo code list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PromoCode"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new promo code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discounts"
+                ],
+                "summary": "Create a new promo code. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "description": "A new promo code data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCodeDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCode"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The promo code already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched promo code's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discounts"
+                ],
+                "summary": "Get a promo code.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCode"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing promo code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discounts"
+                ],
+                "summary": "Update the existing promo code. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promo code data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromoCodeDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCode"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The promo code already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing promo code. It fails while invoices, including the soft-deleted ones, refer to the promo code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discounts"
+                ],
+                "summary": "Delete the existing promo code. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The promo code is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of resources ordered by name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Get a resource list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return only the resources of the department.",
+                        "name": "departmentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Resource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new room or piece of equipment of the department.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Create a new resource. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "description": "A new resource data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResourceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resource"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The department already has a resource with the same name.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched resource's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Get a resource.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resource"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Update the existing resource. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResourceDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resource"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The department already has a resource with the same name.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing resource. It fails while a service requires the resource or a visit has reserved it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Delete the existing resource. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Resource"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The resource is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the reservations of the resource held by the tentative and scheduled visits on the given date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Get the reservations of the resource.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date in the format YYYY-MM-DD, today by default.",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Reservation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The resource does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get a role list. Required user's role: Owner",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create a new role. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "description": "A new role data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched role's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get a role. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update the existing role. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete the existing role. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the clients, pets, users and leads whose names, phones or e-mails start with the query.\nEvery word of the query is matched case-insensitively against the beginnings of the words.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search clients, pets, users and leads.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The query of at least 2 characters, e.g. a name or a phone number.",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "client,pet",
+                        "description": "Comma-separated types of the results, all types by default.",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The largest number of the results of each type, 10 by default and 50 at most.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/service-prices/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the price which is not yet effective, the effective prices are kept as the history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Delete the scheduled price of the service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service price ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServicePrice"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The price does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The price is already effective.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of services, optionally filtered by the category, the department and the archived flag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get a service list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return only the services of the category and its subcategories.",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return only the services of the department.",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only the archived services if true, or only the active ones if false.",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The category or the department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Create a new service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "description": "A new service data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched service's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get a service.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Update the existing service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Delete the existing service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/consumables": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the products used in the service with their quantities.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get the consumables of the service.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceConsumable"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the products used in the service, they are taken from stock when a visit for the service is completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Set the consumables of the service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The consumables of the service.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceConsumablesDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceConsumable"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The product is listed more than once.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The service or the product does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/price": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the price of the service in the department as of the given date. The price overridden for the department takes precedence over the base price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get the price of the service as of a date.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department ID, the base price is returned without it.",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date in the format YYYY-MM-DD, today by default.",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResolvedPrice"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The service does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the base and the department prices of the service, the latest effective date first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get the price history of the service.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServicePrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds the base price of the service, or the price overridden for the department, effective from the given date. The prices of the past visits are not affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Add a price of the service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "A new price data.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServicePriceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServicePrice"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The service does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The price effective from the same date already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The department does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/resources": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the rooms and the equipment required by the service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get the resources of the service.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceResource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the rooms and the equipment required by the service, they are reserved for the visits for the service.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Set the resources of the service. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The resources of the service.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceResourcesDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceResource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The resource is listed more than once.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The service or the resource does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/species": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of species.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Species"
+                ],
+                "summary": "Get a species list.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Species"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new species.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Species"
+                ],
+                "summary": "Create a new species. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "description": "A new species data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpeciesDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Species"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The species with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/species/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched species' id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Species"
+                ],
+                "summary": "Get a species.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Species ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Species"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing species.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Species"
+                ],
+                "summary": "Update the existing species. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Species ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpeciesDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Species"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The species with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing species. It fails while breeds or pets, including the soft-deleted ones, refer to the species.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Species"
+                ],
+                "summary": "Delete the existing species. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Species ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Species"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The species is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user list.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the soft-deleted users. Required user's role: Admin",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "description": "A new user data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserCreateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id_or_slug}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched user's id or slug.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id_or_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update the existing user. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserUpdateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete the existing user. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update the existing user by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Partially update the existing user. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User fields to update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserUpdateDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete the user, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Permanently delete the user, including the soft-deleted one. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The user is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore the soft-deleted user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Restore the soft-deleted user. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/working-hours": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the working hours of the user by the day of the week. A user without working hours works the default hours of the clinic every day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get the working hours of the user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WorkingHours"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the working hours of the user, the online booking offers the slots only within them. The user does not work on the days which are not listed, an empty list restores the default hours of the clinic.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set the working hours of the user. Required user's role: Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The working hours of the user.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WorkingScheduleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WorkingHours"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "A day is listed more than once or the hours end before they start.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of visits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Get a visit list.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the soft-deleted visits. Required user's role: Admin",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Visit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new visit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Create a new visit. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "description": "A new visit data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VisitDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched visit's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Get a visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing visit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Update the existing visit. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Visit data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VisitDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete the existing visit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Delete the existing visit. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially update the existing visit by using JSON Merge Patch. Only the fields present in the patch are validated and updated.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Partially update the existing visit. Required user's role: Admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Visit fields to update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VisitDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the files attached to the visit, the latest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get the attachments of the visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload a file, e.g. a report or an image of a diagnostic service, and attach it to the visit and the pet of the visit. The type of the file is detected from its content and must be one of the configured types.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Attach a file to the visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file to attach.",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The description of the file.",
+                        "name": "description",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to upload.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "The file is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "The type of the file is not allowed.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The file is missing or empty.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks the scheduled visit as completed and takes the consumables of its service from stock, the batches expiring first are used first. A completed visit cannot be reopened.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Complete the scheduled visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The visit is not scheduled or the consumables are out of stock.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/invoice": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Issues the invoice of the completed visit at the price of the service as of the date of the visit. The best of the loyalty discount of the client and the promo code discount applies. If useBalance is set, the invoice is paid from the balance of the client as far as it suffices. The invoice of a deposit service has no discount and is credited to the balance of the client.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invoices"
+                ],
+                "summary": "Invoice the visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The promo code and the payment from the balance.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvoiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Invoice"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The visit is not completed, has already been invoiced or the promo code is not valid.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "The promo code does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/lab-results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the lab results of the visit ordered by the test.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabResults"
+                ],
+                "summary": "Get the lab results of the visit.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LabResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete the visit, e.g. on a data protection request. It fails while other records, including the soft-deleted ones, refer to the visit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Permanently delete the visit, including the soft-deleted one. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The visit is referenced by other records.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore the soft-deleted visit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Visits"
+                ],
+                "summary": "Restore the soft-deleted visit. Required user's role: Superuser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Visit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Visit"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "The visit does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the list of waitlist entries in the order they were added.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Get the waitlist.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return only the entries with the status: waiting, booked or cancelled.",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WaitlistEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new entry of the client waiting for a slot of the service in one of the given time windows.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Create a new waitlist entry.",
+                "parameters": [
+                    {
+                        "description": "A new waitlist entry data for creating.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WaitlistEntryDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.WaitlistEntry"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The service cannot be provided by the doctor or a time window ends before it starts.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed or the pet does not belong to the client.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist/matches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the upcoming slots freed by the cancelled, deleted or moved visits, each with a waiting entry whose preferred doctor, service and time windows it suits.\nA slot is returned only while it is still free, the earliest slot first and the entries in the order they were added.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Get the candidates for the freed slots.",
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WaitlistMatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns one record matched waitlist entry's id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Get a waitlist entry.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.WaitlistEntry"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the existing waitlist entry, the time windows are replaced. The matches of an entry which is no longer waiting are not returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Update the existing waitlist entry.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Waitlist entry data for update.",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WaitlistEntryDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.WaitlistEntry"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the record."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The service cannot be provided by the doctor or a time window ends before it starts.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation failed or the pet does not belong to the client.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently delete the existing waitlist entry with its time windows and matches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Waitlist"
+                ],
+                "summary": "Delete the existing waitlist entry.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the record, the request fails if the record has been changed since it was fetched.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success to fetch data.",
+                        "schema": {
+                            "$ref": "#/definitions/models.WaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to the registration.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to the authentication.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "The record has been changed by another user.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "The If-Match header is required.",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperror.Code": {
+            "type": "string",
+            "enum": [
+                "bad_request",
+                "invalid_id",
+                "unauthorized",
+                "invalid_credentials",
+                "forbidden",
+                "not_found",
+                "conflict",
+                "validation_failed",
+                "invalid_reference",
+                "wrong_password",
+                "invalid_state",
+                "precondition_failed",
+                "precondition_required",
+                "file_too_large",
+                "unsupported_media_type",
+                "too_many_requests",
+                "service_unavailable",
+                "internal_error"
+            ],
+            "x-enum-varnames": [
+                "BadRequest",
+                "InvalidID",
+                "Unauthorized",
+                "InvalidCredentials",
+                "Forbidden",
+                "NotFound",
+                "Conflict",
+                "ValidationFailed",
+                "InvalidReference",
+                "WrongPassword",
+                "InvalidState",
+                "PreconditionFailed",
+                "PreconditionRequired",
+                "FileTooLarge",
+                "UnsupportedMediaType",
+                "TooManyRequests",
+                "Unavailable",
+                "Internal"
+            ]
+        },
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "email must be a valid email address"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "email"
+                }
+            }
+        },
+        "apperror.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apperror.Code"
+                        }
+                    ],
+                    "example": "not_found"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "record not found"
+                }
+            }
+        },
+        "controllers.HealthResult": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                }
+            }
+        },
+        "dto.BalanceEntryDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "note": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.BookingDto": {
+            "type": "object",
+            "required": [
+                "dateTime",
+                "doctorId",
+                "name",
+                "petName",
+                "phone",
+                "serviceId"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "dateTime": {
+                    "description": "Start of a free slot.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "E-mail string.",
+                    "type": "string",
+                    "example": "mail@mail.com"
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "petName": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Китти"
+                },
+                "phone": {
+                    "description": "E.164 phone number string.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "serviceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BreedDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "speciesId"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "speciesId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ClientAccountDto": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "Password is optional, without it the client can log in only with one-time codes.\nIt must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "8p6R*R{3"
+                }
+            }
+        },
+        "dto.ClientContactDto": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "work"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "email",
+                        "messenger"
+                    ],
+                    "example": "phone"
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "+79876543210"
+                }
+            }
+        },
+        "dto.ClientDto": {
+            "type": "object",
+            "properties": {
+                "birthDate": {
+                    "description": "Date only",
+                    "type": "string",
+                    "format": "date"
+                },
+                "contacts": {
+                    "description": "Contacts are the additional contacts, which replace the current ones. If it is absent, they are kept.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ClientContactDto"
+                    }
+                },
+                "email": {
+                    "description": "E-mail string.",
+                    "type": "string",
+                    "example": "mail@mail.com"
+                },
+                "info": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "patronymic": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "description": "E.164 phone number string.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "sex": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.ClientMergeDto": {
+            "type": "object",
+            "required": [
+                "sourceId"
+            ],
+            "properties": {
+                "sourceId": {
+                    "description": "ID of the duplicate client which is merged and soft-deleted.",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DepartmentDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.DiscountTierDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "minSpent": {
+                    "description": "The total of the invoices the client needs to reach the tier.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 20000
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Серебряный"
+                },
+                "percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
+        "dto.InvoiceDto": {
+            "type": "object",
+            "properties": {
+                "promoCode": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "SPRING10"
+                },
+                "useBalance": {
+                    "description": "Pay the invoice from the balance of the client as far as it suffices.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.LabResultDto": {
+            "type": "object",
+            "required": [
+                "test",
+                "visitId"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "referenceMax": {
+                    "description": "Omit if the range has no upper limit.",
+                    "type": "number",
+                    "example": 150
+                },
+                "referenceMin": {
+                    "description": "Omit if the range has no lower limit.",
+                    "type": "number",
+                    "example": 80
+                },
+                "test": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Гемоглобин"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "г/л"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 132
+                },
+                "visitId": {
+                    "description": "The test was done for the pet of the visit.",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeadDto": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "description": "The client the lead came from, 0 if the lead is not linked to a client.",
+                    "type": "integer"
+                },
+                "comment": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "E-mail string.",
+                    "type": "string",
+                    "example": "mail@mail.com"
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "description": "E.164 phone number string.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "status": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeadRequestDto": {
+            "type": "object",
+            "properties": {
+                "captchaToken": {
+                    "description": "CaptchaToken is the response token of the captcha widget, it is required if the captcha is enabled.",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "E-mail string.",
+                    "type": "string",
+                    "example": "mail@mail.com"
+                },
+                "formToken": {
+                    "description": "FormToken is the token issued by GET /leads/form when the form was shown to the user.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "description": "E.164 phone number string.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "type": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "website": {
+                    "description": "Website is a honeypot field hidden from humans, it must be left empty.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginDto": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login using username, e-mail, or phone.",
+                    "type": "string",
+                    "example": "Login"
+                },
+                "password": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "8p6R*R{3"
+                }
+            }
+        },
+        "dto.MedicationDto": {
+            "type": "object",
+            "required": [
+                "form",
+                "name"
+            ],
+            "properties": {
+                "form": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "таблетки"
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "strength": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "unit": {
+                    "description": "The unit of the strength.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "мг"
+                }
+            }
+        },
+        "dto.PetDto": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "birthDate": {
+                    "description": "Date only",
+                    "type": "string",
+                    "format": "date"
+                },
+                "birthDateEstimated": {
+                    "description": "The birth date is estimated from the age told by the owner.",
+                    "type": "boolean"
+                },
+                "breed": {
+                    "description": "The name of the breed, if breedId is not set. Unknown breeds are flagged for review.",
+                    "type": "string"
+                },
+                "breedId": {
+                    "type": "integer"
+                },
+                "chronicConditions": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "clientId": {
+                    "description": "The primary owner.",
+                    "type": "integer"
+                },
+                "colour": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "microchip": {
+                    "description": "The microchip or tattoo ID, unique among the pets.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "neutered": {
+                    "type": "boolean"
+                },
+                "owners": {
+                    "description": "Owners are the co-owners and the emergency contacts, which replace the current ones. If it is absent, they are kept.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PetOwnerDto"
+                    }
+                },
+                "sex": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string"
+                },
+                "speciesId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "active by default",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "deceased",
+                        "transferred"
+                    ],
+                    "example": "active"
+                },
+                "type": {
+                    "description": "The name or an alias of the species, if speciesId is not set.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PetOwnerDto": {
+            "type": "object",
+            "required": [
+                "clientId"
+            ],
+            "properties": {
+                "clientId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "co-owner",
+                        "emergency"
+                    ],
+                    "example": "co-owner"
+                }
+            }
+        },
+        "dto.PetWeightDto": {
+            "type": "object",
+            "required": [
+                "weight"
+            ],
+            "properties": {
+                "measuredAt": {
+                    "description": "The time of the visit or the current time by default.",
+                    "type": "string"
+                },
+                "visitId": {
+                    "description": "The visit the pet was weighed at, 0 if it was weighed elsewhere.",
+                    "type": "integer"
+                },
+                "weight": {
+                    "description": "kg",
+                    "type": "number",
+                    "maximum": 2000,
+                    "example": 4.5
+                }
+            }
+        },
+        "dto.PortalCodeLoginDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "login"
+            ],
+            "properties": {
+                "code": {
+                    "description": "The one-time code sent to the client.",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "login": {
+                    "description": "Login using the phone or the e-mail of the client.",
+                    "type": "string",
+                    "example": "+79876543210"
+                }
+            }
+        },
+        "dto.PortalCodeRequestDto": {
+            "type": "object",
+            "required": [
+                "login"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login using the phone or the e-mail of the client.",
+                    "type": "string",
+                    "example": "+79876543210"
+                }
+            }
+        },
+        "dto.PortalLoginDto": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login using the phone or the e-mail of the client.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "password": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "8p6R*R{3"
+                }
+            }
+        },
+        "dto.PortalVisitDto": {
+            "type": "object",
+            "required": [
+                "dateTime",
+                "petId",
+                "serviceId"
+            ],
+            "properties": {
+                "dateTime": {
+                    "description": "Desired date and time.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "info": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PrescriptionDto": {
+            "type": "object",
+            "required": [
+                "dosage",
+                "frequency",
+                "medicationId",
+                "visitId"
+            ],
+            "properties": {
+                "dosage": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "1 таблетка"
+                },
+                "durationDays": {
+                    "description": "0 if the medication is taken until the prescription is cancelled.",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 0
+                },
+                "frequency": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "2 раза в день"
+                },
+                "instructions": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "medicationId": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "description": "The date of the visit by default.",
+                    "type": "string",
+                    "format": "date"
+                },
+                "visitId": {
+                    "description": "The medication is prescribed for the pet of the visit.",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ProductBatchDto": {
+            "type": "object",
+            "required": [
+                "batchNumber",
+                "expiryDate"
+            ],
+            "properties": {
+                "batchNumber": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "A123B45"
+                },
+                "expiryDate": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "dto.ProductDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "minStock": {
+                    "description": "The stock below which the product is reported as running low.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Нобивак Tricat Trio"
+                },
+                "price": {
+                    "description": "The retail price of a unit, 0 if the product is not sold.",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "доза"
+                }
+            }
+        },
+        "dto.PromoCodeDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "validFrom",
+                "validTo"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Latin letters and digits, stored in upper case.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "SPRING10"
+                },
+                "percent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "example": 10
+                },
+                "usageLimit": {
+                    "description": "0 if unlimited.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "validFrom": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "validTo": {
+                    "description": "The last day of the validity.",
+                    "type": "string",
+                    "format": "date"
+                }
+            }
+        },
+        "dto.ResourceDto": {
+            "type": "object",
+            "required": [
+                "departmentId",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "departmentId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Кабинет 1"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "room",
+                        "equipment"
+                    ],
+                    "example": "room"
+                }
+            }
+        },
+        "dto.RoleDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ServiceConsumableDto": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ServiceConsumablesDto": {
+            "type": "object",
+            "properties": {
+                "consumables": {
+                    "description": "An empty list removes all consumables.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ServiceConsumableDto"
+                    }
+                }
+            }
+        },
+        "dto.ServiceDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "archived": {
+                    "description": "An archived service cannot be booked.",
+                    "type": "boolean"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "deposit": {
+                    "description": "True if the price is a deposit returned to the client.",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "duration": {
+                    "description": "The usual duration in minutes, 0 if not set.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "profession": {
+                    "description": "The profession required from the doctor, empty if any.",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Кардиолог"
+                }
+            }
+        },
+        "dto.ServicePriceDto": {
+            "type": "object",
+            "required": [
+                "effectiveFrom"
+            ],
+            "properties": {
+                "departmentId": {
+                    "description": "The department to override the price for, 0 for the base price.",
+                    "type": "integer"
+                },
+                "effectiveFrom": {
+                    "description": "The price applies from the start of the day.",
+                    "type": "string",
+                    "format": "date"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1500
+                }
+            }
+        },
+        "dto.ServiceResourcesDto": {
+            "type": "object",
+            "required": [
+                "resourceIds"
+            ],
+            "properties": {
+                "resourceIds": {
+                    "description": "An empty list removes all resources.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.SpeciesDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "description": "The other names of the species separated by commas.",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "кот,cat"
+                },
+                "name": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.UpdatePasswordDto": {
+            "description": "The 'NewPassword' and 'OldPassword' should not match, while the 'ConfirmPassword' is required for verification but must match the new password.",
+            "type": "object",
+            "required": [
+                "confirmPassword",
+                "newPassword",
+                "oldPassword"
+            ],
+            "properties": {
+                "confirmPassword": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "6@5JG5hG"
+                },
+                "newPassword": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "6@5JG5hG"
+                },
+                "oldPassword": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "8p6R*R{3"
+                }
+            }
+        },
+        "dto.UserCreateDto": {
+            "type": "object",
+            "required": [
+                "password",
+                "roleId",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "8p6R*R{3"
+                },
+                "roleId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username must start with an alphabetical character.\nIt can consist of ASCII alphanumeric characters and the following symbols: _.-",
+                    "type": "string",
+                    "example": "Username"
+                }
+            }
+        },
+        "dto.UserUpdateDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "birthDate": {
+                    "description": "Date only",
+                    "type": "string",
+                    "format": "date"
+                },
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "email": {
+                    "description": "E-mail string.",
+                    "type": "string",
+                    "example": "mail@mail.com"
+                },
+                "info": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "patronymic": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone": {
+                    "description": "E.164 phone number string.",
+                    "type": "string",
+                    "example": "+79876543210"
+                },
+                "profession": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "roleId": {
+                    "type": "integer"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "sex": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string"
+                },
+                "surname": {
+                    "description": "Alphabetic characters only (Russian and English).",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.VisitDto": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "integer"
+                },
+                "dateTime": {
+                    "description": "Date and time.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "info": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "requested, tentative, scheduled (default), cancelled or completed. A visit is completed by the completion only.",
+                    "type": "string",
+                    "enum": [
+                        "requested",
+                        "tentative",
+                        "scheduled",
+                        "cancelled",
+                        "completed"
+                    ]
+                }
+            }
+        },
+        "dto.WaitlistEntryDto": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "petId",
+                "serviceId",
+                "windows"
+            ],
+            "properties": {
+                "clientId": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "doctorId": {
+                    "description": "The preferred doctor, 0 if any doctor who provides the service will do.",
+                    "type": "integer"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "waiting (default), booked or cancelled. A new entry is always waiting.",
+                    "type": "string",
+                    "enum": [
+                        "waiting",
+                        "booked",
+                        "cancelled"
+                    ]
+                },
+                "windows": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.WaitlistWindowDto"
+                    }
+                }
+            }
+        },
+        "dto.WaitlistWindowDto": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-01-10T13:00:00+03:00"
+                },
+                "startTime": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-01-10T09:00:00+03:00"
+                }
+            }
+        },
+        "dto.WorkingHoursDto": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "0 is Sunday, 6 is Saturday.",
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "dto.WorkingScheduleDto": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "description": "An empty list restores the default hours of the clinic.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WorkingHoursDto"
+                    }
+                }
+            }
+        },
+        "dto.WriteOffDto": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Истёк срок годности"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "echo.pdf"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "bytes",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "uploadedById": {
+                    "type": "integer"
+                },
+                "visitId": {
+                    "description": "0 if the file is attached to the pet only.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BalanceEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Positive if the balance increases, negative if it decreases.",
+                    "type": "number"
+                },
+                "clientId": {
+                    "type": "integer"
+                },
+                "createdById": {
+                    "description": "The user who recorded the entry, 0 if it is recorded by invoicing.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invoiceId": {
+                    "description": "The invoice of the deposit or the payment, 0 for the other reasons.",
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "top-up, deposit, payment or refund",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookableDepartment": {
+            "type": "object",
+            "properties": {
+                "doctors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookableDoctor"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookableService"
+                    }
+                }
+            }
+        },
+        "models.BookableDoctor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "profession": {
+                    "type": "string"
+                },
+                "services": {
+                    "description": "IDs of the services provided by the doctor.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookableService": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Booking": {
+            "type": "object",
+            "properties": {
+                "dateTime": {
+                    "type": "string"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "visitId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Breed": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "species": {
+                    "$ref": "#/definitions/models.Species"
+                },
+                "speciesId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "The subcategories, filled only in the category tree.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "The parent category, 0 for a top-level category.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Client": {
+            "type": "object",
+            "properties": {
+                "birthDate": {
+                    "type": "string"
+                },
+                "contacts": {
+                    "description": "Contacts are the additional phones, e-mails and messengers of the client.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ClientContact"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "info": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "tentative": {
+                    "description": "Tentative is true for the client created by an online booking, whom the staff has yet to reconcile\nwith the existing clients having the same phone or e-mail, e.g. by merging them.",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ClientAccount": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "clientId": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ClientBalance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "clientId": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "description": "The latest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BalanceEntry"
+                    }
+                }
+            }
+        },
+        "models.ClientContact": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "work"
+                },
+                "type": {
+                    "description": "phone, email, messenger",
+                    "type": "string",
+                    "example": "phone"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "+79876543210"
+                }
+            }
+        },
+        "models.ClientDiscount": {
+            "type": "object",
+            "properties": {
+                "spent": {
+                    "description": "The total of the invoices of the client excluding the deposits.",
+                    "type": "number"
+                },
+                "tier": {
+                    "description": "The tier reached by the client, null if none.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DiscountTier"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ClientMerge": {
+            "type": "object",
+            "properties": {
+                "accountMoved": {
+                    "description": "AccountMoved is true if the portal account of the merged client has been moved to the surviving client.",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mergedById": {
+                    "type": "integer"
+                },
+                "petIds": {
+                    "description": "Comma-separated IDs of the moved pets.",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "The data of the merged client as JSON.",
+                    "type": "string"
+                },
+                "sourceId": {
+                    "description": "The merged client, which is soft-deleted.",
+                    "type": "integer"
+                },
+                "targetId": {
+                    "description": "The surviving client.",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visitIds": {
+                    "description": "Comma-separated IDs of the moved visits.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Department": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Service"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "models.DiscountTier": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "minSpent": {
+                    "description": "The total of the invoices the client needs to reach the tier.",
+                    "type": "number",
+                    "example": 20000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Серебряный"
+                },
+                "percent": {
+                    "description": "The discount in percent.",
+                    "type": "number",
+                    "example": 5
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DuplicateClient": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "reasons": {
+                    "description": "What the clients have in common: phone, email or name.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "phone",
+                        "name"
+                    ]
+                }
+            }
+        },
+        "models.Invoice": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "deposit": {
+                    "description": "True if the service is a deposit, it is credited to the balance of the client.",
+                    "type": "boolean"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "discountPercent": {
+                    "type": "number"
+                },
+                "discountTierId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paidFromBalance": {
+                    "type": "number"
+                },
+                "promoCode": {
+                    "$ref": "#/definitions/models.PromoCode"
+                },
+                "promoCodeId": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "description": "The price of the service as of the date of the visit.",
+                    "type": "number"
+                },
+                "total": {
+                    "description": "The amount due after the discount and the payment from the balance.",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visit": {
+                    "$ref": "#/definitions/models.Visit"
+                },
+                "visitId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LabResult": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "flag": {
+                    "description": "normal, low, high; empty if there is no reference range.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "referenceMax": {
+                    "description": "nil if the range has no upper limit.",
+                    "type": "number",
+                    "example": 150
+                },
+                "referenceMin": {
+                    "description": "nil if the range has no lower limit.",
+                    "type": "number",
+                    "example": 80
+                },
+                "test": {
+                    "type": "string",
+                    "example": "Гемоглобин"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "г/л"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 132
+                },
+                "visitId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Lead": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "clientId": {
+                    "description": "The client the lead came from, 0 until the staff links the lead to a client.",
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "doctor": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUpdatedBy": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "lastUpdatedById": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "open, in_progress, closed, rejected",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "in clinic, online, callback",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LeadForm": {
+            "type": "object",
+            "properties": {
+                "formToken": {
+                    "description": "FormToken is signed by the server and records when the form was shown, it is submitted along with the lead.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Medication": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "form": {
+                    "type": "string",
+                    "example": "таблетки"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "strength": {
+                    "type": "number",
+                    "example": 50
+                },
+                "unit": {
+                    "description": "The unit of the strength.",
+                    "type": "string",
+                    "example": "мг"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Pet": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "string"
+                },
+                "birthDate": {
+                    "type": "string"
+                },
+                "birthDateEstimated": {
+                    "description": "The birth date is estimated from the age told by the owner.",
+                    "type": "boolean"
+                },
+                "breed": {
+                    "type": "string"
+                },
+                "breedId": {
+                    "type": "integer"
+                },
+                "breedUnverified": {
+                    "description": "The breed is entered as free text and is to be reviewed.",
+                    "type": "boolean"
+                },
+                "chronicConditions": {
+                    "type": "string"
+                },
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "clientId": {
+                    "description": "The primary owner.",
+                    "type": "integer"
+                },
+                "colour": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "microchip": {
+                    "description": "The microchip or tattoo ID, unique among the pets.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "neutered": {
+                    "type": "boolean"
+                },
+                "owners": {
+                    "description": "Owners are the clients related to the pet along with their roles, including the primary owner.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PetOwner"
+                    }
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "speciesId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "active, deceased, transferred",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PetOwner": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "clientId": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "primary, co-owner, emergency",
+                    "type": "string",
+                    "example": "co-owner"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PetWeight": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "measuredAt": {
+                    "type": "string"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visitId": {
+                    "description": "The visit the pet was weighed at, 0 if it was weighed elsewhere.",
+                    "type": "integer"
+                },
+                "weight": {
+                    "description": "kg",
+                    "type": "number"
+                }
+            }
+        },
+        "models.PortalDoctor": {
             "type": "object",
             "properties": {
-                "message": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "profession": {
+                    "type": "string"
+                },
+                "surname": {
                     "type": "string"
                 }
             }
         },
-        "controllers.HealthResult": {
+        "models.PortalInvoice": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string",
-                    "example": "available"
+                "deposit": {
+                    "type": "boolean"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "discountPercent": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuedAt": {
+                    "type": "string"
+                },
+                "paidFromBalance": {
+                    "type": "number"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "visit": {
+                    "$ref": "#/definitions/models.PortalVisit"
                 }
             }
         },
-        "dto.ClientDto": {
+        "models.PortalPrescription": {
             "type": "object",
             "properties": {
-                "birthDate": {
-                    "description": "Date only",
-                    "type": "string",
-                    "format": "date"
+                "dosage": {
+                    "type": "string"
                 },
-                "email": {
-                    "description": "E-mail string.",
-                    "type": "string",
-                    "example": "mail@mail.com"
+                "durationDays": {
+                    "type": "integer"
                 },
-                "info": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "frequency": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "id": {
+                    "type": "integer"
                 },
-                "patronymic": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "instructions": {
+                    "type": "string"
                 },
-                "phone": {
-                    "description": "E.164 phone number string.",
-                    "type": "string",
-                    "example": "+79876543210"
+                "medication": {
+                    "$ref": "#/definitions/models.Medication"
                 },
-                "sex": {
-                    "description": "Alphabetic characters only (Russian and English).",
+                "prescriber": {
+                    "$ref": "#/definitions/models.PortalDoctor"
+                },
+                "startDate": {
                     "type": "string"
                 },
-                "surname": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "status": {
+                    "description": "active, cancelled",
+                    "type": "string"
+                },
+                "visitId": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.DepartmentDto": {
+        "models.PortalRecord": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "labResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LabResult"
+                    }
                 },
-                "services": {
+                "petId": {
+                    "type": "integer"
+                },
+                "prescriptions": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/models.PortalPrescription"
+                    }
+                },
+                "visits": {
+                    "description": "The completed visits, the latest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PortalVisit"
+                    }
+                },
+                "weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PetWeight"
                     }
                 }
             }
         },
-        "dto.LeadDto": {
+        "models.PortalVisit": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "dateTime": {
                     "type": "string"
                 },
-                "doctorId": {
+                "doctor": {
+                    "description": "nil until the clinic assigns a doctor.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PortalDoctor"
+                        }
+                    ]
+                },
+                "id": {
                     "type": "integer"
                 },
-                "email": {
-                    "description": "E-mail string.",
-                    "type": "string",
-                    "example": "mail@mail.com"
+                "info": {
+                    "type": "string"
                 },
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "pet": {
+                    "$ref": "#/definitions/models.Pet"
                 },
-                "phone": {
-                    "description": "E.164 phone number string.",
-                    "type": "string",
-                    "example": "+79876543210"
+                "petId": {
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "type": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "service": {
+                    "$ref": "#/definitions/models.Service"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "requested, tentative, scheduled, cancelled, completed",
                     "type": "string"
                 }
             }
         },
-        "dto.LoginDto": {
+        "models.Prescription": {
             "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
             "properties": {
-                "login": {
-                    "description": "Login using username, e-mail, or phone.",
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "dosage": {
                     "type": "string",
-                    "example": "Login"
+                    "example": "1 таблетка"
                 },
-                "password": {
-                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                "durationDays": {
+                    "description": "0 if the medication is taken until the prescription is cancelled.",
+                    "type": "integer"
+                },
+                "frequency": {
                     "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8,
-                    "example": "8p6R*R{3"
-                }
-            }
-        },
-        "dto.PetDto": {
-            "type": "object",
-            "properties": {
-                "breed": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
-                    "type": "string"
+                    "example": "2 раза в день"
                 },
-                "clientId": {
+                "id": {
                     "type": "integer"
                 },
-                "colour": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "instructions": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "medication": {
+                    "$ref": "#/definitions/models.Medication"
                 },
-                "sex": {
-                    "description": "Alphabetic characters only (Russian and English).",
+                "medicationId": {
+                    "type": "integer"
+                },
+                "pet": {
+                    "$ref": "#/definitions/models.Pet"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "prescriber": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "prescriberId": {
+                    "type": "integer"
+                },
+                "startDate": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "status": {
+                    "description": "active, cancelled",
                     "type": "string"
-                }
-            }
-        },
-        "dto.RoleDto": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "visitId": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ServiceDto": {
+        "models.PriceListItem": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "category": {
+                    "type": "string"
+                },
                 "categoryId": {
                     "type": "integer"
                 },
-                "name": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
-                },
                 "price": {
-                    "type": "number",
-                    "format": "float64"
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "serviceName": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdatePasswordDto": {
-            "description": "The 'NewPassword' and 'OldPassword' should not match, while the 'ConfirmPassword' is required for verification but must match the new password.",
+        "models.Product": {
             "type": "object",
-            "required": [
-                "confirmPassword",
-                "newPassword",
-                "oldPassword"
-            ],
             "properties": {
-                "confirmPassword": {
-                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
-                    "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8,
-                    "example": "6@5JG5hG"
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
                 },
-                "newPassword": {
-                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                "minStock": {
+                    "description": "The stock below which the product is reported as running low.",
+                    "type": "number",
+                    "example": 5
+                },
+                "name": {
                     "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8,
-                    "example": "6@5JG5hG"
+                    "example": "Нобивак Tricat Trio"
                 },
-                "oldPassword": {
-                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                "price": {
+                    "description": "The retail price of a unit, 0 if the product is not sold.",
+                    "type": "number"
+                },
+                "unit": {
+                    "description": "The unit the quantities are measured in.",
                     "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8,
-                    "example": "8p6R*R{3"
+                    "example": "доза"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UserCreateDto": {
+        "models.ProductBatch": {
             "type": "object",
-            "required": [
-                "password",
-                "roleId",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "description": "Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.\nIt can consist of printable ASCII characters.",
+                "batchNumber": {
                     "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8,
-                    "example": "8p6R*R{3"
+                    "example": "A123B45"
                 },
-                "roleId": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "expiryDate": {
+                    "description": "The batch can be used until the end of the day.",
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "username": {
-                    "description": "Username must start with an alphabetical character.\nIt can consist of ASCII alphanumeric characters and the following symbols: _.-",
-                    "type": "string",
-                    "example": "Username"
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "The quantity left in stock.",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UserUpdateDto": {
+        "models.ProductStock": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "birthDate": {
-                    "description": "Date only",
-                    "type": "string",
-                    "format": "date"
-                },
-                "departments": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "product": {
+                    "$ref": "#/definitions/models.Product"
                 },
-                "email": {
-                    "description": "E-mail string.",
+                "quantity": {
+                    "description": "The total quantity of the batches which have not expired.",
+                    "type": "number"
+                }
+            }
+        },
+        "models.PromoCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Matched ignoring the case.",
                     "type": "string",
-                    "example": "mail@mail.com"
+                    "example": "SPRING10"
                 },
-                "info": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "patronymic": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "id": {
+                    "type": "integer"
                 },
-                "phone": {
-                    "description": "E.164 phone number string.",
-                    "type": "string",
-                    "example": "+79876543210"
+                "percent": {
+                    "description": "The discount in percent.",
+                    "type": "number",
+                    "example": 10
                 },
-                "profession": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "updated_at": {
                     "type": "string"
                 },
-                "roleId": {
-                    "type": "integer"
+                "usageLimit": {
+                    "description": "The number of invoices the code can be used for, 0 if unlimited.",
+                    "type": "integer",
+                    "example": 100
                 },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "usedCount": {
+                    "type": "integer"
                 },
-                "sex": {
-                    "description": "Alphabetic characters only (Russian and English).",
+                "validFrom": {
+                    "description": "The code is valid from the start of the day.",
                     "type": "string"
                 },
-                "surname": {
-                    "description": "Alphabetic characters only (Russian and English).",
-                    "type": "string",
-                    "maxLength": 255
+                "validTo": {
+                    "description": "The code is valid until the end of the day.",
+                    "type": "string"
                 }
             }
         },
-        "dto.VisitDto": {
+        "models.Reservation": {
             "type": "object",
             "properties": {
-                "clientId": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "dateTime": {
-                    "description": "Date and time.",
-                    "type": "string",
-                    "format": "date-time"
+                "resource": {
+                    "$ref": "#/definitions/models.Resource"
                 },
-                "doctorId": {
+                "resourceId": {
                     "type": "integer"
                 },
-                "info": {
-                    "description": "Allowed characters: printable ASCII (Russian and English).",
+                "startTime": {
                     "type": "string"
                 },
-                "petId": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "serviceId": {
+                "visitId": {
                     "type": "integer"
                 }
             }
         },
-        "gorm.DeletedAt": {
+        "models.ResolvedPrice": {
             "type": "object",
             "properties": {
-                "time": {
+                "date": {
                     "type": "string"
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.Category": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "departmentId": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Client": {
+        "models.Resource": {
             "type": "object",
             "properties": {
-                "birthDate": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
                 "deleted_at": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "email": {
-                    "type": "string"
+                "department": {
+                    "$ref": "#/definitions/models.Department"
                 },
-                "id": {
+                "departmentId": {
                     "type": "integer"
                 },
-                "info": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Кабинет 1"
                 },
-                "patronymic": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "room"
                 },
-                "phone": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
                 },
-                "sex": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
                 },
-                "surname": {
+                "subtitle": {
                     "type": "string"
                 },
-                "updated_at": {
+                "title": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "client"
                 }
             }
         },
-        "models.Department": {
+        "models.Service": {
             "type": "object",
             "properties": {
+                "archived": {
+                    "description": "An archived service is kept for the history but cannot be booked.",
+                    "type": "boolean"
+                },
+                "category": {
+                    "$ref": "#/definitions/models.Category"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "deleted_at": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Department"
+                    }
+                },
+                "deposit": {
+                    "description": "True if the price is a deposit returned to the client, it is credited to the balance of the client.",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "The usual duration in minutes, 0 if not set.",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Service"
-                    }
+                "price": {
+                    "description": "The base price set by the last update, the price history keeps the effective prices.",
+                    "type": "number"
                 },
-                "slug": {
+                "profession": {
+                    "description": "The profession required from the doctor providing the service, empty if any.",
                     "type": "string"
                 },
                 "updated_at": {
@@ -2581,70 +13497,70 @@ const docTemplate = `{
                 }
             }
         },
-        "models.Lead": {
+        "models.ServiceConsumable": {
             "type": "object",
             "properties": {
-                "comment": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
                 "deleted_at": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "doctor": {
-                    "$ref": "#/definitions/models.User"
-                },
-                "doctorId": {
-                    "type": "integer"
-                },
-                "email": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "lastUpdatedBy": {
-                    "$ref": "#/definitions/models.User"
+                "product": {
+                    "$ref": "#/definitions/models.Product"
                 },
-                "lastUpdatedById": {
+                "productId": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "phone": {
-                    "type": "string"
-                },
-                "status": {
-                    "description": "open, in_progress, closed, rejected",
-                    "type": "string"
-                },
-                "type": {
-                    "description": "in clinic, online, callback",
-                    "type": "string"
+                "quantity": {
+                    "type": "number",
+                    "example": 1
+                },
+                "serviceId": {
+                    "type": "integer"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.Pet": {
+        "models.ServicePrice": {
             "type": "object",
             "properties": {
-                "breed": {
+                "created_at": {
                     "type": "string"
                 },
-                "client": {
-                    "$ref": "#/definitions/models.Client"
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "clientId": {
+                "departmentId": {
+                    "description": "The department the price is overridden for, 0 for the base price.",
                     "type": "integer"
                 },
-                "colour": {
+                "effectiveFrom": {
+                    "description": "The price applies from the start of the day.",
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ServiceResource": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
@@ -2654,38 +13570,65 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "resource": {
+                    "$ref": "#/definitions/models.Resource"
                 },
-                "sex": {
+                "resourceId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Slot": {
+            "type": "object",
+            "properties": {
+                "doctorId": {
+                    "type": "integer"
                 },
-                "type": {
+                "end": {
                     "type": "string"
                 },
-                "updated_at": {
+                "start": {
                     "type": "string"
                 }
             }
         },
-        "models.Role": {
+        "models.Species": {
             "type": "object",
             "properties": {
+                "aliases": {
+                    "description": "Aliases are the other names of the species separated by commas, e.g. \"кот,cat\".\nThey are recognized in Pet.Type along with the name regardless of the case.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.Service": {
+        "models.StockMovement": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/models.Category"
+                "batch": {
+                    "$ref": "#/definitions/models.ProductBatch"
                 },
-                "categoryId": {
+                "batchId": {
                     "type": "integer"
                 },
                 "created_at": {
@@ -2694,29 +13637,44 @@ const docTemplate = `{
                 "deleted_at": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "departments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Department"
-                    }
-                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "note": {
                     "type": "string"
                 },
-                "price": {
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Positive if the stock increases, negative if it decreases.",
                     "type": "number"
                 },
+                "reason": {
+                    "description": "received, used, written-off",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.User"
-                    }
+                "visitId": {
+                    "description": "The visit the products were used at, 0 for the other reasons.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UnverifiedBreed": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "count": {
+                    "description": "The number of the pets of the breed.",
+                    "type": "integer"
+                },
+                "speciesId": {
+                    "type": "integer"
                 }
             }
         },
@@ -2827,12 +13785,27 @@ const docTemplate = `{
                 "petId": {
                     "type": "integer"
                 },
+                "price": {
+                    "description": "The base price of the service as of the date of the visit.",
+                    "type": "number"
+                },
+                "reservations": {
+                    "description": "The resources reserved for the visit, filled only for a single visit.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Reservation"
+                    }
+                },
                 "serviceId": {
                     "type": "integer"
                 },
                 "services": {
                     "$ref": "#/definitions/models.Service"
                 },
+                "status": {
+                    "description": "requested, tentative, scheduled, cancelled, completed",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -2843,6 +13816,205 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "clientId": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "doctor": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "doctorId": {
+                    "description": "The preferred doctor, 0 if any doctor who provides the service will do.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUpdatedBy": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "lastUpdatedById": {
+                    "type": "integer"
+                },
+                "pet": {
+                    "$ref": "#/definitions/models.Pet"
+                },
+                "petId": {
+                    "type": "integer"
+                },
+                "service": {
+                    "$ref": "#/definitions/models.Service"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "waiting, booked, cancelled",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WaitlistWindow"
+                    }
+                }
+            }
+        },
+        "models.WaitlistMatch": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "doctor": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "doctorId": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "entry": {
+                    "$ref": "#/definitions/models.WaitlistEntry"
+                },
+                "entryId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visitId": {
+                    "description": "The visit which freed the slot.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WaitlistWindow": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "entryId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WeightTrend": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "kg, from the first to the latest weight",
+                    "type": "number"
+                },
+                "changePercent": {
+                    "description": "percent of the first weight",
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "dailyChange": {
+                    "description": "kg per day, the slope of the least squares line",
+                    "type": "number"
+                },
+                "direction": {
+                    "description": "gaining, losing, stable",
+                    "type": "string",
+                    "example": "stable"
+                },
+                "first": {
+                    "$ref": "#/definitions/models.PetWeight"
+                },
+                "latest": {
+                    "$ref": "#/definitions/models.PetWeight"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "petId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WorkingHours": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "weekday": {
+                    "description": "0 is Sunday, 6 is Saturday.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         }
     }
 }`
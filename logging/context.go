@@ -0,0 +1,34 @@
+package logging
+
+import "context"
+
+const (
+	// RequestIDKey is the log field name of the request ID.
+	RequestIDKey = "request_id"
+	// UserIDKey is the log field name of the logged-in user ID.
+	UserIDKey = "user_id"
+	// ClientIDKey is the log field name of the ID of the client logged in to the portal.
+	ClientIDKey = "client_id"
+	// RouteKey is the log field name of the matched route.
+	RouteKey = "route"
+	// TraceIDKey is the log field name of the trace ID.
+	TraceIDKey = "trace_id"
+)
+
+type fieldsKey struct{}
+
+// NewContext returns a copy of ctx that carries the given key-value pairs.
+// Loggers obtained by Logger.WithContext attach these pairs to every entry.
+func NewContext(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields := append(FieldsFromContext(ctx), keysAndValues...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// FieldsFromContext returns a copy of the key-value pairs carried by ctx.
+func FieldsFromContext(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	return append([]interface{}(nil), fields...)
+}
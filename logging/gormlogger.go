@@ -27,50 +27,56 @@ func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
 }
 
 // Info print info
-func (l *GormLogger) Info(_ context.Context, msg string, data ...interface{}) {
-	l.zap.Infof(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	l.logger(ctx).Infof(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
 }
 
 // Warn print warn messages
-func (l *GormLogger) Warn(_ context.Context, msg string, data ...interface{}) {
-	l.zap.Warnf(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	l.logger(ctx).Warnf(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
 }
 
 // Error print error messages
-func (l *GormLogger) Error(_ context.Context, msg string, data ...interface{}) {
-	l.zap.Errorf(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	l.logger(ctx).Errorf(messageFormat, append([]interface{}{msg, utils.FileWithLineNum()}, data...)...)
 }
 
 // Trace print sql message
-func (l *GormLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
 	elapsed := time.Since(begin)
+	logger := l.logger(ctx)
 
 	switch {
 	case err != nil && (!errors.Is(err, errors.New("record not found")) || !l.zap.gormConfig.IgnoreRecordNotFoundError):
 		sql, rows := fc()
 		if rows == -1 {
-			l.zap.Errorf(traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			logger.Errorf(traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.zap.Errorf(traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			logger.Errorf(traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	case elapsed > l.zap.gormConfig.SlowThreshold && l.zap.gormConfig.SlowThreshold != 0:
 		sql, rows := fc()
 		slowLog := fmt.Sprintf("SLOW SQL >= %v", l.zap.gormConfig.SlowThreshold)
 		if rows == -1 {
-			l.zap.Warnf(traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			logger.Warnf(traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.zap.Warnf(traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			logger.Warnf(traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	default:
 		sql, rows := fc()
 		if rows == -1 {
-			l.zap.Debugf(traceStr, float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			logger.Debugf(traceStr, float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.zap.Debugf(traceStr, float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			logger.Debugf(traceStr, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	}
 }
 
+// logger returns the logger enriched with the request fields carried by ctx.
+func (l *GormLogger) logger(ctx context.Context) Logger {
+	return l.zap.WithContext(ctx)
+}
+
 func (l *GormLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
 	if l.zap.gormConfig.ParameterizedQueries {
 		return sql, nil
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"go.uber.org/zap"
 	gormLogger "gorm.io/gorm/logger"
 )
@@ -8,6 +9,8 @@ import (
 type Logger interface {
 	Zap() *zap.SugaredLogger
 	Gorm() gormLogger.Interface
+	With(keysAndValues ...interface{}) Logger
+	WithContext(ctx context.Context) Logger
 	Debugf(msg string, args ...interface{})
 	Infof(msg string, args ...interface{})
 	Warnf(msg string, args ...interface{})
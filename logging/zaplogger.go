@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
@@ -46,6 +47,21 @@ func (l *ZapLogger) Zap() *zap.SugaredLogger {
 func (l *ZapLogger) Gorm() gormLogger.Interface {
 	return &GormLogger{zap: l}
 }
+
+// With returns a logger which adds the given key-value pairs to every entry.
+func (l *ZapLogger) With(keysAndValues ...interface{}) Logger {
+	return &ZapLogger{zap: l.zap.With(keysAndValues...), gormConfig: l.gormConfig}
+}
+
+// WithContext returns a logger which adds the key-value pairs carried by ctx to every entry.
+func (l *ZapLogger) WithContext(ctx context.Context) Logger {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
 func (l *ZapLogger) Debugf(msg string, args ...interface{}) {
 	l.zap.Debugf(msg, args...)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
@@ -11,7 +12,9 @@ import (
 	"vet-clinic/migration"
 	"vet-clinic/repository"
 	"vet-clinic/router"
+	"vet-clinic/service"
 	"vet-clinic/session"
+	"vet-clinic/tracing"
 	"vet-clinic/validate"
 )
 
@@ -39,6 +42,7 @@ func main() {
 
 	conf := config.LoadConfig(configFile)
 	logger := logging.Init(conf)
+	shutdownTracing := tracing.Init(conf, logger)
 	rep := repository.NewRepository(logger, conf)
 	sess := session.NewSession(logger, conf)
 	cont := container.NewContainer(rep, sess, conf, logger)
@@ -49,5 +53,9 @@ func main() {
 	migration.CreateTables(cont)
 	migration.InitMasterData(cont)
 
-	logger.Fatalf(e.Start(":8080").Error())
+	service.NewRetentionService(cont).Start(context.Background())
+
+	err := e.Start(":8080")
+	_ = shutdownTracing(context.Background())
+	logger.Fatalf(err.Error())
 }
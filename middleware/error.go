@@ -1,18 +1,13 @@
 package middleware
 
 import (
-	"errors"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
+	"vet-clinic/validate"
 )
 
-// APIError has a error code and a message.
-type APIError struct {
-	Code    int
-	Message string
-}
-
 // ErrorController is a controller for handling errors.
 type ErrorController interface {
 	JSONError(err error, c echo.Context)
@@ -27,26 +22,29 @@ func NewErrorController(container container.Container) *DefaultErrorController {
 	return &DefaultErrorController{container: container}
 }
 
-// JSONError is cumstomize error handler
+// JSONError is cumstomize error handler.
+// It maps the error to the status and renders apperror.Response, the error format shared by all endpoints.
 func (controller *DefaultErrorController) JSONError(err error, c echo.Context) {
-	logger := controller.container.Logger()
-	code := http.StatusInternalServerError
-	msg := http.StatusText(code)
-
-	var he *echo.HTTPError
-	if errors.As(err, &he) {
-		code = he.Code
-		msg = he.Message.(string)
-	}
-
-	var apiErr APIError
-	apiErr.Code = code
-	apiErr.Message = msg
+	logger := controller.container.Logger().WithContext(c.Request().Context())
+	appErr := apperror.From(err, translator(c))
 
 	if !c.Response().Committed {
-		if reserr := c.JSON(code, apiErr); reserr != nil {
+		if reserr := c.JSON(appErr.Status, appErr.Response()); reserr != nil {
 			logger.Errorf(reserr.Error())
 		}
 	}
-	logger.Debugf(err.Error())
+	if appErr.Status >= http.StatusInternalServerError {
+		logger.Errorf(err.Error())
+	} else {
+		logger.Debugf(err.Error())
+	}
+}
+
+// translator returns the translator of the validation messages to the languages accepted by the client.
+func translator(c echo.Context) apperror.FieldTranslator {
+	v, ok := c.Echo().Validator.(*validate.Validator)
+	if !ok {
+		return nil
+	}
+	return v.Translator(c.Request().Header.Get("Accept-Language"))
 }
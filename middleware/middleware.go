@@ -4,11 +4,9 @@ import (
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
-	"github.com/valyala/fasttemplate"
-	"io"
 	"net/http"
 	"regexp"
-	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/logging"
@@ -22,7 +20,19 @@ func Init(e *echo.Echo, container container.Container) {
 	// Recovery middleware
 	e.Use(setErrorHandler(e, container))
 
+	// Request ID middleware
+	e.Use(requestIDMiddleware())
+
+	// Tracing middleware
+	if conf.Tracing.Enabled {
+		e.Use(tracingMiddleware())
+	}
+
+	// Session middleware
+	e.Use(session.Middleware(container.Session().Store()))
+
 	// Logger middleware
+	e.Use(logContextMiddleware(container))
 	e.Use(actionLoggerMiddleware(logger))
 	e.Use(requestLoggerMiddleware(logger))
 
@@ -41,9 +51,6 @@ func Init(e *echo.Echo, container container.Container) {
 		e.Use(setCSRFMiddleware())
 	}
 
-	// Session middleware
-	e.Use(session.Middleware(container.Session().Store()))
-
 	// Gzip middleware
 	e.Use(echomw.Gzip())
 
@@ -60,31 +67,63 @@ func setErrorHandler(e *echo.Echo, container container.Container) echo.Middlewar
 	return echomw.Recover()
 }
 
+// requestIDMiddleware is middleware for assigning an ID to each request.
+// The ID is taken from the X-Request-ID header if the client sent one, otherwise it is generated.
+// It is returned in the X-Request-ID response header and attached to the request context.
+func requestIDMiddleware() echo.MiddlewareFunc {
+	return echomw.RequestIDWithConfig(echomw.RequestIDConfig{
+		TargetHeader: echo.HeaderXRequestID,
+		RequestIDHandler: func(c echo.Context, id string) {
+			setLogContext(c, logging.RequestIDKey, id)
+		},
+	})
+}
+
+// logContextMiddleware is middleware for attaching the route and the logged-in user or client to the request context.
+// Loggers obtained by Logger.WithContext, including the GORM logger, add them to every entry.
+func logContextMiddleware(container container.Container) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			setLogContext(c, logging.RouteKey, c.Path())
+			if user := container.Session().GetUser(c); user != nil && user.BaseModel != nil {
+				setLogContext(c, logging.UserIDKey, user.ID)
+			}
+			if account := container.Session().GetClientAccount(c); account != nil {
+				setLogContext(c, logging.ClientIDKey, account.ClientID)
+			}
+			return next(c)
+		}
+	}
+}
+
+func setLogContext(c echo.Context, keysAndValues ...interface{}) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(logging.NewContext(req.Context(), keysAndValues...)))
+}
+
 // requestLoggerMiddleware is middleware for logging the contents of requests.
+// The error returned by the handler is rendered later by the error handler,
+// so the status is resolved from the error the same way the error handler does.
 func requestLoggerMiddleware(logger logging.Logger) echo.MiddlewareFunc {
 	return echomw.RequestLoggerWithConfig(echomw.RequestLoggerConfig{
 		LogRemoteIP: true,
 		LogURI:      true,
 		LogMethod:   true,
 		LogStatus:   true,
+		LogLatency:  true,
+		LogError:    true,
 		LogValuesFunc: func(c echo.Context, v echomw.RequestLoggerValues) error {
-			template := fasttemplate.New("${remote_ip} ${uri} ${method} ${status}",
-				"${", "}")
-			result := template.ExecuteFuncString(func(w io.Writer, tag string) (int, error) {
-				switch tag {
-				case "remote_ip":
-					return w.Write([]byte(v.RemoteIP))
-				case "uri":
-					return w.Write([]byte(v.URI))
-				case "method":
-					return w.Write([]byte(v.Method))
-				case "status":
-					return w.Write([]byte(strconv.Itoa(v.Status)))
-				default:
-					return w.Write([]byte(""))
-				}
-			})
-			logger.Infof(result)
+			status := v.Status
+			if v.Error != nil && !c.Response().Committed {
+				status = apperror.From(v.Error, nil).Status
+			}
+			logger.WithContext(c.Request().Context()).With(
+				"remote_ip", v.RemoteIP,
+				"uri", v.URI,
+				"method", v.Method,
+				"status", status,
+				"latency", v.Latency,
+			).Infof("Request completed")
 			return nil
 		},
 	})
@@ -94,11 +133,12 @@ func requestLoggerMiddleware(logger logging.Logger) echo.MiddlewareFunc {
 func actionLoggerMiddleware(logger logging.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			logger.Debugf(c.Path() + " Action Start")
+			log := logger.WithContext(c.Request().Context())
+			log.Debugf("Action Start")
 			if err := next(c); err != nil {
 				c.Error(err)
 			}
-			logger.Debugf(c.Path() + " Action End")
+			log.Debugf("Action End")
 			return nil
 		}
 	}
@@ -124,11 +164,25 @@ func setCORSMiddleware() echo.MiddlewareFunc {
 			echo.HeaderContentType,
 			echo.HeaderContentLength,
 			echo.HeaderAcceptEncoding,
+			echo.HeaderXRequestID,
+			"traceparent",
+			"tracestate",
+			"If-Match",
+			"If-None-Match",
+		},
+		ExposeHeaders: []string{
+			echo.HeaderXRequestID,
+			"ETag",
+			HeaderRateLimitLimit,
+			HeaderRateLimitRemaining,
+			HeaderRateLimitReset,
+			echo.HeaderRetryAfter,
 		},
 		AllowMethods: []string{
 			http.MethodGet,
 			http.MethodPost,
 			http.MethodPut,
+			http.MethodPatch,
 			http.MethodDelete,
 		},
 		MaxAge: 86400,
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"math"
+	"strconv"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/ratelimit"
+)
+
+const (
+	// HeaderRateLimitLimit is the capacity of the rate limit applied to the request.
+	HeaderRateLimitLimit = "RateLimit-Limit"
+	// HeaderRateLimitRemaining is the number of requests the client can make right now.
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	// HeaderRateLimitReset is the number of seconds until the limit is fully restored.
+	HeaderRateLimitReset = "RateLimit-Reset"
+)
+
+// RateLimiter limits the number of requests from one IP address to each group of routes.
+// The limits of the groups are configured in config.RateLimit.Groups.
+type RateLimiter struct {
+	container container.Container
+	store     ratelimit.Store
+	extractIP echo.IPExtractor
+}
+
+// NewRateLimiter is constructor.
+func NewRateLimiter(container container.Container) *RateLimiter {
+	conf := container.Config()
+	limiter := &RateLimiter{container: container, extractIP: echo.ExtractIPDirect()}
+	if conf.RateLimit.TrustProxy {
+		limiter.extractIP = echo.ExtractIPFromXFFHeader()
+	}
+	if conf.RateLimit.Enabled {
+		limiter.store = ratelimit.NewStore(container.Logger(), conf)
+	}
+	return limiter
+}
+
+// Group returns the middleware limiting the requests to the given group of routes.
+// It does nothing if the rate limiting is disabled or the group has no limit.
+// If the store fails, the request is let through so that the limiter never takes the application down.
+func (l *RateLimiter) Group(name string) echo.MiddlewareFunc {
+	rule, ok := l.container.Config().RateLimit.Groups[name]
+	if l.store == nil || !ok || rule.Limit <= 0 || rule.Period <= 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			key := fmt.Sprintf("%s:%s", name, l.extractIP(c.Request()))
+			result, err := l.store.Take(ctx, key, rule, time.Now())
+			if err != nil {
+				l.container.Logger().WithContext(ctx).Errorf("Failed to check rate limit: %v", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				return apperror.NewTooManyRequests()
+			}
+			return next(c)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
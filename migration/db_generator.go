@@ -3,6 +3,7 @@ package migration
 import (
 	"vet-clinic/container"
 	"vet-clinic/models"
+	"vet-clinic/repository"
 )
 
 // CreateTables creates the tables used in this application.
@@ -19,6 +20,33 @@ func CreateTables(container container.Container) {
 		_ = rep.DropTableIfExists(&models.Pet{})
 		_ = rep.DropTableIfExists(&models.Visit{})
 		_ = rep.DropTableIfExists(&models.Lead{})
+		_ = rep.DropTableIfExists(&models.ClientAccount{})
+		_ = rep.DropTableIfExists(&models.ClientMerge{})
+		_ = rep.DropTableIfExists(&models.PetOwner{})
+		_ = rep.DropTableIfExists(&models.ClientContact{})
+		_ = rep.DropTableIfExists(&models.PetWeight{})
+		_ = rep.DropTableIfExists(&models.Species{})
+		_ = rep.DropTableIfExists(&models.Breed{})
+		_ = rep.DropTableIfExists(&models.Medication{})
+		_ = rep.DropTableIfExists(&models.Prescription{})
+		_ = rep.DropTableIfExists(&models.Attachment{})
+		_ = rep.DropTableIfExists(&models.LabResult{})
+		_ = rep.DropTableIfExists(&models.Product{})
+		_ = rep.DropTableIfExists(&models.ProductBatch{})
+		_ = rep.DropTableIfExists(&models.StockMovement{})
+		_ = rep.DropTableIfExists(&models.ServiceConsumable{})
+		_ = rep.DropTableIfExists(&models.ServicePrice{})
+		_ = rep.DropTableIfExists(&models.DiscountTier{})
+		_ = rep.DropTableIfExists(&models.PromoCode{})
+		_ = rep.DropTableIfExists(&models.Invoice{})
+		_ = rep.DropTableIfExists(&models.BalanceEntry{})
+		_ = rep.DropTableIfExists(&models.Resource{})
+		_ = rep.DropTableIfExists(&models.ServiceResource{})
+		_ = rep.DropTableIfExists(&models.Reservation{})
+		_ = rep.DropTableIfExists(&models.WorkingHours{})
+		_ = rep.DropTableIfExists(&models.WaitlistEntry{})
+		_ = rep.DropTableIfExists(&models.WaitlistWindow{})
+		_ = rep.DropTableIfExists(&models.WaitlistMatch{})
 		_ = rep.DropTableIfExists("users_departments")
 		_ = rep.DropTableIfExists("users_services")
 		_ = rep.DropTableIfExists("departments_services")
@@ -32,5 +60,39 @@ func CreateTables(container container.Container) {
 		_ = rep.AutoMigrate(&models.Pet{})
 		_ = rep.AutoMigrate(&models.Visit{})
 		_ = rep.AutoMigrate(&models.Lead{})
+		_ = rep.AutoMigrate(&models.ClientAccount{})
+		_ = rep.AutoMigrate(&models.ClientMerge{})
+		_ = rep.AutoMigrate(&models.PetOwner{})
+		_ = rep.AutoMigrate(&models.ClientContact{})
+		_ = rep.AutoMigrate(&models.PetWeight{})
+		_ = rep.AutoMigrate(&models.Species{})
+		_ = rep.AutoMigrate(&models.Breed{})
+		_ = rep.AutoMigrate(&models.Medication{})
+		_ = rep.AutoMigrate(&models.Prescription{})
+		_ = rep.AutoMigrate(&models.Attachment{})
+		_ = rep.AutoMigrate(&models.LabResult{})
+		_ = rep.AutoMigrate(&models.Product{})
+		_ = rep.AutoMigrate(&models.ProductBatch{})
+		_ = rep.AutoMigrate(&models.StockMovement{})
+		_ = rep.AutoMigrate(&models.ServiceConsumable{})
+		_ = rep.AutoMigrate(&models.ServicePrice{})
+		_ = rep.AutoMigrate(&models.DiscountTier{})
+		_ = rep.AutoMigrate(&models.PromoCode{})
+		_ = rep.AutoMigrate(&models.Invoice{})
+		_ = rep.AutoMigrate(&models.BalanceEntry{})
+		_ = rep.AutoMigrate(&models.Resource{})
+		_ = rep.AutoMigrate(&models.ServiceResource{})
+		_ = rep.AutoMigrate(&models.Reservation{})
+		_ = rep.AutoMigrate(&models.WorkingHours{})
+		_ = rep.AutoMigrate(&models.WaitlistEntry{})
+		_ = rep.AutoMigrate(&models.WaitlistWindow{})
+		_ = rep.AutoMigrate(&models.WaitlistMatch{})
+
+		// The microchip is unique among the pets which have one. MySQL has no partial indexes,
+		// so the pet model keeps the microchips unique there by locking them.
+		if container.Config().Database.Dialect != repository.MYSQL {
+			_ = rep.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_pet_microchip ON pet_master (microchip) " +
+				"WHERE microchip <> ''").Error
+		}
 	}
 }
@@ -22,14 +22,58 @@ func InitMasterData(container container.Container) {
 		rep.Create(models.NewCategory("Кардиология"))
 		rep.Create(models.NewCategory("Инструментальная диагностика"))
 
-		rep.Create(&models.Service{Name: "Консультация", Price: 1000, CategoryID: 1})
-		rep.Create(&models.Service{Name: "Прием врача терапевта", Price: 3000, CategoryID: 1})
-		rep.Create(&models.Service{Name: "Стрижка когтей", Price: 800, CategoryID: 2})
-		rep.Create(&models.Service{Name: "Глюкометрия", Price: 400, CategoryID: 2})
-		rep.Create(&models.Service{Name: "Вакцинация", Price: 2500, CategoryID: 2})
-		rep.Create(&models.Service{Name: "Залог за прибор для телеметрии", Price: 30000, CategoryID: 3})
-		rep.Create(&models.Service{Name: "ЭхоКГ скрининг", Price: 3500, CategoryID: 4})
-		rep.Create(&models.Service{Name: "Холтеровское мониторирование", Price: 9500, CategoryID: 4})
+		_, _ = (&models.Service{Name: "Консультация", Price: 1000, Duration: 30, CategoryID: 1}).Create(rep)
+		_, _ = (&models.Service{Name: "Прием врача терапевта", Price: 3000, Duration: 45, Profession: "Терапевт",
+			Description: "Осмотр, сбор анамнеза и назначение лечения", CategoryID: 1}).Create(rep)
+		_, _ = (&models.Service{Name: "Стрижка когтей", Price: 800, Duration: 15, CategoryID: 2}).Create(rep)
+		_, _ = (&models.Service{Name: "Глюкометрия", Price: 400, Duration: 15, CategoryID: 2}).Create(rep)
+		_, _ = (&models.Service{Name: "Вакцинация", Price: 2500, Duration: 30, CategoryID: 2}).Create(rep)
+		_, _ = (&models.Service{Name: "Залог за прибор для телеметрии", Price: 30000, Deposit: true, CategoryID: 3}).Create(rep)
+		_, _ = (&models.Service{Name: "ЭхоКГ скрининг", Price: 3500, Duration: 40, CategoryID: 4}).Create(rep)
+		_, _ = (&models.Service{Name: "Холтеровское мониторирование", Price: 9500, Duration: 1440,
+			Description: "Установка монитора на сутки", CategoryID: 4}).Create(rep)
+
+		rep.Create(&models.DiscountTier{Name: "Серебряный", Percent: 5, MinSpent: 20000})
+		rep.Create(&models.DiscountTier{Name: "Золотой", Percent: 10, MinSpent: 50000})
+		_, _ = (&models.PromoCode{Code: "WELCOME10", Percent: 10, UsageLimit: 100,
+			ValidFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local),
+			ValidTo:   time.Date(2030, time.December, 31, 0, 0, 0, 0, time.Local)}).Create(rep)
+
+		rep.Create(models.NewSpecies("Кошка", "кот", "cat"))
+		rep.Create(models.NewSpecies("Собака", "пёс", "dog"))
+		rep.Create(models.NewSpecies("Хорёк", "ferret"))
+		rep.Create(models.NewSpecies("Кролик", "rabbit"))
+		rep.Create(models.NewSpecies("Птица", "bird"))
+		rep.Create(models.NewSpecies("Грызун", "rodent"))
+		rep.Create(models.NewSpecies("Рептилия", "reptile"))
+
+		rep.Create(&models.Breed{Name: "Британская короткошёрстная", SpeciesID: 1})
+		rep.Create(&models.Breed{Name: "Мейн-кун", SpeciesID: 1})
+		rep.Create(&models.Breed{Name: "Сиамская", SpeciesID: 1})
+		rep.Create(&models.Breed{Name: "Сфинкс", SpeciesID: 1})
+		rep.Create(&models.Breed{Name: "Лабрадор-ретривер", SpeciesID: 2})
+		rep.Create(&models.Breed{Name: "Немецкая овчарка", SpeciesID: 2})
+		rep.Create(&models.Breed{Name: "Такса", SpeciesID: 2})
+		rep.Create(&models.Breed{Name: "Чихуахуа", SpeciesID: 2})
+
+		rep.Create(&models.Medication{Name: "Синулокс", Form: "таблетки", Strength: 50, Unit: "мг"})
+		rep.Create(&models.Medication{Name: "Мелоксикам", Form: "суспензия", Strength: 0.5, Unit: "мг/мл"})
+		rep.Create(&models.Medication{Name: "Амоксиклав", Form: "таблетки", Strength: 250, Unit: "мг"})
+
+		rep.Create(&models.Product{Name: "Нобивак Tricat Trio", Unit: "доза", Price: 1200, MinStock: 5})
+		rep.Create(&models.Product{Name: "Шприц 2 мл", Unit: "шт", Price: 15, MinStock: 50})
+		rep.Create(&models.Product{Name: "Гель для УЗИ", Unit: "мл", MinStock: 250})
+		_, _ = (&models.ProductBatch{BatchNumber: "A123B45", Quantity: 20,
+			ExpiryDate: time.Date(2030, time.June, 30, 0, 0, 0, 0, time.Local)}).Receive(rep, 1)
+		_, _ = (&models.ProductBatch{BatchNumber: "S-2401", Quantity: 200,
+			ExpiryDate: time.Date(2030, time.January, 31, 0, 0, 0, 0, time.Local)}).Receive(rep, 2)
+		_, _ = (&models.ServiceConsumable{}).SetForService(rep, 5, []*models.ServiceConsumable{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 2, Quantity: 1},
+		})
+		_, _ = (&models.ServiceConsumable{}).SetForService(rep, 7, []*models.ServiceConsumable{
+			{ProductID: 3, Quantity: 20},
+		})
 
 		dep1 := &models.Department{
 			Name: "Терапия",
@@ -52,6 +96,14 @@ func InitMasterData(container container.Container) {
 			},
 		}
 		_, _ = dep2.Create(rep)
+		_, _ = (&models.ServicePrice{DepartmentID: 2, Price: 1500,
+			EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)}).Create(rep, 1)
+
+		_, _ = (&models.Resource{Name: "Кабинет 1", Type: models.ResourceRoom, DepartmentID: 1}).Create(rep)
+		_, _ = (&models.Resource{Name: "Кабинет УЗИ", Type: models.ResourceRoom, DepartmentID: 2}).Create(rep)
+		_, _ = (&models.Resource{Name: "Холтеровский монитор", Type: models.ResourceEquipment, DepartmentID: 2}).Create(rep)
+		_, _ = (&models.ServiceResource{}).SetForService(rep, 7, []uint{2})
+		_, _ = (&models.ServiceResource{}).SetForService(rep, 8, []uint{3})
 
 		user1 := &models.User{
 			Username:   "Test1",
@@ -120,6 +172,7 @@ func InitMasterData(container container.Container) {
 			Type:            "callback",
 			Status:          "rejected",
 			DoctorID:        1,
+			ClientID:        1,
 			LastUpdatedByID: 1,
 		}
 
@@ -0,0 +1,97 @@
+package models
+
+import "vet-clinic/repository"
+
+// Attachment defines struct of a file attached to a pet or a visit, e.g. a report or an image of a diagnostic service.
+// The content of the file is kept in the storage under StorageKey.
+type Attachment struct {
+	*BaseModel
+	PetID        uint   `json:"petId" gorm:"index"`
+	VisitID      uint   `json:"visitId" gorm:"index"` // 0 if the file is attached to the pet only.
+	FileName     string `json:"fileName" gorm:"size:255" example:"echo.pdf"`
+	ContentType  string `json:"contentType" gorm:"size:100" example:"application/pdf"`
+	Size         int64  `json:"size"` // bytes
+	Description  string `json:"description" gorm:"size:1000"`
+	StorageKey   string `json:"-" gorm:"unique;size:255"`
+	UploadedByID uint   `json:"uploadedById"`
+	UploadedBy   *User  `json:"uploadedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+// TableName returns the table name of attachment struct and it is used by gorm.
+func (*Attachment) TableName() string {
+	return "attachment"
+}
+
+// Get returns attachment full matched given attachment ID.
+func (m *Attachment) Get(rep repository.Repository, id uint) (*Attachment, error) {
+	attachment := &Attachment{}
+	if err := rep.Preload("UploadedBy").First(attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAllByPet returns the files attached to the pet and its visits, the latest first.
+func (m *Attachment) GetAllByPet(rep repository.Repository, petID uint) ([]*Attachment, error) {
+	if _, err := (&Pet{}).Exist(rep, petID); err != nil {
+		return nil, err
+	}
+	var attachments []*Attachment
+	if err := rep.Preload("UploadedBy").Where("pet_id = ?", petID).
+		Order("created_at DESC, id DESC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAllByVisit returns the files attached to the visit, the latest first.
+func (m *Attachment) GetAllByVisit(rep repository.Repository, visitID uint) ([]*Attachment, error) {
+	if _, err := (&Visit{}).Exist(rep, visitID); err != nil {
+		return nil, err
+	}
+	var attachments []*Attachment
+	if err := rep.Preload("UploadedBy").Where("visit_id = ?", visitID).
+		Order("created_at DESC, id DESC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// Create persists this attachment data. A file attached to a visit is also attached to the pet of the visit.
+func (m *Attachment) Create(rep repository.Repository) (*Attachment, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if m.VisitID != 0 {
+			visit := &Visit{}
+			if err := tx.First(visit, m.VisitID).Error; err != nil {
+				return err
+			}
+			m.PetID = visit.PetID
+		} else if _, err := (&Pet{}).Exist(tx, m.PetID); err != nil {
+			return err
+		}
+
+		columns := []string{"pet_id", "file_name", "content_type", "size", "description", "storage_key", "uploaded_by_id"}
+		if m.VisitID != 0 {
+			columns = append(columns, "visit_id")
+		}
+		return tx.Select(columns).Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Delete deletes this attachment data permanently, so the file can be removed from the storage.
+func (m *Attachment) Delete(rep repository.Repository, id uint) (*Attachment, error) {
+	attachment := &Attachment{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if attachment, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&Attachment{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
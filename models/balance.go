@@ -0,0 +1,107 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// BalanceTopUp is the reason of the prepayment made by the client.
+	BalanceTopUp = "top-up"
+	// BalanceDeposit is the reason of the deposit paid by the client for a deposit service, e.g. for a device.
+	BalanceDeposit = "deposit"
+	// BalancePayment is the reason of the invoice paid from the balance.
+	BalancePayment = "payment"
+	// BalanceRefund is the reason of the money returned to the client.
+	BalanceRefund = "refund"
+)
+
+// BalanceEntry defines struct of an entry of the balance ledger of a client.
+type BalanceEntry struct {
+	*BaseModel
+	ClientID    uint    `json:"clientId" gorm:"index"`
+	Amount      float64 `json:"amount"`                 // Positive if the balance increases, negative if it decreases.
+	Reason      string  `json:"reason"`                 // top-up, deposit, payment or refund
+	InvoiceID   uint    `json:"invoiceId" gorm:"index"` // The invoice of the deposit or the payment, 0 for the other reasons.
+	Note        string  `json:"note" gorm:"size:1000"`
+	CreatedByID uint    `json:"createdById"` // The user who recorded the entry, 0 if it is recorded by invoicing.
+}
+
+// ClientBalance defines struct of the balance of a client along with its ledger.
+type ClientBalance struct {
+	ClientID uint            `json:"clientId"`
+	Balance  float64         `json:"balance"`
+	Entries  []*BalanceEntry `json:"entries"` // The latest first.
+}
+
+// TableName returns the table name of balance entry struct and it is used by gorm.
+func (*BalanceEntry) TableName() string {
+	return "balance_entry"
+}
+
+// GetBalance returns the balance of the client along with its ledger.
+func (m *BalanceEntry) GetBalance(rep repository.Repository, clientID uint) (*ClientBalance, error) {
+	if _, err := (&Client{}).Exist(rep, clientID); err != nil {
+		return nil, err
+	}
+	balance := &ClientBalance{ClientID: clientID}
+	var err error
+	if balance.Balance, err = txGetBalance(rep, clientID); err != nil {
+		return nil, err
+	}
+	if err = rep.Where("client_id = ?", clientID).Order("created_at DESC, id DESC").
+		Find(&balance.Entries).Error; err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// TopUp records the prepayment made by the client.
+func (m *BalanceEntry) TopUp(rep repository.Repository, clientID uint) (*ClientBalance, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Client{}).Exist(tx, clientID); err != nil {
+			return err
+		}
+		return txRecordBalance(tx, clientID, m.Amount, BalanceTopUp, 0, m.Note, m.CreatedByID)
+	}); err != nil {
+		return nil, err
+	}
+	return m.GetBalance(rep, clientID)
+}
+
+// Refund records the money returned to the client, e.g. the deposit for a returned device.
+// It fails if the amount exceeds the balance of the client.
+func (m *BalanceEntry) Refund(rep repository.Repository, clientID uint) (*ClientBalance, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Client{}).Exist(tx, clientID); err != nil {
+			return err
+		}
+		balance, err := txGetBalance(tx, clientID)
+		if err != nil {
+			return err
+		}
+		if m.Amount > balance {
+			return apperror.NewInvalidState("the amount exceeds the balance of the client")
+		}
+		return txRecordBalance(tx, clientID, -m.Amount, BalanceRefund, 0, m.Note, m.CreatedByID)
+	}); err != nil {
+		return nil, err
+	}
+	return m.GetBalance(rep, clientID)
+}
+
+func txGetBalance(tx repository.Repository, clientID uint) (float64, error) {
+	var balance float64
+	if err := tx.Model(&BalanceEntry{}).Where("client_id = ?", clientID).
+		Select("COALESCE(SUM(amount), 0)").Scan(&balance).Error; err != nil {
+		return 0, err
+	}
+	return roundMoney(balance), nil
+}
+
+func txRecordBalance(tx repository.Repository, clientID uint, amount float64, reason string, invoiceID uint,
+	note string, createdByID uint) error {
+	entry := &BalanceEntry{ClientID: clientID, Amount: amount, Reason: reason, InvoiceID: invoiceID,
+		Note: note, CreatedByID: createdByID}
+	return tx.Select("client_id", "amount", "reason", "invoice_id", "note", "created_by_id").Create(entry).Error
+}
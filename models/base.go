@@ -1,8 +1,12 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
 	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
 )
 
 type BaseModel struct {
@@ -11,3 +15,74 @@ type BaseModel struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
+
+// ETag returns the entity tag of this record, which changes every time the record is updated.
+func (m *BaseModel) ETag() string {
+	return fmt.Sprintf("\"%d-%x\"", m.ID, m.UpdatedAt.UnixNano())
+}
+
+// Version returns the column values which identify this version of the record, see repository.WithPrecondition.
+func (m *BaseModel) Version() map[string]interface{} {
+	return map[string]interface{}{"id": m.ID, "updated_at": m.UpdatedAt}
+}
+
+// reference describes a column of another table which refers to a record.
+type reference struct {
+	model  interface{}
+	column string
+}
+
+// txCheckNotReferenced returns an error if any record, including the soft-deleted ones, refers to the record with the given ID.
+func txCheckNotReferenced(tx repository.Repository, id uint, refs ...reference) error {
+	for _, ref := range refs {
+		var count int64
+		if err := tx.Unscoped().Model(ref.model).Where(ref.column+" = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperror.NewReferenced()
+		}
+	}
+	return nil
+}
+
+// txLock locks the row of the record until the transaction ends by a no-op update, which blocks
+// the transactions locking the same record, so the checks made after it cannot be invalidated concurrently.
+// SELECT ... FOR UPDATE is not used since SQLite ignores it.
+func txLock(tx repository.Repository, model interface{}, id uint) error {
+	return tx.Model(model).Where("id = ?", id).UpdateColumn("id", gorm.Expr("id")).Error
+}
+
+// txRestore clears the deletion time of the soft-deleted record.
+func txRestore(tx repository.Repository, model interface{}, id uint) error {
+	if err := tx.Unscoped().First(model, id).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(model).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// purgeDeletedBefore permanently deletes the records soft-deleted before the given time by using txPurge.
+// The records which are still referenced are skipped. It returns the number of the purged records.
+func purgeDeletedBefore(rep repository.Repository, model interface{}, before time.Time,
+	txPurge func(tx repository.Repository, id uint) error) (int64, error) {
+	var ids []uint
+	if err := rep.Unscoped().Model(model).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for _, id := range ids {
+		err := rep.Transaction(func(tx repository.Repository) error {
+			return txPurge(tx, id)
+		})
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code == apperror.Conflict {
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
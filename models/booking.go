@@ -0,0 +1,270 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// BookableService is the public data of a service which can be booked online.
+type BookableService struct {
+	ID    uint    `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// BookableDoctor is the public data of a doctor who can be booked online.
+type BookableDoctor struct {
+	ID         uint   `json:"id"`
+	Surname    string `json:"surname"`
+	Name       string `json:"name"`
+	Patronymic string `json:"patronymic"`
+	Profession string `json:"profession"`
+	Services   []uint `json:"services"` // IDs of the services provided by the doctor.
+}
+
+// BookableDepartment is the public data of a department along with its services and doctors.
+type BookableDepartment struct {
+	ID       uint               `json:"id"`
+	Name     string             `json:"name"`
+	Services []*BookableService `json:"services"`
+	Doctors  []*BookableDoctor  `json:"doctors"`
+}
+
+// Slot is a free time slot of a doctor.
+type Slot struct {
+	DoctorID uint      `json:"doctorId"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+// Booking is the public data of the tentative visit booked online.
+type Booking struct {
+	VisitID   uint      `json:"visitId"`
+	DoctorID  uint      `json:"doctorId"`
+	ServiceID uint      `json:"serviceId"`
+	DateTime  time.Time `json:"dateTime"`
+	Status    string    `json:"status"`
+}
+
+// GetBookable returns all departments along with their services and active doctors.
+// The archived services are not listed.
+func (m *Department) GetBookable(rep repository.Repository) ([]*BookableDepartment, error) {
+	var departments []*Department
+	if err := rep.Preload("Services").Find(&departments).Error; err != nil {
+		return nil, err
+	}
+	var users []*User
+	if err := rep.Preload("Departments").Preload("Services").
+		Where("active = ?", true).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	doctors := make(map[uint][]*BookableDoctor)
+	for _, user := range users {
+		doctor := &BookableDoctor{
+			ID:         user.ID,
+			Surname:    user.Surname,
+			Name:       user.Name,
+			Patronymic: user.Patronymic,
+			Profession: user.Profession,
+			Services:   []uint{},
+		}
+		for _, service := range user.Services {
+			if service.Archived || !service.providedBy(user) {
+				continue
+			}
+			doctor.Services = append(doctor.Services, service.ID)
+		}
+		for _, department := range user.Departments {
+			doctors[department.ID] = append(doctors[department.ID], doctor)
+		}
+	}
+
+	result := make([]*BookableDepartment, 0, len(departments))
+	for _, department := range departments {
+		bookable := &BookableDepartment{
+			ID:       department.ID,
+			Name:     department.Name,
+			Services: []*BookableService{},
+			Doctors:  doctors[department.ID],
+		}
+		if bookable.Doctors == nil {
+			bookable.Doctors = []*BookableDoctor{}
+		}
+		for _, service := range department.Services {
+			if service.Archived {
+				continue
+			}
+			bookable.Services = append(bookable.Services,
+				&BookableService{ID: service.ID, Name: service.Name, Price: service.Price})
+		}
+		result = append(result, bookable)
+	}
+	return result, nil
+}
+
+// GetBookableDoctors returns the active doctors who provide the given service,
+// which is not archived, and have the profession required by it.
+// The department and the doctor are optional filters, 0 means any.
+func (m *User) GetBookableDoctors(rep repository.Repository, departmentID, serviceID, doctorID uint) ([]*User, error) {
+	var users []*User
+	query := rep.Where("user_master.active = ?", true).
+		Joins("JOIN users_services ON users_services.user_id = user_master.id AND users_services.service_id = ?", serviceID).
+		Joins("JOIN service_master ON service_master.id = users_services.service_id AND service_master.archived = ? "+
+			"AND (service_master.profession = '' OR LOWER(service_master.profession) = LOWER(TRIM(user_master.profession)))", false)
+	if departmentID != 0 {
+		query = query.Joins("JOIN users_departments ON users_departments.user_id = user_master.id "+
+			"AND users_departments.department_id = ?", departmentID)
+	}
+	if doctorID != 0 {
+		query = query.Where("user_master.id = ?", doctorID)
+	}
+	if err := query.Order("user_master.id").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetBooked returns the tentative and scheduled visits of the given doctors which overlap the given period.
+// A visit takes the duration of its service, the given duration is used if the service has none.
+func (m *Visit) GetBooked(rep repository.Repository, doctorIDs []uint, from, to time.Time,
+	fallback time.Duration) ([]*Visit, error) {
+	var longest int
+	if err := rep.Model(&Service{}).Select("COALESCE(MAX(duration), 0)").Scan(&longest).Error; err != nil {
+		return nil, err
+	}
+	lookback := fallback
+	if d := time.Duration(longest) * time.Minute; d > lookback {
+		lookback = d
+	}
+
+	var visits []*Visit
+	if err := rep.Preload("Service").
+		Where("doctor_id IN ? AND status IN ?", doctorIDs, []string{VisitTentative, VisitScheduled}).
+		Where("date_time > ? AND date_time < ?", from.Add(-lookback), to).
+		Order("date_time").Find(&visits).Error; err != nil {
+		return nil, err
+	}
+	booked := make([]*Visit, 0, len(visits))
+	for _, visit := range visits {
+		if visit.EndTime(fallback).After(from) {
+			booked = append(booked, visit)
+		}
+	}
+	return booked, nil
+}
+
+// EndTime returns the time the visit ends, the given duration is used if its service has no duration.
+func (m *Visit) EndTime(fallback time.Duration) time.Time {
+	if m.Service == nil {
+		return m.DateTime.Add(fallback)
+	}
+	return m.DateTime.Add(m.Service.VisitDuration(fallback))
+}
+
+// Book persists this visit booked online as a tentative visit, which is confirmed by the clinic later.
+// The given client is created as a tentative client along with the pet with the given name, the existing clients
+// are never changed by the anonymous booking, so the staff reconciles the tentative client with them.
+// The visit occupies the doctor and the resources required by the service for the duration of the service
+// or else for the given slot duration. The doctor is locked until the booking is saved.
+// It fails if the slot or a required resource is taken or if a booking with the same phone or e-mail is pending.
+func (m *Visit) Book(rep repository.Repository, client *Client, petName string,
+	duration time.Duration, now time.Time) (*Visit, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := txLock(tx, &User{}, m.DoctorID); err != nil {
+			return err
+		}
+		doctor := &User{}
+		if err := tx.Where("user_master.active = ?", true).
+			Joins("JOIN users_services ON users_services.user_id = user_master.id AND users_services.service_id = ?", m.ServiceID).
+			First(doctor, m.DoctorID).Error; err != nil {
+			return apperror.NewInvalidReference("doctorId", err)
+		}
+		if err := txCheckServiceProvider(tx, m.ServiceID, m.DoctorID); err != nil {
+			return err
+		}
+
+		service := &Service{}
+		if err := tx.First(service, m.ServiceID).Error; err != nil {
+			return err
+		}
+		taken, err := m.GetBooked(tx, []uint{m.DoctorID}, m.DateTime, m.DateTime.Add(service.VisitDuration(duration)), duration)
+		if err != nil {
+			return err
+		}
+		if len(taken) > 0 {
+			return apperror.NewInvalidState("the slot is already taken")
+		}
+
+		pending, err := txCountPendingBookings(tx, client.Phone, client.Email, now)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return apperror.NewDuplicate("a booking with the same phone or e-mail is already pending")
+		}
+
+		client.Tentative = true
+		if err := tx.Select("name", "phone", "email", "tentative").Create(client).Error; err != nil {
+			return err
+		}
+		pet := &Pet{Name: petName, ClientID: client.ID}
+		if err := tx.Select("name", "client_id").Create(pet).Error; err != nil {
+			return err
+		}
+		if err := txSetPetOwners(tx, pet.ID, client.ID, nil); err != nil {
+			return err
+		}
+
+		m.ClientID = client.ID
+		m.PetID = pet.ID
+		m.Status = VisitTentative
+		if err := tx.Select("date_time", "info", "client_id", "pet_id", "doctor_id", "service_id", "status").Create(m).Error; err != nil {
+			return err
+		}
+		if err := txExpireWaitlistMatches(tx, m, duration); err != nil {
+			return err
+		}
+		return txSyncReservations(tx, m, duration)
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// txCountPendingBookings returns the number of the upcoming tentative visits of the clients
+// with the given phone or e-mail. The e-mails are compared case-insensitively.
+func txCountPendingBookings(tx repository.Repository, phone, email string, now time.Time) (int64, error) {
+	var count int64
+	contacts := [][2]string{
+		{"client_master.phone = ?", phone},
+		{"LOWER(TRIM(client_master.email)) = ?", strings.ToLower(strings.TrimSpace(email))},
+	}
+	for _, contact := range contacts {
+		if contact[1] == "" {
+			continue
+		}
+		var pending int64
+		if err := tx.Model(&Visit{}).Joins("JOIN client_master ON client_master.id = visit_master.client_id").
+			Where(contact[0], contact[1]).
+			Where("visit_master.status = ? AND visit_master.date_time >= ?", VisitTentative, now).
+			Count(&pending).Error; err != nil {
+			return 0, err
+		}
+		count += pending
+	}
+	return count, nil
+}
+
+// ToBooking returns the public data of this visit booked online.
+func (m *Visit) ToBooking() *Booking {
+	return &Booking{
+		VisitID:   m.ID,
+		DoctorID:  m.DoctorID,
+		ServiceID: m.ServiceID,
+		DateTime:  m.DateTime,
+		Status:    m.Status,
+	}
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+	"vet-clinic/util"
+)
+
+// Breed defines struct of breed data.
+type Breed struct {
+	*BaseModel
+	Name      string   `json:"name" gorm:"not null;size:255"`
+	SpeciesID uint     `json:"speciesId" gorm:"index"`
+	Species   *Species `json:"species" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+// TableName returns the table name of breed struct and it is used by gorm.
+func (*Breed) TableName() string {
+	return "breed_master"
+}
+
+// UnverifiedBreed defines struct of a breed entered as free text, which is not in the catalogue yet.
+type UnverifiedBreed struct {
+	SpeciesID uint   `json:"speciesId"`
+	Breed     string `json:"breed"`
+	Count     int64  `json:"count"` // The number of the pets of the breed.
+}
+
+// Exist returns true if a given breed exits.
+func (m *Breed) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&Breed{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns breed full matched given breed ID.
+func (m *Breed) Get(rep repository.Repository, id uint) (*Breed, error) {
+	breed := &Breed{}
+	if err := rep.Preload("Species").First(breed, id).Error; err != nil {
+		return nil, err
+	}
+	return breed, nil
+}
+
+// GetAll returns a slice of all breeds. If speciesID is not 0, only the breeds of the species are returned.
+func (m *Breed) GetAll(rep repository.Repository, speciesID uint) ([]*Breed, error) {
+	var breeds []*Breed
+	db := rep.Preload("Species").Order("name")
+	if speciesID != 0 {
+		db = db.Where("species_id = ?", speciesID)
+	}
+	if err := db.Find(&breeds).Error; err != nil {
+		return nil, err
+	}
+	return breeds, nil
+}
+
+// GetUnverified returns the breeds entered as free text for the pets, which are flagged for review.
+func (m *Breed) GetUnverified(rep repository.Repository) ([]*UnverifiedBreed, error) {
+	var breeds []*UnverifiedBreed
+	if err := rep.Model(&Pet{}).Select("species_id, breed, COUNT(*) AS count").
+		Where("breed_unverified = ?", true).Group("species_id, breed").Order("species_id, breed").
+		Scan(&breeds).Error; err != nil {
+		return nil, err
+	}
+	return breeds, nil
+}
+
+// Create persists this breed data.
+// The pets of the species whose breed has been entered as the same free text are linked to the new breed.
+func (m *Breed) Create(rep repository.Repository) (*Breed, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := m.txCheck(tx, 0); err != nil {
+			return err
+		}
+		if err := tx.Select("name", "species_id").Create(m).Error; err != nil {
+			return err
+		}
+		return m.txLinkUnverifiedPets(tx)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this breed data.
+// The breed of the pets is renamed along with the breed.
+func (m *Breed) Update(rep repository.Repository, id uint) (*Breed, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		current := &Breed{}
+		if err := tx.First(current, id).Error; err != nil {
+			return err
+		}
+		if err := m.txCheck(tx, id); err != nil {
+			return err
+		}
+		// The pets of the breed would belong to another species.
+		if m.SpeciesID != current.SpeciesID {
+			if err := txCheckNotReferenced(tx, id, reference{&Pet{}, "breed_id"}); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&Breed{}).Where("id = ?", id).Select("name", "species_id").Updates(m).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Pet{}).Where("breed_id = ?", id).Update("breed", m.Name).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this breed data.
+// It fails if pets, including the soft-deleted ones, still refer to the breed.
+func (m *Breed) Delete(rep repository.Repository, id uint) (*Breed, error) {
+	breed := &Breed{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if breed, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&Pet{}, "breed_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&Breed{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return breed, nil
+}
+
+// txCheck returns an error if the species of this breed does not exist
+// or another breed of the species has the same name regardless of the case.
+func (m *Breed) txCheck(tx repository.Repository, id uint) error {
+	species := &Species{}
+	if _, err := species.Exist(tx, m.SpeciesID); err != nil {
+		return apperror.NewInvalidReference("speciesId", err)
+	}
+
+	others, err := m.GetAll(tx, m.SpeciesID)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.ID != id && util.NormalizeName(other.Name) == util.NormalizeName(m.Name) {
+			return apperror.NewDuplicate("a breed with the same name already exists for the species")
+		}
+	}
+	return nil
+}
+
+// txLinkUnverifiedPets links the pets of the species whose breed has been entered as the name of this breed.
+func (m *Breed) txLinkUnverifiedPets(tx repository.Repository) error {
+	var pets []*Pet
+	if err := tx.Unscoped().Where("species_id = ? AND breed_unverified = ?", m.SpeciesID, true).
+		Find(&pets).Error; err != nil {
+		return err
+	}
+	var ids []uint
+	for _, pet := range pets {
+		if util.NormalizeName(pet.Breed) == util.NormalizeName(m.Name) {
+			ids = append(ids, pet.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Unscoped().Model(&Pet{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"breed_id": m.ID, "breed": m.Name, "breed_unverified": false}).Error
+}
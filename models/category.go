@@ -1,13 +1,18 @@
 package models
 
 import (
+	"fmt"
+	"hash/crc32"
+	"vet-clinic/apperror"
 	"vet-clinic/repository"
 )
 
 // Category defines struct of category data.
 type Category struct {
-	ID   uint   `json:"id" gorm:"primary_key"`
-	Name string `json:"name" gorm:"unique;not null;size:255"`
+	ID       uint        `json:"id" gorm:"primary_key"`
+	Name     string      `json:"name" gorm:"unique;not null;size:255"`
+	ParentID uint        `json:"parentId" gorm:"index"`       // The parent category, 0 for a top-level category.
+	Children []*Category `json:"children,omitempty" gorm:"-"` // The subcategories, filled only in the category tree.
 }
 
 // TableName returns the table name of category struct and it is used by gorm.
@@ -20,6 +25,17 @@ func NewCategory(name string) *Category {
 	return &Category{Name: name}
 }
 
+// ETag returns the entity tag of this category.
+// The category has no update time, so the tag is derived from its name and parent.
+func (m *Category) ETag() string {
+	return fmt.Sprintf("\"%d-%x\"", m.ID, crc32.ChecksumIEEE([]byte(fmt.Sprintf("%d/%s", m.ParentID, m.Name))))
+}
+
+// Version returns the column values which identify this version of the category, the ones its tag is derived from.
+func (m *Category) Version() map[string]interface{} {
+	return map[string]interface{}{"id": m.ID, "parent_id": m.ParentID, "name": m.Name}
+}
+
 // Exist returns true if a given category exits.
 func (m *Category) Exist(rep repository.Repository, id uint) (bool, error) {
 	if err := rep.First(&Category{}, id).Error; err != nil {
@@ -46,10 +62,36 @@ func (m *Category) GetAll(rep repository.Repository) ([]*Category, error) {
 	return categories, nil
 }
 
+// GetTree returns the top-level categories with their subcategories nested, each level ordered by name.
+func (m *Category) GetTree(rep repository.Repository) ([]*Category, error) {
+	var categories []*Category
+	if err := rep.Model(&Category{}).Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	roots := []*Category{}
+	for _, category := range categories {
+		if parent, ok := byID[category.ParentID]; ok {
+			parent.Children = append(parent.Children, category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+	return roots, nil
+}
+
 // Create persists this category data.
 func (m *Category) Create(rep repository.Repository) (*Category, error) {
 	if err := rep.Transaction(func(tx repository.Repository) error {
-		return tx.Select("name").Create(m).Error
+		if m.ParentID != 0 {
+			if _, err := m.Exist(tx, m.ParentID); err != nil {
+				return apperror.NewInvalidReference("parentId", err)
+			}
+		}
+		return tx.Select("name", "parent_id").Create(m).Error
 	}); err != nil {
 		return nil, err
 	}
@@ -62,9 +104,23 @@ func (m *Category) Update(rep repository.Repository, id uint) (*Category, error)
 		if _, err := m.Exist(tx, id); err != nil {
 			return err
 		}
+		if m.ParentID != 0 {
+			if _, err := m.Exist(tx, m.ParentID); err != nil {
+				return apperror.NewInvalidReference("parentId", err)
+			}
+			subtree, err := txGetCategorySubtree(tx, id)
+			if err != nil {
+				return err
+			}
+			for _, subID := range subtree {
+				if subID == m.ParentID {
+					return apperror.NewInvalidState("a category cannot be moved under itself or its subcategories")
+				}
+			}
+		}
 
 		return tx.Model(&Category{}).Where("id = ?", id).
-			Select("name").Updates(m).Error
+			Select("name", "parent_id").Updates(m).Error
 	}); err != nil {
 		return nil, err
 	}
@@ -72,6 +128,7 @@ func (m *Category) Update(rep repository.Repository, id uint) (*Category, error)
 }
 
 // Delete deletes this category data.
+// The subcategories are moved to the parent of the deleted category.
 func (m *Category) Delete(rep repository.Repository, id uint) (*Category, error) {
 	category := &Category{}
 	if err := rep.Transaction(func(tx repository.Repository) error {
@@ -79,9 +136,30 @@ func (m *Category) Delete(rep repository.Repository, id uint) (*Category, error)
 		if category, err = m.Get(tx, id); err != nil {
 			return err
 		}
+		if err := tx.Model(&Category{}).Where("parent_id = ?", id).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
 		return tx.Delete(&Category{}, id).Error
 	}); err != nil {
 		return nil, err
 	}
 	return category, nil
 }
+
+// txGetCategorySubtree returns the IDs of the category and all of its subcategories.
+func txGetCategorySubtree(tx repository.Repository, id uint) ([]uint, error) {
+	var categories []*Category
+	if err := tx.Select("id", "parent_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+	}
+	subtree := []uint{id}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, children[subtree[i]]...)
+	}
+	return subtree, nil
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"gorm.io/gorm"
 	"time"
 	"vet-clinic/repository"
 )
@@ -16,6 +17,11 @@ type Client struct {
 	Phone      string    `json:"phone"`
 	Email      string    `json:"email"`
 	Info       string    `json:"info"`
+	// Tentative is true for the client created by an online booking, whom the staff has yet to reconcile
+	// with the existing clients having the same phone or e-mail, e.g. by merging them.
+	Tentative bool `json:"tentative"`
+	// Contacts are the additional phones, e-mails and messengers of the client.
+	Contacts []*ClientContact `json:"contacts" gorm:"foreignKey:ClientID"`
 }
 
 // TableName returns the table name of client struct and it is used by gorm.
@@ -34,7 +40,7 @@ func (m *Client) Exist(rep repository.Repository, id uint) (bool, error) {
 // Get returns client full matched given client ID.
 func (m *Client) Get(rep repository.Repository, id uint) (*Client, error) {
 	client := &Client{}
-	if err := rep.First(client, id).Error; err != nil {
+	if err := rep.Scopes(preloadClientContacts).First(client, id).Error; err != nil {
 		return nil, err
 	}
 	return client, nil
@@ -43,7 +49,7 @@ func (m *Client) Get(rep repository.Repository, id uint) (*Client, error) {
 // GetAll returns a slice of all clients.
 func (m *Client) GetAll(rep repository.Repository) ([]*Client, error) {
 	var clients []*Client
-	if err := rep.Find(&clients).Error; err != nil {
+	if err := rep.Scopes(preloadClientContacts).Find(&clients).Error; err != nil {
 		return nil, err
 	}
 	return clients, nil
@@ -52,8 +58,11 @@ func (m *Client) GetAll(rep repository.Repository) ([]*Client, error) {
 // Create persists this client data.
 func (m *Client) Create(rep repository.Repository) (*Client, error) {
 	if err := rep.Transaction(func(tx repository.Repository) error {
-		return tx.Select("surname", "name", "patronymic", "sex",
-			"birth_date", "phone", "email", "info").Create(m).Error
+		if err := tx.Select("surname", "name", "patronymic", "sex",
+			"birth_date", "phone", "email", "info").Create(m).Error; err != nil {
+			return err
+		}
+		return txSetClientContacts(tx, m.ID, m.Contacts)
 	}); err != nil {
 		return nil, err
 	}
@@ -67,9 +76,12 @@ func (m *Client) Update(rep repository.Repository, id uint) (*Client, error) {
 			return err
 		}
 
-		return tx.Model(&Client{}).Where("id = ?", id).
+		if err := tx.Model(&Client{}).Where("id = ?", id).
 			Select("surname", "name", "patronymic", "sex",
-				"birth_date", "phone", "email", "info").Updates(m).Error
+				"birth_date", "phone", "email", "info").Updates(m).Error; err != nil {
+			return err
+		}
+		return txSetClientContacts(tx, id, m.Contacts)
 	}); err != nil {
 		return nil, err
 	}
@@ -90,3 +102,70 @@ func (m *Client) Delete(rep repository.Repository, id uint) (*Client, error) {
 	}
 	return client, nil
 }
+
+// GetAllWithDeleted returns a slice of all clients including the soft-deleted ones.
+func (m *Client) GetAllWithDeleted(rep repository.Repository) ([]*Client, error) {
+	var clients []*Client
+	if err := rep.Unscoped().Scopes(preloadClientContacts).Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// Restore restores this soft-deleted client data.
+func (m *Client) Restore(rep repository.Repository, id uint) (*Client, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		return txRestore(tx, &Client{}, id)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Purge permanently deletes this client data, including the soft-deleted one.
+// It fails if pets or visits, including the soft-deleted ones, still refer to the client.
+func (m *Client) Purge(rep repository.Repository, id uint) (*Client, error) {
+	client := &Client{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := tx.Unscoped().First(client, id).Error; err != nil {
+			return err
+		}
+		return txPurgeClient(tx, id)
+	}); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// PurgeDeletedBefore permanently deletes the clients soft-deleted before the given time, except the ones still referred to.
+func (m *Client) PurgeDeletedBefore(rep repository.Repository, before time.Time) (int64, error) {
+	return purgeDeletedBefore(rep, &Client{}, before, txPurgeClient)
+}
+
+func txPurgeClient(tx repository.Repository, id uint) error {
+	if err := txCheckNotReferenced(tx, id, reference{&Pet{}, "client_id"}, reference{&Visit{}, "client_id"},
+		reference{&Invoice{}, "client_id"}, reference{&BalanceEntry{}, "client_id"},
+		reference{&WaitlistEntry{}, "client_id"}); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("client_id = ?", id).Delete(&ClientAccount{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("client_id = ?", id).Delete(&ClientContact{}).Error; err != nil {
+		return err
+	}
+	// The leads are kept, they are no longer linked to the client.
+	if err := tx.Unscoped().Model(&Lead{}).Where("client_id = ?", id).Update("client_id", nil).Error; err != nil {
+		return err
+	}
+	// The pets of the client have already been checked, so only the roles for the pets of other clients remain.
+	if err := tx.Unscoped().Where("client_id = ?", id).Delete(&PetOwner{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Client{}, id).Error
+}
+
+// preloadClientContacts loads the contacts of the clients.
+func preloadClientContacts(db *gorm.DB) *gorm.DB {
+	return db.Preload("Contacts", func(db *gorm.DB) *gorm.DB { return db.Order("id") })
+}
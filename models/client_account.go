@@ -0,0 +1,183 @@
+package models
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/config"
+	"vet-clinic/repository"
+)
+
+// ErrInvalidOneTimeCode is returned if the one-time code is wrong, expired or has been used.
+var ErrInvalidOneTimeCode = errors.New("one-time code is invalid or expired")
+
+// ClientAccount defines struct of the portal account of a client.
+// The client logs in to the portal with the phone or the e-mail of the client data.
+type ClientAccount struct {
+	*BaseModel
+	ClientID             uint      `json:"clientId" gorm:"uniqueIndex"`
+	Client               *Client   `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Password             string    `json:"-"`
+	Active               bool      `json:"active"`
+	OneTimeCode          string    `json:"-"`
+	OneTimeCodeExpiresAt time.Time `json:"-"`
+	OneTimeCodeAttempts  int       `json:"-"`
+}
+
+// TableName returns the table name of client account struct and it is used by gorm.
+func (*ClientAccount) TableName() string {
+	return "client_account"
+}
+
+// NewClientAccount is constructor.
+func NewClientAccount(clientID uint, password string) *ClientAccount {
+	return &ClientAccount{ClientID: clientID, Password: password, Active: true}
+}
+
+// Get returns client account full matched given account ID.
+func (m *ClientAccount) Get(rep repository.Repository, id uint) (*ClientAccount, error) {
+	account := &ClientAccount{}
+	if err := rep.Preload("Client").First(account, id).Error; err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetByClient returns the account of the given client.
+func (m *ClientAccount) GetByClient(rep repository.Repository, clientID uint) (*ClientAccount, error) {
+	account := &ClientAccount{}
+	if err := rep.Preload("Client").Where("client_id = ?", clientID).First(account).Error; err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Create persists this client account data.
+// The password may be empty, then the client can log in only with one-time codes.
+func (m *ClientAccount) Create(rep repository.Repository) (*ClientAccount, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		client := &Client{}
+		if _, err := client.Exist(tx, m.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+
+		if m.Password != "" {
+			hashed, err := bcrypt.GenerateFromPassword([]byte(m.Password), config.PasswordHashCost)
+			if err != nil {
+				return err
+			}
+			m.Password = string(hashed)
+		}
+		return tx.Select("client_id", "password", "active").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// DeleteByClient deletes the account of the given client.
+func (m *ClientAccount) DeleteByClient(rep repository.Repository, clientID uint) (*ClientAccount, error) {
+	account := &ClientAccount{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if account, err = m.GetByClient(tx, clientID); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&ClientAccount{}, account.ID).Error
+	}); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// FindByLogin returns the active account of the client with the given phone or e-mail.
+func (m *ClientAccount) FindByLogin(rep repository.Repository, login string) (*ClientAccount, error) {
+	account := &ClientAccount{}
+	if err := rep.Preload("Client").
+		Joins("JOIN client_master ON client_master.id = client_account.client_id AND client_master.deleted_at IS NULL").
+		Where("client_master.phone = ? OR client_master.email = ?", login, login).
+		Where("client_account.active = ?", true).
+		First(account).Error; err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Login finds the client account by using phone or e-mail and checks the password.
+func (m *ClientAccount) Login(rep repository.Repository, login, password string) (*ClientAccount, error) {
+	account, err := m.FindByLogin(rep, login)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(password)); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// SetOneTimeCode replaces the one-time code of the account, the previous code becomes invalid.
+func (m *ClientAccount) SetOneTimeCode(rep repository.Repository, id uint, code string, expiresAt time.Time) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(code), config.PasswordHashCost)
+	if err != nil {
+		return err
+	}
+	return rep.Model(&ClientAccount{}).Where("id = ?", id).
+		Select("one_time_code", "one_time_code_expires_at", "one_time_code_attempts").
+		Updates(&ClientAccount{OneTimeCode: string(hashed), OneTimeCodeExpiresAt: expiresAt}).Error
+}
+
+// LoginWithCode finds the client account by using phone or e-mail and checks the one-time code.
+// The code can be used once, and it is discarded after the given number of failed attempts.
+// Each attempt is counted by a conditional update before the code is checked, which also locks the account,
+// so parallel attempts cannot exceed the limit.
+func (m *ClientAccount) LoginWithCode(rep repository.Repository, login, code string, now time.Time, maxAttempts int) (*ClientAccount, error) {
+	account := &ClientAccount{}
+	var codeErr error
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if account, err = m.FindByLogin(tx, login); err != nil {
+			return err
+		}
+		result := tx.Model(&ClientAccount{}).
+			Where("id = ? AND one_time_code <> ? AND one_time_code_attempts < ?", account.ID, "", maxAttempts).
+			Update("one_time_code_attempts", gorm.Expr("one_time_code_attempts + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			codeErr = ErrInvalidOneTimeCode
+			return nil
+		}
+		current := &ClientAccount{}
+		if err := tx.First(current, account.ID).Error; err != nil {
+			return err
+		}
+		if now.After(current.OneTimeCodeExpiresAt) {
+			codeErr = ErrInvalidOneTimeCode
+			return nil
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(current.OneTimeCode), []byte(code)); err != nil {
+			codeErr = ErrInvalidOneTimeCode
+			if current.OneTimeCodeAttempts >= maxAttempts {
+				return txClearOneTimeCode(tx, account.ID)
+			}
+			return nil
+		}
+		return txClearOneTimeCode(tx, account.ID)
+	}); err != nil {
+		return nil, err
+	}
+	if codeErr != nil {
+		return nil, codeErr
+	}
+	return account, nil
+}
+
+func txClearOneTimeCode(tx repository.Repository, id uint) error {
+	return tx.Model(&ClientAccount{}).Where("id = ?", id).
+		Select("one_time_code", "one_time_code_attempts").
+		Updates(&ClientAccount{}).Error
+}
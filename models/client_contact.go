@@ -0,0 +1,46 @@
+package models
+
+import (
+	"vet-clinic/repository"
+)
+
+const (
+	// ContactPhone is the type of a phone number.
+	ContactPhone = "phone"
+	// ContactEmail is the type of an e-mail.
+	ContactEmail = "email"
+	// ContactMessenger is the type of a messenger account.
+	ContactMessenger = "messenger"
+)
+
+// ClientContact defines an additional contact of a client.
+// The main phone and e-mail of the client are kept in Client.
+type ClientContact struct {
+	*BaseModel
+	ClientID uint   `json:"clientId" gorm:"index"`
+	Type     string `json:"type" example:"phone"` // phone, email, messenger
+	Value    string `json:"value" gorm:"size:255" example:"+79876543210"`
+	Label    string `json:"label" gorm:"size:255" example:"work"`
+}
+
+// TableName returns the table name of client contact struct and it is used by gorm.
+func (*ClientContact) TableName() string {
+	return "client_contact"
+}
+
+// txSetClientContacts replaces the contacts of the client with the given ones, nil keeps the current contacts.
+func txSetClientContacts(tx repository.Repository, clientID uint, contacts []*ClientContact) error {
+	if contacts == nil {
+		return nil
+	}
+	if err := tx.Unscoped().Where("client_id = ?", clientID).Delete(&ClientContact{}).Error; err != nil {
+		return err
+	}
+	for _, contact := range contacts {
+		row := &ClientContact{ClientID: clientID, Type: contact.Type, Value: contact.Value, Label: contact.Label}
+		if err := tx.Select("client_id", "type", "value", "label").Create(row).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
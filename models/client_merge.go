@@ -0,0 +1,244 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+	"vet-clinic/util"
+)
+
+const (
+	// DuplicatePhone means the clients have the same phone number, ignoring the formatting.
+	DuplicatePhone = "phone"
+	// DuplicateEmail means the clients have the same e-mail, ignoring the case.
+	DuplicateEmail = "email"
+	// DuplicateName means the full names of the clients differ by at most one typo in each part.
+	DuplicateName = "name"
+)
+
+// DuplicateClient is a client which is likely the same person as another client.
+type DuplicateClient struct {
+	Client  *Client  `json:"client"`
+	Reasons []string `json:"reasons" example:"phone,name"` // What the clients have in common: phone, email or name.
+}
+
+// ClientMerge is the audit record of a client merged into another client.
+// It keeps the IDs without the foreign keys, so that it outlives the purge of the clients.
+type ClientMerge struct {
+	*BaseModel
+	TargetID   uint   `json:"targetId" gorm:"index"` // The surviving client.
+	SourceID   uint   `json:"sourceId" gorm:"index"` // The merged client, which is soft-deleted.
+	MergedByID uint   `json:"mergedById"`
+	Source     string `json:"source"`   // The data of the merged client as JSON.
+	PetIDs     string `json:"petIds"`   // Comma-separated IDs of the moved pets.
+	VisitIDs   string `json:"visitIds"` // Comma-separated IDs of the moved visits.
+	// AccountMoved is true if the portal account of the merged client has been moved to the surviving client.
+	AccountMoved bool `json:"accountMoved"`
+}
+
+// TableName returns the table name of client merge struct and it is used by gorm.
+func (*ClientMerge) TableName() string {
+	return "client_merge"
+}
+
+// FindDuplicates returns the other clients which likely are the same person as the client with the given ID.
+func (m *Client) FindDuplicates(rep repository.Repository, id uint) ([]*DuplicateClient, error) {
+	client, err := m.Get(rep, id)
+	if err != nil {
+		return nil, err
+	}
+	var clients []*Client
+	if err := rep.Where("id <> ?", id).Order("id").Find(&clients).Error; err != nil {
+		return nil, err
+	}
+
+	duplicates := []*DuplicateClient{}
+	for _, other := range clients {
+		if reasons := duplicateReasons(client, other); len(reasons) > 0 {
+			duplicates = append(duplicates, &DuplicateClient{Client: other, Reasons: reasons})
+		}
+	}
+	return duplicates, nil
+}
+
+// duplicateReasons returns what the given clients have in common.
+func duplicateReasons(a, b *Client) []string {
+	var reasons []string
+	if phone := util.NormalizePhone(a.Phone); phone != "" && phone == util.NormalizePhone(b.Phone) {
+		reasons = append(reasons, DuplicatePhone)
+	}
+	if email := strings.ToLower(strings.TrimSpace(a.Email)); email != "" && email == strings.ToLower(strings.TrimSpace(b.Email)) {
+		reasons = append(reasons, DuplicateEmail)
+	}
+	if similarNames(a, b) {
+		reasons = append(reasons, DuplicateName)
+	}
+	return reasons
+}
+
+// similarNames returns true if the surnames and the names differ by at most one typo,
+// and so do the patronymics unless either of them is unknown.
+func similarNames(a, b *Client) bool {
+	similar := func(x, y string) bool {
+		return util.Levenshtein(util.NormalizeName(x), util.NormalizeName(y)) <= 1
+	}
+	if strings.TrimSpace(a.Surname) == "" || strings.TrimSpace(a.Name) == "" ||
+		strings.TrimSpace(b.Surname) == "" || strings.TrimSpace(b.Name) == "" {
+		return false
+	}
+	if !similar(a.Surname, b.Surname) || !similar(a.Name, b.Name) {
+		return false
+	}
+	return strings.TrimSpace(a.Patronymic) == "" || strings.TrimSpace(b.Patronymic) == "" ||
+		similar(a.Patronymic, b.Patronymic)
+}
+
+// Merge merges the source client into the target client in one transaction. The pets, the visits, the roles for
+// the pets, the contacts, the invoices, the balance ledger, the waitlist entries, the leads and the portal account
+// of the source client, including the soft-deleted ones, are moved to the target client, the empty fields
+// of the target client are filled in from the source client and the source client is soft-deleted.
+// If both clients have portal accounts, the account of the target client is kept.
+func (m *Client) Merge(rep repository.Repository, targetID, sourceID, mergedByID uint) (*ClientMerge, error) {
+	if targetID == sourceID {
+		return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, "a client cannot be merged into itself")
+	}
+
+	merge := &ClientMerge{TargetID: targetID, SourceID: sourceID, MergedByID: mergedByID}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		target, err := m.Get(tx, targetID)
+		if err != nil {
+			return err
+		}
+		source, err := m.Get(tx, sourceID)
+		if err != nil {
+			return apperror.NewInvalidReference("sourceId", err)
+		}
+		snapshot, err := json.Marshal(source)
+		if err != nil {
+			return err
+		}
+		merge.Source = string(snapshot)
+
+		var petIDs, visitIDs []uint
+		if err := tx.Unscoped().Model(&Pet{}).Where("client_id = ?", sourceID).Order("id").Pluck("id", &petIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Pet{}).Where("client_id = ?", sourceID).Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Visit{}).Where("client_id = ?", sourceID).Order("id").Pluck("id", &visitIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Visit{}).Where("client_id = ?", sourceID).Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		merge.PetIDs = joinIDs(petIDs)
+		merge.VisitIDs = joinIDs(visitIDs)
+
+		if err := txMovePetOwners(tx, targetID, sourceID); err != nil {
+			return err
+		}
+		if len(petIDs) > 0 {
+			if err := tx.Model(&PetOwner{}).Where("client_id = ? AND pet_id IN ?", targetID, petIDs).
+				Update("role", PetOwnerPrimary).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Model(&ClientContact{}).Where("client_id = ?", sourceID).
+			Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Invoice{}).Where("client_id = ?", sourceID).
+			Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&BalanceEntry{}).Where("client_id = ?", sourceID).
+			Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&WaitlistEntry{}).Where("client_id = ?", sourceID).
+			Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Lead{}).Where("client_id = ?", sourceID).
+			Update("client_id", targetID).Error; err != nil {
+			return err
+		}
+
+		if merge.AccountMoved, err = txMergeClientAccount(tx, targetID, sourceID); err != nil {
+			return err
+		}
+
+		if columns := fillEmptyFields(target, source); len(columns) > 0 {
+			if err := tx.Model(&Client{}).Where("id = ?", targetID).Select(columns).Updates(target).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&Client{}, sourceID).Error; err != nil {
+			return err
+		}
+		return tx.Select("target_id", "source_id", "merged_by_id", "source", "pet_ids", "visit_ids", "account_moved").
+			Create(merge).Error
+	}); err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// txMergeClientAccount moves the portal account of the source client to the target client unless the target client
+// has one, in which case the account of the source client is deleted. It returns true if the account is moved.
+func txMergeClientAccount(tx repository.Repository, targetID, sourceID uint) (bool, error) {
+	var count int64
+	if err := tx.Unscoped().Model(&ClientAccount{}).Where("client_id = ?", targetID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, tx.Unscoped().Where("client_id = ?", sourceID).Delete(&ClientAccount{}).Error
+	}
+	result := tx.Unscoped().Model(&ClientAccount{}).Where("client_id = ?", sourceID).Update("client_id", targetID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// fillEmptyFields copies the fields of the source client to the empty fields of the target client.
+// It returns the columns of the copied fields.
+func fillEmptyFields(target, source *Client) []string {
+	var columns []string
+	fill := func(column string, to *string, from string) {
+		if *to == "" && from != "" {
+			*to = from
+			columns = append(columns, column)
+		}
+	}
+	fill("surname", &target.Surname, source.Surname)
+	fill("name", &target.Name, source.Name)
+	fill("patronymic", &target.Patronymic, source.Patronymic)
+	fill("sex", &target.Sex, source.Sex)
+	fill("phone", &target.Phone, source.Phone)
+	fill("email", &target.Email, source.Email)
+	fill("info", &target.Info, source.Info)
+	if target.BirthDate.IsZero() && !source.BirthDate.IsZero() {
+		target.BirthDate = source.BirthDate
+		columns = append(columns, "birth_date")
+	}
+	return columns
+}
+
+func joinIDs(ids []uint) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(values, ",")
+}
+
+// GetAllByClient returns the merges into and from the client with the given ID.
+func (m *ClientMerge) GetAllByClient(rep repository.Repository, clientID uint) ([]*ClientMerge, error) {
+	var merges []*ClientMerge
+	if err := rep.Where("target_id = ? OR source_id = ?", clientID, clientID).Order("id").Find(&merges).Error; err != nil {
+		return nil, err
+	}
+	return merges, nil
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"vet-clinic/repository"
+)
+
+// DiscountTier defines struct of a tier of the loyalty programme.
+// The client gets the discount of the highest tier whose threshold the client has spent.
+type DiscountTier struct {
+	*BaseModel
+	Name     string  `json:"name" gorm:"unique;not null;size:255" example:"Серебряный"`
+	Percent  float64 `json:"percent" example:"5"`      // The discount in percent.
+	MinSpent float64 `json:"minSpent" example:"20000"` // The total of the invoices the client needs to reach the tier.
+}
+
+// ClientDiscount defines struct of the loyalty programme status of a client.
+type ClientDiscount struct {
+	Spent float64       `json:"spent"` // The total of the invoices of the client excluding the deposits.
+	Tier  *DiscountTier `json:"tier"`  // The tier reached by the client, null if none.
+}
+
+// TableName returns the table name of discount tier struct and it is used by gorm.
+func (*DiscountTier) TableName() string {
+	return "discount_tier"
+}
+
+// Exist returns true if a given discount tier exits.
+func (m *DiscountTier) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&DiscountTier{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns discount tier full matched given discount tier ID.
+func (m *DiscountTier) Get(rep repository.Repository, id uint) (*DiscountTier, error) {
+	tier := &DiscountTier{}
+	if err := rep.First(tier, id).Error; err != nil {
+		return nil, err
+	}
+	return tier, nil
+}
+
+// GetAll returns a slice of all discount tiers ordered by the threshold.
+func (m *DiscountTier) GetAll(rep repository.Repository) ([]*DiscountTier, error) {
+	var tiers []*DiscountTier
+	if err := rep.Model(&DiscountTier{}).Order("min_spent").Find(&tiers).Error; err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// GetForClient returns the loyalty programme status of the client.
+func (m *DiscountTier) GetForClient(rep repository.Repository, clientID uint) (*ClientDiscount, error) {
+	if _, err := (&Client{}).Exist(rep, clientID); err != nil {
+		return nil, err
+	}
+	return txGetClientDiscount(rep, clientID)
+}
+
+// Create persists this discount tier data.
+func (m *DiscountTier) Create(rep repository.Repository) (*DiscountTier, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		return tx.Select("name", "percent", "min_spent").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this discount tier data.
+func (m *DiscountTier) Update(rep repository.Repository, id uint) (*DiscountTier, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+
+		return tx.Model(&DiscountTier{}).Where("id = ?", id).
+			Select("name", "percent", "min_spent").Updates(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this discount tier data.
+// It fails if invoices, including the soft-deleted ones, still refer to the tier.
+func (m *DiscountTier) Delete(rep repository.Repository, id uint) (*DiscountTier, error) {
+	tier := &DiscountTier{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if tier, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&Invoice{}, "discount_tier_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&DiscountTier{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return tier, nil
+}
+
+// txGetClientDiscount returns the total spent by the client and the tier reached by the client.
+func txGetClientDiscount(tx repository.Repository, clientID uint) (*ClientDiscount, error) {
+	discount := &ClientDiscount{}
+	if err := tx.Model(&Invoice{}).Where("client_id = ? AND deposit = ?", clientID, false).
+		Select("COALESCE(SUM(subtotal - discount), 0)").Scan(&discount.Spent).Error; err != nil {
+		return nil, err
+	}
+	var tiers []*DiscountTier
+	if err := tx.Where("min_spent <= ?", discount.Spent).Order("min_spent DESC").Limit(1).
+		Find(&tiers).Error; err != nil {
+		return nil, err
+	}
+	if len(tiers) > 0 {
+		discount.Tier = tiers[0]
+	}
+	return discount, nil
+}
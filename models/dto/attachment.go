@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"vet-clinic/models"
+)
+
+// AttachmentDto defines a data transfer object for a file uploaded as multipart form data.
+// The file itself is the "file" part of the form.
+type AttachmentDto struct {
+	Description string `form:"description" validate:"omitempty,ruprintascii,max=1000"`
+	FileName    string `form:"-" validate:"required,max=255"` // The name of the uploaded file.
+	Size        int64  `form:"-"`                             // The size of the uploaded file declared by the client.
+}
+
+// ToModel creates models.Attachment from this DTO.
+func (d *AttachmentDto) ToModel() *models.Attachment {
+	return &models.Attachment{
+		FileName:    d.FileName,
+		Description: d.Description,
+	}
+}
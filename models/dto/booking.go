@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+	"vet-clinic/models"
+)
+
+// BookingDto defines a data transfer object for the visit booked online.
+type BookingDto struct {
+	DoctorID  uint      `json:"doctorId" validate:"required"`
+	ServiceID uint      `json:"serviceId" validate:"required"`
+	DateTime  time.Time `json:"dateTime" validate:"required" format:"date-time"`                  // Start of a free slot.
+	Name      string    `json:"name" validate:"required,rualpha,max=255"`                         // Alphabetic characters only (Russian and English).
+	Phone     string    `json:"phone" validate:"required,e164" example:"+79876543210"`            // E.164 phone number string.
+	Email     string    `json:"email" validate:"omitempty,email" example:"mail@mail.com"`         // E-mail string.
+	PetName   string    `json:"petName" validate:"required,ruprintascii,max=255" example:"Китти"` // Allowed characters: printable ASCII (Russian and English).
+	Comment   string    `json:"comment" validate:"ruprintascii"`                                  // Allowed characters: printable ASCII (Russian and English).
+}
+
+// ToModel creates models.Visit and models.Client of the booking from this DTO.
+func (d *BookingDto) ToModel() (*models.Visit, *models.Client) {
+	visit := &models.Visit{
+		DateTime:  d.DateTime,
+		Info:      d.Comment,
+		DoctorID:  d.DoctorID,
+		ServiceID: d.ServiceID,
+	}
+	client := &models.Client{
+		Name:  d.Name,
+		Phone: d.Phone,
+		Email: d.Email,
+	}
+	return visit, client
+}
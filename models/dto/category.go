@@ -6,10 +6,13 @@ import (
 
 // CategoryDto defines a data transfer object for category.
 type CategoryDto struct {
-	Name string `json:"name" validate:"required,ruprintascii"` // Allowed characters: printable ASCII (Russian and English).
+	Name     string `json:"name" validate:"required,ruprintascii"` // Allowed characters: printable ASCII (Russian and English).
+	ParentID uint   `json:"parentId"`                              // The parent category, 0 for a top-level category.
 }
 
 // ToModel creates models.Category from this DTO.
 func (d *CategoryDto) ToModel() *models.Category {
-	return models.NewCategory(d.Name)
+	category := models.NewCategory(d.Name)
+	category.ParentID = d.ParentID
+	return category
 }
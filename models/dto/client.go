@@ -15,6 +15,38 @@ type ClientDto struct {
 	Phone      string    `json:"phone" validate:"e164" example:"+79876543210"`    // E.164 phone number string.
 	Email      string    `json:"email" validate:"email" example:"mail@mail.com"`  // E-mail string.
 	Info       string    `json:"info" validate:"ruprintascii"`                    // Allowed characters: printable ASCII (Russian and English).
+	// Contacts are the additional contacts, which replace the current ones. If it is absent, they are kept.
+	Contacts []*ClientContactDto `json:"contacts" validate:"omitempty,dive"`
+}
+
+// ClientContactDto defines a data transfer object for an additional contact of a client.
+type ClientContactDto struct {
+	Type  string `json:"type" validate:"oneof=phone email messenger" example:"phone"`
+	Value string `json:"value" validate:"required,max=255,ruprintascii" example:"+79876543210"`
+	Label string `json:"label" validate:"omitempty,max=255,ruprintascii" example:"work"`
+}
+
+// NewClientDto creates ClientDto from the existing client.
+func NewClientDto(client *models.Client) *ClientDto {
+	return &ClientDto{
+		Surname:    client.Surname,
+		Name:       client.Name,
+		Patronymic: client.Patronymic,
+		Sex:        client.Sex,
+		BirthDate:  client.BirthDate,
+		Phone:      client.Phone,
+		Email:      client.Email,
+		Info:       client.Info,
+		Contacts:   newClientContactDtos(client.Contacts),
+	}
+}
+
+func newClientContactDtos(contacts []*models.ClientContact) []*ClientContactDto {
+	dtos := []*ClientContactDto{}
+	for _, contact := range contacts {
+		dtos = append(dtos, &ClientContactDto{Type: contact.Type, Value: contact.Value, Label: contact.Label})
+	}
+	return dtos
 }
 
 // ToModel creates models.Client from this DTO.
@@ -28,5 +60,22 @@ func (d *ClientDto) ToModel() *models.Client {
 		Phone:      d.Phone,
 		Email:      d.Email,
 		Info:       d.Info,
+		Contacts:   d.contactsToModel(),
+	}
+}
+
+func (d *ClientDto) contactsToModel() []*models.ClientContact {
+	if d.Contacts == nil {
+		return nil
 	}
+	contacts := []*models.ClientContact{}
+	for _, contact := range d.Contacts {
+		contacts = append(contacts, &models.ClientContact{Type: contact.Type, Value: contact.Value, Label: contact.Label})
+	}
+	return contacts
+}
+
+// ClientMergeDto defines a data transfer object for merging a duplicate client into another client.
+type ClientMergeDto struct {
+	SourceID uint `json:"sourceId" validate:"required"` // ID of the duplicate client which is merged and soft-deleted.
 }
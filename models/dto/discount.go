@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"strings"
+	"time"
+	"vet-clinic/models"
+)
+
+// DiscountTierDto defines a data transfer object for the tier of the loyalty programme.
+type DiscountTierDto struct {
+	Name     string  `json:"name" validate:"required,ruprintascii,max=255" example:"Серебряный"` // Allowed characters: printable ASCII (Russian and English).
+	Percent  float64 `json:"percent" validate:"gte=0,lte=100" example:"5"`
+	MinSpent float64 `json:"minSpent" validate:"gte=0" example:"20000"` // The total of the invoices the client needs to reach the tier.
+}
+
+// ToModel creates models.DiscountTier from this DTO.
+func (d *DiscountTierDto) ToModel() *models.DiscountTier {
+	return &models.DiscountTier{
+		Name:     d.Name,
+		Percent:  d.Percent,
+		MinSpent: d.MinSpent,
+	}
+}
+
+// PromoCodeDto defines a data transfer object for the promo code.
+type PromoCodeDto struct {
+	Code       string    `json:"code" validate:"required,alphanum,max=50" example:"SPRING10"` // Latin letters and digits, stored in upper case.
+	Percent    float64   `json:"percent" validate:"gt=0,lte=100" example:"10"`
+	ValidFrom  time.Time `json:"validFrom" validate:"required" format:"date"`
+	ValidTo    time.Time `json:"validTo" validate:"required,gtefield=ValidFrom" format:"date"` // The last day of the validity.
+	UsageLimit int       `json:"usageLimit" validate:"gte=0" example:"100"`                    // 0 if unlimited.
+}
+
+// ToModel creates models.PromoCode from this DTO.
+func (d *PromoCodeDto) ToModel() *models.PromoCode {
+	return &models.PromoCode{
+		Code:       strings.ToUpper(d.Code),
+		Percent:    d.Percent,
+		ValidFrom:  d.ValidFrom,
+		ValidTo:    d.ValidTo,
+		UsageLimit: d.UsageLimit,
+	}
+}
+
+// BalanceEntryDto defines a data transfer object for the top-up or the refund of the balance of the client.
+type BalanceEntryDto struct {
+	Amount float64 `json:"amount" validate:"gt=0" example:"5000"`
+	Note   string  `json:"note" validate:"omitempty,ruprintascii,max=1000"` // Allowed characters: printable ASCII (Russian and English).
+}
+
+// ToModel creates models.BalanceEntry from this DTO.
+func (d *BalanceEntryDto) ToModel() *models.BalanceEntry {
+	return &models.BalanceEntry{
+		Amount: d.Amount,
+		Note:   d.Note,
+	}
+}
+
+// InvoiceDto defines a data transfer object for issuing the invoice of the visit.
+type InvoiceDto struct {
+	PromoCode  string `json:"promoCode" validate:"omitempty,alphanum,max=50" example:"SPRING10"`
+	UseBalance bool   `json:"useBalance"` // Pay the invoice from the balance of the client as far as it suffices.
+}
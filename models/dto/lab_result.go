@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"vet-clinic/models"
+)
+
+// LabResultDto defines a data transfer object for lab result.
+type LabResultDto struct {
+	VisitID      uint     `json:"visitId" validate:"required"` // The test was done for the pet of the visit.
+	Test         string   `json:"test" validate:"required,ruprintascii,max=255" example:"Гемоглобин"`
+	Value        float64  `json:"value" example:"132"`
+	Unit         string   `json:"unit" validate:"omitempty,ruprintascii,max=50" example:"г/л"`
+	ReferenceMin *float64 `json:"referenceMin" example:"80"`  // Omit if the range has no lower limit.
+	ReferenceMax *float64 `json:"referenceMax" example:"150"` // Omit if the range has no upper limit.
+	Note         string   `json:"note" validate:"omitempty,ruprintascii,max=1000"`
+}
+
+// ToModel creates models.LabResult from this DTO.
+func (d *LabResultDto) ToModel() *models.LabResult {
+	return &models.LabResult{
+		VisitID:      d.VisitID,
+		Test:         d.Test,
+		Value:        d.Value,
+		Unit:         d.Unit,
+		ReferenceMin: d.ReferenceMin,
+		ReferenceMax: d.ReferenceMax,
+		Note:         d.Note,
+	}
+}
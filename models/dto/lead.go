@@ -13,9 +13,25 @@ type LeadDto struct {
 	Type            string `json:"type" validate:"ruprintascii"`                             // Allowed characters: printable ASCII (Russian and English).
 	Status          string `json:"status" validate:"ruprintascii"`                           // Allowed characters: printable ASCII (Russian and English).
 	DoctorID        uint   `json:"doctorId"`
+	ClientID        uint   `json:"clientId"` // The client the lead came from, 0 if the lead is not linked to a client.
 	LastUpdatedByID uint   `json:"-"`
 }
 
+// NewLeadDto creates LeadDto from the existing lead.
+func NewLeadDto(lead *models.Lead) *LeadDto {
+	return &LeadDto{
+		Name:            lead.Name,
+		Phone:           lead.Phone,
+		Email:           lead.Email,
+		Comment:         lead.Comment,
+		Type:            lead.Type,
+		Status:          lead.Status,
+		DoctorID:        lead.DoctorID,
+		ClientID:        lead.ClientID,
+		LastUpdatedByID: lead.LastUpdatedByID,
+	}
+}
+
 // ToModel creates models.Lead from this DTO.
 func (d *LeadDto) ToModel() *models.Lead {
 	return &models.Lead{
@@ -26,6 +42,34 @@ func (d *LeadDto) ToModel() *models.Lead {
 		Type:            d.Type,
 		Status:          d.Status,
 		DoctorID:        d.DoctorID,
+		ClientID:        d.ClientID,
 		LastUpdatedByID: d.LastUpdatedByID,
 	}
 }
+
+// LeadRequestDto defines a data transfer object for the lead submitted by the public form.
+// The lead is assigned automatically, so it carries no doctor.
+type LeadRequestDto struct {
+	Name    string `json:"name" validate:"omitempty,rualpha,max=255"`                // Alphabetic characters only (Russian and English).
+	Phone   string `json:"phone" validate:"omitempty,e164" example:"+79876543210"`   // E.164 phone number string.
+	Email   string `json:"email" validate:"omitempty,email" example:"mail@mail.com"` // E-mail string.
+	Comment string `json:"comment" validate:"ruprintascii"`                          // Allowed characters: printable ASCII (Russian and English).
+	Type    string `json:"type" validate:"ruprintascii"`                             // Allowed characters: printable ASCII (Russian and English).
+	// Website is a honeypot field hidden from humans, it must be left empty.
+	Website string `json:"website"`
+	// FormToken is the token issued by GET /leads/form when the form was shown to the user.
+	FormToken string `json:"formToken"`
+	// CaptchaToken is the response token of the captcha widget, it is required if the captcha is enabled.
+	CaptchaToken string `json:"captchaToken"`
+}
+
+// ToModel creates models.Lead from this DTO.
+func (d *LeadRequestDto) ToModel() *models.Lead {
+	return &models.Lead{
+		Name:    d.Name,
+		Phone:   d.Phone,
+		Email:   d.Email,
+		Comment: d.Comment,
+		Type:    d.Type,
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"vet-clinic/models"
+)
+
+// MedicationDto defines a data transfer object for medication.
+type MedicationDto struct {
+	Name     string  `json:"name" validate:"required,ruprintascii,max=255"`                    // Allowed characters: printable ASCII (Russian and English).
+	Form     string  `json:"form" validate:"required,ruprintascii,max=255" example:"таблетки"` // Allowed characters: printable ASCII (Russian and English).
+	Strength float64 `json:"strength" validate:"gte=0" example:"50"`
+	Unit     string  `json:"unit" validate:"omitempty,ruprintascii,max=50" example:"мг"` // The unit of the strength.
+}
+
+// ToModel creates models.Medication from this DTO.
+func (d *MedicationDto) ToModel() *models.Medication {
+	return &models.Medication{
+		Name:     d.Name,
+		Form:     d.Form,
+		Strength: d.Strength,
+		Unit:     d.Unit,
+	}
+}
@@ -1,27 +1,114 @@
 package dto
 
 import (
+	"time"
 	"vet-clinic/models"
 )
 
 // PetDto defines a data transfer object for pet.
 type PetDto struct {
-	Name     string `json:"name" validate:"omitempty,rualpha,max=255"` // Alphabetic characters only (Russian and English).
-	Type     string `json:"type" validate:"ruprintascii"`              // Allowed characters: printable ASCII (Russian and English).
-	Breed    string `json:"breed" validate:"ruprintascii"`             // Allowed characters: printable ASCII (Russian and English).
-	Colour   string `json:"colour" validate:"ruprintascii"`            // Allowed characters: printable ASCII (Russian and English).
-	Sex      string `json:"sex" validate:"omitempty,rualpha"`          // Alphabetic characters only (Russian and English).
-	ClientID uint   `json:"clientId"`
+	Name               string    `json:"name" validate:"omitempty,rualpha,max=255"` // Alphabetic characters only (Russian and English).
+	Type               string    `json:"type" validate:"ruprintascii"`              // The name or an alias of the species, if speciesId is not set.
+	Breed              string    `json:"breed" validate:"ruprintascii"`             // The name of the breed, if breedId is not set. Unknown breeds are flagged for review.
+	SpeciesID          uint      `json:"speciesId"`
+	BreedID            uint      `json:"breedId"`
+	Colour             string    `json:"colour" validate:"ruprintascii"`   // Allowed characters: printable ASCII (Russian and English).
+	Sex                string    `json:"sex" validate:"omitempty,rualpha"` // Alphabetic characters only (Russian and English).
+	ClientID           uint      `json:"clientId"`                         // The primary owner.
+	BirthDate          time.Time `json:"birthDate" format:"date"`          // Date only
+	BirthDateEstimated bool      `json:"birthDateEstimated"`               // The birth date is estimated from the age told by the owner.
+	Neutered           bool      `json:"neutered"`
+	Microchip          string    `json:"microchip" validate:"omitempty,rualphanum,max=255"`                              // The microchip or tattoo ID, unique among the pets.
+	Allergies          string    `json:"allergies" validate:"omitempty,ruprintascii,max=1000"`                           // Allowed characters: printable ASCII (Russian and English).
+	ChronicConditions  string    `json:"chronicConditions" validate:"omitempty,ruprintascii,max=1000"`                   // Allowed characters: printable ASCII (Russian and English).
+	Status             string    `json:"status" validate:"omitempty,oneof=active deceased transferred" example:"active"` // active by default
+	// Owners are the co-owners and the emergency contacts, which replace the current ones. If it is absent, they are kept.
+	Owners []*PetOwnerDto `json:"owners" validate:"omitempty,dive"`
+}
+
+// PetOwnerDto defines a data transfer object for the role of a client for a pet other than the primary owner.
+type PetOwnerDto struct {
+	ClientID uint   `json:"clientId" validate:"required"`
+	Role     string `json:"role" validate:"oneof=co-owner emergency" example:"co-owner"`
+}
+
+// NewPetDto creates PetDto from the existing pet.
+func NewPetDto(pet *models.Pet) *PetDto {
+	return &PetDto{
+		Name:               pet.Name,
+		Type:               pet.Type,
+		Breed:              pet.Breed,
+		Colour:             pet.Colour,
+		Sex:                pet.Sex,
+		SpeciesID:          pet.SpeciesID,
+		BreedID:            pet.BreedID,
+		ClientID:           pet.ClientID,
+		BirthDate:          pet.BirthDate,
+		BirthDateEstimated: pet.BirthDateEstimated,
+		Neutered:           pet.Neutered,
+		Microchip:          pet.Microchip,
+		Allergies:          pet.Allergies,
+		ChronicConditions:  pet.ChronicConditions,
+		Status:             pet.Status,
+		Owners:             newPetOwnerDtos(pet.Owners),
+	}
+}
+
+func newPetOwnerDtos(owners []*models.PetOwner) []*PetOwnerDto {
+	dtos := []*PetOwnerDto{}
+	for _, owner := range owners {
+		if owner.Role != models.PetOwnerPrimary {
+			dtos = append(dtos, &PetOwnerDto{ClientID: owner.ClientID, Role: owner.Role})
+		}
+	}
+	return dtos
 }
 
 // ToModel creates models.Pet from this DTO.
 func (d *PetDto) ToModel() *models.Pet {
 	return &models.Pet{
-		Name:     d.Name,
-		Type:     d.Type,
-		Breed:    d.Breed,
-		Colour:   d.Colour,
-		Sex:      d.Sex,
-		ClientID: d.ClientID,
+		Name:               d.Name,
+		Type:               d.Type,
+		Breed:              d.Breed,
+		Colour:             d.Colour,
+		Sex:                d.Sex,
+		SpeciesID:          d.SpeciesID,
+		BreedID:            d.BreedID,
+		ClientID:           d.ClientID,
+		BirthDate:          d.BirthDate,
+		BirthDateEstimated: d.BirthDateEstimated,
+		Neutered:           d.Neutered,
+		Microchip:          d.Microchip,
+		Allergies:          d.Allergies,
+		ChronicConditions:  d.ChronicConditions,
+		Status:             d.Status,
+		Owners:             d.ownersToModel(),
+	}
+}
+
+func (d *PetDto) ownersToModel() []*models.PetOwner {
+	if d.Owners == nil {
+		return nil
+	}
+	owners := []*models.PetOwner{}
+	for _, owner := range d.Owners {
+		owners = append(owners, &models.PetOwner{ClientID: owner.ClientID, Role: owner.Role})
+	}
+	return owners
+}
+
+// PetWeightDto defines a data transfer object for a weight measurement of a pet.
+type PetWeightDto struct {
+	Weight     float64   `json:"weight" validate:"required,gt=0,lte=2000" example:"4.5"` // kg
+	VisitID    uint      `json:"visitId"`                                                // The visit the pet was weighed at, 0 if it was weighed elsewhere.
+	MeasuredAt time.Time `json:"measuredAt"`                                             // The time of the visit or the current time by default.
+}
+
+// ToModel creates models.PetWeight from this DTO.
+func (d *PetWeightDto) ToModel() *models.PetWeight {
+	return &models.PetWeight{
+		Weight:     d.Weight,
+		VisitID:    d.VisitID,
+		MeasuredAt: d.MeasuredAt,
 	}
 }
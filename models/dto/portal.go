@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+	"vet-clinic/models"
+)
+
+// ClientAccountDto defines a data transfer object for creating the portal account of a client.
+type ClientAccountDto struct {
+	// Password is optional, without it the client can log in only with one-time codes.
+	// It must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.
+	Password string `json:"password" validate:"omitempty,min=8,max=72,password" example:"8p6R*R{3"`
+}
+
+// ToModel creates models.ClientAccount of the given client from this DTO.
+func (d *ClientAccountDto) ToModel(clientID uint) *models.ClientAccount {
+	return models.NewClientAccount(clientID, d.Password)
+}
+
+// PortalLoginDto defines a data transfer object for the client login with the password.
+type PortalLoginDto struct {
+	// Login using the phone or the e-mail of the client.
+	Login string `json:"login" validate:"required,email|e164" example:"+79876543210"`
+	// Password must contain at least one uppercase letter, one lowercase letter, one digit, and one special symbol.
+	Password string `json:"password" validate:"required,min=8,max=72,password" example:"8p6R*R{3"`
+}
+
+// PortalCodeRequestDto defines a data transfer object for requesting a one-time login code.
+type PortalCodeRequestDto struct {
+	// Login using the phone or the e-mail of the client.
+	Login string `json:"login" validate:"required,email|e164" example:"+79876543210"`
+}
+
+// PortalCodeLoginDto defines a data transfer object for the client login with a one-time code.
+type PortalCodeLoginDto struct {
+	// Login using the phone or the e-mail of the client.
+	Login string `json:"login" validate:"required,email|e164" example:"+79876543210"`
+	Code  string `json:"code" validate:"required,numeric,len=6" example:"123456"` // The one-time code sent to the client.
+}
+
+// PortalVisitDto defines a data transfer object for the visit requested by the client.
+type PortalVisitDto struct {
+	DateTime  time.Time `json:"dateTime" validate:"required" format:"date-time"` // Desired date and time.
+	Info      string    `json:"info" validate:"ruprintascii"`                    // Allowed characters: printable ASCII (Russian and English).
+	PetID     uint      `json:"petId" validate:"required"`
+	ServiceID uint      `json:"serviceId" validate:"required"`
+}
+
+// ToModel creates models.Visit of the given client from this DTO.
+func (d *PortalVisitDto) ToModel(clientID uint) *models.Visit {
+	return &models.Visit{
+		DateTime:  d.DateTime,
+		Info:      d.Info,
+		ClientID:  clientID,
+		PetID:     d.PetID,
+		ServiceID: d.ServiceID,
+	}
+}
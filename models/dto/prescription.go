@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+	"vet-clinic/models"
+)
+
+// PrescriptionDto defines a data transfer object for prescription.
+type PrescriptionDto struct {
+	VisitID      uint      `json:"visitId" validate:"required"` // The medication is prescribed for the pet of the visit.
+	MedicationID uint      `json:"medicationId" validate:"required"`
+	Dosage       string    `json:"dosage" validate:"required,ruprintascii,max=255" example:"1 таблетка"`
+	Frequency    string    `json:"frequency" validate:"required,ruprintascii,max=255" example:"2 раза в день"`
+	DurationDays int       `json:"durationDays" validate:"gte=0,lte=3650"` // 0 if the medication is taken until the prescription is cancelled.
+	StartDate    time.Time `json:"startDate" format:"date"`                // The date of the visit by default.
+	Instructions string    `json:"instructions" validate:"omitempty,ruprintascii,max=1000"`
+}
+
+// ToModel creates models.Prescription from this DTO.
+func (d *PrescriptionDto) ToModel() *models.Prescription {
+	return &models.Prescription{
+		VisitID:      d.VisitID,
+		MedicationID: d.MedicationID,
+		Dosage:       d.Dosage,
+		Frequency:    d.Frequency,
+		DurationDays: d.DurationDays,
+		StartDate:    d.StartDate,
+		Instructions: d.Instructions,
+	}
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+	"vet-clinic/models"
+)
+
+// ProductDto defines a data transfer object for product.
+type ProductDto struct {
+	Name     string  `json:"name" validate:"required,ruprintascii,max=255" example:"Нобивак Tricat Trio"` // Allowed characters: printable ASCII (Russian and English).
+	Unit     string  `json:"unit" validate:"required,ruprintascii,max=50" example:"доза"`
+	Price    float64 `json:"price" validate:"gte=0"`                // The retail price of a unit, 0 if the product is not sold.
+	MinStock float64 `json:"minStock" validate:"gte=0" example:"5"` // The stock below which the product is reported as running low.
+}
+
+// ToModel creates models.Product from this DTO.
+func (d *ProductDto) ToModel() *models.Product {
+	return &models.Product{
+		Name:     d.Name,
+		Unit:     d.Unit,
+		Price:    d.Price,
+		MinStock: d.MinStock,
+	}
+}
+
+// ProductBatchDto defines a data transfer object for a batch of a product received in stock.
+type ProductBatchDto struct {
+	BatchNumber string    `json:"batchNumber" validate:"required,printascii,max=100" example:"A123B45"`
+	ExpiryDate  time.Time `json:"expiryDate" validate:"required" format:"date"`
+	Quantity    float64   `json:"quantity" validate:"gt=0" example:"20"`
+}
+
+// ToModel creates models.ProductBatch from this DTO.
+func (d *ProductBatchDto) ToModel() *models.ProductBatch {
+	return &models.ProductBatch{
+		BatchNumber: d.BatchNumber,
+		ExpiryDate:  d.ExpiryDate,
+		Quantity:    d.Quantity,
+	}
+}
+
+// WriteOffDto defines a data transfer object for writing off a quantity of a batch.
+type WriteOffDto struct {
+	Quantity float64 `json:"quantity" validate:"gt=0" example:"1"`
+	Note     string  `json:"note" validate:"omitempty,ruprintascii,max=1000" example:"Истёк срок годности"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"vet-clinic/models"
+)
+
+// ResourceDto defines a data transfer object for resource.
+type ResourceDto struct {
+	Name         string `json:"name" validate:"required,ruprintascii,max=255" example:"Кабинет 1"` // Allowed characters: printable ASCII (Russian and English).
+	Type         string `json:"type" validate:"required,oneof=room equipment" example:"room"`
+	DepartmentID uint   `json:"departmentId" validate:"required"`
+}
+
+// ToModel creates models.Resource from this DTO.
+func (d *ResourceDto) ToModel() *models.Resource {
+	return &models.Resource{
+		Name:         d.Name,
+		Type:         d.Type,
+		DepartmentID: d.DepartmentID,
+	}
+}
+
+// ServiceResourcesDto defines a data transfer object for the resources required by the service.
+type ServiceResourcesDto struct {
+	ResourceIDs []uint `json:"resourceIds" validate:"dive,required"` // An empty list removes all resources.
+}
+
+// WorkingHoursDto defines a data transfer object for the working hours of a doctor on a day of the week.
+type WorkingHoursDto struct {
+	Weekday int    `json:"weekday" validate:"min=0,max=6" example:"1"` // 0 is Sunday, 6 is Saturday.
+	Start   string `json:"start" validate:"required,clock" example:"09:00"`
+	End     string `json:"end" validate:"required,clock" example:"18:00"`
+}
+
+// WorkingScheduleDto defines a data transfer object for the working hours of a doctor.
+type WorkingScheduleDto struct {
+	Hours []*WorkingHoursDto `json:"hours" validate:"dive"` // An empty list restores the default hours of the clinic.
+}
+
+// ToModel creates models.WorkingHours slice from this DTO.
+func (d *WorkingScheduleDto) ToModel() []*models.WorkingHours {
+	hours := []*models.WorkingHours{}
+	for _, h := range d.Hours {
+		hours = append(hours, &models.WorkingHours{Weekday: h.Weekday, Start: h.Start, End: h.End})
+	}
+	return hours
+}
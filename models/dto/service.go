@@ -1,21 +1,68 @@
 package dto
 
 import (
+	"time"
 	"vet-clinic/models"
 )
 
 // ServiceDto defines a data transfer object for service.
 type ServiceDto struct {
-	Name       string  `json:"name" validate:"required,ruprintascii,max=255"` // Allowed characters: printable ASCII (Russian and English).
-	Price      float64 `json:"price" format:"float64"`
-	CategoryID uint    `json:"categoryId"`
+	Name        string  `json:"name" validate:"required,ruprintascii,max=255"` // Allowed characters: printable ASCII (Russian and English).
+	Price       float64 `json:"price" format:"float64"`
+	Deposit     bool    `json:"deposit"` // True if the price is a deposit returned to the client.
+	Description string  `json:"description" validate:"max=1000"`
+	Duration    int     `json:"duration" validate:"gte=0" example:"30"`            // The usual duration in minutes, 0 if not set.
+	Profession  string  `json:"profession" validate:"max=255" example:"Кардиолог"` // The profession required from the doctor, empty if any.
+	Archived    bool    `json:"archived"`                                          // An archived service cannot be booked.
+	CategoryID  uint    `json:"categoryId"`
 }
 
 // ToModel creates models.Service from this DTO.
 func (d *ServiceDto) ToModel() *models.Service {
 	return &models.Service{
-		Name:       d.Name,
-		Price:      d.Price,
-		CategoryID: d.CategoryID,
+		Name:        d.Name,
+		Price:       d.Price,
+		Deposit:     d.Deposit,
+		Description: d.Description,
+		Duration:    d.Duration,
+		Profession:  d.Profession,
+		Archived:    d.Archived,
+		CategoryID:  d.CategoryID,
+	}
+}
+
+// ServiceConsumableDto defines a data transfer object for the quantity of a product used by the service.
+type ServiceConsumableDto struct {
+	ProductID uint    `json:"productId" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"gt=0" example:"1"`
+}
+
+// ServiceConsumablesDto defines a data transfer object for the consumables of the service.
+type ServiceConsumablesDto struct {
+	Consumables []*ServiceConsumableDto `json:"consumables" validate:"dive"` // An empty list removes all consumables.
+}
+
+// ToModel creates models.ServiceConsumable slice from this DTO.
+func (d *ServiceConsumablesDto) ToModel() []*models.ServiceConsumable {
+	consumables := []*models.ServiceConsumable{}
+	for _, c := range d.Consumables {
+		consumables = append(consumables, &models.ServiceConsumable{ProductID: c.ProductID, Quantity: c.Quantity})
+	}
+	return consumables
+}
+
+// ServicePriceDto defines a data transfer object for the price of the service effective from a date.
+type ServicePriceDto struct {
+	DepartmentID  uint      `json:"departmentId"` // The department to override the price for, 0 for the base price.
+	Price         float64   `json:"price" validate:"gte=0" example:"1500"`
+	EffectiveFrom time.Time `json:"effectiveFrom" validate:"required" format:"date"` // The price applies from the start of the day.
+}
+
+// ToModel creates models.ServicePrice from this DTO.
+func (d *ServicePriceDto) ToModel() *models.ServicePrice {
+	return &models.ServicePrice{
+		DepartmentID:  d.DepartmentID,
+		Price:         d.Price,
+		EffectiveFrom: d.EffectiveFrom,
 	}
 }
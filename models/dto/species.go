@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"vet-clinic/models"
+)
+
+// SpeciesDto defines a data transfer object for species.
+type SpeciesDto struct {
+	Name    string `json:"name" validate:"required,ruprintascii,max=255"`                        // Allowed characters: printable ASCII (Russian and English).
+	Aliases string `json:"aliases" validate:"omitempty,ruprintascii,max=1000" example:"кот,cat"` // The other names of the species separated by commas.
+}
+
+// ToModel creates models.Species from this DTO.
+func (d *SpeciesDto) ToModel() *models.Species {
+	return &models.Species{
+		Name:    d.Name,
+		Aliases: d.Aliases,
+	}
+}
+
+// BreedDto defines a data transfer object for breed.
+type BreedDto struct {
+	Name      string `json:"name" validate:"required,ruprintascii,max=255"` // Allowed characters: printable ASCII (Russian and English).
+	SpeciesID uint   `json:"speciesId" validate:"required"`
+}
+
+// ToModel creates models.Breed from this DTO.
+func (d *BreedDto) ToModel() *models.Breed {
+	return &models.Breed{
+		Name:      d.Name,
+		SpeciesID: d.SpeciesID,
+	}
+}
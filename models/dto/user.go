@@ -38,6 +38,33 @@ type UserUpdateDto struct {
 	Services    []uint    `json:"services"`
 }
 
+// NewUserUpdateDto creates UserUpdateDto from the existing user.
+func NewUserUpdateDto(user *models.User) *UserUpdateDto {
+	var departments []uint
+	for _, department := range user.Departments {
+		departments = append(departments, department.ID)
+	}
+	var services []uint
+	for _, service := range user.Services {
+		services = append(services, service.ID)
+	}
+	return &UserUpdateDto{
+		Email:       user.Email,
+		Phone:       user.Phone,
+		Active:      user.Active,
+		Surname:     user.Surname,
+		Name:        user.Name,
+		Patronymic:  user.Patronymic,
+		Sex:         user.Sex,
+		BirthDate:   user.BirthDate,
+		Profession:  user.Profession,
+		Info:        user.Info,
+		RoleID:      user.RoleID,
+		Departments: departments,
+		Services:    services,
+	}
+}
+
 // ToModel creates models.User from this DTO.
 // If the owner value is true, then fields are included that can only be changed by a user with the owner role.
 func (d *UserUpdateDto) ToModel(owner bool) *models.User {
@@ -13,9 +13,24 @@ type VisitDto struct {
 	PetID           uint      `json:"petId"`
 	DoctorID        uint      `json:"doctorId"`
 	ServiceID       uint      `json:"serviceId"`
+	Status          string    `json:"status" validate:"omitempty,oneof=requested tentative scheduled cancelled completed"` // requested, tentative, scheduled (default), cancelled or completed. A visit is completed by the completion only.
 	LastUpdatedByID uint      `json:"-"`
 }
 
+// NewVisitDto creates VisitDto from the existing visit.
+func NewVisitDto(visit *models.Visit) *VisitDto {
+	return &VisitDto{
+		DateTime:        visit.DateTime,
+		Info:            visit.Info,
+		ClientID:        visit.ClientID,
+		PetID:           visit.PetID,
+		DoctorID:        visit.DoctorID,
+		ServiceID:       visit.ServiceID,
+		Status:          visit.Status,
+		LastUpdatedByID: visit.LastUpdatedByID,
+	}
+}
+
 // ToModel creates models.Visit from this DTO.
 func (d *VisitDto) ToModel() *models.Visit {
 	return &models.Visit{
@@ -25,6 +40,7 @@ func (d *VisitDto) ToModel() *models.Visit {
 		PetID:           d.PetID,
 		DoctorID:        d.DoctorID,
 		ServiceID:       d.ServiceID,
+		Status:          d.Status,
 		LastUpdatedByID: d.LastUpdatedByID,
 	}
 }
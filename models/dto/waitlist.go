@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+	"vet-clinic/models"
+)
+
+// WaitlistWindowDto defines a data transfer object for a period in which the client can come.
+type WaitlistWindowDto struct {
+	StartTime time.Time `json:"startTime" validate:"required" format:"date-time" example:"2024-01-10T09:00:00+03:00"`
+	EndTime   time.Time `json:"endTime" validate:"required" format:"date-time" example:"2024-01-10T13:00:00+03:00"`
+}
+
+// WaitlistEntryDto defines a data transfer object for waitlist entry.
+type WaitlistEntryDto struct {
+	ClientID        uint                 `json:"clientId" validate:"required"`
+	PetID           uint                 `json:"petId" validate:"required"`
+	ServiceID       uint                 `json:"serviceId" validate:"required"`
+	DoctorID        uint                 `json:"doctorId"`                                                   // The preferred doctor, 0 if any doctor who provides the service will do.
+	Comment         string               `json:"comment" validate:"ruprintascii,max=1000"`                   // Allowed characters: printable ASCII (Russian and English).
+	Status          string               `json:"status" validate:"omitempty,oneof=waiting booked cancelled"` // waiting (default), booked or cancelled. A new entry is always waiting.
+	Windows         []*WaitlistWindowDto `json:"windows" validate:"required,min=1,dive"`
+	LastUpdatedByID uint                 `json:"-"`
+}
+
+// ToModel creates models.WaitlistEntry from this DTO.
+func (d *WaitlistEntryDto) ToModel() *models.WaitlistEntry {
+	windows := []*models.WaitlistWindow{}
+	for _, w := range d.Windows {
+		windows = append(windows, &models.WaitlistWindow{StartTime: w.StartTime, EndTime: w.EndTime})
+	}
+	return &models.WaitlistEntry{
+		ClientID:        d.ClientID,
+		PetID:           d.PetID,
+		ServiceID:       d.ServiceID,
+		DoctorID:        d.DoctorID,
+		Comment:         d.Comment,
+		Status:          d.Status,
+		Windows:         windows,
+		LastUpdatedByID: d.LastUpdatedByID,
+	}
+}
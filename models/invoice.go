@@ -0,0 +1,150 @@
+package models
+
+import (
+	"math"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// Invoice defines struct of the invoice of a visit.
+// The best of the loyalty discount and the promo code discount applies, the discounts do not apply to deposits.
+type Invoice struct {
+	*BaseModel
+	ClientID        uint       `json:"clientId" gorm:"index"`
+	VisitID         uint       `json:"visitId" gorm:"uniqueIndex"`
+	Visit           *Visit     `json:"visit" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Deposit         bool       `json:"deposit"`  // True if the service is a deposit, it is credited to the balance of the client.
+	Subtotal        float64    `json:"subtotal"` // The price of the service as of the date of the visit.
+	DiscountTierID  uint       `json:"discountTierId"`
+	PromoCodeID     uint       `json:"promoCodeId"`
+	PromoCode       *PromoCode `json:"promoCode"`
+	DiscountPercent float64    `json:"discountPercent"`
+	Discount        float64    `json:"discount"`
+	PaidFromBalance float64    `json:"paidFromBalance"`
+	Total           float64    `json:"total"` // The amount due after the discount and the payment from the balance.
+}
+
+// TableName returns the table name of invoice struct and it is used by gorm.
+func (*Invoice) TableName() string {
+	return "invoice"
+}
+
+// Get returns invoice full matched given invoice ID.
+func (m *Invoice) Get(rep repository.Repository, id uint) (*Invoice, error) {
+	invoice := &Invoice{}
+	if err := rep.Preload("Visit").Preload("Visit.Service").Preload("PromoCode").
+		First(invoice, id).Error; err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// GetAllByClient returns the invoices of the client, the latest first.
+func (m *Invoice) GetAllByClient(rep repository.Repository, clientID uint) ([]*Invoice, error) {
+	var invoices []*Invoice
+	if err := rep.Preload("Visit").Preload("Visit.Doctor").Preload("Visit.Service").Preload("PromoCode").
+		Where("client_id = ?", clientID).Order("created_at DESC, id DESC").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// Issue issues the invoice of the completed visit.
+// The given promo code is optional. If useBalance is true, the invoice is paid from the balance of the client
+// as far as it suffices.
+func (m *Invoice) Issue(rep repository.Repository, visitID uint, promoCode string, useBalance bool,
+	now time.Time) (*Invoice, error) {
+	invoice := &Invoice{VisitID: visitID}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		visit, err := (&Visit{}).Get(tx, visitID)
+		if err != nil {
+			return err
+		}
+		if visit.Status != VisitCompleted {
+			return apperror.NewInvalidState("only completed visits can be invoiced")
+		}
+		if visit.ClientID == 0 || visit.Service == nil {
+			return apperror.NewInvalidState("the visit has no client or service to invoice")
+		}
+		var count int64
+		if err := tx.Unscoped().Model(&Invoice{}).Where("visit_id = ?", visitID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperror.NewDuplicate("the visit has already been invoiced")
+		}
+
+		invoice.ClientID = visit.ClientID
+		invoice.Subtotal = roundMoney(visit.Price)
+		invoice.Deposit = visit.Service.Deposit
+		if invoice.Deposit && promoCode != "" {
+			return apperror.NewInvalidState("the discounts do not apply to deposits")
+		}
+		if !invoice.Deposit {
+			if err := txApplyDiscount(tx, invoice, promoCode, now); err != nil {
+				return err
+			}
+		}
+		invoice.Total = roundMoney(invoice.Subtotal - invoice.Discount)
+
+		var balance float64
+		if useBalance && !invoice.Deposit {
+			if balance, err = txGetBalance(tx, invoice.ClientID); err != nil {
+				return err
+			}
+			invoice.PaidFromBalance = math.Max(0, math.Min(balance, invoice.Total))
+			invoice.Total = roundMoney(invoice.Total - invoice.PaidFromBalance)
+		}
+
+		if err := tx.Select("client_id", "visit_id", "deposit", "subtotal", "discount_tier_id", "promo_code_id",
+			"discount_percent", "discount", "paid_from_balance", "total").Create(invoice).Error; err != nil {
+			return err
+		}
+		if invoice.Deposit {
+			return txRecordBalance(tx, invoice.ClientID, invoice.Subtotal, BalanceDeposit, invoice.ID, "", 0)
+		}
+		if invoice.PaidFromBalance > 0 {
+			return txRecordBalance(tx, invoice.ClientID, -invoice.PaidFromBalance, BalancePayment, invoice.ID, "", 0)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, invoice.ID)
+}
+
+// txApplyDiscount sets the best of the loyalty discount of the client and the discount of the promo code.
+func txApplyDiscount(tx repository.Repository, invoice *Invoice, promoCode string, now time.Time) error {
+	loyalty, err := txGetClientDiscount(tx, invoice.ClientID)
+	if err != nil {
+		return err
+	}
+	if loyalty.Tier != nil {
+		invoice.DiscountTierID = loyalty.Tier.ID
+		invoice.DiscountPercent = loyalty.Tier.Percent
+	}
+	if promoCode != "" {
+		promo, err := txGetValidPromoCode(tx, promoCode, now)
+		if err != nil {
+			return err
+		}
+		// The promo code is used up only if it gives a better discount than the loyalty programme.
+		if promo.Percent > invoice.DiscountPercent {
+			if err := txUsePromoCode(tx, promo); err != nil {
+				return err
+			}
+			invoice.DiscountTierID = 0
+			invoice.PromoCodeID = promo.ID
+			invoice.DiscountPercent = promo.Percent
+		}
+	}
+	invoice.DiscountPercent = math.Min(invoice.DiscountPercent, 100)
+	invoice.Discount = roundMoney(invoice.Subtotal * invoice.DiscountPercent / 100)
+	return nil
+}
+
+// roundMoney rounds the amount to kopecks.
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+	"vet-clinic/util"
+)
+
+const (
+	// LabResultNormal is the flag of the value within the reference range.
+	LabResultNormal = "normal"
+	// LabResultLow is the flag of the value below the reference range.
+	LabResultLow = "low"
+	// LabResultHigh is the flag of the value above the reference range.
+	LabResultHigh = "high"
+)
+
+// LabResult defines struct of a value measured by a laboratory test of a pet at a visit.
+type LabResult struct {
+	*BaseModel
+	PetID        uint     `json:"petId" gorm:"index"`
+	VisitID      uint     `json:"visitId" gorm:"index"`
+	Test         string   `json:"test" gorm:"size:255" example:"Гемоглобин"`
+	Value        float64  `json:"value" example:"132"`
+	Unit         string   `json:"unit" gorm:"size:50" example:"г/л"`
+	ReferenceMin *float64 `json:"referenceMin" example:"80"`  // nil if the range has no lower limit.
+	ReferenceMax *float64 `json:"referenceMax" example:"150"` // nil if the range has no upper limit.
+	Flag         string   `json:"flag"`                       // normal, low, high; empty if there is no reference range.
+	Note         string   `json:"note" gorm:"size:1000"`
+}
+
+// TableName returns the table name of lab result struct and it is used by gorm.
+func (*LabResult) TableName() string {
+	return "lab_result"
+}
+
+// Evaluate sets the flag of the value according to the reference range.
+func (m *LabResult) Evaluate() {
+	switch {
+	case m.ReferenceMin == nil && m.ReferenceMax == nil:
+		m.Flag = ""
+	case m.ReferenceMin != nil && m.Value < *m.ReferenceMin:
+		m.Flag = LabResultLow
+	case m.ReferenceMax != nil && m.Value > *m.ReferenceMax:
+		m.Flag = LabResultHigh
+	default:
+		m.Flag = LabResultNormal
+	}
+}
+
+// Get returns lab result full matched given lab result ID.
+func (m *LabResult) Get(rep repository.Repository, id uint) (*LabResult, error) {
+	result := &LabResult{}
+	if err := rep.First(result, id).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAllByVisit returns the lab results of the visit ordered by the test.
+func (m *LabResult) GetAllByVisit(rep repository.Repository, visitID uint) ([]*LabResult, error) {
+	if _, err := (&Visit{}).Exist(rep, visitID); err != nil {
+		return nil, err
+	}
+	var results []*LabResult
+	if err := rep.Where("visit_id = ?", visitID).Order("test, id").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetAllByPet returns the lab results of the pet in the order of the visits.
+// If test is not empty, only the results of the test regardless of the case are returned, i.e. its history.
+func (m *LabResult) GetAllByPet(rep repository.Repository, petID uint, test string) ([]*LabResult, error) {
+	if _, err := (&Pet{}).Exist(rep, petID); err != nil {
+		return nil, err
+	}
+	var results []*LabResult
+	if err := rep.Model(&LabResult{}).Joins("JOIN visit_master ON visit_master.id = lab_result.visit_id").
+		Where("lab_result.pet_id = ?", petID).Order("visit_master.date_time, lab_result.test, lab_result.id").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if test == "" {
+		return results, nil
+	}
+	filtered := []*LabResult{}
+	for _, result := range results {
+		if util.NormalizeName(result.Test) == util.NormalizeName(test) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered, nil
+}
+
+// Create persists this lab result data. The pet is the one of the visit.
+func (m *LabResult) Create(rep repository.Repository) (*LabResult, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		visit := &Visit{}
+		if err := tx.First(visit, m.VisitID).Error; err != nil {
+			return apperror.NewInvalidReference("visitId", err)
+		}
+		m.PetID = visit.PetID
+		m.Evaluate()
+		return tx.Select("pet_id", "visit_id", "test", "value", "unit", "reference_min", "reference_max", "flag",
+			"note").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Delete deletes this lab result data permanently, e.g. the value entered by mistake.
+func (m *LabResult) Delete(rep repository.Repository, id uint) (*LabResult, error) {
+	result := &LabResult{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if result, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&LabResult{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
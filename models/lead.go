@@ -1,22 +1,26 @@
 package models
 
 import (
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/repository"
 )
 
 // Lead defines struct of lead data.
 type Lead struct {
 	*BaseModel
-	Name            string `json:"name" gorm:"size:255"`
-	Phone           string `json:"phone"`
-	Email           string `json:"email"`
-	Comment         string `json:"comment"`
-	Type            string `json:"type"`   // in clinic, online, callback
-	Status          string `json:"status"` // open, in_progress, closed, rejected
-	DoctorID        uint   `json:"doctorId"`
-	Doctor          *User  `json:"doctor" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	LastUpdatedByID uint   `json:"lastUpdatedById"`
-	LastUpdatedBy   *User  `json:"lastUpdatedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Name            string  `json:"name" gorm:"size:255"`
+	Phone           string  `json:"phone"`
+	Email           string  `json:"email"`
+	Comment         string  `json:"comment"`
+	Type            string  `json:"type"`   // in clinic, online, callback
+	Status          string  `json:"status"` // open, in_progress, closed, rejected
+	DoctorID        uint    `json:"doctorId"`
+	Doctor          *User   `json:"doctor" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ClientID        uint    `json:"clientId"` // The client the lead came from, 0 until the staff links the lead to a client.
+	Client          *Client `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	LastUpdatedByID uint    `json:"lastUpdatedById"`
+	LastUpdatedBy   *User   `json:"lastUpdatedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 }
 
 // TableName returns the table name of lead struct and it is used by gorm.
@@ -35,7 +39,7 @@ func (m *Lead) Exist(rep repository.Repository, id uint) (bool, error) {
 // Get returns lead full matched given lead ID.
 func (m *Lead) Get(rep repository.Repository, id uint) (*Lead, error) {
 	lead := &Lead{}
-	if err := rep.Preload("Doctor").Preload("LastUpdatedBy").First(lead, id).Error; err != nil {
+	if err := rep.Preload("Doctor").Preload("Client").Preload("LastUpdatedBy").First(lead, id).Error; err != nil {
 		return nil, err
 	}
 	return lead, nil
@@ -44,7 +48,7 @@ func (m *Lead) Get(rep repository.Repository, id uint) (*Lead, error) {
 // GetAll returns a slice of all leads.
 func (m *Lead) GetAll(rep repository.Repository) ([]*Lead, error) {
 	var leads []*Lead
-	if err := rep.Preload("Doctor").Preload("LastUpdatedBy").Find(&leads).Error; err != nil {
+	if err := rep.Preload("Doctor").Preload("Client").Preload("LastUpdatedBy").Find(&leads).Error; err != nil {
 		return nil, err
 	}
 	return leads, nil
@@ -63,11 +67,63 @@ func (m *Lead) Create(rep repository.Repository) (*Lead, error) {
 func txCreateLead(tx repository.Repository, m *Lead) error {
 	user := &User{}
 	if _, err := user.Exist(tx, m.DoctorID); err != nil {
-		return err
+		return apperror.NewInvalidReference("doctorId", err)
+	}
+	columns := []string{"name", "phone", "email", "comment", "type", "status", "doctor_id"}
+	if m.ClientID != 0 {
+		if _, err := (&Client{}).Exist(tx, m.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+		columns = append(columns, "client_id")
 	}
 
 	m.Status = "open"
-	return tx.Select("name", "phone", "email", "comment", "type", "status", "doctor_id").Create(m).Error
+	return tx.Select(columns).Create(m).Error
+}
+
+// LeadForm is the data for showing the public lead form.
+type LeadForm struct {
+	// FormToken is signed by the server and records when the form was shown, it is submitted along with the lead.
+	FormToken string `json:"formToken"`
+}
+
+// Submit persists this lead submitted by the public form and assigns it to the given user.
+// The lead is left unassigned if the assignee is 0 or is not an active user.
+// It fails if another lead with the same phone or e-mail has been submitted within the given window.
+func (m *Lead) Submit(rep repository.Repository, assigneeID uint, window time.Duration, now time.Time) (*Lead, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		for _, contact := range [][2]string{{"phone", m.Phone}, {"email", m.Email}} {
+			if contact[1] == "" {
+				continue
+			}
+			var count int64
+			if err := tx.Model(&Lead{}).Where(contact[0]+" = ? AND created_at >= ?", contact[1], now.Add(-window)).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return apperror.NewDuplicate("a lead with the same phone or e-mail has already been submitted")
+			}
+		}
+
+		var assignees []*User
+		if assigneeID != 0 {
+			if err := tx.Where("active = ?", true).Limit(1).Find(&assignees, assigneeID).Error; err != nil {
+				return err
+			}
+		}
+		columns := []string{"name", "phone", "email", "comment", "type", "status"}
+		m.DoctorID = 0
+		if len(assignees) > 0 {
+			m.DoctorID = assignees[0].ID
+			columns = append(columns, "doctor_id")
+		}
+		m.Status = "open"
+		return tx.Select(columns).Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
 }
 
 // Update updates this lead data.
@@ -86,16 +142,35 @@ func txUpdateLead(tx repository.Repository, m *Lead, id uint) error {
 	}
 
 	user := &User{}
-	if _, err := user.Exist(tx, m.DoctorID); err != nil {
-		return err
+	if m.DoctorID != 0 {
+		if _, err := user.Exist(tx, m.DoctorID); err != nil {
+			return apperror.NewInvalidReference("doctorId", err)
+		}
 	}
 	if _, err := user.Exist(tx, m.LastUpdatedByID); err != nil {
-		return err
+		return apperror.NewInvalidReference("lastUpdatedById", err)
+	}
+	if m.ClientID != 0 {
+		if _, err := (&Client{}).Exist(tx, m.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
 	}
 
-	return tx.Model(&Lead{}).Where("id = ?", id).
+	if err := tx.Model(&Lead{}).Where("id = ?", id).
 		Select("name", "phone", "email", "comment", "type",
-			"status", "doctor_id", "last_updated_by_id").Updates(m).Error
+			"status", "last_updated_by_id").Updates(m).Error; err != nil {
+		return err
+	}
+	// The unassigned or unlinked lead has no doctor or client, so the references are cleared instead of being set to 0.
+	var doctorID, clientID interface{}
+	if m.DoctorID != 0 {
+		doctorID = m.DoctorID
+	}
+	if m.ClientID != 0 {
+		clientID = m.ClientID
+	}
+	return tx.Model(&Lead{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"doctor_id": doctorID, "client_id": clientID}).Error
 }
 
 // Delete deletes this lead data.
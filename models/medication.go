@@ -0,0 +1,89 @@
+package models
+
+import (
+	"vet-clinic/repository"
+)
+
+// Medication defines struct of medication data.
+type Medication struct {
+	*BaseModel
+	Name     string  `json:"name" gorm:"not null;size:255;uniqueIndex:idx_medication"`
+	Form     string  `json:"form" gorm:"size:255;uniqueIndex:idx_medication" example:"таблетки"`
+	Strength float64 `json:"strength" gorm:"uniqueIndex:idx_medication" example:"50"`
+	Unit     string  `json:"unit" gorm:"size:50;uniqueIndex:idx_medication" example:"мг"` // The unit of the strength.
+}
+
+// TableName returns the table name of medication struct and it is used by gorm.
+func (*Medication) TableName() string {
+	return "medication_master"
+}
+
+// Exist returns true if a given medication exits.
+func (m *Medication) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&Medication{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns medication full matched given medication ID.
+func (m *Medication) Get(rep repository.Repository, id uint) (*Medication, error) {
+	medication := &Medication{}
+	if err := rep.First(medication, id).Error; err != nil {
+		return nil, err
+	}
+	return medication, nil
+}
+
+// GetAll returns a slice of all medications.
+func (m *Medication) GetAll(rep repository.Repository) ([]*Medication, error) {
+	var medications []*Medication
+	if err := rep.Model(&Medication{}).Order("name, form, strength").Find(&medications).Error; err != nil {
+		return nil, err
+	}
+	return medications, nil
+}
+
+// Create persists this medication data.
+func (m *Medication) Create(rep repository.Repository) (*Medication, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		return tx.Select("name", "form", "strength", "unit").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this medication data.
+func (m *Medication) Update(rep repository.Repository, id uint) (*Medication, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+
+		return tx.Model(&Medication{}).Where("id = ?", id).
+			Select("name", "form", "strength", "unit").Updates(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this medication data.
+// It fails if prescriptions, including the soft-deleted ones, still refer to the medication.
+func (m *Medication) Delete(rep repository.Repository, id uint) (*Medication, error) {
+	medication := &Medication{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if medication, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&Prescription{}, "medication_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&Medication{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return medication, nil
+}
@@ -1,21 +1,52 @@
 package models
 
 import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/repository"
+	"vet-clinic/util"
 )
 
 // Pet defines struct of pet data.
 type Pet struct {
 	*BaseModel
-	Name     string  `json:"name" gorm:"size:255"`
-	Type     string  `json:"type" gorm:"size:255"`
-	Breed    string  `json:"breed" gorm:"size:255"`
-	Colour   string  `json:"colour" gorm:"size:255"`
-	Sex      string  `json:"sex" gorm:"size:255"`
-	ClientID uint    `json:"clientId"`
-	Client   *Client `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Name               string    `json:"name" gorm:"size:255"`
+	Type               string    `json:"type" gorm:"size:255"`
+	Breed              string    `json:"breed" gorm:"size:255"`
+	Colour             string    `json:"colour" gorm:"size:255"`
+	Sex                string    `json:"sex" gorm:"size:255"`
+	ClientID           uint      `json:"clientId"` // The primary owner.
+	Client             *Client   `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	BirthDate          time.Time `json:"birthDate"`
+	BirthDateEstimated bool      `json:"birthDateEstimated"` // The birth date is estimated from the age told by the owner.
+	Neutered           bool      `json:"neutered"`
+	Microchip          string    `json:"microchip" gorm:"size:255;index"` // The microchip or tattoo ID, unique among the pets.
+	Allergies          string    `json:"allergies" gorm:"size:1000"`
+	ChronicConditions  string    `json:"chronicConditions" gorm:"size:1000"`
+	Status             string    `json:"status" gorm:"size:255"` // active, deceased, transferred
+	SpeciesID          uint      `json:"speciesId" gorm:"index"`
+	BreedID            uint      `json:"breedId" gorm:"index"`
+	BreedUnverified    bool      `json:"breedUnverified"` // The breed is entered as free text and is to be reviewed.
+	// Owners are the clients related to the pet along with their roles, including the primary owner.
+	Owners []*PetOwner `json:"owners" gorm:"foreignKey:PetID"`
 }
 
+const (
+	// PetActive is the status of the pet under the care of the clinic.
+	PetActive = "active"
+	// PetDeceased is the status of the deceased pet.
+	PetDeceased = "deceased"
+	// PetTransferred is the status of the pet transferred to another clinic.
+	PetTransferred = "transferred"
+)
+
+// petColumns are the columns of the pet which are written on create and update.
+var petColumns = []string{"name", "type", "breed", "colour", "sex", "client_id", "birth_date", "birth_date_estimated",
+	"neutered", "microchip", "allergies", "chronic_conditions", "status", "species_id", "breed_id", "breed_unverified"}
+
 // TableName returns the table name of pet struct and it is used by gorm.
 func (*Pet) TableName() string {
 	return "pet_master"
@@ -32,7 +63,7 @@ func (m *Pet) Exist(rep repository.Repository, id uint) (bool, error) {
 // Get returns pet full matched given pet ID.
 func (m *Pet) Get(rep repository.Repository, id uint) (*Pet, error) {
 	pet := &Pet{}
-	if err := rep.Preload("Client").First(pet, id).Error; err != nil {
+	if err := rep.Preload("Client").Scopes(preloadPetOwners).First(pet, id).Error; err != nil {
 		return nil, err
 	}
 	return pet, nil
@@ -42,7 +73,16 @@ func (m *Pet) Get(rep repository.Repository, id uint) (*Pet, error) {
 func (m *Pet) GetAll(rep repository.Repository) ([]*Pet, error) {
 	var pets []*Pet
 
-	if err := rep.Preload("Client").Find(&pets).Error; err != nil {
+	if err := rep.Preload("Client").Scopes(preloadPetOwners).Find(&pets).Error; err != nil {
+		return nil, err
+	}
+	return pets, nil
+}
+
+// GetAllByClient returns a slice of the pets of the given client.
+func (m *Pet) GetAllByClient(rep repository.Repository, clientID uint) ([]*Pet, error) {
+	var pets []*Pet
+	if err := rep.Where("client_id = ?", clientID).Find(&pets).Error; err != nil {
 		return nil, err
 	}
 	return pets, nil
@@ -53,10 +93,22 @@ func (m *Pet) Create(rep repository.Repository) (*Pet, error) {
 	if err := rep.Transaction(func(tx repository.Repository) error {
 		client := &Client{}
 		if _, err := client.Exist(tx, m.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+		if err := m.txCheckMicrochip(tx, 0); err != nil {
+			return err
+		}
+		if err := m.txResolveSpecies(tx); err != nil {
 			return err
 		}
 
-		return tx.Select("name", "type", "breed", "colour", "sex", "client_id").Create(m).Error
+		if m.Status == "" {
+			m.Status = PetActive
+		}
+		if err := tx.Select(petColumns).Create(m).Error; err != nil {
+			return microchipError(err)
+		}
+		return txSetPetOwners(tx, m.ID, m.ClientID, m.Owners)
 	}); err != nil {
 		return nil, err
 	}
@@ -72,11 +124,22 @@ func (m *Pet) Update(rep repository.Repository, id uint) (*Pet, error) {
 
 		client := &Client{}
 		if _, err := client.Exist(tx, m.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+		if err := m.txCheckMicrochip(tx, id); err != nil {
+			return err
+		}
+		if err := m.txResolveSpecies(tx); err != nil {
 			return err
 		}
 
-		return tx.Model(&Pet{}).Where("id = ?", id).
-			Select("name", "type", "breed", "colour", "sex", "client_id").Updates(m).Error
+		if m.Status == "" {
+			m.Status = PetActive
+		}
+		if err := tx.Model(&Pet{}).Where("id = ?", id).Select(petColumns).Updates(m).Error; err != nil {
+			return microchipError(err)
+		}
+		return txSetPetOwners(tx, id, m.ClientID, m.Owners)
 	}); err != nil {
 		return nil, err
 	}
@@ -97,3 +160,156 @@ func (m *Pet) Delete(rep repository.Repository, id uint) (*Pet, error) {
 	}
 	return pet, nil
 }
+
+// GetAllWithDeleted returns a slice of all pets including the soft-deleted ones.
+func (m *Pet) GetAllWithDeleted(rep repository.Repository) ([]*Pet, error) {
+	var pets []*Pet
+	if err := rep.Unscoped().Preload("Client").Scopes(preloadPetOwners).Find(&pets).Error; err != nil {
+		return nil, err
+	}
+	return pets, nil
+}
+
+// Restore restores this soft-deleted pet data.
+// It fails if the records the pet refers to are deleted, they have to be restored first.
+func (m *Pet) Restore(rep repository.Repository, id uint) (*Pet, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := txRestore(tx, &Pet{}, id); err != nil {
+			return err
+		}
+		pet := &Pet{}
+		if err := tx.First(pet, id).Error; err != nil {
+			return err
+		}
+		client := &Client{}
+		if _, err := client.Exist(tx, pet.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Purge permanently deletes this pet data, including the soft-deleted one.
+// It fails if visits, including the soft-deleted ones, still refer to the pet.
+func (m *Pet) Purge(rep repository.Repository, id uint) (*Pet, error) {
+	pet := &Pet{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := tx.Unscoped().First(pet, id).Error; err != nil {
+			return err
+		}
+		return txPurgePet(tx, id)
+	}); err != nil {
+		return nil, err
+	}
+	return pet, nil
+}
+
+// PurgeDeletedBefore permanently deletes the pets soft-deleted before the given time, except the ones still referred to.
+func (m *Pet) PurgeDeletedBefore(rep repository.Repository, before time.Time) (int64, error) {
+	return purgeDeletedBefore(rep, &Pet{}, before, txPurgePet)
+}
+
+func txPurgePet(tx repository.Repository, id uint) error {
+	if err := txCheckNotReferenced(tx, id, reference{&Visit{}, "pet_id"}, reference{&Prescription{}, "pet_id"},
+		reference{&Attachment{}, "pet_id"}, reference{&LabResult{}, "pet_id"},
+		reference{&WaitlistEntry{}, "pet_id"}); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("pet_id = ?", id).Delete(&PetOwner{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("pet_id = ?", id).Delete(&PetWeight{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Pet{}, id).Error
+}
+
+// txCheckMicrochip returns an error if another pet, including the soft-deleted one, has the same microchip.
+// The pets with the microchip are locked until the transaction ends, which also keeps other transactions from
+// storing the microchip where the database locks the index range, e.g. MySQL. The unique index created
+// by the migration rejects the concurrent duplicates on the other databases.
+func (m *Pet) txCheckMicrochip(tx repository.Repository, id uint) error {
+	if m.Microchip == "" {
+		return nil
+	}
+	var ids []uint
+	if err := tx.Unscoped().Model(&Pet{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("microchip = ? AND id <> ?", m.Microchip, id).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		return errDuplicateMicrochip()
+	}
+	return nil
+}
+
+// microchipError returns the duplicate error if the pet is rejected by the unique index of the microchip.
+func microchipError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errDuplicateMicrochip()
+	}
+	return err
+}
+
+func errDuplicateMicrochip() error {
+	return apperror.NewDuplicate("a pet with the same microchip already exists")
+}
+
+// preloadPetOwners loads the owners of the pets along with the clients.
+func preloadPetOwners(db *gorm.DB) *gorm.DB {
+	return db.Preload("Owners", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Preload("Owners.Client")
+}
+
+// txResolveSpecies links this pet to the species and the breed of the catalogue.
+// The species is given by SpeciesID or recognized in Type by its name or alias, then Type is set to its name.
+// The breed is given by BreedID or recognized in Breed by its name, then Breed is set to its name.
+// Otherwise the breed is kept as free text and flagged for review.
+func (m *Pet) txResolveSpecies(tx repository.Repository) error {
+	m.BreedUnverified = false
+	if m.SpeciesID == 0 && m.Type != "" {
+		species, err := (&Species{}).FindByName(tx, m.Type)
+		if err != nil {
+			return err
+		}
+		if species == nil {
+			return apperror.NewInvalidReference("type", gorm.ErrRecordNotFound)
+		}
+		m.SpeciesID = species.ID
+	}
+	if m.SpeciesID != 0 {
+		species, err := (&Species{}).Get(tx, m.SpeciesID)
+		if err != nil {
+			return apperror.NewInvalidReference("speciesId", err)
+		}
+		m.Type = species.Name
+	}
+
+	if m.BreedID != 0 {
+		breed := &Breed{}
+		if err := tx.Where("species_id = ?", m.SpeciesID).First(breed, m.BreedID).Error; err != nil {
+			return apperror.NewInvalidReference("breedId", err)
+		}
+		m.Breed = breed.Name
+		return nil
+	}
+	if m.Breed == "" {
+		return nil
+	}
+	if m.SpeciesID != 0 {
+		breeds, err := (&Breed{}).GetAll(tx, m.SpeciesID)
+		if err != nil {
+			return err
+		}
+		for _, breed := range breeds {
+			if util.NormalizeName(breed.Name) == util.NormalizeName(m.Breed) {
+				m.BreedID, m.Breed = breed.ID, breed.Name
+				return nil
+			}
+		}
+	}
+	m.BreedUnverified = true
+	return nil
+}
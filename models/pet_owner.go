@@ -0,0 +1,80 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// PetOwnerPrimary is the role of the primary owner, who is also referred to by Pet.ClientID.
+	PetOwnerPrimary = "primary"
+	// PetOwnerCoOwner is the role of another member of the family who owns the pet.
+	PetOwnerCoOwner = "co-owner"
+	// PetOwnerEmergency is the role of the client to be contacted if the owners cannot be reached.
+	PetOwnerEmergency = "emergency"
+)
+
+// PetOwner defines the relation between a pet and a client along with the role of the client.
+type PetOwner struct {
+	*BaseModel
+	PetID    uint    `json:"petId" gorm:"uniqueIndex:idx_pet_owner"`
+	ClientID uint    `json:"clientId" gorm:"uniqueIndex:idx_pet_owner;index"`
+	Client   *Client `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Role     string  `json:"role" example:"co-owner"` // primary, co-owner, emergency
+}
+
+// TableName returns the table name of pet owner struct and it is used by gorm.
+func (*PetOwner) TableName() string {
+	return "pet_owner"
+}
+
+// txSetPetOwners replaces the owners of the pet with the primary owner and the given other owners.
+// If owners is nil, the current other owners are kept and only the primary owner is updated.
+func txSetPetOwners(tx repository.Repository, petID, primaryID uint, owners []*PetOwner) error {
+	if owners == nil {
+		if err := tx.Where("pet_id = ? AND role <> ? AND client_id <> ?", petID, PetOwnerPrimary, primaryID).
+			Order("id").Find(&owners).Error; err != nil {
+			return err
+		}
+	} else {
+		client := &Client{}
+		for _, owner := range owners {
+			if _, err := client.Exist(tx, owner.ClientID); err != nil {
+				return apperror.NewInvalidReference("owners", err)
+			}
+		}
+	}
+	if err := tx.Unscoped().Where("pet_id = ?", petID).Delete(&PetOwner{}).Error; err != nil {
+		return err
+	}
+
+	rows := []*PetOwner{{PetID: petID, ClientID: primaryID, Role: PetOwnerPrimary}}
+	for _, owner := range owners {
+		// The primary owner has only the primary role.
+		if owner.ClientID != primaryID {
+			rows = append(rows, &PetOwner{PetID: petID, ClientID: owner.ClientID, Role: owner.Role})
+		}
+	}
+	for _, row := range rows {
+		if err := tx.Select("pet_id", "client_id", "role").Create(row).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txMovePetOwners moves the roles of the source client to the target client,
+// except for the pets the target client already has a role for.
+func txMovePetOwners(tx repository.Repository, targetID, sourceID uint) error {
+	var petIDs []uint
+	if err := tx.Model(&PetOwner{}).Where("client_id = ?", targetID).Pluck("pet_id", &petIDs).Error; err != nil {
+		return err
+	}
+	if len(petIDs) > 0 {
+		if err := tx.Unscoped().Where("client_id = ? AND pet_id IN ?", sourceID, petIDs).
+			Delete(&PetOwner{}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&PetOwner{}).Where("client_id = ?", sourceID).Update("client_id", targetID).Error
+}
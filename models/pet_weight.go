@@ -0,0 +1,159 @@
+package models
+
+import (
+	"math"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// WeightGaining is the direction of the trend when the pet gains weight.
+	WeightGaining = "gaining"
+	// WeightLosing is the direction of the trend when the pet loses weight.
+	WeightLosing = "losing"
+	// WeightStable is the direction of the trend when the weight changes less than weightStablePercent.
+	WeightStable = "stable"
+)
+
+// weightStablePercent is the change of the weight, in percent of the first weight, regarded as no change.
+const weightStablePercent = 2.0
+
+// PetWeight defines struct of a weight measurement of a pet.
+type PetWeight struct {
+	*BaseModel
+	PetID      uint      `json:"petId" gorm:"index"`
+	VisitID    uint      `json:"visitId" gorm:"index"` // The visit the pet was weighed at, 0 if it was weighed elsewhere.
+	Weight     float64   `json:"weight"`               // kg
+	MeasuredAt time.Time `json:"measuredAt"`
+}
+
+// TableName returns the table name of pet weight struct and it is used by gorm.
+func (*PetWeight) TableName() string {
+	return "pet_weight"
+}
+
+// WeightTrend defines struct of the summary of the weight history of a pet.
+type WeightTrend struct {
+	PetID         uint       `json:"petId"`
+	Count         int        `json:"count"`
+	First         *PetWeight `json:"first"`
+	Latest        *PetWeight `json:"latest"`
+	Min           float64    `json:"min"`
+	Max           float64    `json:"max"`
+	Change        float64    `json:"change"`                     // kg, from the first to the latest weight
+	ChangePercent float64    `json:"changePercent"`              // percent of the first weight
+	DailyChange   float64    `json:"dailyChange"`                // kg per day, the slope of the least squares line
+	Direction     string     `json:"direction" example:"stable"` // gaining, losing, stable
+}
+
+// GetAllByPet returns the weight history of the pet ordered by the measurement time.
+func (m *PetWeight) GetAllByPet(rep repository.Repository, petID uint) ([]*PetWeight, error) {
+	if _, err := (&Pet{}).Exist(rep, petID); err != nil {
+		return nil, err
+	}
+	var weights []*PetWeight
+	if err := rep.Where("pet_id = ?", petID).Order("measured_at, id").Find(&weights).Error; err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// Record persists this weight measurement of the pet.
+// If the weight has already been recorded at the visit, it is replaced.
+// If the measurement time is not set, the time of the visit or the current time is used.
+func (m *PetWeight) Record(rep repository.Repository, petID uint, now time.Time) (*PetWeight, error) {
+	m.PetID = petID
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Pet{}).Exist(tx, petID); err != nil {
+			return err
+		}
+		if m.VisitID != 0 {
+			visit := &Visit{}
+			if err := tx.Where("pet_id = ?", petID).First(visit, m.VisitID).Error; err != nil {
+				return apperror.NewInvalidReference("visitId", err)
+			}
+			if m.MeasuredAt.IsZero() {
+				m.MeasuredAt = visit.DateTime
+			}
+			if err := tx.Unscoped().Where("visit_id = ?", m.VisitID).Delete(&PetWeight{}).Error; err != nil {
+				return err
+			}
+		}
+		if m.MeasuredAt.IsZero() {
+			m.MeasuredAt = now
+		}
+
+		columns := []string{"pet_id", "weight", "measured_at"}
+		if m.VisitID != 0 {
+			columns = append(columns, "visit_id")
+		}
+		return tx.Select(columns).Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetTrend returns the summary of the weight history of the pet.
+func (m *PetWeight) GetTrend(rep repository.Repository, petID uint) (*WeightTrend, error) {
+	weights, err := m.GetAllByPet(rep, petID)
+	if err != nil {
+		return nil, err
+	}
+	return newWeightTrend(petID, weights), nil
+}
+
+// newWeightTrend summarizes the weights ordered by the measurement time.
+func newWeightTrend(petID uint, weights []*PetWeight) *WeightTrend {
+	trend := &WeightTrend{PetID: petID, Count: len(weights), Direction: WeightStable}
+	if len(weights) == 0 {
+		return trend
+	}
+
+	trend.First = weights[0]
+	trend.Latest = weights[len(weights)-1]
+	trend.Min, trend.Max = trend.First.Weight, trend.First.Weight
+	for _, w := range weights {
+		trend.Min = math.Min(trend.Min, w.Weight)
+		trend.Max = math.Max(trend.Max, w.Weight)
+	}
+	trend.Change = roundTo(trend.Latest.Weight-trend.First.Weight, 2)
+	if trend.First.Weight > 0 {
+		trend.ChangePercent = roundTo(trend.Change/trend.First.Weight*100, 2)
+	}
+	trend.DailyChange = roundTo(weightSlope(weights), 3)
+
+	switch {
+	case trend.ChangePercent >= weightStablePercent:
+		trend.Direction = WeightGaining
+	case trend.ChangePercent <= -weightStablePercent:
+		trend.Direction = WeightLosing
+	}
+	return trend
+}
+
+// weightSlope returns the slope of the least squares line of the weights in kg per day.
+func weightSlope(weights []*PetWeight) float64 {
+	start := weights[0].MeasuredAt
+	n := float64(len(weights))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, w := range weights {
+		x := w.MeasuredAt.Sub(start).Hours() / 24
+		sumX += x
+		sumY += w.Weight
+		sumXY += x * w.Weight
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// roundTo rounds the value to the given number of decimal places.
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
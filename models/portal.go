@@ -0,0 +1,182 @@
+package models
+
+import (
+	"time"
+	"vet-clinic/repository"
+)
+
+// PortalDoctor is the data of a doctor shown to the clients in the portal.
+type PortalDoctor struct {
+	ID         uint   `json:"id"`
+	Surname    string `json:"surname"`
+	Name       string `json:"name"`
+	Patronymic string `json:"patronymic"`
+	Profession string `json:"profession"`
+}
+
+// PortalVisit is the data of a visit shown to its client in the portal.
+type PortalVisit struct {
+	ID        uint          `json:"id"`
+	DateTime  time.Time     `json:"dateTime"`
+	Info      string        `json:"info"`
+	PetID     uint          `json:"petId"`
+	Pet       *Pet          `json:"pet"`
+	ServiceID uint          `json:"serviceId"`
+	Service   *Service      `json:"service"`
+	Doctor    *PortalDoctor `json:"doctor"` // nil until the clinic assigns a doctor.
+	Status    string        `json:"status"` // requested, tentative, scheduled, cancelled, completed
+	Price     float64       `json:"price"`
+}
+
+// PortalInvoice is the data of an invoice shown to its client in the portal.
+type PortalInvoice struct {
+	ID              uint         `json:"id"`
+	IssuedAt        time.Time    `json:"issuedAt"`
+	Visit           *PortalVisit `json:"visit"`
+	Deposit         bool         `json:"deposit"`
+	Subtotal        float64      `json:"subtotal"`
+	DiscountPercent float64      `json:"discountPercent"`
+	Discount        float64      `json:"discount"`
+	PaidFromBalance float64      `json:"paidFromBalance"`
+	Total           float64      `json:"total"`
+}
+
+// PortalPrescription is the data of a prescription shown to the client in the portal.
+type PortalPrescription struct {
+	ID           uint          `json:"id"`
+	VisitID      uint          `json:"visitId"`
+	Medication   *Medication   `json:"medication"`
+	Dosage       string        `json:"dosage"`
+	Frequency    string        `json:"frequency"`
+	DurationDays int           `json:"durationDays"`
+	StartDate    time.Time     `json:"startDate"`
+	Instructions string        `json:"instructions"`
+	Prescriber   *PortalDoctor `json:"prescriber"`
+	Status       string        `json:"status"` // active, cancelled
+}
+
+// PortalRecord is the medical record of a pet shown to its client in the portal.
+type PortalRecord struct {
+	PetID         uint                  `json:"petId"`
+	Visits        []*PortalVisit        `json:"visits"` // The completed visits, the latest first.
+	Prescriptions []*PortalPrescription `json:"prescriptions"`
+	LabResults    []*LabResult          `json:"labResults"`
+	Weights       []*PetWeight          `json:"weights"`
+}
+
+// NewPortalDoctor returns the portal data of the doctor, nil if the doctor is not set.
+func NewPortalDoctor(user *User) *PortalDoctor {
+	if user == nil || user.BaseModel == nil {
+		return nil
+	}
+	return &PortalDoctor{
+		ID:         user.ID,
+		Surname:    user.Surname,
+		Name:       user.Name,
+		Patronymic: user.Patronymic,
+		Profession: user.Profession,
+	}
+}
+
+// NewPortalVisit returns the portal data of the visit.
+func NewPortalVisit(visit *Visit) *PortalVisit {
+	if visit == nil || visit.BaseModel == nil {
+		return nil
+	}
+	return &PortalVisit{
+		ID:        visit.ID,
+		DateTime:  visit.DateTime,
+		Info:      visit.Info,
+		PetID:     visit.PetID,
+		Pet:       visit.Pet,
+		ServiceID: visit.ServiceID,
+		Service:   visit.Service,
+		Doctor:    NewPortalDoctor(visit.Doctor),
+		Status:    visit.Status,
+		Price:     visit.Price,
+	}
+}
+
+// NewPortalVisits returns the portal data of the visits.
+func NewPortalVisits(visits []*Visit) []*PortalVisit {
+	result := make([]*PortalVisit, 0, len(visits))
+	for _, visit := range visits {
+		result = append(result, NewPortalVisit(visit))
+	}
+	return result
+}
+
+// NewPortalInvoices returns the portal data of the invoices.
+func NewPortalInvoices(invoices []*Invoice) []*PortalInvoice {
+	result := make([]*PortalInvoice, 0, len(invoices))
+	for _, invoice := range invoices {
+		result = append(result, &PortalInvoice{
+			ID:              invoice.ID,
+			IssuedAt:        invoice.CreatedAt,
+			Visit:           NewPortalVisit(invoice.Visit),
+			Deposit:         invoice.Deposit,
+			Subtotal:        invoice.Subtotal,
+			DiscountPercent: invoice.DiscountPercent,
+			Discount:        invoice.Discount,
+			PaidFromBalance: invoice.PaidFromBalance,
+			Total:           invoice.Total,
+		})
+	}
+	return result
+}
+
+// NewPortalPrescriptions returns the portal data of the prescriptions.
+func NewPortalPrescriptions(prescriptions []*Prescription) []*PortalPrescription {
+	result := make([]*PortalPrescription, 0, len(prescriptions))
+	for _, prescription := range prescriptions {
+		result = append(result, &PortalPrescription{
+			ID:           prescription.ID,
+			VisitID:      prescription.VisitID,
+			Medication:   prescription.Medication,
+			Dosage:       prescription.Dosage,
+			Frequency:    prescription.Frequency,
+			DurationDays: prescription.DurationDays,
+			StartDate:    prescription.StartDate,
+			Instructions: prescription.Instructions,
+			Prescriber:   NewPortalDoctor(prescription.Prescriber),
+			Status:       prescription.Status,
+		})
+	}
+	return result
+}
+
+// GetPortalRecord returns the medical record of the pet of the client.
+// It fails with record not found if the client is not the primary owner of the pet.
+func (m *Pet) GetPortalRecord(rep repository.Repository, id, clientID uint) (*PortalRecord, error) {
+	if err := rep.Where("client_id = ?", clientID).First(&Pet{}, id).Error; err != nil {
+		return nil, err
+	}
+
+	var visits []*Visit
+	if err := rep.Preload("Doctor").Preload("Service").Where("pet_id = ? AND status = ?", id, VisitCompleted).
+		Order("date_time DESC").Find(&visits).Error; err != nil {
+		return nil, err
+	}
+	if err := fillVisitPrices(rep, visits); err != nil {
+		return nil, err
+	}
+	prescriptions, err := (&Prescription{}).GetAllByPet(rep, id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := (&LabResult{}).GetAllByPet(rep, id, "")
+	if err != nil {
+		return nil, err
+	}
+	weights, err := (&PetWeight{}).GetAllByPet(rep, id)
+	if err != nil {
+		return nil, err
+	}
+	return &PortalRecord{
+		PetID:         id,
+		Visits:        NewPortalVisits(visits),
+		Prescriptions: NewPortalPrescriptions(prescriptions),
+		LabResults:    results,
+		Weights:       weights,
+	}, nil
+}
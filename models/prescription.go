@@ -0,0 +1,135 @@
+package models
+
+import (
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// PrescriptionActive is the status of the prescription to be followed.
+	PrescriptionActive = "active"
+	// PrescriptionCancelled is the status of the prescription cancelled by a doctor.
+	PrescriptionCancelled = "cancelled"
+)
+
+// Prescription defines struct of a medication prescribed for a pet at a visit.
+type Prescription struct {
+	*BaseModel
+	PetID        uint        `json:"petId" gorm:"index"`
+	Pet          *Pet        `json:"pet" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	VisitID      uint        `json:"visitId" gorm:"index"`
+	MedicationID uint        `json:"medicationId"`
+	Medication   *Medication `json:"medication" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Dosage       string      `json:"dosage" gorm:"size:255" example:"1 таблетка"`
+	Frequency    string      `json:"frequency" gorm:"size:255" example:"2 раза в день"`
+	DurationDays int         `json:"durationDays"` // 0 if the medication is taken until the prescription is cancelled.
+	StartDate    time.Time   `json:"startDate"`
+	Instructions string      `json:"instructions" gorm:"size:1000"`
+	PrescriberID uint        `json:"prescriberId"`
+	Prescriber   *User       `json:"prescriber" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Status       string      `json:"status"` // active, cancelled
+}
+
+// TableName returns the table name of prescription struct and it is used by gorm.
+func (*Prescription) TableName() string {
+	return "prescription"
+}
+
+// EndDate returns the date when the course ends. The zero time is returned if the course has no end.
+func (m *Prescription) EndDate() time.Time {
+	if m.DurationDays == 0 {
+		return time.Time{}
+	}
+	return m.StartDate.AddDate(0, 0, m.DurationDays)
+}
+
+// IsCurrent returns true if the medication is to be taken at the given time.
+func (m *Prescription) IsCurrent(now time.Time) bool {
+	if m.Status != PrescriptionActive || now.Before(m.StartDate) {
+		return false
+	}
+	end := m.EndDate()
+	return end.IsZero() || now.Before(end)
+}
+
+// Get returns prescription full matched given prescription ID.
+func (m *Prescription) Get(rep repository.Repository, id uint) (*Prescription, error) {
+	prescription := &Prescription{}
+	if err := rep.Preload("Pet").Preload("Pet.Client").Preload("Medication").Preload("Prescriber").
+		First(prescription, id).Error; err != nil {
+		return nil, err
+	}
+	return prescription, nil
+}
+
+// GetAllByPet returns the prescriptions of the pet, the latest first.
+func (m *Prescription) GetAllByPet(rep repository.Repository, petID uint) ([]*Prescription, error) {
+	if _, err := (&Pet{}).Exist(rep, petID); err != nil {
+		return nil, err
+	}
+	var prescriptions []*Prescription
+	if err := rep.Preload("Medication").Preload("Prescriber").Where("pet_id = ?", petID).
+		Order("start_date DESC, id DESC").Find(&prescriptions).Error; err != nil {
+		return nil, err
+	}
+	return prescriptions, nil
+}
+
+// GetCurrentByPet returns the active prescriptions of the pet whose course includes the given time.
+func (m *Prescription) GetCurrentByPet(rep repository.Repository, petID uint, now time.Time) ([]*Prescription, error) {
+	prescriptions, err := m.GetAllByPet(rep, petID)
+	if err != nil {
+		return nil, err
+	}
+	current := []*Prescription{}
+	for _, prescription := range prescriptions {
+		if prescription.IsCurrent(now) {
+			current = append(current, prescription)
+		}
+	}
+	return current, nil
+}
+
+// Create persists this prescription data. The pet is the one of the visit.
+// If the start date is not set, the course starts at the visit.
+func (m *Prescription) Create(rep repository.Repository) (*Prescription, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		visit := &Visit{}
+		if err := tx.First(visit, m.VisitID).Error; err != nil {
+			return apperror.NewInvalidReference("visitId", err)
+		}
+		medication := &Medication{}
+		if _, err := medication.Exist(tx, m.MedicationID); err != nil {
+			return apperror.NewInvalidReference("medicationId", err)
+		}
+
+		m.PetID = visit.PetID
+		m.Status = PrescriptionActive
+		if m.StartDate.IsZero() {
+			m.StartDate = visit.DateTime
+		}
+		return tx.Select("pet_id", "visit_id", "medication_id", "dosage", "frequency", "duration_days", "start_date",
+			"instructions", "prescriber_id", "status").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Cancel cancels this prescription, so the medication is no longer current.
+func (m *Prescription) Cancel(rep repository.Repository, id uint) (*Prescription, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		prescription := &Prescription{}
+		if err := tx.First(prescription, id).Error; err != nil {
+			return err
+		}
+		if prescription.Status == PrescriptionCancelled {
+			return apperror.NewInvalidState("the prescription has already been cancelled")
+		}
+		return tx.Model(&Prescription{}).Where("id = ?", id).Update("status", PrescriptionCancelled).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+	"vet-clinic/repository"
+)
+
+// Product defines struct of a product kept in stock, e.g. a vaccine or a consumable used in services.
+type Product struct {
+	*BaseModel
+	Name     string  `json:"name" gorm:"unique;not null;size:255" example:"Нобивак Tricat Trio"`
+	Unit     string  `json:"unit" gorm:"size:50" example:"доза"` // The unit the quantities are measured in.
+	Price    float64 `json:"price"`                              // The retail price of a unit, 0 if the product is not sold.
+	MinStock float64 `json:"minStock" example:"5"`               // The stock below which the product is reported as running low.
+}
+
+// TableName returns the table name of product struct and it is used by gorm.
+func (*Product) TableName() string {
+	return "product_master"
+}
+
+// ProductStock defines struct of the quantity of a product in stock.
+type ProductStock struct {
+	Product  *Product `json:"product"`
+	Quantity float64  `json:"quantity"` // The total quantity of the batches which have not expired.
+}
+
+// Exist returns true if a given product exits.
+func (m *Product) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&Product{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns product full matched given product ID.
+func (m *Product) Get(rep repository.Repository, id uint) (*Product, error) {
+	product := &Product{}
+	if err := rep.First(product, id).Error; err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetAll returns a slice of all products ordered by the name.
+func (m *Product) GetAll(rep repository.Repository) ([]*Product, error) {
+	var products []*Product
+	if err := rep.Model(&Product{}).Order("name").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetLowStock returns the products whose quantity in stock is below the minimum at the given time.
+// The expired batches are not counted, as they cannot be used.
+func (m *Product) GetLowStock(rep repository.Repository, now time.Time) ([]*ProductStock, error) {
+	products, err := m.GetAll(rep)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		ProductID uint
+		Quantity  float64
+	}
+	if err = rep.Model(&ProductBatch{}).Select("product_id, SUM(quantity) AS quantity").
+		Where("expiry_date >= ?", startOfDay(now)).Group("product_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	quantities := make(map[uint]float64)
+	for _, row := range rows {
+		quantities[row.ProductID] = row.Quantity
+	}
+
+	low := []*ProductStock{}
+	for _, product := range products {
+		if quantities[product.ID] < product.MinStock {
+			low = append(low, &ProductStock{Product: product, Quantity: quantities[product.ID]})
+		}
+	}
+	return low, nil
+}
+
+// Create persists this product data.
+func (m *Product) Create(rep repository.Repository) (*Product, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		return tx.Select("name", "unit", "price", "min_stock").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this product data.
+func (m *Product) Update(rep repository.Repository, id uint) (*Product, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+		return tx.Model(&Product{}).Where("id = ?", id).Select("name", "unit", "price", "min_stock").Updates(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this product data.
+// It fails if batches or services, including the soft-deleted ones, still refer to the product.
+func (m *Product) Delete(rep repository.Repository, id uint) (*Product, error) {
+	product := &Product{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if product, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&ProductBatch{}, "product_id"},
+			reference{&ServiceConsumable{}, "product_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&Product{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// StockReceived is the reason of the movement which receives a batch.
+	StockReceived = "received"
+	// StockUsed is the reason of the movement which uses the consumables of the service at a visit.
+	StockUsed = "used"
+	// StockWrittenOff is the reason of the movement which writes off the expired or damaged products.
+	StockWrittenOff = "written-off"
+)
+
+// ProductBatch defines struct of a batch of a product in stock.
+type ProductBatch struct {
+	*BaseModel
+	ProductID   uint      `json:"productId" gorm:"uniqueIndex:idx_product_batch"`
+	Product     *Product  `json:"product" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	BatchNumber string    `json:"batchNumber" gorm:"size:100;uniqueIndex:idx_product_batch" example:"A123B45"`
+	ExpiryDate  time.Time `json:"expiryDate"` // The batch can be used until the end of the day.
+	Quantity    float64   `json:"quantity"`   // The quantity left in stock.
+}
+
+// TableName returns the table name of product batch struct and it is used by gorm.
+func (*ProductBatch) TableName() string {
+	return "product_batch"
+}
+
+// StockMovement defines struct of a change of the quantity of a batch.
+type StockMovement struct {
+	*BaseModel
+	ProductID uint          `json:"productId" gorm:"index"`
+	BatchID   uint          `json:"batchId" gorm:"index"`
+	Batch     *ProductBatch `json:"batch" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	VisitID   uint          `json:"visitId" gorm:"index"` // The visit the products were used at, 0 for the other reasons.
+	Quantity  float64       `json:"quantity"`             // Positive if the stock increases, negative if it decreases.
+	Reason    string        `json:"reason"`               // received, used, written-off
+	Note      string        `json:"note" gorm:"size:1000"`
+}
+
+// TableName returns the table name of stock movement struct and it is used by gorm.
+func (*StockMovement) TableName() string {
+	return "stock_movement"
+}
+
+// Get returns product batch full matched given product batch ID.
+func (m *ProductBatch) Get(rep repository.Repository, id uint) (*ProductBatch, error) {
+	batch := &ProductBatch{}
+	if err := rep.Preload("Product").First(batch, id).Error; err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetAllByProduct returns the batches of the product, the earliest expiring first.
+func (m *ProductBatch) GetAllByProduct(rep repository.Repository, productID uint) ([]*ProductBatch, error) {
+	if _, err := (&Product{}).Exist(rep, productID); err != nil {
+		return nil, err
+	}
+	var batches []*ProductBatch
+	if err := rep.Where("product_id = ?", productID).Order("expiry_date, id").Find(&batches).Error; err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// GetExpiring returns the batches left in stock which expire before the given time, including the expired ones.
+func (m *ProductBatch) GetExpiring(rep repository.Repository, before time.Time) ([]*ProductBatch, error) {
+	var batches []*ProductBatch
+	if err := rep.Preload("Product").Where("quantity > 0 AND expiry_date < ?", before).
+		Order("expiry_date, id").Find(&batches).Error; err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// Receive persists this batch of the product received in stock.
+func (m *ProductBatch) Receive(rep repository.Repository, productID uint) (*ProductBatch, error) {
+	m.ProductID = productID
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Product{}).Exist(tx, productID); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Unscoped().Model(&ProductBatch{}).Where("product_id = ? AND batch_number = ?", productID,
+			m.BatchNumber).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperror.NewDuplicate("a batch with the same number already exists for the product")
+		}
+
+		if err := tx.Select("product_id", "batch_number", "expiry_date", "quantity").Create(m).Error; err != nil {
+			return err
+		}
+		return txRecordMovement(tx, m, 0, m.Quantity, StockReceived, "")
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// WriteOff decreases the quantity of the batch, e.g. the expired or damaged products.
+func (m *ProductBatch) WriteOff(rep repository.Repository, id uint, quantity float64, note string) (*ProductBatch, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		batch := &ProductBatch{}
+		if err := tx.First(batch, id).Error; err != nil {
+			return err
+		}
+		if quantity > batch.Quantity {
+			return apperror.NewInvalidState("the quantity exceeds the stock of the batch")
+		}
+		return txTakeFromBatch(tx, batch, quantity, 0, StockWrittenOff, note)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// GetAllByProduct returns the stock movements of the product, the latest first.
+func (m *StockMovement) GetAllByProduct(rep repository.Repository, productID uint) ([]*StockMovement, error) {
+	if _, err := (&Product{}).Exist(rep, productID); err != nil {
+		return nil, err
+	}
+	var movements []*StockMovement
+	if err := rep.Preload("Batch").Where("product_id = ?", productID).
+		Order("created_at DESC, id DESC").Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// txUseConsumables takes the consumables of the service of the visit from stock.
+// The batches expiring first are used first, the expired ones are not used.
+func txUseConsumables(tx repository.Repository, visit *Visit, now time.Time) error {
+	consumables, err := (&ServiceConsumable{}).GetAllByService(tx, visit.ServiceID)
+	if err != nil {
+		return err
+	}
+	for _, consumable := range consumables {
+		var batches []*ProductBatch
+		if err = tx.Where("product_id = ? AND quantity > 0 AND expiry_date >= ?", consumable.ProductID,
+			startOfDay(now)).Order("expiry_date, id").Find(&batches).Error; err != nil {
+			return err
+		}
+		remaining := consumable.Quantity
+		for _, batch := range batches {
+			if remaining <= 0 {
+				break
+			}
+			taken := batch.Quantity
+			if taken > remaining {
+				taken = remaining
+			}
+			if err = txTakeFromBatch(tx, batch, taken, visit.ID, StockUsed, ""); err != nil {
+				return err
+			}
+			remaining = roundTo(remaining-taken, 3)
+		}
+		if remaining > 0 {
+			return apperror.NewInvalidState(fmt.Sprintf("not enough stock of %s", consumable.Product.Name))
+		}
+	}
+	return nil
+}
+
+// txTakeFromBatch decreases the quantity of the batch and records the movement.
+// The quantity is decreased in the database, so a concurrent change of the stock is not lost,
+// and it fails if the batch no longer has the quantity.
+func txTakeFromBatch(tx repository.Repository, batch *ProductBatch, quantity float64, visitID uint,
+	reason, note string) error {
+	result := tx.Model(&ProductBatch{}).Where("id = ? AND quantity >= ?", batch.ID, quantity).
+		Update("quantity", gorm.Expr("ROUND(quantity - ?, 3)", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewInvalidState("the quantity exceeds the stock of the batch")
+	}
+	return txRecordMovement(tx, batch, visitID, -quantity, reason, note)
+}
+
+func txRecordMovement(tx repository.Repository, batch *ProductBatch, visitID uint, quantity float64,
+	reason, note string) error {
+	movement := &StockMovement{ProductID: batch.ProductID, BatchID: batch.ID, VisitID: visitID,
+		Quantity: quantity, Reason: reason, Note: note}
+	columns := []string{"product_id", "batch_id", "quantity", "reason", "note"}
+	if visitID != 0 {
+		columns = append(columns, "visit_id")
+	}
+	return tx.Select(columns).Create(movement).Error
+}
+
+// startOfDay returns the midnight of the day of the given time.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
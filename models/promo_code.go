@@ -0,0 +1,135 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// PromoCode defines struct of a promo code giving a discount on the invoice.
+type PromoCode struct {
+	*BaseModel
+	Code       string    `json:"code" gorm:"unique;not null;size:50" example:"SPRING10"` // Matched ignoring the case.
+	Percent    float64   `json:"percent" example:"10"`                                   // The discount in percent.
+	ValidFrom  time.Time `json:"validFrom"`                                              // The code is valid from the start of the day.
+	ValidTo    time.Time `json:"validTo"`                                                // The code is valid until the end of the day.
+	UsageLimit int       `json:"usageLimit" example:"100"`                               // The number of invoices the code can be used for, 0 if unlimited.
+	UsedCount  int       `json:"usedCount"`
+}
+
+// TableName returns the table name of promo code struct and it is used by gorm.
+func (*PromoCode) TableName() string {
+	return "promo_code"
+}
+
+// Exist returns true if a given promo code exits.
+func (m *PromoCode) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&PromoCode{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns promo code full matched given promo code ID.
+func (m *PromoCode) Get(rep repository.Repository, id uint) (*PromoCode, error) {
+	code := &PromoCode{}
+	if err := rep.First(code, id).Error; err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetAll returns a slice of all promo codes, the latest first.
+func (m *PromoCode) GetAll(rep repository.Repository) ([]*PromoCode, error) {
+	var codes []*PromoCode
+	if err := rep.Model(&PromoCode{}).Order("valid_from DESC, code").Find(&codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Create persists this promo code data.
+func (m *PromoCode) Create(rep repository.Repository) (*PromoCode, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		m.normalizePeriod()
+		return tx.Select("code", "percent", "valid_from", "valid_to", "usage_limit", "used_count").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this promo code data, the number of the uses is kept.
+func (m *PromoCode) Update(rep repository.Repository, id uint) (*PromoCode, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+		m.normalizePeriod()
+
+		return tx.Model(&PromoCode{}).Where("id = ?", id).
+			Select("code", "percent", "valid_from", "valid_to", "usage_limit").Updates(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this promo code data.
+// It fails if invoices, including the soft-deleted ones, still refer to the promo code.
+func (m *PromoCode) Delete(rep repository.Repository, id uint) (*PromoCode, error) {
+	code := &PromoCode{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if code, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&Invoice{}, "promo_code_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&PromoCode{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func (m *PromoCode) normalizePeriod() {
+	m.ValidFrom = startOfDay(m.ValidFrom)
+	m.ValidTo = startOfDay(m.ValidTo)
+}
+
+// txGetValidPromoCode returns the promo code matched the given code.
+// It fails if the code is not valid at the given time or its usage limit is reached.
+func txGetValidPromoCode(tx repository.Repository, code string, now time.Time) (*PromoCode, error) {
+	promo := &PromoCode{}
+	if err := tx.Where("UPPER(code) = UPPER(?)", code).First(promo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewInvalidReference("promoCode", err)
+		}
+		return nil, err
+	}
+	if now.Before(promo.ValidFrom) || !now.Before(promo.ValidTo.AddDate(0, 0, 1)) {
+		return nil, apperror.NewInvalidState("the promo code is not valid on this date")
+	}
+	if promo.UsageLimit > 0 && promo.UsedCount >= promo.UsageLimit {
+		return nil, apperror.NewInvalidState("the promo code has reached its usage limit")
+	}
+	return promo, nil
+}
+
+// txUsePromoCode counts the use of the promo code.
+// The usage limit is checked by the same update, so concurrent uses cannot exceed it.
+func txUsePromoCode(tx repository.Repository, promo *PromoCode) error {
+	result := tx.Model(&PromoCode{}).Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", promo.ID).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewInvalidState("the promo code has reached its usage limit")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// DefaultVisitDuration is the time a visit reserves the resources for if its service has no duration.
+const DefaultVisitDuration = 30 * time.Minute
+
+// Reservation defines struct of a resource reserved for a visit.
+type Reservation struct {
+	*BaseModel
+	VisitID    uint      `json:"visitId" gorm:"index"`
+	ResourceID uint      `json:"resourceId" gorm:"index"`
+	Resource   *Resource `json:"resource,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+}
+
+// TableName returns the table name of reservation struct and it is used by gorm.
+func (*Reservation) TableName() string {
+	return "reservation"
+}
+
+// GetAllByResource returns the reservations of the resource held by the upcoming visits in the given period.
+func (m *Reservation) GetAllByResource(rep repository.Repository, resourceID uint, from, to time.Time) ([]*Reservation, error) {
+	if _, err := (&Resource{}).Exist(rep, resourceID); err != nil {
+		return nil, err
+	}
+	return m.getActive(rep, []uint{resourceID}, 0, from, to)
+}
+
+// GetAllForService returns the reservations of the resources required by the service
+// held by the upcoming visits in the given period.
+func (m *Reservation) GetAllForService(rep repository.Repository, serviceID uint, from, to time.Time) ([]*Reservation, error) {
+	resourceIDs, err := txGetServiceResourceIDs(rep, serviceID)
+	if err != nil || len(resourceIDs) == 0 {
+		return []*Reservation{}, err
+	}
+	return m.getActive(rep, resourceIDs, 0, from, to)
+}
+
+// getActive returns the reservations of the given resources held by the tentative and scheduled visits,
+// except the given visit, which overlap the given period.
+func (m *Reservation) getActive(rep repository.Repository, resourceIDs []uint, exceptVisitID uint,
+	from, to time.Time) ([]*Reservation, error) {
+	var reservations []*Reservation
+	if err := rep.Model(&Reservation{}).Joins("JOIN visit_master ON visit_master.id = reservation.visit_id "+
+		"AND visit_master.deleted_at IS NULL AND visit_master.status IN ?", []string{VisitTentative, VisitScheduled}).
+		Where("reservation.resource_id IN ? AND reservation.visit_id <> ?", resourceIDs, exceptVisitID).
+		Where("reservation.start_time < ? AND reservation.end_time > ?", to, from).
+		Order("reservation.start_time").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// Overlaps returns true if the reservation overlaps the given period.
+func (m *Reservation) Overlaps(start, end time.Time) bool {
+	return m.StartTime.Before(end) && m.EndTime.After(start)
+}
+
+// txSyncReservations reserves the resources required by the service of the visit for the time of the visit,
+// the previous reservations of the visit are released. Only the tentative and scheduled visits hold reservations,
+// the reservations of a completed visit are kept as the record of the resources used.
+// The resources are locked until the transaction ends, so concurrent visits cannot reserve them at the same time.
+// It fails if a resource is already reserved for another visit at that time.
+func txSyncReservations(tx repository.Repository, visit *Visit, fallback time.Duration) error {
+	if visit.Status == VisitCompleted {
+		return nil
+	}
+	if err := tx.Unscoped().Where("visit_id = ?", visit.ID).Delete(&Reservation{}).Error; err != nil {
+		return err
+	}
+	if visit.Status != VisitTentative && visit.Status != VisitScheduled {
+		return nil
+	}
+	service := &Service{}
+	if err := tx.First(service, visit.ServiceID).Error; err != nil {
+		return err
+	}
+	resourceIDs, err := txGetServiceResourceIDs(tx, visit.ServiceID)
+	if err != nil || len(resourceIDs) == 0 {
+		return err
+	}
+
+	for _, resourceID := range resourceIDs {
+		if err := txLock(tx, &Resource{}, resourceID); err != nil {
+			return err
+		}
+	}
+
+	start := visit.DateTime
+	end := start.Add(service.VisitDuration(fallback))
+	taken, err := (&Reservation{}).getActive(tx, resourceIDs, visit.ID, start, end)
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		resource := &Resource{}
+		if err := tx.First(resource, taken[0].ResourceID).Error; err != nil {
+			return err
+		}
+		return apperror.NewInvalidState(fmt.Sprintf("the resource %s is already reserved for this time", resource.Name))
+	}
+	for _, resourceID := range resourceIDs {
+		reservation := &Reservation{VisitID: visit.ID, ResourceID: resourceID, StartTime: start, EndTime: end}
+		if err := tx.Select("visit_id", "resource_id", "start_time", "end_time").Create(reservation).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txGetServiceResourceIDs returns the IDs of the resources required by the service.
+func txGetServiceResourceIDs(tx repository.Repository, serviceID uint) ([]uint, error) {
+	var resourceIDs []uint
+	if err := tx.Model(&ServiceResource{}).Where("service_id = ?", serviceID).Order("id").
+		Pluck("resource_id", &resourceIDs).Error; err != nil {
+		return nil, err
+	}
+	return resourceIDs, nil
+}
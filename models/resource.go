@@ -0,0 +1,124 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// ResourceRoom is the type of a room where the visits take place.
+	ResourceRoom = "room"
+	// ResourceEquipment is the type of a piece of equipment used at the visits or lent to the clients.
+	ResourceEquipment = "equipment"
+)
+
+// Resource defines struct of a room or a piece of equipment of a department, which the services may require.
+type Resource struct {
+	*BaseModel
+	Name         string      `json:"name" gorm:"not null;size:255;uniqueIndex:idx_resource_name" example:"Кабинет 1"`
+	Type         string      `json:"type" gorm:"not null;size:20" example:"room"`
+	DepartmentID uint        `json:"departmentId" gorm:"uniqueIndex:idx_resource_name"`
+	Department   *Department `json:"department"`
+}
+
+// TableName returns the table name of resource struct and it is used by gorm.
+func (*Resource) TableName() string {
+	return "resource"
+}
+
+// Exist returns true if a given resource exits.
+func (m *Resource) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&Resource{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns resource full matched given resource ID.
+func (m *Resource) Get(rep repository.Repository, id uint) (*Resource, error) {
+	resource := &Resource{}
+	if err := rep.Preload("Department").First(resource, id).Error; err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll returns a slice of the resources ordered by name, the department is an optional filter, 0 means any.
+func (m *Resource) GetAll(rep repository.Repository, departmentID uint) ([]*Resource, error) {
+	var resources []*Resource
+	db := rep.Preload("Department").Order("name")
+	if departmentID != 0 {
+		db = db.Where("department_id = ?", departmentID)
+	}
+	if err := db.Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// Create persists this resource data.
+func (m *Resource) Create(rep repository.Repository) (*Resource, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := m.txCheck(tx, 0); err != nil {
+			return err
+		}
+		return tx.Select("name", "type", "department_id").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this resource data.
+func (m *Resource) Update(rep repository.Repository, id uint) (*Resource, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+		if err := m.txCheck(tx, id); err != nil {
+			return err
+		}
+		return tx.Model(&Resource{}).Where("id = ?", id).
+			Select("name", "type", "department_id").Updates(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// txCheck returns an error if the department does not exist
+// or if the department has another resource with the same name.
+func (m *Resource) txCheck(tx repository.Repository, id uint) error {
+	if _, err := (&Department{}).Exist(tx, m.DepartmentID); err != nil {
+		return apperror.NewInvalidReference("departmentId", err)
+	}
+	var count int64
+	if err := tx.Model(&Resource{}).Where("department_id = ? AND name = ? AND id <> ?", m.DepartmentID, m.Name, id).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperror.NewDuplicate("the department already has a resource with the same name")
+	}
+	return nil
+}
+
+// Delete deletes this resource data.
+// The resource which is required by a service or reserved for a visit cannot be deleted.
+func (m *Resource) Delete(rep repository.Repository, id uint) (*Resource, error) {
+	resource := &Resource{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if resource, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&ServiceResource{}, "resource_id"},
+			reference{&Reservation{}, "resource_id"}); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&Resource{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
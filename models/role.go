@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"hash/crc32"
 	"vet-clinic/repository"
 )
 
@@ -20,6 +22,17 @@ func NewRole(name string) *Role {
 	return &Role{Name: name}
 }
 
+// ETag returns the entity tag of this role.
+// The role has no update time, so the tag is derived from its name.
+func (m *Role) ETag() string {
+	return fmt.Sprintf("\"%d-%x\"", m.ID, crc32.ChecksumIEEE([]byte(m.Name)))
+}
+
+// Version returns the column values which identify this version of the role, the ones its tag is derived from.
+func (m *Role) Version() map[string]interface{} {
+	return map[string]interface{}{"id": m.ID, "name": m.Name}
+}
+
 // Exist returns true if a given role exits.
 func (m *Role) Exist(rep repository.Repository, id uint) (bool, error) {
 	if err := rep.First(&Role{}, id).Error; err != nil {
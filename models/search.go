@@ -0,0 +1,192 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"unicode"
+	"vet-clinic/repository"
+)
+
+const (
+	// SearchClient is the type of the search results for clients.
+	SearchClient = "client"
+	// SearchPet is the type of the search results for pets.
+	SearchPet = "pet"
+	// SearchUser is the type of the search results for users.
+	SearchUser = "user"
+	// SearchLead is the type of the search results for leads.
+	SearchLead = "lead"
+)
+
+// SearchResult is a record found by the search.
+type SearchResult struct {
+	Type     string `json:"type" example:"client"`
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+}
+
+// searchColumns are the columns of a table matched by the search.
+// The words of the query are matched against the text columns and the whole query is matched against the phone columns.
+type searchColumns struct {
+	text  []string
+	phone []string
+}
+
+var (
+	clientSearchColumns = searchColumns{text: []string{"surname", "name", "patronymic", "email"}, phone: []string{"phone"}}
+	petSearchColumns    = searchColumns{text: []string{"name", "breed"}}
+	userSearchColumns   = searchColumns{
+		text: []string{"surname", "name", "patronymic", "username", "email"}, phone: []string{"phone"}}
+	leadSearchColumns = searchColumns{text: []string{"name", "email"}, phone: []string{"phone"}}
+)
+
+// Search returns the clients whose names, phone or e-mail start with the query, at most limit records.
+func (m *Client) Search(rep repository.Repository, query string, limit int) ([]*Client, error) {
+	var clients []*Client
+	if err := rep.Scopes(searchScope(clientSearchColumns, query)).
+		Order("id").Limit(limit).Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// Search returns the pets whose name or breed start with the query, at most limit records.
+func (m *Pet) Search(rep repository.Repository, query string, limit int) ([]*Pet, error) {
+	var pets []*Pet
+	if err := rep.Preload("Client").Scopes(searchScope(petSearchColumns, query)).
+		Order("id").Limit(limit).Find(&pets).Error; err != nil {
+		return nil, err
+	}
+	return pets, nil
+}
+
+// Search returns the users whose names, username, phone or e-mail start with the query, at most limit records.
+func (m *User) Search(rep repository.Repository, query string, limit int) ([]*User, error) {
+	var users []*User
+	if err := rep.Scopes(searchScope(userSearchColumns, query)).
+		Order("id").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Search returns the leads whose name, phone or e-mail start with the query, at most limit records.
+func (m *Lead) Search(rep repository.Repository, query string, limit int) ([]*Lead, error) {
+	var leads []*Lead
+	if err := rep.Scopes(searchScope(leadSearchColumns, query)).
+		Order("id").Limit(limit).Find(&leads).Error; err != nil {
+		return nil, err
+	}
+	return leads, nil
+}
+
+// searchScope matches the records where each word of the query is a case-insensitive prefix of a word
+// of any text column, or where the query is a prefix of any phone column ignoring the spaces, dashes and parentheses.
+func searchScope(columns searchColumns, query string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		var conditions []string
+		var args []interface{}
+
+		words := strings.Fields(strings.ToLower(query))
+		if len(words) > 0 && len(columns.text) > 0 {
+			var all []string
+			for _, word := range words {
+				var matches []string
+				for _, column := range columns.text {
+					matches = append(matches, fmt.Sprintf("LOWER(%[1]s) LIKE ? ESCAPE '!' OR LOWER(%[1]s) LIKE ? ESCAPE '!'", column))
+					args = append(args, escapeLike(word)+"%", "% "+escapeLike(word)+"%")
+				}
+				all = append(all, "("+strings.Join(matches, " OR ")+")")
+			}
+			conditions = append(conditions, "("+strings.Join(all, " AND ")+")")
+		}
+
+		if phone := normalizePhone(query); phone != "" {
+			for _, column := range columns.phone {
+				conditions = append(conditions, fmt.Sprintf("%[1]s LIKE ? OR %[1]s LIKE ?", column))
+				args = append(args, phone+"%", "+"+strings.TrimPrefix(phone, "+")+"%")
+			}
+		}
+
+		if len(conditions) == 0 {
+			return db.Where("1 = 0")
+		}
+		return db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+}
+
+// escapeLike escapes the wildcards of the LIKE pattern with '!'.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+// normalizePhone returns the query without the phone number formatting, or "" if it is not a phone number.
+func normalizePhone(query string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(query) {
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return ""
+		}
+	}
+	if len(strings.TrimPrefix(b.String(), "+")) == 0 {
+		return ""
+	}
+	return b.String()
+}
+
+// ToSearchResult returns the search result of this client.
+func (m *Client) ToSearchResult() *SearchResult {
+	return &SearchResult{
+		Type:     SearchClient,
+		ID:       m.ID,
+		Title:    joinNonEmpty(" ", m.Surname, m.Name, m.Patronymic),
+		Subtitle: joinNonEmpty(", ", m.Phone, m.Email),
+	}
+}
+
+// ToSearchResult returns the search result of this pet.
+func (m *Pet) ToSearchResult() *SearchResult {
+	result := &SearchResult{Type: SearchPet, ID: m.ID, Title: m.Name, Subtitle: joinNonEmpty(", ", m.Type, m.Breed)}
+	if m.Client != nil {
+		result.Subtitle = joinNonEmpty(", ", result.Subtitle, joinNonEmpty(" ", m.Client.Surname, m.Client.Name))
+	}
+	return result
+}
+
+// ToSearchResult returns the search result of this user.
+func (m *User) ToSearchResult() *SearchResult {
+	return &SearchResult{
+		Type:     SearchUser,
+		ID:       m.ID,
+		Title:    joinNonEmpty(" ", m.Surname, m.Name, m.Patronymic),
+		Subtitle: joinNonEmpty(", ", m.Profession, m.Phone, m.Email),
+	}
+}
+
+// ToSearchResult returns the search result of this lead.
+func (m *Lead) ToSearchResult() *SearchResult {
+	return &SearchResult{
+		Type:     SearchLead,
+		ID:       m.ID,
+		Title:    m.Name,
+		Subtitle: joinNonEmpty(", ", m.Phone, m.Email, m.Status),
+	}
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
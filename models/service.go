@@ -1,6 +1,9 @@
 package models
 
 import (
+	"strings"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/repository"
 )
 
@@ -8,7 +11,12 @@ import (
 type Service struct {
 	*BaseModel
 	Name        string        `json:"name" gorm:"unique;not null;size:255"`
-	Price       float64       `json:"price" gorm:"not null"`
+	Price       float64       `json:"price" gorm:"not null"` // The base price set by the last update, the price history keeps the effective prices.
+	Deposit     bool          `json:"deposit"`               // True if the price is a deposit returned to the client, it is credited to the balance of the client.
+	Description string        `json:"description" gorm:"size:1000"`
+	Duration    int           `json:"duration"`                   // The usual duration in minutes, 0 if not set.
+	Profession  string        `json:"profession" gorm:"size:255"` // The profession required from the doctor providing the service, empty if any.
+	Archived    bool          `json:"archived"`                   // An archived service is kept for the history but cannot be booked.
 	CategoryID  uint          `json:"categoryId"`
 	Category    *Category     `json:"category"`
 	Users       []*User       `json:"users" gorm:"many2many:users_services;"`
@@ -43,12 +51,32 @@ func (m *Service) Get(rep repository.Repository, id uint) (*Service, error) {
 	return service, nil
 }
 
-// GetAll returns a slice of all services.
-func (m *Service) GetAll(rep repository.Repository) ([]*Service, error) {
+// GetAll returns a slice of the services filtered by the category along with its subcategories,
+// the department and the archived flag. The zero IDs and a nil flag mean any.
+func (m *Service) GetAll(rep repository.Repository, categoryID, departmentID uint, archived *bool) ([]*Service, error) {
 	var services []*Service
-
-	if err := rep.Preload("Category").Preload("Users").
-		Preload("Departments").Find(&services).Error; err != nil {
+	query := rep.Preload("Category").Preload("Users").Preload("Departments")
+	if categoryID != 0 {
+		if _, err := (&Category{}).Exist(rep, categoryID); err != nil {
+			return nil, apperror.NewInvalidReference("categoryId", err)
+		}
+		subtree, err := txGetCategorySubtree(rep, categoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("service_master.category_id IN ?", subtree)
+	}
+	if departmentID != 0 {
+		if _, err := (&Department{}).Exist(rep, departmentID); err != nil {
+			return nil, apperror.NewInvalidReference("departmentId", err)
+		}
+		query = query.Joins("JOIN departments_services ON departments_services.service_id = service_master.id "+
+			"AND departments_services.department_id = ?", departmentID)
+	}
+	if archived != nil {
+		query = query.Where("service_master.archived = ?", *archived)
+	}
+	if err := query.Order("service_master.id").Find(&services).Error; err != nil {
 		return nil, err
 	}
 	return services, nil
@@ -67,10 +95,15 @@ func (m *Service) Create(rep repository.Repository) (*Service, error) {
 func txCreateService(tx repository.Repository, m *Service) error {
 	category := &Category{}
 	if _, err := category.Exist(tx, m.CategoryID); err != nil {
-		return err
+		return apperror.NewInvalidReference("categoryId", err)
 	}
 
-	return tx.Select("name", "price", "category_id").Create(m).Error
+	m.Profession = strings.TrimSpace(m.Profession)
+	if err := tx.Select("name", "price", "deposit", "description", "duration", "profession", "archived",
+		"category_id").Create(m).Error; err != nil {
+		return err
+	}
+	return txRecordServicePrice(tx, m.ID, m.Price, m.CreatedAt)
 }
 
 // Update updates this service data.
@@ -84,17 +117,29 @@ func (m *Service) Update(rep repository.Repository, id uint) (*Service, error) {
 }
 
 func txUpdateService(tx repository.Repository, m *Service, id uint) error {
-	if _, err := m.Exist(tx, id); err != nil {
+	current := &Service{}
+	if err := tx.First(current, id).Error; err != nil {
 		return err
 	}
 
 	category := &Category{}
 	if _, err := category.Exist(tx, m.CategoryID); err != nil {
-		return err
+		return apperror.NewInvalidReference("categoryId", err)
 	}
 
-	return tx.Model(&Service{}).Where("id = ?", id).
-		Select("name", "price", "category_id").Updates(m).Error
+	m.Profession = strings.TrimSpace(m.Profession)
+	if err := tx.Model(&Service{}).Where("id = ?", id).
+		Select("name", "price", "deposit", "description", "duration", "profession", "archived",
+			"category_id").Updates(m).Error; err != nil {
+		return err
+	}
+	if current.Price == m.Price {
+		return nil
+	}
+	if err := tx.First(current, id).Error; err != nil {
+		return err
+	}
+	return txRecordServicePrice(tx, id, m.Price, current.UpdatedAt)
 }
 
 // Delete deletes this service data.
@@ -111,3 +156,39 @@ func (m *Service) Delete(rep repository.Repository, id uint) (*Service, error) {
 	}
 	return service, nil
 }
+
+// VisitDuration returns the time a visit for the service takes, the given duration is used if the service has none.
+func (m *Service) VisitDuration(fallback time.Duration) time.Duration {
+	if m.Duration > 0 {
+		return time.Duration(m.Duration) * time.Minute
+	}
+	return fallback
+}
+
+// providedBy returns true if the user has the profession required by this service.
+func (m *Service) providedBy(user *User) bool {
+	return m.Profession == "" || strings.EqualFold(strings.TrimSpace(user.Profession), m.Profession)
+}
+
+// txCheckServiceProvider returns an error if the service is archived
+// or if the doctor does not have the profession required by the service.
+func txCheckServiceProvider(tx repository.Repository, serviceID, doctorID uint) error {
+	service := &Service{}
+	if err := tx.First(service, serviceID).Error; err != nil {
+		return apperror.NewInvalidReference("serviceId", err)
+	}
+	if service.Archived {
+		return apperror.NewInvalidState("the service is archived")
+	}
+	if service.Profession == "" {
+		return nil
+	}
+	doctor := &User{}
+	if err := tx.First(doctor, doctorID).Error; err != nil {
+		return apperror.NewInvalidReference("doctorId", err)
+	}
+	if !service.providedBy(doctor) {
+		return apperror.NewInvalidState("the doctor does not have the profession required by the service")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// ServiceConsumable defines the quantity of a product used every time the service is provided.
+type ServiceConsumable struct {
+	*BaseModel
+	ServiceID uint     `json:"serviceId" gorm:"uniqueIndex:idx_service_consumable"`
+	ProductID uint     `json:"productId" gorm:"uniqueIndex:idx_service_consumable;index"`
+	Product   *Product `json:"product" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Quantity  float64  `json:"quantity" example:"1"`
+}
+
+// TableName returns the table name of service consumable struct and it is used by gorm.
+func (*ServiceConsumable) TableName() string {
+	return "service_consumable"
+}
+
+// GetAllByService returns the consumables of the service.
+func (m *ServiceConsumable) GetAllByService(rep repository.Repository, serviceID uint) ([]*ServiceConsumable, error) {
+	if _, err := (&Service{}).Exist(rep, serviceID); err != nil {
+		return nil, err
+	}
+	var consumables []*ServiceConsumable
+	if err := rep.Preload("Product").Where("service_id = ?", serviceID).Order("id").
+		Find(&consumables).Error; err != nil {
+		return nil, err
+	}
+	return consumables, nil
+}
+
+// SetForService replaces the consumables of the service with the given ones.
+func (m *ServiceConsumable) SetForService(rep repository.Repository, serviceID uint,
+	consumables []*ServiceConsumable) ([]*ServiceConsumable, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Service{}).Exist(tx, serviceID); err != nil {
+			return err
+		}
+		seen := make(map[uint]bool)
+		for _, consumable := range consumables {
+			if _, err := (&Product{}).Exist(tx, consumable.ProductID); err != nil {
+				return apperror.NewInvalidReference("productId", err)
+			}
+			if seen[consumable.ProductID] {
+				return apperror.NewDuplicate("the product is listed more than once")
+			}
+			seen[consumable.ProductID] = true
+		}
+		if err := tx.Unscoped().Where("service_id = ?", serviceID).Delete(&ServiceConsumable{}).Error; err != nil {
+			return err
+		}
+		for _, consumable := range consumables {
+			row := &ServiceConsumable{ServiceID: serviceID, ProductID: consumable.ProductID, Quantity: consumable.Quantity}
+			if err := tx.Select("service_id", "product_id", "quantity").Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m.GetAllByService(rep, serviceID)
+}
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// ServicePrice defines struct of the price of a service effective from a date.
+type ServicePrice struct {
+	*BaseModel
+	ServiceID     uint      `json:"serviceId" gorm:"uniqueIndex:idx_service_price"`
+	DepartmentID  uint      `json:"departmentId" gorm:"uniqueIndex:idx_service_price"` // The department the price is overridden for, 0 for the base price.
+	Price         float64   `json:"price" gorm:"not null"`
+	EffectiveFrom time.Time `json:"effectiveFrom" gorm:"uniqueIndex:idx_service_price"` // The price applies from the start of the day.
+}
+
+// PriceListItem defines struct of a line of the price list.
+type PriceListItem struct {
+	CategoryID  uint    `json:"categoryId"`
+	Category    string  `json:"category"`
+	ServiceID   uint    `json:"serviceId"`
+	ServiceName string  `json:"serviceName"`
+	Price       float64 `json:"price"`
+}
+
+// ResolvedPrice defines struct of the price of a service in a department as of a date.
+type ResolvedPrice struct {
+	ServiceID    uint      `json:"serviceId"`
+	DepartmentID uint      `json:"departmentId"`
+	Date         time.Time `json:"date"`
+	Price        float64   `json:"price"`
+}
+
+// TableName returns the table name of service price struct and it is used by gorm.
+func (*ServicePrice) TableName() string {
+	return "service_price"
+}
+
+// Get returns service price full matched given service price ID.
+func (m *ServicePrice) Get(rep repository.Repository, id uint) (*ServicePrice, error) {
+	price := &ServicePrice{}
+	if err := rep.First(price, id).Error; err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// GetAllByService returns the price history of the service, the latest first.
+func (m *ServicePrice) GetAllByService(rep repository.Repository, serviceID uint) ([]*ServicePrice, error) {
+	var prices []*ServicePrice
+	if err := rep.Where("service_id = ?", serviceID).
+		Order("effective_from DESC").Order("department_id").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// Create persists this service price data.
+func (m *ServicePrice) Create(rep repository.Repository, serviceID uint) (*ServicePrice, error) {
+	m.ServiceID = serviceID
+	m.EffectiveFrom = startOfDay(m.EffectiveFrom)
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Service{}).Exist(tx, serviceID); err != nil {
+			return err
+		}
+		if m.DepartmentID != 0 {
+			if _, err := (&Department{}).Exist(tx, m.DepartmentID); err != nil {
+				return apperror.NewInvalidReference("departmentId", err)
+			}
+		}
+		var count int64
+		if err := tx.Model(&ServicePrice{}).Where("service_id = ? AND department_id = ? AND effective_from = ?",
+			serviceID, m.DepartmentID, m.EffectiveFrom).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperror.NewDuplicate("a price effective from the same date already exists for the service and department")
+		}
+		return tx.Select("service_id", "department_id", "price", "effective_from").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Delete deletes the price which is not yet effective.
+func (m *ServicePrice) Delete(rep repository.Repository, id uint, now time.Time) (*ServicePrice, error) {
+	price := &ServicePrice{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if price, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if !price.EffectiveFrom.After(now) {
+			return apperror.NewInvalidState("only the prices which are not yet effective can be deleted")
+		}
+		return tx.Unscoped().Delete(&ServicePrice{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// Resolve returns the price of the service in the department as of the given date.
+// The price overridden for the department takes precedence over the base price.
+func (m *ServicePrice) Resolve(rep repository.Repository, serviceID, departmentID uint, date time.Time) (*ResolvedPrice, error) {
+	service := &Service{}
+	if err := rep.First(service, serviceID).Error; err != nil {
+		return nil, err
+	}
+	if departmentID != 0 {
+		if _, err := (&Department{}).Exist(rep, departmentID); err != nil {
+			return nil, apperror.NewInvalidReference("departmentId", err)
+		}
+	}
+	prices, err := m.getHistory(rep, []uint{serviceID}, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedPrice{
+		ServiceID:    serviceID,
+		DepartmentID: departmentID,
+		Date:         date,
+		Price:        prices.resolve(service.ID, departmentID, date, service.Price),
+	}, nil
+}
+
+// GetPriceList returns the prices of the services in the department as of the given date ordered by category and name.
+// The archived services are not listed. The category, which includes its subcategories, and the department
+// are optional filters, the department also limits the list to its services.
+func (m *ServicePrice) GetPriceList(rep repository.Repository, categoryID, departmentID uint,
+	date time.Time) ([]*PriceListItem, error) {
+	query := rep.Preload("Category").Where("service_master.archived = ?", false)
+	if categoryID != 0 {
+		if _, err := (&Category{}).Exist(rep, categoryID); err != nil {
+			return nil, apperror.NewInvalidReference("categoryId", err)
+		}
+		subtree, err := txGetCategorySubtree(rep, categoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("service_master.category_id IN ?", subtree)
+	}
+	if departmentID != 0 {
+		if _, err := (&Department{}).Exist(rep, departmentID); err != nil {
+			return nil, apperror.NewInvalidReference("departmentId", err)
+		}
+		query = query.Joins("JOIN departments_services ON departments_services.service_id = service_master.id "+
+			"AND departments_services.department_id = ?", departmentID)
+	}
+	var services []*Service
+	if err := query.Order("service_master.name").Find(&services).Error; err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(services))
+	for _, service := range services {
+		ids = append(ids, service.ID)
+	}
+	prices, err := m.getHistory(rep, ids, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*PriceListItem, 0, len(services))
+	for _, service := range services {
+		item := &PriceListItem{
+			CategoryID:  service.CategoryID,
+			ServiceID:   service.ID,
+			ServiceName: service.Name,
+			Price:       prices.resolve(service.ID, departmentID, date, service.Price),
+		}
+		if service.Category != nil {
+			item.Category = service.Category.Name
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Category < items[j].Category })
+	return items, nil
+}
+
+// priceHistory is the price history of services, each list is ordered by the effective date.
+type priceHistory map[uint][]*ServicePrice
+
+func (m *ServicePrice) getHistory(rep repository.Repository, serviceIDs []uint, departmentID uint) (priceHistory, error) {
+	var prices []*ServicePrice
+	if err := rep.Where("service_id IN ? AND department_id IN ?", serviceIDs, []uint{0, departmentID}).
+		Order("effective_from").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	history := make(priceHistory)
+	for _, price := range prices {
+		history[price.ServiceID] = append(history[price.ServiceID], price)
+	}
+	return history, nil
+}
+
+// resolve returns the latest price effective on the date, the department price first.
+// Before the first base price the service was created with that price, so it applies to the earlier dates too.
+func (h priceHistory) resolve(serviceID, departmentID uint, date time.Time, fallback float64) float64 {
+	var base, override *ServicePrice
+	for _, price := range h[serviceID] {
+		if price.EffectiveFrom.After(date) {
+			if price.DepartmentID == 0 && base == nil {
+				base = price
+			}
+			continue
+		}
+		if price.DepartmentID == 0 {
+			base = price
+		} else if departmentID != 0 {
+			override = price
+		}
+	}
+	if override != nil {
+		return override.Price
+	}
+	if base != nil {
+		return base.Price
+	}
+	return fallback
+}
+
+// txRecordServicePrice records the base price of the service effective from the day of the given time,
+// the price already recorded for that day is replaced.
+func txRecordServicePrice(tx repository.Repository, serviceID uint, price float64, at time.Time) error {
+	day := startOfDay(at)
+	current := &ServicePrice{}
+	err := tx.Where("service_id = ? AND department_id = 0 AND effective_from = ?", serviceID, day).First(current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		record := &ServicePrice{ServiceID: serviceID, Price: price, EffectiveFrom: day}
+		return tx.Select("service_id", "department_id", "price", "effective_from").Create(record).Error
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(&ServicePrice{}).Where("id = ?", current.ID).Update("price", price).Error
+}
+
+// fillVisitPrices sets the base price of the service as of the date of each visit.
+func fillVisitPrices(rep repository.Repository, visits []*Visit) error {
+	ids := make([]uint, 0, len(visits))
+	for _, visit := range visits {
+		if visit.ServiceID != 0 {
+			ids = append(ids, visit.ServiceID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	prices, err := (&ServicePrice{}).getHistory(rep, ids, 0)
+	if err != nil {
+		return err
+	}
+	for _, visit := range visits {
+		var fallback float64
+		if visit.Service != nil {
+			fallback = visit.Service.Price
+		}
+		visit.Price = prices.resolve(visit.ServiceID, 0, visit.DateTime, fallback)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// ServiceResource defines a resource required every time the service is provided.
+type ServiceResource struct {
+	*BaseModel
+	ServiceID  uint      `json:"serviceId" gorm:"uniqueIndex:idx_service_resource"`
+	ResourceID uint      `json:"resourceId" gorm:"uniqueIndex:idx_service_resource;index"`
+	Resource   *Resource `json:"resource"`
+}
+
+// TableName returns the table name of service resource struct and it is used by gorm.
+func (*ServiceResource) TableName() string {
+	return "service_resource"
+}
+
+// GetAllByService returns the resources required by the service.
+func (m *ServiceResource) GetAllByService(rep repository.Repository, serviceID uint) ([]*ServiceResource, error) {
+	if _, err := (&Service{}).Exist(rep, serviceID); err != nil {
+		return nil, err
+	}
+	var resources []*ServiceResource
+	if err := rep.Preload("Resource").Where("service_id = ?", serviceID).Order("id").
+		Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// SetForService replaces the resources required by the service with the given ones.
+func (m *ServiceResource) SetForService(rep repository.Repository, serviceID uint,
+	resourceIDs []uint) ([]*ServiceResource, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&Service{}).Exist(tx, serviceID); err != nil {
+			return err
+		}
+		seen := make(map[uint]bool)
+		for _, resourceID := range resourceIDs {
+			if _, err := (&Resource{}).Exist(tx, resourceID); err != nil {
+				return apperror.NewInvalidReference("resourceIds", err)
+			}
+			if seen[resourceID] {
+				return apperror.NewDuplicate("the resource is listed more than once")
+			}
+			seen[resourceID] = true
+		}
+		if err := tx.Unscoped().Where("service_id = ?", serviceID).Delete(&ServiceResource{}).Error; err != nil {
+			return err
+		}
+		for _, resourceID := range resourceIDs {
+			row := &ServiceResource{ServiceID: serviceID, ResourceID: resourceID}
+			if err := tx.Select("service_id", "resource_id").Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m.GetAllByService(rep, serviceID)
+}
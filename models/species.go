@@ -0,0 +1,162 @@
+package models
+
+import (
+	"strings"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+	"vet-clinic/util"
+)
+
+// Species defines struct of species data.
+type Species struct {
+	*BaseModel
+	Name string `json:"name" gorm:"unique;not null;size:255"`
+	// Aliases are the other names of the species separated by commas, e.g. "кот,cat".
+	// They are recognized in Pet.Type along with the name regardless of the case.
+	Aliases string `json:"aliases" gorm:"size:1000"`
+}
+
+// TableName returns the table name of species struct and it is used by gorm.
+func (*Species) TableName() string {
+	return "species_master"
+}
+
+// NewSpecies is constructor.
+func NewSpecies(name string, aliases ...string) *Species {
+	return &Species{Name: name, Aliases: strings.Join(aliases, ",")}
+}
+
+// Exist returns true if a given species exits.
+func (m *Species) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&Species{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns species full matched given species ID.
+func (m *Species) Get(rep repository.Repository, id uint) (*Species, error) {
+	species := &Species{}
+	if err := rep.First(species, id).Error; err != nil {
+		return nil, err
+	}
+	return species, nil
+}
+
+// GetAll returns a slice of all species.
+func (m *Species) GetAll(rep repository.Repository) ([]*Species, error) {
+	var species []*Species
+	if err := rep.Model(&Species{}).Order("name").Find(&species).Error; err != nil {
+		return nil, err
+	}
+	return species, nil
+}
+
+// FindByName returns the species whose name or alias equals the given name regardless of the case.
+// It returns nil if there is no such species.
+func (m *Species) FindByName(rep repository.Repository, name string) (*Species, error) {
+	all, err := m.GetAll(rep)
+	if err != nil {
+		return nil, err
+	}
+	for _, species := range all {
+		if species.matches(name) {
+			return species, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create persists this species data.
+func (m *Species) Create(rep repository.Repository) (*Species, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := m.txCheckNames(tx, 0); err != nil {
+			return err
+		}
+		return tx.Select("name", "aliases").Create(m).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this species data.
+// The type of the pets of the species is renamed along with the species.
+func (m *Species) Update(rep repository.Repository, id uint) (*Species, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+		if err := m.txCheckNames(tx, id); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&Species{}).Where("id = ?", id).Select("name", "aliases").Updates(m).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Pet{}).Where("species_id = ?", id).Update("type", m.Name).Error
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete deletes this species data.
+// It fails if breeds or pets, including the soft-deleted ones, still refer to the species.
+func (m *Species) Delete(rep repository.Repository, id uint) (*Species, error) {
+	species := &Species{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if species, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err = txCheckNotReferenced(tx, id, reference{&Breed{}, "species_id"}, reference{&Pet{}, "species_id"}); err != nil {
+			return err
+		}
+		return tx.Delete(&Species{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return species, nil
+}
+
+// names returns the normalized name and aliases of this species.
+func (m *Species) names() []string {
+	names := []string{util.NormalizeName(m.Name)}
+	for _, alias := range strings.Split(m.Aliases, ",") {
+		if alias = util.NormalizeName(alias); alias != "" {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
+// matches returns true if the given name equals the name or an alias of this species regardless of the case.
+func (m *Species) matches(name string) bool {
+	name = util.NormalizeName(name)
+	for _, n := range m.names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// txCheckNames returns an error if the name or an alias of this species is used by another species.
+func (m *Species) txCheckNames(tx repository.Repository, id uint) error {
+	all, err := m.GetAll(tx)
+	if err != nil {
+		return err
+	}
+	for _, other := range all {
+		if other.ID == id {
+			continue
+		}
+		for _, name := range m.names() {
+			if other.matches(name) {
+				return apperror.NewDuplicate("a species with the same name or alias already exists")
+			}
+		}
+	}
+	return nil
+}
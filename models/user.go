@@ -6,6 +6,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"strconv"
 	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/config"
 	"vet-clinic/repository"
 )
@@ -114,7 +115,7 @@ func (m *User) Create(rep repository.Repository) (*User, error) {
 func txCreateUser(tx repository.Repository, m *User) error {
 	role := &Role{}
 	if _, err := role.Exist(tx, m.RoleID); err != nil {
-		return err
+		return apperror.NewInvalidReference("roleId", err)
 	}
 
 	m.Active = true
@@ -153,7 +154,7 @@ func txUpdateUser(tx repository.Repository, m *User, id uint) error {
 		return err
 	}
 
-	makeUserSlug(m)
+	makeUserSlug(m, id)
 
 	return tx.Model(&User{}).Where("id = ?", id).
 		Select("email", "phone", "surname", "name", "patronymic", "sex", "birth_date", "slug").
@@ -167,7 +168,7 @@ func txUpdateUserOwner(tx repository.Repository, m *User, id uint) error {
 
 	role := &Role{}
 	if _, err := role.Exist(tx, m.RoleID); err != nil {
-		return err
+		return apperror.NewInvalidReference("roleId", err)
 	}
 
 	i := 0
@@ -204,7 +205,7 @@ func txUpdateUserOwner(tx repository.Repository, m *User, id uint) error {
 		return err
 	}
 
-	makeUserSlug(m)
+	makeUserSlug(m, id)
 
 	return tx.Model(&User{}).Where("id = ?", id).
 		Select("email", "phone", "active", "surname", "name", "patronymic",
@@ -222,7 +223,7 @@ func (m *User) UpdatePassword(rep repository.Repository, id uint, old, new strin
 		}
 
 		if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(old)); err != nil {
-			return err
+			return apperror.NewWrongPassword(err)
 		}
 
 		var hashed []byte
@@ -255,7 +256,65 @@ func (m *User) Delete(rep repository.Repository, id uint) (*User, error) {
 	return user, nil
 }
 
-func makeUserSlug(user *User) {
+// GetAllWithDeleted returns a slice of all users including the soft-deleted ones.
+func (m *User) GetAllWithDeleted(rep repository.Repository) ([]*User, error) {
+	var users []*User
+	if err := rep.Unscoped().Preload("Role").Preload("Departments").
+		Preload("Services").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Restore restores this soft-deleted user data.
+func (m *User) Restore(rep repository.Repository, id uint) (*User, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		return txRestore(tx, &User{}, id)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Purge permanently deletes this user data, including the soft-deleted one.
+// It fails if visits or leads, including the soft-deleted ones, still refer to the user.
+func (m *User) Purge(rep repository.Repository, id uint) (*User, error) {
+	user := &User{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := tx.Unscoped().First(user, id).Error; err != nil {
+			return err
+		}
+		return txPurgeUser(tx, id)
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeDeletedBefore permanently deletes the users soft-deleted before the given time, except the ones still referred to.
+func (m *User) PurgeDeletedBefore(rep repository.Repository, before time.Time) (int64, error) {
+	return purgeDeletedBefore(rep, &User{}, before, txPurgeUser)
+}
+
+func txPurgeUser(tx repository.Repository, id uint) error {
+	if err := txCheckNotReferenced(tx, id,
+		reference{&Visit{}, "doctor_id"}, reference{&Visit{}, "last_updated_by_id"},
+		reference{&Lead{}, "doctor_id"}, reference{&Lead{}, "last_updated_by_id"},
+		reference{&WaitlistEntry{}, "doctor_id"}, reference{&WaitlistEntry{}, "last_updated_by_id"},
+		reference{&ClientMerge{}, "merged_by_id"}, reference{&Prescription{}, "prescriber_id"},
+		reference{&Attachment{}, "uploaded_by_id"}, reference{&BalanceEntry{}, "created_by_id"}); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("user_id = ?", id).Delete(&WorkingHours{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("doctor_id = ?", id).Delete(&WaitlistMatch{}).Error; err != nil {
+		return err
+	}
+	return tx.Select("Departments", "Services").Unscoped().Delete(&User{BaseModel: &BaseModel{ID: id}}).Error
+}
+
+func makeUserSlug(user *User, id uint) {
 	slug.MaxLength = 40
 	slug.EnableSmartTruncate = false
 	slug.CustomSub = map[string]string{
@@ -267,6 +326,6 @@ func makeUserSlug(user *User) {
 	if user.Surname != "" || user.Name != "" || user.Patronymic != "" {
 		user.Slug = slug.Make(fmt.Sprintf("%s %s %s", user.Surname, user.Name, user.Patronymic))
 	} else {
-		user.Slug = strconv.Itoa(int(user.ID))
+		user.Slug = strconv.Itoa(int(id))
 	}
 }
@@ -2,26 +2,43 @@ package models
 
 import (
 	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/repository"
 )
 
 // Visit defines struct of visit data.
 type Visit struct {
 	*BaseModel
-	DateTime        time.Time `json:"dateTime"`
-	Info            string    `json:"info"`
-	ClientID        uint      `json:"clientId"`
-	Client          *Client   `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	PetID           uint      `json:"petId"`
-	Pet             *Pet      `json:"pet" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	DoctorID        uint      `json:"userId"`
-	Doctor          *User     `json:"user" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	ServiceID       uint      `json:"serviceId"`
-	Service         *Service  `json:"services" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	LastUpdatedByID uint      `json:"lastUpdatedById"`
-	LastUpdatedBy   *User     `json:"lastUpdatedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	DateTime        time.Time      `json:"dateTime"`
+	Info            string         `json:"info"`
+	ClientID        uint           `json:"clientId"`
+	Client          *Client        `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	PetID           uint           `json:"petId"`
+	Pet             *Pet           `json:"pet" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	DoctorID        uint           `json:"userId"`
+	Doctor          *User          `json:"user" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ServiceID       uint           `json:"serviceId"`
+	Service         *Service       `json:"services" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	LastUpdatedByID uint           `json:"lastUpdatedById"`
+	LastUpdatedBy   *User          `json:"lastUpdatedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Status          string         `json:"status"`                 // requested, tentative, scheduled, cancelled, completed
+	Price           float64        `json:"price" gorm:"-"`         // The base price of the service as of the date of the visit.
+	Reservations    []*Reservation `json:"reservations,omitempty"` // The resources reserved for the visit, filled only for a single visit.
 }
 
+const (
+	// VisitRequested is the status of the visit requested by the client in the portal, it has no doctor yet.
+	VisitRequested = "requested"
+	// VisitTentative is the status of the visit booked online, it holds the slot until the clinic confirms it.
+	VisitTentative = "tentative"
+	// VisitScheduled is the status of the visit confirmed by the clinic.
+	VisitScheduled = "scheduled"
+	// VisitCancelled is the status of the cancelled visit.
+	VisitCancelled = "cancelled"
+	// VisitCompleted is the status of the visit which took place, the consumables of its service are taken from stock.
+	VisitCompleted = "completed"
+)
+
 // TableName returns the table name of visit struct and it is used by gorm.
 func (*Visit) TableName() string {
 	return "visit_master"
@@ -39,7 +56,10 @@ func (m *Visit) Exist(rep repository.Repository, id uint) (bool, error) {
 func (m *Visit) Get(rep repository.Repository, id uint) (*Visit, error) {
 	visit := &Visit{}
 	if err := rep.Preload("Client").Preload("Pet").Preload("Doctor").
-		Preload("LastUpdatedBy").Preload("Service").First(visit, id).Error; err != nil {
+		Preload("LastUpdatedBy").Preload("Service").Preload("Reservations.Resource").First(visit, id).Error; err != nil {
+		return nil, err
+	}
+	if err := fillVisitPrices(rep, []*Visit{visit}); err != nil {
 		return nil, err
 	}
 	return visit, nil
@@ -52,6 +72,9 @@ func (m *Visit) GetAll(rep repository.Repository) ([]*Visit, error) {
 		Preload("LastUpdatedBy").Preload("Service").Find(&visits).Error; err != nil {
 		return nil, err
 	}
+	if err := fillVisitPrices(rep, visits); err != nil {
+		return nil, err
+	}
 	return visits, nil
 }
 
@@ -60,75 +83,215 @@ func (m *Visit) Create(rep repository.Repository) (*Visit, error) {
 	if err := rep.Transaction(func(tx repository.Repository) error {
 		client := &Client{}
 		if _, err := client.Exist(tx, m.ClientID); err != nil {
-			return err
+			return apperror.NewInvalidReference("clientId", err)
 		}
 
 		pet := &Pet{}
 		if _, err := pet.Exist(tx, m.PetID); err != nil {
-			return err
+			return apperror.NewInvalidReference("petId", err)
 		}
 
 		user := &User{}
 		if _, err := user.Exist(tx, m.DoctorID); err != nil {
-			return err
+			return apperror.NewInvalidReference("doctorId", err)
 		}
 		if _, err := user.Exist(tx, m.LastUpdatedByID); err != nil {
-			return err
+			return apperror.NewInvalidReference("lastUpdatedById", err)
 		}
 
 		service := &Service{}
 		if _, err := service.Exist(tx, m.ServiceID); err != nil {
+			return apperror.NewInvalidReference("serviceId", err)
+		}
+		if err := txCheckServiceProvider(tx, m.ServiceID, m.DoctorID); err != nil {
+			return err
+		}
+
+		if m.Status == "" {
+			m.Status = VisitScheduled
+		}
+		if err := tx.Select("date_time", "info", "client_id", "pet_id",
+			"doctor_id", "last_updated_by_id", "service_id", "status").Create(m).Error; err != nil {
+			return err
+		}
+		if err := txExpireWaitlistMatches(tx, m, DefaultVisitDuration); err != nil {
 			return err
 		}
+		return txSyncReservations(tx, m, DefaultVisitDuration)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// GetAllByClient returns a slice of the visits of the given client ordered by date.
+// If upcoming is not nil, only the visits after the given time, or before it if upcoming is false, are returned.
+func (m *Visit) GetAllByClient(rep repository.Repository, clientID uint, upcoming *bool, now time.Time) ([]*Visit, error) {
+	var visits []*Visit
+	query := rep.Preload("Pet").Preload("Doctor").Preload("Service").Where("client_id = ?", clientID)
+	if upcoming != nil && *upcoming {
+		query = query.Where("date_time >= ?", now).Order("date_time")
+	} else if upcoming != nil {
+		query = query.Where("date_time < ?", now).Order("date_time DESC")
+	} else {
+		query = query.Order("date_time")
+	}
+	if err := query.Find(&visits).Error; err != nil {
+		return nil, err
+	}
+	if err := fillVisitPrices(rep, visits); err != nil {
+		return nil, err
+	}
+	return visits, nil
+}
+
+// Request persists this visit requested by the client.
+// The pet must belong to the client, the doctor is assigned by the clinic when the visit is scheduled.
+func (m *Visit) Request(rep repository.Repository) (*Visit, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		pet := &Pet{}
+		if err := tx.Where("client_id = ?", m.ClientID).First(pet, m.PetID).Error; err != nil {
+			return apperror.NewInvalidReference("petId", err)
+		}
+
+		service := &Service{}
+		if _, err := service.Exist(tx, m.ServiceID); err != nil {
+			return apperror.NewInvalidReference("serviceId", err)
+		}
 
-		return tx.Select("date_time", "info", "client_id", "pet_id",
-			"doctor_id", "last_updated_by_id", "service_id").Create(m).Error
+		m.Status = VisitRequested
+		return tx.Select("date_time", "info", "client_id", "pet_id", "service_id", "status").Create(m).Error
 	}); err != nil {
 		return nil, err
 	}
 	return m.Get(rep, m.ID)
 }
 
+// Cancel cancels the upcoming visit of the given client, the freed slot is matched against the waitlist.
+func (m *Visit) Cancel(rep repository.Repository, id, clientID uint, now time.Time) (*Visit, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		visit := &Visit{}
+		if err := tx.Where("client_id = ?", clientID).First(visit, id).Error; err != nil {
+			return err
+		}
+		if visit.Status == VisitCancelled || visit.Status == VisitCompleted || visit.DateTime.Before(now) {
+			return apperror.NewInvalidState("only upcoming visits can be cancelled")
+		}
+		if err := txMatchWaitlist(tx, visit); err != nil {
+			return err
+		}
+		if err := tx.Model(&Visit{}).Where("id = ?", id).Update("status", VisitCancelled).Error; err != nil {
+			return err
+		}
+		visit.Status = VisitCancelled
+		return txSyncReservations(tx, visit, DefaultVisitDuration)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
 // Update updates this visit data.
+// A visit is completed only by Complete, and a completed visit stays completed.
+// The resources required by the service are reserved again for the new time of the visit.
+// If the visit is cancelled or moved to another time or doctor, the freed slot is matched against the waitlist.
 func (m *Visit) Update(rep repository.Repository, id uint) (*Visit, error) {
 	if err := rep.Transaction(func(tx repository.Repository) error {
-		if _, err := m.Exist(tx, id); err != nil {
+		current := &Visit{}
+		if err := tx.First(current, id).Error; err != nil {
 			return err
 		}
+		if current.Status == VisitCompleted && m.Status == "" {
+			m.Status = VisitCompleted
+		}
+		if (current.Status == VisitCompleted) != (m.Status == VisitCompleted) {
+			return apperror.NewInvalidState("the visit can be completed only by the completion, which cannot be undone")
+		}
 
 		client := &Client{}
 		if _, err := client.Exist(tx, m.ClientID); err != nil {
-			return err
+			return apperror.NewInvalidReference("clientId", err)
 		}
 
 		pet := &Pet{}
 		if _, err := pet.Exist(tx, m.PetID); err != nil {
-			return err
+			return apperror.NewInvalidReference("petId", err)
 		}
 
 		user := &User{}
 		if _, err := user.Exist(tx, m.DoctorID); err != nil {
-			return err
+			return apperror.NewInvalidReference("doctorId", err)
 		}
 		if _, err := user.Exist(tx, m.LastUpdatedByID); err != nil {
-			return err
+			return apperror.NewInvalidReference("lastUpdatedById", err)
 		}
 
 		service := &Service{}
 		if _, err := service.Exist(tx, m.ServiceID); err != nil {
-			return err
+			return apperror.NewInvalidReference("serviceId", err)
+		}
+		if current.ServiceID != m.ServiceID || current.DoctorID != m.DoctorID {
+			if err := txCheckServiceProvider(tx, m.ServiceID, m.DoctorID); err != nil {
+				return err
+			}
 		}
 
-		return tx.Model(&Visit{}).Where("id = ?", id).
+		if m.Status == "" {
+			m.Status = VisitScheduled
+		}
+		if err := tx.Model(&Visit{}).Where("id = ?", id).
 			Select("date_time", "info", "client_id", "pet_id",
-				"doctor_id", "last_updated_by_id", "service_id").Updates(m).Error
+				"doctor_id", "last_updated_by_id", "service_id", "status").Updates(m).Error; err != nil {
+			return err
+		}
+		visit := &Visit{}
+		if err := tx.First(visit, id).Error; err != nil {
+			return err
+		}
+		if (visit.Status != VisitTentative && visit.Status != VisitScheduled) ||
+			!visit.DateTime.Equal(current.DateTime) || visit.DoctorID != current.DoctorID {
+			if err := txMatchWaitlist(tx, current); err != nil {
+				return err
+			}
+		}
+		if err := txExpireWaitlistMatches(tx, visit, DefaultVisitDuration); err != nil {
+			return err
+		}
+		return txSyncReservations(tx, visit, DefaultVisitDuration)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Complete completes the scheduled visit and takes the consumables of its service from stock.
+func (m *Visit) Complete(rep repository.Repository, id, updatedByID uint, now time.Time) (*Visit, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		visit := &Visit{}
+		if err := tx.First(visit, id).Error; err != nil {
+			return err
+		}
+		if visit.Status != VisitScheduled {
+			return apperror.NewInvalidState("only scheduled visits can be completed")
+		}
+		// The status is changed first and only if it is still scheduled, so a visit completed concurrently
+		// does not use the consumables twice.
+		result := tx.Model(&Visit{}).Where("id = ? AND status = ?", id, VisitScheduled).
+			Updates(map[string]interface{}{"status": VisitCompleted, "last_updated_by_id": updatedByID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewInvalidState("only scheduled visits can be completed")
+		}
+		return txUseConsumables(tx, visit, now)
 	}); err != nil {
 		return nil, err
 	}
 	return m.Get(rep, id)
 }
 
-// Delete deletes this visit data.
+// Delete deletes this visit data, the freed slot is matched against the waitlist.
 func (m *Visit) Delete(rep repository.Repository, id uint) (*Visit, error) {
 	visit := &Visit{}
 	if err := rep.Transaction(func(tx repository.Repository) error {
@@ -136,9 +299,96 @@ func (m *Visit) Delete(rep repository.Repository, id uint) (*Visit, error) {
 		if visit, err = m.Get(tx, id); err != nil {
 			return err
 		}
+		if err := txMatchWaitlist(tx, visit); err != nil {
+			return err
+		}
 		return tx.Delete(&Visit{}, id).Error
 	}); err != nil {
 		return nil, err
 	}
 	return visit, nil
 }
+
+// GetAllWithDeleted returns a slice of all visits including the soft-deleted ones.
+func (m *Visit) GetAllWithDeleted(rep repository.Repository) ([]*Visit, error) {
+	var visits []*Visit
+	if err := rep.Unscoped().Preload("Client").Preload("Pet").Preload("Doctor").
+		Preload("LastUpdatedBy").Preload("Service").Find(&visits).Error; err != nil {
+		return nil, err
+	}
+	if err := fillVisitPrices(rep, visits); err != nil {
+		return nil, err
+	}
+	return visits, nil
+}
+
+// Restore restores this soft-deleted visit data.
+// It fails if the records the visit refers to are deleted, they have to be restored first.
+func (m *Visit) Restore(rep repository.Repository, id uint) (*Visit, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := txRestore(tx, &Visit{}, id); err != nil {
+			return err
+		}
+		visit := &Visit{}
+		if err := tx.First(visit, id).Error; err != nil {
+			return err
+		}
+		client := &Client{}
+		if _, err := client.Exist(tx, visit.ClientID); err != nil {
+			return apperror.NewInvalidReference("clientId", err)
+		}
+		pet := &Pet{}
+		if _, err := pet.Exist(tx, visit.PetID); err != nil {
+			return apperror.NewInvalidReference("petId", err)
+		}
+		if err := txExpireWaitlistMatches(tx, visit, DefaultVisitDuration); err != nil {
+			return err
+		}
+		return txSyncReservations(tx, visit, DefaultVisitDuration)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Purge permanently deletes this visit data, including the soft-deleted one.
+func (m *Visit) Purge(rep repository.Repository, id uint) (*Visit, error) {
+	visit := &Visit{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := tx.Unscoped().First(visit, id).Error; err != nil {
+			return err
+		}
+		return txPurgeVisit(tx, id)
+	}); err != nil {
+		return nil, err
+	}
+	return visit, nil
+}
+
+// PurgeDeletedBefore permanently deletes the visits soft-deleted before the given time.
+func (m *Visit) PurgeDeletedBefore(rep repository.Repository, before time.Time) (int64, error) {
+	return purgeDeletedBefore(rep, &Visit{}, before, txPurgeVisit)
+}
+
+func txPurgeVisit(tx repository.Repository, id uint) error {
+	if err := txCheckNotReferenced(tx, id, reference{&Prescription{}, "visit_id"},
+		reference{&Attachment{}, "visit_id"}, reference{&LabResult{}, "visit_id"},
+		reference{&Invoice{}, "visit_id"}); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("visit_id = ?", id).Delete(&Reservation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("visit_id = ?", id).Delete(&WaitlistMatch{}).Error; err != nil {
+		return err
+	}
+	// The products used at the visit stay in the history of the stock.
+	if err := tx.Unscoped().Model(&StockMovement{}).Where("visit_id = ?", id).Update("visit_id", 0).Error; err != nil {
+		return err
+	}
+	// The weight measured at the visit is kept in the history of the pet.
+	if err := tx.Unscoped().Model(&PetWeight{}).Where("visit_id = ?", id).Update("visit_id", 0).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Visit{}, id).Error
+}
@@ -0,0 +1,326 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+const (
+	// WaitlistWaiting is the status of the waitlist entry waiting for a slot to free up.
+	WaitlistWaiting = "waiting"
+	// WaitlistBooked is the status of the waitlist entry for which a visit has been scheduled.
+	WaitlistBooked = "booked"
+	// WaitlistCancelled is the status of the waitlist entry the client no longer needs.
+	WaitlistCancelled = "cancelled"
+)
+
+// WaitlistEntry defines struct of a client waiting for a slot of the service to free up.
+type WaitlistEntry struct {
+	*BaseModel
+	ClientID        uint              `json:"clientId" gorm:"index"`
+	Client          *Client           `json:"client" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	PetID           uint              `json:"petId"`
+	Pet             *Pet              `json:"pet" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ServiceID       uint              `json:"serviceId"`
+	Service         *Service          `json:"service" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	DoctorID        uint              `json:"doctorId"` // The preferred doctor, 0 if any doctor who provides the service will do.
+	Doctor          *User             `json:"doctor" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Comment         string            `json:"comment" gorm:"size:1000"`
+	Status          string            `json:"status"` // waiting, booked, cancelled
+	Windows         []*WaitlistWindow `json:"windows" gorm:"foreignKey:EntryID"`
+	LastUpdatedByID uint              `json:"lastUpdatedById"`
+	LastUpdatedBy   *User             `json:"lastUpdatedBy" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+// WaitlistWindow defines struct of a period in which the client of the waitlist entry can come.
+type WaitlistWindow struct {
+	*BaseModel
+	EntryID   uint      `json:"entryId" gorm:"index"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// WaitlistMatch defines struct of a slot freed by a cancelled or moved visit which suits the waitlist entry.
+type WaitlistMatch struct {
+	*BaseModel
+	EntryID   uint           `json:"entryId" gorm:"index"`
+	Entry     *WaitlistEntry `json:"entry,omitempty"`
+	VisitID   uint           `json:"visitId"` // The visit which freed the slot.
+	DoctorID  uint           `json:"doctorId"`
+	Doctor    *User          `json:"doctor" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	StartTime time.Time      `json:"startTime"`
+	EndTime   time.Time      `json:"endTime"`
+}
+
+// TableName returns the table name of waitlist entry struct and it is used by gorm.
+func (*WaitlistEntry) TableName() string {
+	return "waitlist_entry"
+}
+
+// TableName returns the table name of waitlist window struct and it is used by gorm.
+func (*WaitlistWindow) TableName() string {
+	return "waitlist_window"
+}
+
+// TableName returns the table name of waitlist match struct and it is used by gorm.
+func (*WaitlistMatch) TableName() string {
+	return "waitlist_match"
+}
+
+// Accepts returns true if a visit for the service of the entry starting at the given time fits both
+// the free period up to the given end and one of the windows of the entry.
+func (m *WaitlistEntry) Accepts(start, end time.Time) bool {
+	finish := start.Add(m.Service.VisitDuration(DefaultVisitDuration))
+	if finish.After(end) {
+		return false
+	}
+	for _, window := range m.Windows {
+		if !window.StartTime.After(start) && !window.EndTime.Before(finish) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exist returns true if a given waitlist entry exits.
+func (m *WaitlistEntry) Exist(rep repository.Repository, id uint) (bool, error) {
+	if err := rep.First(&WaitlistEntry{}, id).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns waitlist entry full matched given waitlist entry ID.
+func (m *WaitlistEntry) Get(rep repository.Repository, id uint) (*WaitlistEntry, error) {
+	entry := &WaitlistEntry{}
+	if err := rep.Scopes(preloadWaitlistWindows).Preload("Client").Preload("Pet").Preload("Service").
+		Preload("Doctor").Preload("LastUpdatedBy").First(entry, id).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetAll returns a slice of the waitlist entries in the order they were added, optionally only with the given status.
+func (m *WaitlistEntry) GetAll(rep repository.Repository, status string) ([]*WaitlistEntry, error) {
+	var entries []*WaitlistEntry
+	query := rep.Scopes(preloadWaitlistWindows).Preload("Client").Preload("Pet").Preload("Service").Preload("Doctor")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Create persists this waitlist entry data, the entry waits for a slot.
+func (m *WaitlistEntry) Create(rep repository.Repository) (*WaitlistEntry, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if err := txCheckWaitlistEntry(tx, m); err != nil {
+			return err
+		}
+
+		m.Status = WaitlistWaiting
+		columns := []string{"client_id", "pet_id", "service_id", "comment", "status", "last_updated_by_id"}
+		if m.DoctorID != 0 {
+			columns = append(columns, "doctor_id")
+		}
+		if err := tx.Select(columns).Create(m).Error; err != nil {
+			return err
+		}
+		return txSetWaitlistWindows(tx, m.ID, m.Windows)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, m.ID)
+}
+
+// Update updates this waitlist entry data, the windows are replaced with the given ones.
+func (m *WaitlistEntry) Update(rep repository.Repository, id uint) (*WaitlistEntry, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := m.Exist(tx, id); err != nil {
+			return err
+		}
+		if err := txCheckWaitlistEntry(tx, m); err != nil {
+			return err
+		}
+
+		if m.Status == "" {
+			m.Status = WaitlistWaiting
+		}
+		if err := tx.Model(&WaitlistEntry{}).Where("id = ?", id).
+			Select("client_id", "pet_id", "service_id", "comment", "status", "last_updated_by_id").
+			Updates(m).Error; err != nil {
+			return err
+		}
+		// Any doctor will do if no doctor is preferred, so the reference is cleared instead of being set to 0.
+		var doctorID interface{}
+		if m.DoctorID != 0 {
+			doctorID = m.DoctorID
+		}
+		if err := tx.Model(&WaitlistEntry{}).Where("id = ?", id).Update("doctor_id", doctorID).Error; err != nil {
+			return err
+		}
+		return txSetWaitlistWindows(tx, id, m.Windows)
+	}); err != nil {
+		return nil, err
+	}
+	return m.Get(rep, id)
+}
+
+// Delete permanently deletes this waitlist entry data with its windows and matches.
+func (m *WaitlistEntry) Delete(rep repository.Repository, id uint) (*WaitlistEntry, error) {
+	entry := &WaitlistEntry{}
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		var err error
+		if entry, err = m.Get(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("entry_id = ?", id).Delete(&WaitlistWindow{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("entry_id = ?", id).Delete(&WaitlistMatch{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&WaitlistEntry{}, id).Error
+	}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// preloadWaitlistWindows loads the windows of the waitlist entries, the earliest first.
+func preloadWaitlistWindows(db *gorm.DB) *gorm.DB {
+	return db.Preload("Windows", func(db *gorm.DB) *gorm.DB { return db.Order("start_time") })
+}
+
+// txCheckWaitlistEntry returns an error if the pet does not belong to the client,
+// or if the service cannot be provided by the preferred doctor.
+func txCheckWaitlistEntry(tx repository.Repository, m *WaitlistEntry) error {
+	client := &Client{}
+	if _, err := client.Exist(tx, m.ClientID); err != nil {
+		return apperror.NewInvalidReference("clientId", err)
+	}
+	pet := &Pet{}
+	if err := tx.Where("client_id = ?", m.ClientID).First(pet, m.PetID).Error; err != nil {
+		return apperror.NewInvalidReference("petId", err)
+	}
+
+	service := &Service{}
+	if err := tx.First(service, m.ServiceID).Error; err != nil {
+		return apperror.NewInvalidReference("serviceId", err)
+	}
+	if m.DoctorID == 0 {
+		if service.Archived {
+			return apperror.NewInvalidState("the service is archived")
+		}
+	} else {
+		if _, err := (&User{}).Exist(tx, m.DoctorID); err != nil {
+			return apperror.NewInvalidReference("doctorId", err)
+		}
+		if err := txCheckServiceProvider(tx, m.ServiceID, m.DoctorID); err != nil {
+			return err
+		}
+	}
+
+	for _, window := range m.Windows {
+		if !window.EndTime.After(window.StartTime) {
+			return apperror.NewInvalidState("the time window must end after it starts")
+		}
+	}
+	return nil
+}
+
+// txSetWaitlistWindows replaces the windows of the waitlist entry with the given ones.
+func txSetWaitlistWindows(tx repository.Repository, entryID uint, windows []*WaitlistWindow) error {
+	if err := tx.Unscoped().Where("entry_id = ?", entryID).Delete(&WaitlistWindow{}).Error; err != nil {
+		return err
+	}
+	for _, window := range windows {
+		row := &WaitlistWindow{EntryID: entryID, StartTime: window.StartTime, EndTime: window.EndTime}
+		if err := tx.Select("entry_id", "start_time", "end_time").Create(row).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetOpen returns the matches of the waiting entries whose slot starts after the given time
+// and is still free, the earliest slot first and the entries in the order they were added.
+func (m *WaitlistMatch) GetOpen(rep repository.Repository, now time.Time) ([]*WaitlistMatch, error) {
+	var matches []*WaitlistMatch
+	if err := rep.Model(&WaitlistMatch{}).Preload("Doctor").Preload("Entry.Client").Preload("Entry.Pet").
+		Preload("Entry.Service").Preload("Entry.Windows").
+		Joins("JOIN waitlist_entry ON waitlist_entry.id = waitlist_match.entry_id "+
+			"AND waitlist_entry.deleted_at IS NULL AND waitlist_entry.status = ?", WaitlistWaiting).
+		Where("waitlist_match.start_time > ?", now).
+		Where("NOT EXISTS (SELECT 1 FROM visit_master WHERE visit_master.doctor_id = waitlist_match.doctor_id "+
+			"AND visit_master.deleted_at IS NULL AND visit_master.status IN ? "+
+			"AND visit_master.date_time >= waitlist_match.start_time AND visit_master.date_time < waitlist_match.end_time)",
+			[]string{VisitTentative, VisitScheduled}).
+		Order("waitlist_match.start_time, waitlist_match.entry_id").Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// txMatchWaitlist records the slot which the tentative or scheduled visit frees as a match
+// of the waiting entries it suits: the entry accepts the doctor of the visit, the doctor provides
+// the service of the entry and the slot fits one of the windows of the entry.
+func txMatchWaitlist(tx repository.Repository, visit *Visit) error {
+	if visit.Status != VisitTentative && visit.Status != VisitScheduled {
+		return nil
+	}
+	service := &Service{}
+	if err := tx.First(service, visit.ServiceID).Error; err != nil {
+		return err
+	}
+	start := visit.DateTime
+	end := start.Add(service.VisitDuration(DefaultVisitDuration))
+
+	var entries []*WaitlistEntry
+	if err := tx.Model(&WaitlistEntry{}).Preload("Service").Preload("Windows").
+		Joins("JOIN users_services ON users_services.service_id = waitlist_entry.service_id "+
+			"AND users_services.user_id = ?", visit.DoctorID).
+		Where("waitlist_entry.status = ?", WaitlistWaiting).
+		Where("waitlist_entry.doctor_id IS NULL OR waitlist_entry.doctor_id = ?", visit.DoctorID).
+		Order("waitlist_entry.id").Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Accepts(start, end) {
+			continue
+		}
+		var count int64
+		if err := tx.Model(&WaitlistMatch{}).Where("entry_id = ? AND doctor_id = ? AND start_time = ?",
+			entry.ID, visit.DoctorID, start).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		match := &WaitlistMatch{EntryID: entry.ID, VisitID: visit.ID, DoctorID: visit.DoctorID, StartTime: start, EndTime: end}
+		if err := tx.Select("entry_id", "visit_id", "doctor_id", "start_time", "end_time").Create(match).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txExpireWaitlistMatches deletes the matches of the doctor whose slot the tentative or scheduled visit takes,
+// even partly. The visit takes the duration of its service, the given duration is used if the service has none.
+func txExpireWaitlistMatches(tx repository.Repository, visit *Visit, fallback time.Duration) error {
+	if visit.Status != VisitTentative && visit.Status != VisitScheduled {
+		return nil
+	}
+	service := &Service{}
+	if err := tx.First(service, visit.ServiceID).Error; err != nil {
+		return err
+	}
+	start := visit.DateTime
+	end := start.Add(service.VisitDuration(fallback))
+	return tx.Unscoped().Where("doctor_id = ? AND start_time < ? AND end_time > ?", visit.DoctorID, end, start).
+		Delete(&WaitlistMatch{}).Error
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/repository"
+)
+
+// ClockFormat is the format of the time of day of the working hours.
+const ClockFormat = "15:04"
+
+// WorkingHours defines struct of the working hours of a doctor on a day of the week.
+// A doctor without working hours works the default hours of the clinic every day.
+type WorkingHours struct {
+	*BaseModel
+	UserID  uint   `json:"userId" gorm:"uniqueIndex:idx_working_hours"`
+	Weekday int    `json:"weekday" gorm:"uniqueIndex:idx_working_hours" example:"1"` // 0 is Sunday, 6 is Saturday.
+	Start   string `json:"start" gorm:"size:5" example:"09:00"`
+	End     string `json:"end" gorm:"size:5" example:"18:00"`
+}
+
+// TableName returns the table name of working hours struct and it is used by gorm.
+func (*WorkingHours) TableName() string {
+	return "working_hours"
+}
+
+// Offsets returns the start and the end of the working hours as offsets from midnight.
+func (m *WorkingHours) Offsets() (time.Duration, time.Duration) {
+	return clockOffset(m.Start), clockOffset(m.End)
+}
+
+func clockOffset(clock string) time.Duration {
+	t, err := time.Parse(ClockFormat, clock)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// GetAllByUser returns the working hours of the user ordered by the day of the week.
+func (m *WorkingHours) GetAllByUser(rep repository.Repository, userID uint) ([]*WorkingHours, error) {
+	if _, err := (&User{}).Exist(rep, userID); err != nil {
+		return nil, err
+	}
+	var hours []*WorkingHours
+	if err := rep.Where("user_id = ?", userID).Order("weekday").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// GetAllByUsers returns the working hours of the given users grouped by the user ID.
+func (m *WorkingHours) GetAllByUsers(rep repository.Repository, userIDs []uint) (map[uint][]*WorkingHours, error) {
+	var hours []*WorkingHours
+	if err := rep.Where("user_id IN ?", userIDs).Order("weekday").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint][]*WorkingHours)
+	for _, h := range hours {
+		result[h.UserID] = append(result[h.UserID], h)
+	}
+	return result, nil
+}
+
+// SetForUser replaces the working hours of the user with the given ones, an empty list restores the default hours.
+func (m *WorkingHours) SetForUser(rep repository.Repository, userID uint, hours []*WorkingHours) ([]*WorkingHours, error) {
+	if err := rep.Transaction(func(tx repository.Repository) error {
+		if _, err := (&User{}).Exist(tx, userID); err != nil {
+			return err
+		}
+		seen := make(map[int]bool)
+		for _, h := range hours {
+			if seen[h.Weekday] {
+				return apperror.NewDuplicate("the day of the week is listed more than once")
+			}
+			seen[h.Weekday] = true
+			if start, end := h.Offsets(); start >= end {
+				return apperror.NewInvalidState("the working hours must end after they start")
+			}
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&WorkingHours{}).Error; err != nil {
+			return err
+		}
+		for _, h := range hours {
+			row := &WorkingHours{UserID: userID, Weekday: h.Weekday, Start: h.Start, End: h.End}
+			if err := tx.Select("user_id", "weekday", "start", "end").Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m.GetAllByUser(rep, userID)
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/config"
+)
+
+func TestMemoryStoreTake_Limit(t *testing.T) {
+	store := NewMemoryStore()
+	rule := config.RateLimitRule{Limit: 3, Period: time.Minute}
+	now := time.Now()
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "auth:127.0.0.1", rule, now)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(context.Background(), "auth:127.0.0.1", rule, now)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 20*time.Second, result.RetryAfter)
+	assert.Equal(t, time.Minute, result.Reset)
+}
+
+func TestMemoryStoreTake_Refill(t *testing.T) {
+	store := NewMemoryStore()
+	rule := config.RateLimitRule{Limit: 3, Period: time.Minute}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		_, _ = store.Take(context.Background(), "auth:127.0.0.1", rule, now)
+	}
+
+	result, err := store.Take(context.Background(), "auth:127.0.0.1", rule, now.Add(20*time.Second))
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = store.Take(context.Background(), "auth:127.0.0.1", rule, now.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryStoreTake_SeparateKeys(t *testing.T) {
+	store := NewMemoryStore()
+	rule := config.RateLimitRule{Limit: 1, Period: time.Minute}
+	now := time.Now()
+
+	first, _ := store.Take(context.Background(), "auth:127.0.0.1", rule, now)
+	second, _ := store.Take(context.Background(), "auth:192.0.2.10", rule, now)
+	third, _ := store.Take(context.Background(), "public:127.0.0.1", rule, now)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, second.Allowed)
+	assert.True(t, third.Allowed)
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"vet-clinic/config"
+	"vet-clinic/logging"
+)
+
+// Result is the state of a token bucket after a request took a token from it.
+type Result struct {
+	Allowed bool
+	// Limit is the capacity of the bucket.
+	Limit int
+	// Remaining is the number of requests allowed right now.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, it is zero if this request is allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets.
+type Store interface {
+	// Take takes a token from the bucket with the given key, which is created full if it does not exist.
+	Take(ctx context.Context, key string, rule config.RateLimitRule, now time.Time) (*Result, error)
+}
+
+// NewStore creates the store selected by the configuration.
+func NewStore(logger logging.Logger, conf *config.Config) Store {
+	if conf.RateLimit.Store != "redis" {
+		logger.Infof("use memory store for rate limiting")
+		return NewMemoryStore()
+	}
+	logger.Infof("use redis store for rate limiting")
+	return NewRedisStore(fmt.Sprintf("%s:%s", conf.Redis.Host, conf.Redis.Port), conf.Redis.ConnectionPoolSize)
+}
+
+// refill returns the number of tokens in the bucket after the elapsed time, which is at most the limit.
+func refill(rule config.RateLimitRule, tokens float64, elapsed time.Duration) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(rule.Limit), tokens+elapsed.Seconds()*rate(rule))
+}
+
+// newResult describes the bucket which has the given number of tokens left.
+func newResult(rule config.RateLimitRule, tokens float64, allowed bool) *Result {
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(rule.Limit) - tokens) / rate(rule)),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / rate(rule))
+	}
+	return result
+}
+
+// rate returns the number of tokens added to the bucket per second.
+func rate(rule config.RateLimitRule) float64 {
+	return float64(rule.Limit) / rule.Period.Seconds()
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
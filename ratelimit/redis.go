@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/garyburd/redigo/redis"
+	"strconv"
+	"time"
+	"vet-clinic/config"
+)
+
+// takeScript takes a token from the bucket atomically. The bucket is a hash of the number of tokens
+// and the time of the last update in milliseconds, and it expires when it would be full again.
+var takeScript = redis.NewScript(1, `
+local limit = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1]) or limit
+local updated = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + math.max(0, now - updated) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps the token buckets in Redis, so the limits are shared between the instances of the application.
+type RedisStore struct {
+	pool *redis.Pool
+}
+
+// NewRedisStore is constructor.
+func NewRedisStore(address string, poolSize int) *RedisStore {
+	return &RedisStore{pool: &redis.Pool{
+		MaxIdle:     poolSize,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", address)
+		},
+	}}
+}
+
+// Take takes a token from the bucket with the given key.
+func (s *RedisStore) Take(ctx context.Context, key string, rule config.RateLimitRule, now time.Time) (*Result, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	reply, err := redis.Values(takeScript.Do(conn, "ratelimit:"+key,
+		rule.Limit, rate(rule)/1000, now.UnixMilli(), rule.Period.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+	var allowed int
+	var tokens string
+	if _, err := redis.Scan(reply, &allowed, &tokens); err != nil {
+		return nil, err
+	}
+	remaining, err := strconv.ParseFloat(tokens, 64)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(rule, remaining, allowed == 1), nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	deadlineParentKey = "deadline:parent_context"
+	deadlineCancelKey = "deadline:cancel"
+)
+
+// DeadlinePlugin is a GORM plugin that bounds each SQL statement with a timeout,
+// so a slow query is cancelled instead of holding the request and the connection.
+// The statements of Rows, Row and Scan are bounded too, including the reading of their rows.
+type DeadlinePlugin struct {
+	timeout time.Duration
+}
+
+// NewDeadlinePlugin is constructor.
+func NewDeadlinePlugin(timeout time.Duration) *DeadlinePlugin {
+	return &DeadlinePlugin{timeout: timeout}
+}
+
+// Name returns the name of this plugin.
+func (p *DeadlinePlugin) Name() string {
+	return "deadline"
+}
+
+// Initialize registers the callbacks which set and release the deadline of the statements.
+func (p *DeadlinePlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("*").Register("deadline:before_create", p.before); err != nil {
+		return err
+	}
+	if err := callback.Create().After("*").Register("deadline:after_create", p.after); err != nil {
+		return err
+	}
+	if err := callback.Query().Before("*").Register("deadline:before_query", p.before); err != nil {
+		return err
+	}
+	if err := callback.Query().After("*").Register("deadline:after_query", p.after); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("*").Register("deadline:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callback.Update().After("*").Register("deadline:after_update", p.after); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("*").Register("deadline:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("*").Register("deadline:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := callback.Raw().Before("*").Register("deadline:before_raw", p.before); err != nil {
+		return err
+	}
+	if err := callback.Raw().After("*").Register("deadline:after_raw", p.after); err != nil {
+		return err
+	}
+	if err := callback.Row().Before("*").Register("deadline:before_row", p.before); err != nil {
+		return err
+	}
+	return callback.Row().After("*").Register("deadline:after_row", p.afterRow)
+}
+
+func (p *DeadlinePlugin) before(db *gorm.DB) {
+	parent := db.Statement.Context
+	ctx, cancel := context.WithTimeout(parent, p.timeout)
+	db.InstanceSet(deadlineParentKey, parent)
+	db.InstanceSet(deadlineCancelKey, cancel)
+	db.Statement.Context = ctx
+}
+
+func (p *DeadlinePlugin) after(db *gorm.DB) {
+	if cancel, ok := db.InstanceGet(deadlineCancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+	if parent, ok := db.InstanceGet(deadlineParentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+}
+
+// afterRow restores the context of the statement, but keeps the deadline running:
+// the rows are read after the callbacks, and the deadline releases itself when it expires.
+func (p *DeadlinePlugin) afterRow(db *gorm.DB) {
+	if parent, ok := db.InstanceGet(deadlineParentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+}
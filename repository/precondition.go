@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"sync"
+	"vet-clinic/apperror"
+)
+
+// preconditionKey is the context key of the precondition of the request.
+type preconditionKey struct{}
+
+// precondition is the version of a record which the client of the request expects to write.
+type precondition struct {
+	model   interface{}
+	version map[string]interface{}
+	once    sync.Once
+}
+
+// WithPrecondition returns a copy of the context which requires the record of the model to still have
+// the given column values, e.g. the ID and the update time the client fetched, when it is written.
+// The first transaction run with the context checks it with a conditional update of the record,
+// which also locks the row until the transaction ends, and fails with 412 if the record has been changed.
+func WithPrecondition(ctx context.Context, model interface{}, version map[string]interface{}) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, &precondition{model: model, version: version})
+}
+
+// txCheckPrecondition checks the precondition of the context in the transaction, if it has not been checked yet.
+func txCheckPrecondition(ctx context.Context, tx *gorm.DB) error {
+	p, ok := ctx.Value(preconditionKey{}).(*precondition)
+	if !ok {
+		return nil
+	}
+
+	var err error
+	p.once.Do(func() {
+		result := tx.Model(p.model).Where(p.version).UpdateColumn("id", gorm.Expr("id"))
+		if err = result.Error; err == nil && result.RowsAffected == 0 {
+			err = apperror.NewPreconditionFailed()
+		}
+	})
+	return err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -11,9 +12,11 @@ import (
 	"os"
 	"vet-clinic/config"
 	"vet-clinic/logging"
+	"vet-clinic/tracing"
 )
 
 type Repository interface {
+	WithContext(ctx context.Context) Repository
 	Model(value interface{}) *gorm.DB
 	Select(query interface{}, args ...interface{}) *gorm.DB
 	Omit(columns ...string) *gorm.DB
@@ -28,6 +31,7 @@ type Repository interface {
 	Where(query interface{}, args ...interface{}) *gorm.DB
 	Preload(column string, conditions ...interface{}) *gorm.DB
 	Scopes(funcs ...func(*gorm.DB) *gorm.DB) *gorm.DB
+	Unscoped() *gorm.DB
 	ScanRows(rows *sql.Rows, result interface{}) error
 	Transaction(fc func(tx Repository) error) (err error)
 	Close() error
@@ -46,6 +50,18 @@ func NewRepository(logger logging.Logger, conf *config.Config) *GormRepo {
 		logger.Errorf("Failure database connection")
 		os.Exit(config.ErrExitStatus)
 	}
+	if conf.Tracing.Enabled {
+		if err = db.Use(tracing.NewGormPlugin()); err != nil {
+			logger.Errorf("Failure to register the tracing plugin: %v", err)
+			os.Exit(config.ErrExitStatus)
+		}
+	}
+	if conf.Database.RequestTimeout > 0 {
+		if err = db.Use(NewDeadlinePlugin(conf.Database.RequestTimeout)); err != nil {
+			logger.Errorf("Failure to register the deadline plugin: %v", err)
+			os.Exit(config.ErrExitStatus)
+		}
+	}
 	logger.Infof("Success database connection, %s:%s", conf.Database.Host, conf.Database.Port)
 
 	return &GormRepo{db: db}
@@ -62,7 +78,7 @@ const (
 
 func connectDatabase(logger logging.Logger, config *config.Config) (*gorm.DB, error) {
 	var dsn string
-	gormConfig := &gorm.Config{Logger: logger.Gorm()}
+	gormConfig := &gorm.Config{Logger: logger.Gorm(), TranslateError: true}
 
 	switch config.Database.Dialect {
 	case POSTGRES:
@@ -74,7 +90,9 @@ func connectDatabase(logger logging.Logger, config *config.Config) (*gorm.DB, er
 			config.Database.Password)
 		return gorm.Open(postgres.Open(dsn), gormConfig)
 	case MYSQL:
-		dsn = fmt.Sprintf("%s:%s@(%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		// clientFoundRows makes the affected rows include the matched rows whose values are unchanged,
+		// which the precondition check relies on.
+		dsn = fmt.Sprintf("%s:%s@(%s)/%s?charset=utf8&parseTime=True&loc=Local&clientFoundRows=true",
 			config.Database.Username,
 			config.Database.Password,
 			config.Database.Host,
@@ -87,6 +105,13 @@ func connectDatabase(logger logging.Logger, config *config.Config) (*gorm.DB, er
 	}
 }
 
+// WithContext returns a repository whose operations are bound to the given context.
+// Queries and transactions are cancelled when the context is done, e.g. the client disconnects.
+// Each statement is also bounded by the configured database deadline. The context is passed to the GORM logger.
+func (rep *GormRepo) WithContext(ctx context.Context) Repository {
+	return &GormRepo{db: rep.db.WithContext(ctx)}
+}
+
 // Model specify the models you would like to run db operations
 func (rep *GormRepo) Model(value interface{}) *gorm.DB {
 	return rep.db.Model(value)
@@ -157,6 +182,11 @@ func (rep *GormRepo) Scopes(funcs ...func(*gorm.DB) *gorm.DB) *gorm.DB {
 	return rep.db.Scopes(funcs...)
 }
 
+// Unscoped disables the soft-delete scope, so the soft-deleted records are included and deletions are permanent.
+func (rep *GormRepo) Unscoped() *gorm.DB {
+	return rep.db.Unscoped()
+}
+
 // ScanRows scan `*sql.Rows` to give struct
 func (rep *GormRepo) ScanRows(rows *sql.Rows, result interface{}) error {
 	return rep.db.ScanRows(rows, result)
@@ -181,6 +211,7 @@ func (rep *GormRepo) AutoMigrate(value interface{}) error {
 // Transaction start a transaction as a block.
 // If it is failed, will rollback and return error.
 // If it is successed, will commit.
+// The precondition of the context, if any, is checked at the start of the first transaction of the request.
 // ref: https://github.com/jinzhu/gorm/blob/master/main.go#L533
 func (rep *GormRepo) Transaction(fc func(tx Repository) error) (err error) {
 	panicked := true
@@ -191,6 +222,11 @@ func (rep *GormRepo) Transaction(fc func(tx Repository) error) (err error) {
 		}
 	}()
 
+	if err = txCheckPrecondition(rep.db.Statement.Context, tx); err != nil {
+		panicked = false
+		return
+	}
+
 	txrep := &GormRepo{}
 	txrep.db = tx
 	err = fc(txrep)
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"database/sql/driver"
+	sqlite "github.com/glebarez/go-sqlite"
+	"strings"
+)
+
+// The built-in LOWER function of SQLite folds only ASCII letters, unlike PostgreSQL and MySQL.
+// It is replaced with the Unicode-aware one, so that the case-insensitive search works for Cyrillic on all dialects.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("lower", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			switch value := args[0].(type) {
+			case string:
+				return strings.ToLower(value), nil
+			case []byte:
+				return strings.ToLower(string(value)), nil
+			default:
+				return value, nil
+			}
+		})
+}
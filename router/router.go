@@ -5,6 +5,7 @@ import (
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/controllers"
+	"vet-clinic/middleware"
 
 	echoSwagger "github.com/swaggo/echo-swagger"
 	_ "vet-clinic/docs" // for using echo-swagger
@@ -12,16 +13,32 @@ import (
 
 // Init initialize the routing of this application.
 func Init(e *echo.Echo, container container.Container) {
+	limiter := middleware.NewRateLimiter(container)
 	setSystemRoutes(e, container)
 	setRoleRoutes(e, container)
-	setUserRoutes(e, container)
+	setUserRoutes(e, container, limiter)
 	setDepartmentRoutes(e, container)
+	setResourceRoutes(e, container)
 	setCategoryRoutes(e, container)
 	setServiceRoutes(e, container)
+	setServicePriceRoutes(e, container)
 	setClientRoutes(e, container)
+	setDiscountRoutes(e, container)
+	setInvoiceRoutes(e, container)
+	setSpeciesRoutes(e, container)
+	setBreedRoutes(e, container)
 	setPetRoutes(e, container)
+	setMedicationRoutes(e, container)
+	setProductRoutes(e, container)
+	setPrescriptionRoutes(e, container)
+	setAttachmentRoutes(e, container)
+	setLabResultRoutes(e, container)
 	setVisitRoutes(e, container)
-	setLeadRoutes(e, container)
+	setWaitlistRoutes(e, container)
+	setLeadRoutes(e, container, limiter)
+	setPortalRoutes(e, container, limiter)
+	setBookingRoutes(e, container, limiter)
+	setSearchRoutes(e, container)
 }
 
 func setSystemRoutes(e *echo.Echo, container container.Container) {
@@ -41,17 +58,23 @@ func setRoleRoutes(e *echo.Echo, container container.Container) {
 	e.DELETE(config.APIv1RolesID, func(c echo.Context) error { return role.Delete(c) })
 }
 
-func setUserRoutes(e *echo.Echo, container container.Container) {
+func setUserRoutes(e *echo.Echo, container container.Container, limiter *middleware.RateLimiter) {
 	user := controllers.NewUserController(container)
 	e.GET(config.APIv1UsersID, func(c echo.Context) error { return user.Get(c) })
 	e.GET(config.APIv1Users, func(c echo.Context) error { return user.GetAll(c) })
 	e.POST(config.APIv1Users, func(c echo.Context) error { return user.Create(c) })
 	e.PUT(config.APIv1UsersID, func(c echo.Context) error { return user.Update(c) })
+	e.PATCH(config.APIv1UsersID, func(c echo.Context) error { return user.Patch(c) })
 	e.DELETE(config.APIv1UsersID, func(c echo.Context) error { return user.Delete(c) })
+	e.POST(config.APIv1UsersIDRestore, func(c echo.Context) error { return user.Restore(c) })
+	e.DELETE(config.APIv1UsersIDPurge, func(c echo.Context) error { return user.Purge(c) })
+	e.GET(config.APIv1UsersIDWorkingHours, func(c echo.Context) error { return user.GetWorkingHours(c) })
+	e.PUT(config.APIv1UsersIDWorkingHours, func(c echo.Context) error { return user.SetWorkingHours(c) })
 	e.GET(config.APIv1Profile, func(c echo.Context) error { return user.GetSelf(c) })
 	e.PUT(config.APIv1Profile, func(c echo.Context) error { return user.UpdateSelf(c) })
+	e.PATCH(config.APIv1Profile, func(c echo.Context) error { return user.PatchSelf(c) })
 	e.PUT(config.APIv1Password, func(c echo.Context) error { return user.UpdatePassword(c) })
-	e.POST(config.APIv1Login, func(c echo.Context) error { return user.Login(c) })
+	e.POST(config.APIv1Login, func(c echo.Context) error { return user.Login(c) }, limiter.Group("auth"))
 	e.POST(config.APIv1Logout, func(c echo.Context) error { return user.Logout(c) })
 }
 
@@ -64,10 +87,21 @@ func setDepartmentRoutes(e *echo.Echo, container container.Container) {
 	e.DELETE(config.APIv1DepartmentsID, func(c echo.Context) error { return department.Delete(c) })
 }
 
+func setResourceRoutes(e *echo.Echo, container container.Container) {
+	resource := controllers.NewResourceController(container)
+	e.GET(config.APIv1ResourcesID, func(c echo.Context) error { return resource.Get(c) })
+	e.GET(config.APIv1Resources, func(c echo.Context) error { return resource.GetAll(c) })
+	e.POST(config.APIv1Resources, func(c echo.Context) error { return resource.Create(c) })
+	e.PUT(config.APIv1ResourcesID, func(c echo.Context) error { return resource.Update(c) })
+	e.DELETE(config.APIv1ResourcesID, func(c echo.Context) error { return resource.Delete(c) })
+	e.GET(config.APIv1ResourcesIDReservations, func(c echo.Context) error { return resource.GetReservations(c) })
+}
+
 func setCategoryRoutes(e *echo.Echo, container container.Container) {
 	category := controllers.NewCategoryController(container)
 	e.GET(config.APIv1CategoriesID, func(c echo.Context) error { return category.Get(c) })
 	e.GET(config.APIv1Categories, func(c echo.Context) error { return category.GetAll(c) })
+	e.GET(config.APIv1CategoriesTree, func(c echo.Context) error { return category.GetTree(c) })
 	e.POST(config.APIv1Categories, func(c echo.Context) error { return category.Create(c) })
 	e.PUT(config.APIv1CategoriesID, func(c echo.Context) error { return category.Update(c) })
 	e.DELETE(config.APIv1CategoriesID, func(c echo.Context) error { return category.Delete(c) })
@@ -80,6 +114,20 @@ func setServiceRoutes(e *echo.Echo, container container.Container) {
 	e.POST(config.APIv1Services, func(c echo.Context) error { return service.Create(c) })
 	e.PUT(config.APIv1ServicesID, func(c echo.Context) error { return service.Update(c) })
 	e.DELETE(config.APIv1ServicesID, func(c echo.Context) error { return service.Delete(c) })
+	e.GET(config.APIv1ServicesIDConsumables, func(c echo.Context) error { return service.GetConsumables(c) })
+	e.PUT(config.APIv1ServicesIDConsumables, func(c echo.Context) error { return service.SetConsumables(c) })
+	e.GET(config.APIv1ServicesIDResources, func(c echo.Context) error { return service.GetResources(c) })
+	e.PUT(config.APIv1ServicesIDResources, func(c echo.Context) error { return service.SetResources(c) })
+}
+
+func setServicePriceRoutes(e *echo.Echo, container container.Container) {
+	price := controllers.NewServicePriceController(container)
+	e.GET(config.APIv1ServicesIDPrices, func(c echo.Context) error { return price.GetAllByService(c) })
+	e.POST(config.APIv1ServicesIDPrices, func(c echo.Context) error { return price.Create(c) })
+	e.GET(config.APIv1ServicesIDPrice, func(c echo.Context) error { return price.Resolve(c) })
+	e.DELETE(config.APIv1ServicePricesID, func(c echo.Context) error { return price.Delete(c) })
+	e.GET(config.APIv1PriceList, func(c echo.Context) error { return price.GetPriceList(c) })
+	e.GET(config.APIv1PriceListExport, func(c echo.Context) error { return price.ExportPriceList(c) })
 }
 
 func setClientRoutes(e *echo.Echo, container container.Container) {
@@ -88,7 +136,64 @@ func setClientRoutes(e *echo.Echo, container container.Container) {
 	e.GET(config.APIv1Clients, func(c echo.Context) error { return client.GetAll(c) })
 	e.POST(config.APIv1Clients, func(c echo.Context) error { return client.Create(c) })
 	e.PUT(config.APIv1ClientsID, func(c echo.Context) error { return client.Update(c) })
+	e.PATCH(config.APIv1ClientsID, func(c echo.Context) error { return client.Patch(c) })
 	e.DELETE(config.APIv1ClientsID, func(c echo.Context) error { return client.Delete(c) })
+	e.POST(config.APIv1ClientsIDRestore, func(c echo.Context) error { return client.Restore(c) })
+	e.DELETE(config.APIv1ClientsIDPurge, func(c echo.Context) error { return client.Purge(c) })
+	e.GET(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.GetAccount(c) })
+	e.POST(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.CreateAccount(c) })
+	e.DELETE(config.APIv1ClientsIDAccount, func(c echo.Context) error { return client.DeleteAccount(c) })
+	e.GET(config.APIv1ClientsIDDuplicates, func(c echo.Context) error { return client.GetDuplicates(c) })
+	e.POST(config.APIv1ClientsIDMerge, func(c echo.Context) error { return client.Merge(c) })
+	e.GET(config.APIv1ClientsIDMerges, func(c echo.Context) error { return client.GetMerges(c) })
+}
+
+func setDiscountRoutes(e *echo.Echo, container container.Container) {
+	tier := controllers.NewDiscountTierController(container)
+	e.GET(config.APIv1DiscountTiersID, func(c echo.Context) error { return tier.Get(c) })
+	e.GET(config.APIv1DiscountTiers, func(c echo.Context) error { return tier.GetAll(c) })
+	e.POST(config.APIv1DiscountTiers, func(c echo.Context) error { return tier.Create(c) })
+	e.PUT(config.APIv1DiscountTiersID, func(c echo.Context) error { return tier.Update(c) })
+	e.DELETE(config.APIv1DiscountTiersID, func(c echo.Context) error { return tier.Delete(c) })
+	e.GET(config.APIv1ClientsIDDiscount, func(c echo.Context) error { return tier.GetForClient(c) })
+
+	code := controllers.NewPromoCodeController(container)
+	e.GET(config.APIv1PromoCodesID, func(c echo.Context) error { return code.Get(c) })
+	e.GET(config.APIv1PromoCodes, func(c echo.Context) error { return code.GetAll(c) })
+	e.POST(config.APIv1PromoCodes, func(c echo.Context) error { return code.Create(c) })
+	e.PUT(config.APIv1PromoCodesID, func(c echo.Context) error { return code.Update(c) })
+	e.DELETE(config.APIv1PromoCodesID, func(c echo.Context) error { return code.Delete(c) })
+}
+
+func setInvoiceRoutes(e *echo.Echo, container container.Container) {
+	balance := controllers.NewBalanceController(container)
+	e.GET(config.APIv1ClientsIDBalance, func(c echo.Context) error { return balance.Get(c) })
+	e.POST(config.APIv1ClientsIDBalanceTopUp, func(c echo.Context) error { return balance.TopUp(c) })
+	e.POST(config.APIv1ClientsIDBalanceRefund, func(c echo.Context) error { return balance.Refund(c) })
+
+	invoice := controllers.NewInvoiceController(container)
+	e.GET(config.APIv1InvoicesID, func(c echo.Context) error { return invoice.Get(c) })
+	e.GET(config.APIv1ClientsIDInvoices, func(c echo.Context) error { return invoice.GetAllByClient(c) })
+	e.POST(config.APIv1VisitsIDInvoice, func(c echo.Context) error { return invoice.Issue(c) })
+}
+
+func setSpeciesRoutes(e *echo.Echo, container container.Container) {
+	species := controllers.NewSpeciesController(container)
+	e.GET(config.APIv1SpeciesID, func(c echo.Context) error { return species.Get(c) })
+	e.GET(config.APIv1Species, func(c echo.Context) error { return species.GetAll(c) })
+	e.POST(config.APIv1Species, func(c echo.Context) error { return species.Create(c) })
+	e.PUT(config.APIv1SpeciesID, func(c echo.Context) error { return species.Update(c) })
+	e.DELETE(config.APIv1SpeciesID, func(c echo.Context) error { return species.Delete(c) })
+}
+
+func setBreedRoutes(e *echo.Echo, container container.Container) {
+	breed := controllers.NewBreedController(container)
+	e.GET(config.APIv1BreedsUnverified, func(c echo.Context) error { return breed.GetUnverified(c) })
+	e.GET(config.APIv1BreedsID, func(c echo.Context) error { return breed.Get(c) })
+	e.GET(config.APIv1Breeds, func(c echo.Context) error { return breed.GetAll(c) })
+	e.POST(config.APIv1Breeds, func(c echo.Context) error { return breed.Create(c) })
+	e.PUT(config.APIv1BreedsID, func(c echo.Context) error { return breed.Update(c) })
+	e.DELETE(config.APIv1BreedsID, func(c echo.Context) error { return breed.Delete(c) })
 }
 
 func setPetRoutes(e *echo.Echo, container container.Container) {
@@ -97,7 +202,67 @@ func setPetRoutes(e *echo.Echo, container container.Container) {
 	e.GET(config.APIv1Pets, func(c echo.Context) error { return pet.GetAll(c) })
 	e.POST(config.APIv1Pets, func(c echo.Context) error { return pet.Create(c) })
 	e.PUT(config.APIv1PetsID, func(c echo.Context) error { return pet.Update(c) })
+	e.PATCH(config.APIv1PetsID, func(c echo.Context) error { return pet.Patch(c) })
 	e.DELETE(config.APIv1PetsID, func(c echo.Context) error { return pet.Delete(c) })
+	e.POST(config.APIv1PetsIDRestore, func(c echo.Context) error { return pet.Restore(c) })
+	e.DELETE(config.APIv1PetsIDPurge, func(c echo.Context) error { return pet.Purge(c) })
+	e.GET(config.APIv1PetsIDWeights, func(c echo.Context) error { return pet.GetWeights(c) })
+	e.POST(config.APIv1PetsIDWeights, func(c echo.Context) error { return pet.RecordWeight(c) })
+	e.GET(config.APIv1PetsIDWeightsTrend, func(c echo.Context) error { return pet.GetWeightTrend(c) })
+}
+
+func setMedicationRoutes(e *echo.Echo, container container.Container) {
+	medication := controllers.NewMedicationController(container)
+	e.GET(config.APIv1MedicationsID, func(c echo.Context) error { return medication.Get(c) })
+	e.GET(config.APIv1Medications, func(c echo.Context) error { return medication.GetAll(c) })
+	e.POST(config.APIv1Medications, func(c echo.Context) error { return medication.Create(c) })
+	e.PUT(config.APIv1MedicationsID, func(c echo.Context) error { return medication.Update(c) })
+	e.DELETE(config.APIv1MedicationsID, func(c echo.Context) error { return medication.Delete(c) })
+}
+
+func setProductRoutes(e *echo.Echo, container container.Container) {
+	product := controllers.NewProductController(container)
+	e.GET(config.APIv1ProductsLowStock, func(c echo.Context) error { return product.GetLowStock(c) })
+	e.GET(config.APIv1ProductsID, func(c echo.Context) error { return product.Get(c) })
+	e.GET(config.APIv1Products, func(c echo.Context) error { return product.GetAll(c) })
+	e.POST(config.APIv1Products, func(c echo.Context) error { return product.Create(c) })
+	e.PUT(config.APIv1ProductsID, func(c echo.Context) error { return product.Update(c) })
+	e.DELETE(config.APIv1ProductsID, func(c echo.Context) error { return product.Delete(c) })
+	e.GET(config.APIv1ProductsIDBatches, func(c echo.Context) error { return product.GetBatches(c) })
+	e.POST(config.APIv1ProductsIDBatches, func(c echo.Context) error { return product.ReceiveBatch(c) })
+	e.GET(config.APIv1ProductsIDMovements, func(c echo.Context) error { return product.GetMovements(c) })
+	e.GET(config.APIv1BatchesExpiring, func(c echo.Context) error { return product.GetExpiring(c) })
+	e.POST(config.APIv1BatchesIDWriteOff, func(c echo.Context) error { return product.WriteOff(c) })
+}
+
+func setPrescriptionRoutes(e *echo.Echo, container container.Container) {
+	prescription := controllers.NewPrescriptionController(container)
+	e.GET(config.APIv1PrescriptionsID, func(c echo.Context) error { return prescription.Get(c) })
+	e.POST(config.APIv1Prescriptions, func(c echo.Context) error { return prescription.Create(c) })
+	e.POST(config.APIv1PrescriptionsIDCancel, func(c echo.Context) error { return prescription.Cancel(c) })
+	e.GET(config.APIv1PrescriptionsIDPrint, func(c echo.Context) error { return prescription.Print(c) })
+	e.GET(config.APIv1PetsIDPrescriptions, func(c echo.Context) error { return prescription.GetAllByPet(c) })
+	e.GET(config.APIv1PetsIDMedications, func(c echo.Context) error { return prescription.GetCurrentByPet(c) })
+}
+
+func setAttachmentRoutes(e *echo.Echo, container container.Container) {
+	attachment := controllers.NewAttachmentController(container)
+	e.GET(config.APIv1AttachmentsID, func(c echo.Context) error { return attachment.Get(c) })
+	e.GET(config.APIv1AttachmentsIDDownload, func(c echo.Context) error { return attachment.Download(c) })
+	e.DELETE(config.APIv1AttachmentsID, func(c echo.Context) error { return attachment.Delete(c) })
+	e.GET(config.APIv1PetsIDAttachments, func(c echo.Context) error { return attachment.GetAllByPet(c) })
+	e.POST(config.APIv1PetsIDAttachments, func(c echo.Context) error { return attachment.CreateForPet(c) })
+	e.GET(config.APIv1VisitsIDAttachments, func(c echo.Context) error { return attachment.GetAllByVisit(c) })
+	e.POST(config.APIv1VisitsIDAttachments, func(c echo.Context) error { return attachment.CreateForVisit(c) })
+}
+
+func setLabResultRoutes(e *echo.Echo, container container.Container) {
+	result := controllers.NewLabResultController(container)
+	e.GET(config.APIv1LabResultsID, func(c echo.Context) error { return result.Get(c) })
+	e.POST(config.APIv1LabResults, func(c echo.Context) error { return result.Create(c) })
+	e.DELETE(config.APIv1LabResultsID, func(c echo.Context) error { return result.Delete(c) })
+	e.GET(config.APIv1PetsIDLabResults, func(c echo.Context) error { return result.GetAllByPet(c) })
+	e.GET(config.APIv1VisitsIDLabResults, func(c echo.Context) error { return result.GetAllByVisit(c) })
 }
 
 func setVisitRoutes(e *echo.Echo, container container.Container) {
@@ -106,14 +271,58 @@ func setVisitRoutes(e *echo.Echo, container container.Container) {
 	e.GET(config.APIv1Visits, func(c echo.Context) error { return visit.GetAll(c) })
 	e.POST(config.APIv1Visits, func(c echo.Context) error { return visit.Create(c) })
 	e.PUT(config.APIv1VisitsID, func(c echo.Context) error { return visit.Update(c) })
+	e.PATCH(config.APIv1VisitsID, func(c echo.Context) error { return visit.Patch(c) })
 	e.DELETE(config.APIv1VisitsID, func(c echo.Context) error { return visit.Delete(c) })
+	e.POST(config.APIv1VisitsIDRestore, func(c echo.Context) error { return visit.Restore(c) })
+	e.DELETE(config.APIv1VisitsIDPurge, func(c echo.Context) error { return visit.Purge(c) })
+	e.POST(config.APIv1VisitsIDComplete, func(c echo.Context) error { return visit.Complete(c) })
 }
 
-func setLeadRoutes(e *echo.Echo, container container.Container) {
+func setWaitlistRoutes(e *echo.Echo, container container.Container) {
+	waitlist := controllers.NewWaitlistController(container)
+	e.GET(config.APIv1WaitlistMatches, func(c echo.Context) error { return waitlist.GetMatches(c) })
+	e.GET(config.APIv1WaitlistID, func(c echo.Context) error { return waitlist.Get(c) })
+	e.GET(config.APIv1Waitlist, func(c echo.Context) error { return waitlist.GetAll(c) })
+	e.POST(config.APIv1Waitlist, func(c echo.Context) error { return waitlist.Create(c) })
+	e.PUT(config.APIv1WaitlistID, func(c echo.Context) error { return waitlist.Update(c) })
+	e.DELETE(config.APIv1WaitlistID, func(c echo.Context) error { return waitlist.Delete(c) })
+}
+
+func setLeadRoutes(e *echo.Echo, container container.Container, limiter *middleware.RateLimiter) {
 	lead := controllers.NewLeadController(container)
 	e.GET(config.APIv1LeadsID, func(c echo.Context) error { return lead.Get(c) })
 	e.GET(config.APIv1Leads, func(c echo.Context) error { return lead.GetAll(c) })
-	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) })
+	e.GET(config.APIv1LeadsForm, func(c echo.Context) error { return lead.GetForm(c) }, limiter.Group("public"))
+	e.POST(config.APIv1Leads, func(c echo.Context) error { return lead.Create(c) }, limiter.Group("public"))
 	e.PUT(config.APIv1LeadsID, func(c echo.Context) error { return lead.Update(c) })
+	e.PATCH(config.APIv1LeadsID, func(c echo.Context) error { return lead.Patch(c) })
 	e.DELETE(config.APIv1LeadsID, func(c echo.Context) error { return lead.Delete(c) })
 }
+
+func setPortalRoutes(e *echo.Echo, container container.Container, limiter *middleware.RateLimiter) {
+	portal := controllers.NewPortalController(container)
+	e.POST(config.APIv1PortalLogin, func(c echo.Context) error { return portal.Login(c) }, limiter.Group("auth"))
+	e.POST(config.APIv1PortalLoginCode, func(c echo.Context) error { return portal.LoginWithCode(c) }, limiter.Group("auth"))
+	e.POST(config.APIv1PortalCode, func(c echo.Context) error { return portal.RequestCode(c) }, limiter.Group("auth"))
+	e.POST(config.APIv1PortalLogout, func(c echo.Context) error { return portal.Logout(c) })
+	e.GET(config.APIv1PortalProfile, func(c echo.Context) error { return portal.GetProfile(c) })
+	e.GET(config.APIv1PortalPets, func(c echo.Context) error { return portal.GetPets(c) })
+	e.GET(config.APIv1PortalVisits, func(c echo.Context) error { return portal.GetVisits(c) })
+	e.POST(config.APIv1PortalVisits, func(c echo.Context) error { return portal.RequestVisit(c) })
+	e.POST(config.APIv1PortalVisitsIDCancel, func(c echo.Context) error { return portal.CancelVisit(c) })
+	e.GET(config.APIv1PortalInvoices, func(c echo.Context) error { return portal.GetInvoices(c) })
+	e.GET(config.APIv1PortalPetsIDRecord, func(c echo.Context) error { return portal.GetRecord(c) })
+}
+
+func setBookingRoutes(e *echo.Echo, container container.Container, limiter *middleware.RateLimiter) {
+	booking := controllers.NewBookingController(container)
+	e.GET(config.APIv1BookingDepartments, func(c echo.Context) error { return booking.GetDepartments(c) })
+	e.GET(config.APIv1BookingSlots, func(c echo.Context) error { return booking.GetSlots(c) })
+	e.POST(config.APIv1BookingVisits, func(c echo.Context) error { return booking.Book(c) },
+		limiter.Group("public"))
+}
+
+func setSearchRoutes(e *echo.Echo, container container.Container) {
+	search := controllers.NewSearchController(container)
+	e.GET(config.APIv1Search, func(c echo.Context) error { return search.Search(c) })
+}
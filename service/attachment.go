@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/storage"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// sniffLength is the number of the leading bytes used to detect the type of the file.
+const sniffLength = 512
+
+type AttachmentService struct {
+	container container.Container
+	storage   storage.Storage
+}
+
+// NewAttachmentService is constructor.
+func NewAttachmentService(container container.Container) *AttachmentService {
+	return NewAttachmentServiceWithStorage(container, storage.NewStorage(container.Logger(), container.Config()))
+}
+
+// NewAttachmentServiceWithStorage is constructor which keeps the files in the given storage.
+func NewAttachmentServiceWithStorage(container container.Container, storage storage.Storage) *AttachmentService {
+	return &AttachmentService{container: container, storage: storage}
+}
+
+// Get returns attachment full matched given attachment ID.
+func (s *AttachmentService) Get(ctx context.Context, id string) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch attachment ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	attachment := &models.Attachment{}
+	var err error
+
+	if attachment, err = attachment.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch attachment with ID %s: %v", id, err)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAllByPet returns the files attached to the pet and its visits.
+func (s *AttachmentService) GetAllByPet(ctx context.Context, petID string) ([]*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetAllByPet")
+	defer span.End()
+
+	if !util.IsNumeric(petID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", petID)
+		return nil, apperror.NewInvalidID(petID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Attachment{}
+	var attachments []*models.Attachment
+	var err error
+
+	if attachments, err = model.GetAllByPet(rep, util.ConvertToUint(petID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch attachments of pet with ID %s: %v", petID, err)
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAllByVisit returns the files attached to the visit.
+func (s *AttachmentService) GetAllByVisit(ctx context.Context, visitID string) ([]*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetAllByVisit")
+	defer span.End()
+
+	if !util.IsNumeric(visitID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", visitID)
+		return nil, apperror.NewInvalidID(visitID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Attachment{}
+	var attachments []*models.Attachment
+	var err error
+
+	if attachments, err = model.GetAllByVisit(rep, util.ConvertToUint(visitID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch attachments of visit with ID %s: %v", visitID, err)
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// CreateForPet stores the uploaded file and attaches it to the pet.
+func (s *AttachmentService) CreateForPet(ctx context.Context, petID string, dto *dto.AttachmentDto,
+	content io.Reader, uploaderID uint) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.CreateForPet")
+	defer span.End()
+
+	if !util.IsNumeric(petID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", petID)
+		return nil, apperror.NewInvalidID(petID)
+	}
+
+	attachment := dto.ToModel()
+	attachment.PetID = util.ConvertToUint(petID)
+	attachment.UploadedByID = uploaderID
+	return s.create(ctx, attachment, dto.Size, content)
+}
+
+// CreateForVisit stores the uploaded file and attaches it to the visit and the pet of the visit.
+func (s *AttachmentService) CreateForVisit(ctx context.Context, visitID string, dto *dto.AttachmentDto,
+	content io.Reader, uploaderID uint) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.CreateForVisit")
+	defer span.End()
+
+	if !util.IsNumeric(visitID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", visitID)
+		return nil, apperror.NewInvalidID(visitID)
+	}
+
+	attachment := dto.ToModel()
+	attachment.VisitID = util.ConvertToUint(visitID)
+	attachment.UploadedByID = uploaderID
+	return s.create(ctx, attachment, dto.Size, content)
+}
+
+// Download returns the attachment along with the content of the file. The caller must close the content.
+func (s *AttachmentService) Download(ctx context.Context, id string) (*models.Attachment, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Download")
+	defer span.End()
+
+	attachment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to open the file of attachment with ID %s: %v", id, err)
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// Delete deletes the attachment along with the file.
+func (s *AttachmentService) Delete(ctx context.Context, id string) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch attachment ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	attachment := &models.Attachment{}
+	var err error
+
+	if attachment, err = attachment.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete attachment with ID %s: %v", id, err)
+		return nil, err
+	}
+	// The record is gone anyway, an orphaned file only takes space.
+	if err = s.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete the file of attachment with ID %s: %v", id, err)
+	}
+	return attachment, nil
+}
+
+// create checks the size and the type of the file, stores it and persists the attachment.
+// The type is detected from the content, so a renamed executable is not accepted as a PDF.
+func (s *AttachmentService) create(ctx context.Context, attachment *models.Attachment, size int64,
+	content io.Reader) (*models.Attachment, error) {
+	conf := s.container.Config().Attachment
+	if size > conf.MaxSize {
+		return nil, apperror.NewFileTooLarge(conf.MaxSize)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n == 0 {
+		e := apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed, "the file is empty")
+		e.Details = []*apperror.FieldError{{Field: "file", Tag: "required", Message: e.Message}}
+		return nil, e
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !s.allowed(contentType) {
+		return nil, apperror.NewUnsupportedMediaType(contentType)
+	}
+
+	key, err := newStorageKey(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// One byte over the limit is enough to tell that the file is too large.
+	limited := &io.LimitedReader{R: io.MultiReader(bytes.NewReader(head[:n]), content), N: conf.MaxSize + 1}
+	if err = s.storage.Save(ctx, key, limited); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to store the file %s: %v", attachment.FileName, err)
+		return nil, err
+	}
+	if limited.N == 0 {
+		_ = s.storage.Delete(ctx, key)
+		return nil, apperror.NewFileTooLarge(conf.MaxSize)
+	}
+
+	attachment.ContentType = contentType
+	attachment.Size = conf.MaxSize + 1 - limited.N
+	attachment.StorageKey = key
+	rep := s.container.Repository().WithContext(ctx)
+	if attachment, err = attachment.Create(rep); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create attachment: %v", err)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// allowed returns true if the files of the type are accepted.
+func (s *AttachmentService) allowed(contentType string) bool {
+	for _, t := range s.container.Config().Attachment.AllowedTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// newStorageKey returns a random key, which is grouped by the month of the upload.
+func newStorageKey(now time.Time) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return now.Format("2006/01/") + hex.EncodeToString(b), nil
+}
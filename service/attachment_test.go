@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"vet-clinic/models/dto"
+	"vet-clinic/storage"
+	"vet-clinic/test"
+)
+
+const testPDF = "%PDF-1.4\n% ЭхоКГ скрининг\n"
+
+func TestCreateAttachmentForVisit_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	result, err := s.CreateForVisit(context.Background(), "1", createAttachmentForCreate(), strings.NewReader(testPDF), 1)
+	byPet, _ := s.GetAllByPet(context.Background(), "1")
+	byVisit, _ := s.GetAllByVisit(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.PetID)
+	assert.Equal(t, uint(1), result.VisitID)
+	assert.Equal(t, "application/pdf", result.ContentType)
+	assert.Equal(t, int64(len(testPDF)), result.Size)
+	assert.Equal(t, uint(1), result.UploadedByID)
+	assert.Len(t, byPet, 1)
+	assert.Len(t, byVisit, 1)
+}
+
+func TestCreateAttachmentForPet_NotPet(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	result, err := s.CreateForPet(context.Background(), "99", createAttachmentForCreate(), strings.NewReader(testPDF), 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record not found", err.Error())
+}
+
+func TestCreateAttachment_UnsupportedType(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	param := createAttachmentForCreate()
+	param.FileName = "report.pdf"
+	result, err := s.CreateForPet(context.Background(), "1", param, strings.NewReader("MZ\x90\x00\x03\x00\x00\x00"), 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the file type application/octet-stream is not allowed", err.Error())
+}
+
+func TestCreateAttachment_TooLarge(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	content := testPDF + strings.Repeat("0", int(cont.Config().Attachment.MaxSize))
+	// The declared size is not trusted.
+	result, err := s.CreateForPet(context.Background(), "1", createAttachmentForCreate(), strings.NewReader(content), 1)
+	all, _ := s.GetAllByPet(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the file must not be larger than 1048576 bytes", err.Error())
+	assert.Empty(t, all)
+}
+
+func TestCreateAttachment_Empty(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	result, err := s.CreateForPet(context.Background(), "1", createAttachmentForCreate(), strings.NewReader(""), 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the file is empty", err.Error())
+}
+
+func TestDownloadAttachment_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewAttachmentServiceWithStorage(cont, storage.NewLocalStorage(t.TempDir()))
+	_, _ = s.CreateForPet(context.Background(), "1", createAttachmentForCreate(), strings.NewReader(testPDF), 1)
+
+	result, content, err := s.Download(context.Background(), "1")
+
+	if assert.NoError(t, err) {
+		defer content.Close()
+		data, _ := io.ReadAll(content)
+		assert.Equal(t, "echo.pdf", result.FileName)
+		assert.Equal(t, testPDF, string(data))
+	}
+}
+
+func TestDeleteAttachment_RemovesFile(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	files := storage.NewLocalStorage(t.TempDir())
+	s := NewAttachmentServiceWithStorage(cont, files)
+	_, _ = s.CreateForPet(context.Background(), "1", createAttachmentForCreate(), strings.NewReader(testPDF), 1)
+
+	result, err := s.Delete(context.Background(), "1")
+	_, openErr := files.Open(context.Background(), result.StorageKey)
+	_, getErr := s.Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Error(t, openErr)
+	assert.Equal(t, "record not found", getErr.Error())
+}
+
+func createAttachmentForCreate() *dto.AttachmentDto {
+	return &dto.AttachmentDto{
+		FileName:    "echo.pdf",
+		Description: "ЭхоКГ скрининг",
+		Size:        int64(len(testPDF)),
+	}
+}
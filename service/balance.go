@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// BalanceService manages the balance ledger of the clients.
+type BalanceService struct {
+	container container.Container
+}
+
+// NewBalanceService is constructor.
+func NewBalanceService(container container.Container) *BalanceService {
+	return &BalanceService{container: container}
+}
+
+// Get returns the balance of the client along with its ledger.
+func (s *BalanceService) Get(ctx context.Context, clientID string) (*models.ClientBalance, error) {
+	ctx, span := tracing.Start(ctx, "BalanceService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.BalanceEntry{}
+	var balance *models.ClientBalance
+	var err error
+
+	if balance, err = model.GetBalance(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch balance of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return balance, nil
+}
+
+// TopUp records the prepayment made by the client.
+func (s *BalanceService) TopUp(ctx context.Context, dto *dto.BalanceEntryDto, clientID string,
+	createdByID uint) (*models.ClientBalance, error) {
+	ctx, span := tracing.Start(ctx, "BalanceService.TopUp")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := dto.ToModel()
+	entry.CreatedByID = createdByID
+	var balance *models.ClientBalance
+	var err error
+
+	if balance, err = entry.TopUp(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to top up balance of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return balance, nil
+}
+
+// Refund records the money returned to the client.
+func (s *BalanceService) Refund(ctx context.Context, dto *dto.BalanceEntryDto, clientID string,
+	createdByID uint) (*models.ClientBalance, error) {
+	ctx, span := tracing.Start(ctx, "BalanceService.Refund")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := dto.ToModel()
+	entry.CreatedByID = createdByID
+	var balance *models.ClientBalance
+	var err error
+
+	if balance, err = entry.Refund(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to refund balance of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return balance, nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// BookingDateFormat is the format of the date of the free slots query.
+const BookingDateFormat = "2006-01-02"
+
+// BookingService provides the public online booking.
+type BookingService struct {
+	container container.Container
+}
+
+// NewBookingService is constructor.
+func NewBookingService(container container.Container) *BookingService {
+	return &BookingService{container: container}
+}
+
+// GetDepartments returns all departments along with their bookable services and doctors.
+func (s *BookingService) GetDepartments(ctx context.Context) ([]*models.BookableDepartment, error) {
+	ctx, span := tracing.Start(ctx, "BookingService.GetDepartments")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	department := &models.Department{}
+
+	departments, err := department.GetBookable(rep)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch bookable departments: %v", err)
+		return nil, err
+	}
+	return departments, nil
+}
+
+// GetSlots returns the free slots on the given date of the doctors who provide the given service.
+// The slots are within the working hours of each doctor, and the doctor and the resources required by the service
+// must be free for the duration of the service.
+// The department and the doctor IDs are optional filters.
+func (s *BookingService) GetSlots(ctx context.Context, departmentID, serviceID, doctorID, date string) ([]*models.Slot, error) {
+	ctx, span := tracing.Start(ctx, "BookingService.GetSlots")
+	defer span.End()
+
+	for _, id := range []string{departmentID, doctorID} {
+		if id != "" && !util.IsNumeric(id) {
+			return nil, apperror.NewInvalidID(id)
+		}
+	}
+	if !util.IsNumeric(serviceID) {
+		return nil, apperror.NewInvalidID(serviceID)
+	}
+	day, err := time.ParseInLocation(BookingDateFormat, date, time.Local)
+	if err != nil {
+		return nil, apperror.Wrap(err, http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid date: %s", date))
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	user := &models.User{}
+	doctors, err := user.GetBookableDoctors(rep, util.ConvertToUint(departmentID),
+		util.ConvertToUint(serviceID), util.ConvertToUint(doctorID))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch bookable doctors: %v", err)
+		return nil, err
+	}
+	slots := []*models.Slot{}
+	if len(doctors) == 0 {
+		return slots, nil
+	}
+
+	conf := s.container.Config().Booking
+	doctorIDs := make([]uint, 0, len(doctors))
+	for _, doctor := range doctors {
+		doctorIDs = append(doctorIDs, doctor.ID)
+	}
+	hours, err := (&models.WorkingHours{}).GetAllByUsers(rep, doctorIDs)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch working hours: %v", err)
+		return nil, err
+	}
+	service, err := (&models.Service{}).Get(rep, util.ConvertToUint(serviceID))
+	if err != nil {
+		return nil, err
+	}
+	duration := service.VisitDuration(conf.SlotDuration)
+	visit := &models.Visit{}
+	booked, err := visit.GetBooked(rep, doctorIDs, day, day.AddDate(0, 0, 1).Add(duration), conf.SlotDuration)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch booked visits: %v", err)
+		return nil, err
+	}
+	reservations, err := (&models.Reservation{}).GetAllForService(rep, service.ID, day, day.AddDate(0, 0, 1).Add(duration))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch reservations: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, doctorID := range doctorIDs {
+		dayStart, dayEnd, working := s.workingDay(hours[doctorID], day)
+		if !working {
+			continue
+		}
+		for offset := dayStart; offset+conf.SlotDuration <= dayEnd; offset += conf.SlotDuration {
+			start := day.Add(offset)
+			if !s.bookable(start, duration, now, dayStart, dayEnd) ||
+				overlaps(booked, doctorID, start, start.Add(duration), conf.SlotDuration) ||
+				reserved(reservations, start, start.Add(duration)) {
+				continue
+			}
+			slots = append(slots, &models.Slot{DoctorID: doctorID, Start: start, End: start.Add(conf.SlotDuration)})
+		}
+	}
+	return slots, nil
+}
+
+// Book reserves the slot for the client and creates a tentative visit, which is confirmed by the clinic later.
+func (s *BookingService) Book(ctx context.Context, dto *dto.BookingDto) (*models.Booking, error) {
+	ctx, span := tracing.Start(ctx, "BookingService.Book")
+	defer span.End()
+
+	visit, client := dto.ToModel()
+	visit.DateTime = visit.DateTime.In(time.Local)
+	now := time.Now()
+
+	rep := s.container.Repository().WithContext(ctx)
+	hours, err := (&models.WorkingHours{}).GetAllByUsers(rep, []uint{visit.DoctorID})
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch working hours: %v", err)
+		return nil, err
+	}
+	service, err := (&models.Service{}).Get(rep, visit.ServiceID)
+	if err != nil {
+		return nil, apperror.NewInvalidReference("serviceId", err)
+	}
+	duration := service.VisitDuration(s.container.Config().Booking.SlotDuration)
+	dayStart, dayEnd, working := s.workingDay(hours[visit.DoctorID], visit.DateTime)
+	if !working || !s.bookable(visit.DateTime, duration, now, dayStart, dayEnd) {
+		e := apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed, "dateTime is not a bookable slot")
+		e.Details = []*apperror.FieldError{{Field: "dateTime", Tag: "slot", Message: e.Message}}
+		return nil, e
+	}
+
+	if visit, err = visit.Book(rep, client, dto.PetName, s.container.Config().Booking.SlotDuration, now); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to book visit: %v", err)
+		return nil, err
+	}
+	return visit.ToBooking(), nil
+}
+
+// workingDay returns the working hours of the doctor on the day of the given time as offsets from midnight,
+// or false if the doctor does not work on that day. A doctor without working hours works the default hours.
+func (s *BookingService) workingDay(hours []*models.WorkingHours, day time.Time) (time.Duration, time.Duration, bool) {
+	if len(hours) == 0 {
+		conf := s.container.Config().Booking
+		return conf.DayStart, conf.DayEnd, true
+	}
+	for _, h := range hours {
+		if h.Weekday == int(day.Weekday()) {
+			start, end := h.Offsets()
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// bookable returns true if the given time is the start of a slot within the booking horizon, and a visit
+// of the given duration starting then ends within the given working hours.
+func (s *BookingService) bookable(start time.Time, duration time.Duration, now time.Time, dayStart, dayEnd time.Duration) bool {
+	conf := s.container.Config().Booking
+	if conf.SlotDuration <= 0 || !start.After(now) || start.After(now.Add(conf.Horizon)) {
+		return false
+	}
+	midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	offset := start.Sub(midnight)
+	return offset >= dayStart && offset+duration <= dayEnd &&
+		(offset-dayStart)%conf.SlotDuration == 0
+}
+
+// overlaps returns true if any of the booked visits of the doctor overlaps the given period.
+func overlaps(booked []*models.Visit, doctorID uint, start, end time.Time, fallback time.Duration) bool {
+	for _, visit := range booked {
+		if visit.DoctorID == doctorID && visit.DateTime.Before(end) && visit.EndTime(fallback).After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// reserved returns true if any of the reservations overlaps the given period.
+func reserved(reservations []*models.Reservation, start, end time.Time) bool {
+	for _, reservation := range reservations {
+		if reservation.Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestGetSlots_NoDoctors(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBookingService(cont)
+	date := time.Now().AddDate(0, 0, 1).Format(BookingDateFormat)
+	result, err := s.GetSlots(context.Background(), "2", "2", "", date)
+
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetSlots_PastDate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBookingService(cont)
+	date := time.Now().AddDate(0, 0, -1).Format(BookingDateFormat)
+	result, err := s.GetSlots(context.Background(), "", "1", "", date)
+
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestBook_OutsideHorizon(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBookingService(cont)
+	day := time.Now().AddDate(0, 1, 0)
+	result, err := s.Book(context.Background(), &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 1,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		PetName:   "Барсик",
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.ValidationFailed, apperror.From(err, nil).Code)
+}
+
+func TestBook_DoctorWithoutService(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBookingService(cont)
+	day := time.Now().AddDate(0, 0, 1)
+	result, err := s.Book(context.Background(), &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 2,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		PetName:   "Барсик",
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.InvalidReference, apperror.From(err, nil).Code)
+}
+
+func TestGetSlots_ProfessionRequired(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewServiceService(cont).Update(context.Background(),
+		&dto.ServiceDto{Name: "Консультация", Price: 1000, Profession: "Кардиолог", CategoryID: 1}, "1")
+
+	s := NewBookingService(cont)
+	date := time.Now().AddDate(0, 0, 1).Format(BookingDateFormat)
+	result, err := s.GetSlots(context.Background(), "", "1", "", date)
+
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetBookable_ArchivedService(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewServiceService(cont).Update(context.Background(),
+		&dto.ServiceDto{Name: "Консультация", Price: 1000, Archived: true, CategoryID: 1}, "1")
+
+	s := NewBookingService(cont)
+	result, err := s.GetDepartments(context.Background())
+
+	assert.Nil(t, err)
+	if assert.Len(t, result, 2) {
+		assert.Len(t, result[0].Services, 4)
+		assert.NotContains(t, result[0].Doctors[0].Services, uint(1))
+	}
+}
+
+func TestGetSlots_WorkingHours(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	day := time.Now().AddDate(0, 0, 1)
+	users := NewUserService(cont)
+	_, _ = users.SetWorkingHours(context.Background(), &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: int(day.Weekday()), Start: "10:00", End: "11:00"}}}, "1")
+
+	s := NewBookingService(cont)
+	result, err := s.GetSlots(context.Background(), "", "1", "1", day.Format(BookingDateFormat))
+
+	assert.Nil(t, err)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, 10, result[0].Start.Hour())
+		assert.Equal(t, 30, result[1].Start.Minute())
+	}
+
+	result, err = s.GetSlots(context.Background(), "", "1", "1", day.AddDate(0, 0, 1).Format(BookingDateFormat))
+
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetSlots_ResourceReserved(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	day := time.Now().AddDate(0, 0, 1)
+	start := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.Local)
+	_, _ = NewServiceService(cont).SetResources(context.Background(), &dto.ServiceResourcesDto{ResourceIDs: []uint{1}}, "1")
+	_, _ = NewUserService(cont).SetWorkingHours(context.Background(), &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: int(day.Weekday()), Start: "10:00", End: "11:00"}}}, "1")
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 1
+	visitDto.DoctorID = 1
+	visitDto.DateTime = start
+	_, _ = NewVisitService(cont).Create(context.Background(), visitDto)
+
+	s := NewBookingService(cont)
+	result, err := s.GetSlots(context.Background(), "", "1", "", day.Format(BookingDateFormat))
+
+	assert.Nil(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, start.Add(30*time.Minute), result[0].Start)
+	}
+}
+
+func TestGetSlots_LongVisitBooked(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	day := time.Now().AddDate(0, 0, 1)
+	_ = cont.Repository().Model(&models.Service{}).Where("id = ?", 3).Update("duration", 60).Error
+	_, _ = NewUserService(cont).SetWorkingHours(context.Background(), &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: int(day.Weekday()), Start: "10:00", End: "11:30"}}}, "1")
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 3
+	visitDto.DoctorID = 1
+	visitDto.DateTime = time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.Local)
+	_, _ = NewVisitService(cont).Create(context.Background(), visitDto)
+
+	s := NewBookingService(cont)
+	result, err := s.GetSlots(context.Background(), "", "1", "1", day.Format(BookingDateFormat))
+
+	assert.Nil(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 11, result[0].Start.Hour())
+	}
+
+	_, err = s.Book(context.Background(), &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 1,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), 10, 30, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		PetName:   "Барсик",
+	})
+
+	assert.Equal(t, apperror.InvalidState, apperror.From(err, nil).Code)
+}
+
+func TestGetSlots_LongServiceEndsWithinHours(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	day := time.Now().AddDate(0, 0, 1)
+	_ = cont.Repository().Model(&models.Service{}).Where("id = ?", 3).Update("duration", 60).Error
+	_, _ = NewUserService(cont).SetWorkingHours(context.Background(), &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: int(day.Weekday()), Start: "10:00", End: "11:00"}}}, "1")
+
+	s := NewBookingService(cont)
+	result, err := s.GetSlots(context.Background(), "", "3", "1", day.Format(BookingDateFormat))
+
+	assert.Nil(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 10, result[0].Start.Hour())
+		assert.Equal(t, 0, result[0].Start.Minute())
+	}
+
+	_, err = s.Book(context.Background(), &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 3,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), 10, 30, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		PetName:   "Барсик",
+	})
+
+	assert.Equal(t, apperror.ValidationFailed, apperror.From(err, nil).Code)
+}
+
+func TestBook_DayOff(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	day := time.Now().AddDate(0, 0, 1)
+	_, _ = NewUserService(cont).SetWorkingHours(context.Background(), &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: int(day.AddDate(0, 0, 1).Weekday()), Start: "10:00", End: "11:00"}}}, "1")
+
+	s := NewBookingService(cont)
+	result, err := s.Book(context.Background(), &dto.BookingDto{
+		DoctorID:  1,
+		ServiceID: 1,
+		DateTime:  time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.Local),
+		Name:      "Иван",
+		Phone:     "+79876543210",
+		PetName:   "Барсик",
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.ValidationFailed, apperror.From(err, nil).Code)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type BreedService struct {
+	container container.Container
+}
+
+// NewBreedService is constructor.
+func NewBreedService(container container.Container) *BreedService {
+	return &BreedService{container: container}
+}
+
+// Get returns breed full matched given breed ID.
+func (s *BreedService) Get(ctx context.Context, id string) (*models.Breed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch breed ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	breed := &models.Breed{}
+	var err error
+
+	if breed, err = breed.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch breed with ID %s: %v", id, err)
+		return nil, err
+	}
+	return breed, nil
+}
+
+// GetAll returns a slice of all breeds. If speciesID is not empty, only the breeds of the species are returned.
+func (s *BreedService) GetAll(ctx context.Context, speciesID string) ([]*models.Breed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.GetAll")
+	defer span.End()
+
+	if speciesID != "" && !util.IsNumeric(speciesID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species ID: %s", speciesID)
+		return nil, apperror.NewInvalidID(speciesID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Breed{}
+	var breeds []*models.Breed
+	var err error
+
+	if breeds, err = model.GetAll(rep, util.ConvertToUint(speciesID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch breeds: %v", err)
+		return nil, err
+	}
+	return breeds, nil
+}
+
+// Create persists this breed data.
+func (s *BreedService) Create(ctx context.Context, dto *dto.BreedDto) (*models.Breed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	breed := &models.Breed{}
+	var err error
+
+	if breed, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create breed: %v", err)
+		return nil, err
+	}
+	return breed, nil
+}
+
+// Update updates this breed data.
+func (s *BreedService) Update(ctx context.Context, dto *dto.BreedDto, id string) (*models.Breed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch breed ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	breed := &models.Breed{}
+	var err error
+
+	if breed, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update breed with ID %s: %v", id, err)
+		return nil, err
+	}
+	return breed, nil
+}
+
+// Delete deletes this breed data.
+func (s *BreedService) Delete(ctx context.Context, id string) (*models.Breed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch breed ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	breed := &models.Breed{}
+	var err error
+
+	if breed, err = breed.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete breed: %v", err)
+		return nil, err
+	}
+	return breed, nil
+}
+
+// GetUnverified returns the breeds entered as free text for the pets, which are to be reviewed.
+func (s *BreedService) GetUnverified(ctx context.Context) ([]*models.UnverifiedBreed, error) {
+	ctx, span := tracing.Start(ctx, "BreedService.GetUnverified")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Breed{}
+
+	breeds, err := model.GetUnverified(rep)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch unverified breeds: %v", err)
+		return nil, err
+	}
+	return breeds, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestFindAllBreeds_BySpecies(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBreedService(cont)
+	result, err := s.GetAll(context.Background(), "2")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 4) {
+		assert.Equal(t, "Лабрадор-ретривер", result[0].Name)
+		assert.Equal(t, "Собака", result[0].Species.Name)
+	}
+}
+
+func TestFindAllBreeds_SpeciesNotNumeric(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBreedService(cont)
+	result, err := s.GetAll(context.Background(), "ABCD")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid id: ABCD", err.Error())
+}
+
+func TestCreateBreed_DuplicateName(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBreedService(cont)
+	result, err := s.Create(context.Background(), &dto.BreedDto{Name: "такса", SpeciesID: 2})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a breed with the same name already exists for the species", err.Error())
+}
+
+func TestCreateBreed_NotSpecies(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBreedService(cont)
+	result, err := s.Create(context.Background(), &dto.BreedDto{Name: "Такса", SpeciesID: 99})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "speciesId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestCreateBreed_LinksUnverifiedPets(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBreedService(cont)
+	before, _ := s.GetUnverified(context.Background())
+	breed, err := s.Create(context.Background(), &dto.BreedDto{Name: "Дворняга", SpeciesID: 1})
+	after, _ := s.GetUnverified(context.Background())
+	pet, _ := (&models.Pet{}).Get(cont.Repository(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.UnverifiedBreed{{SpeciesID: 1, Breed: "Дворняга", Count: 1}}, before)
+	assert.Empty(t, after)
+	assert.Equal(t, breed.ID, pet.BreedID)
+	assert.False(t, pet.BreedUnverified)
+}
+
+func TestUpdateBreed_RenamesPets(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	pets := NewPetService(cont)
+	petDto := createPetForCreate()
+	_, _ = pets.Update(context.Background(), petDto, "1")
+
+	s := NewBreedService(cont)
+	_, err := s.Update(context.Background(), &dto.BreedDto{Name: "Такса стандартная", SpeciesID: 2}, "7")
+	pet, _ := pets.Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Такса стандартная", pet.Breed)
+}
+
+func TestUpdateBreed_SpeciesOfReferenced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewPetService(cont).Update(context.Background(), createPetForCreate(), "1")
+
+	s := NewBreedService(cont)
+	result, err := s.Update(context.Background(), &dto.BreedDto{Name: "Такса", SpeciesID: 1}, "7")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
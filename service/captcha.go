@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"vet-clinic/container"
+)
+
+// CaptchaVerifier checks the response tokens of the captcha widget.
+type CaptchaVerifier interface {
+	// Verify returns true if the token was issued to a human who solved the captcha.
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// NewCaptchaVerifier returns the verifier configured by config.Lead.Captcha, or nil if the captcha is disabled.
+func NewCaptchaVerifier(container container.Container) CaptchaVerifier {
+	conf := container.Config().Lead.Captcha
+	if !conf.Enabled {
+		return nil
+	}
+	return &httpCaptchaVerifier{
+		url:    conf.VerifyURL,
+		secret: conf.Secret,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// httpCaptchaVerifier calls the siteverify endpoint, which is compatible among reCAPTCHA, hCaptcha and Turnstile.
+type httpCaptchaVerifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func (v *httpCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha verification returned status %d", res.StatusCode)
+	}
+
+	result := struct {
+		Success bool `json:"success"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
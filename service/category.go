@@ -1,10 +1,12 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,81 +20,113 @@ func NewCategoryService(container container.Container) *CategoryService {
 }
 
 // Get returns category full matched given category ID.
-func (s *CategoryService) Get(id string) (*models.Category, error) {
+func (s *CategoryService) Get(ctx context.Context, id string) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch category ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch category ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	category := &models.Category{}
 	var err error
 
 	if category, err = category.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch category with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch category with ID %s: %v", id, err)
 		return nil, err
 	}
 	return category, nil
 }
 
 // GetAll returns a slice of all categories.
-func (s *CategoryService) GetAll() ([]*models.Category, error) {
-	rep := s.container.Repository()
+func (s *CategoryService) GetAll(ctx context.Context) ([]*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Category{}
 	var categories []*models.Category
 	var err error
 
 	if categories, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch categories: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch categories: %v", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetTree returns the top-level categories with their subcategories nested.
+func (s *CategoryService) GetTree(ctx context.Context) ([]*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetTree")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Category{}
+	var categories []*models.Category
+	var err error
+
+	if categories, err = model.GetTree(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch category tree: %v", err)
 		return nil, err
 	}
 	return categories, nil
 }
 
 // Create persists this category data.
-func (s *CategoryService) Create(dto *dto.CategoryDto) (*models.Category, error) {
-	rep := s.container.Repository()
+func (s *CategoryService) Create(ctx context.Context, dto *dto.CategoryDto) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	category := &models.Category{}
 	var err error
 
 	if category, err = dto.ToModel().Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create category: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create category: %v", err)
 		return nil, err
 	}
 	return category, nil
 }
 
 // Update updates this category data.
-func (s *CategoryService) Update(dto *dto.CategoryDto, id string) (*models.Category, error) {
+func (s *CategoryService) Update(ctx context.Context, dto *dto.CategoryDto, id string) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch category ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch category ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	category := &models.Category{}
 	var err error
 
 	if category, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update category with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update category with ID %s: %v", id, err)
 		return nil, err
 	}
 	return category, nil
 }
 
 // Delete deletes this category data.
-func (s *CategoryService) Delete(id string) (*models.Category, error) {
+func (s *CategoryService) Delete(ctx context.Context, id string) (*models.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch category ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch category ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	category := &models.Category{}
 	var err error
 
 	if category, err = category.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete category: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete category: %v", err)
 		return nil, err
 	}
 	return category, nil
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"vet-clinic/models"
@@ -12,7 +14,7 @@ func TestFindCategoryByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -23,7 +25,7 @@ func TestFindCategoryByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -33,7 +35,7 @@ func TestFindCategoryByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -43,7 +45,7 @@ func TestFindAllCategories_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background())
 
 	assert.Len(t, result, 4)
 	assert.NoError(t, err)
@@ -53,11 +55,11 @@ func TestCreateCategory_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	_, err := s.Create(createCategoryForCreate())
+	_, err := s.Create(context.Background(), createCategoryForCreate())
 	result := createResultCategory()
 	result.ID = 5
 
-	data, _ := s.Get("5")
+	data, _ := s.Get(context.Background(), "5")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -68,11 +70,11 @@ func TestUpdateCategory_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	_, err := s.Update(createCategoryForCreate(), "1")
+	_, err := s.Update(context.Background(), createCategoryForCreate(), "1")
 	result := createResultCategory()
 	result.ID = 1
 
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -83,7 +85,7 @@ func TestUpdateCategory_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.Update(createCategoryForCreate(), "99")
+	result, err := s.Update(context.Background(), createCategoryForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -93,9 +95,9 @@ func TestDeleteCategory_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -105,7 +107,7 @@ func TestDeleteCategory_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewCategoryService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -122,3 +124,54 @@ func createResultCategory() *models.Category {
 		Name: "Category",
 	}
 }
+
+func TestGetCategoryTree_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewCategoryService(cont)
+	_, _ = s.Create(context.Background(), &dto.CategoryDto{Name: "Ультразвуковая диагностика", ParentID: 4})
+	result, err := s.GetTree(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 4) {
+		assert.Equal(t, "Инструментальная диагностика", result[0].Name)
+		if assert.Len(t, result[0].Children, 1) {
+			assert.Equal(t, "Ультразвуковая диагностика", result[0].Children[0].Name)
+		}
+	}
+}
+
+func TestCreateCategory_InvalidParent(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewCategoryService(cont)
+	result, err := s.Create(context.Background(), &dto.CategoryDto{Name: "Category", ParentID: 99})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "parentId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestUpdateCategory_Cycle(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewCategoryService(cont)
+	child, _ := s.Create(context.Background(), &dto.CategoryDto{Name: "Ультразвуковая диагностика", ParentID: 4})
+	result, err := s.Update(context.Background(),
+		&dto.CategoryDto{Name: "Инструментальная диагностика", ParentID: child.ID}, "4")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a category cannot be moved under itself or its subcategories", err.Error())
+}
+
+func TestDeleteCategory_MovesSubcategories(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewCategoryService(cont)
+	parent, _ := s.Create(context.Background(), &dto.CategoryDto{Name: "Ультразвуковая диагностика", ParentID: 4})
+	child, _ := s.Create(context.Background(), &dto.CategoryDto{Name: "Эхокардиография", ParentID: parent.ID})
+	_, err := s.Delete(context.Background(), fmt.Sprint(parent.ID))
+	assert.NoError(t, err)
+
+	result, _ := s.Get(context.Background(), fmt.Sprint(child.ID))
+	assert.Equal(t, uint(4), result.ParentID)
+}
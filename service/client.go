@@ -1,10 +1,12 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,82 +20,238 @@ func NewClientService(container container.Container) *ClientService {
 }
 
 // Get returns client full matched given client ID.
-func (s *ClientService) Get(id string) (*models.Client, error) {
+func (s *ClientService) Get(ctx context.Context, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	client := &models.Client{}
 	var err error
 
 	if client, err = client.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch client with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client with ID %s: %v", id, err)
 		return nil, err
 	}
 	return client, nil
 }
 
-// GetAll returns a slice of all clients.
-func (s *ClientService) GetAll() ([]*models.Client, error) {
-	rep := s.container.Repository()
+// GetAll returns a slice of all clients. If includeDeleted is true, the soft-deleted clients are included.
+func (s *ClientService) GetAll(ctx context.Context, includeDeleted bool) ([]*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Client{}
 	var clients []*models.Client
 	var err error
 
-	if clients, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch clients: %v", err)
+	if includeDeleted {
+		clients, err = model.GetAllWithDeleted(rep)
+	} else {
+		clients, err = model.GetAll(rep)
+	}
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch clients: %v", err)
 		return nil, err
 	}
 	return clients, nil
 }
 
 // Create persists this client data.
-func (s *ClientService) Create(dto *dto.ClientDto) (*models.Client, error) {
-	rep := s.container.Repository()
+func (s *ClientService) Create(ctx context.Context, dto *dto.ClientDto) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	client := dto.ToModel()
 	var err error
 
 	if client, err = client.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create client: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create client: %v", err)
 		return nil, err
 	}
 	return client, nil
 }
 
 // Update updates this client data.
-func (s *ClientService) Update(dto *dto.ClientDto, id string) (*models.Client, error) {
+func (s *ClientService) Update(ctx context.Context, dto *dto.ClientDto, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	client := dto.ToModel()
 	var err error
 
 	if client, err = client.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update client with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update client with ID %s: %v", id, err)
 		return nil, err
 	}
 	return client, nil
 }
 
+// Patch applies the JSON Merge Patch to this client data.
+// The fields which are absent in the patch keep their current values.
+func (s *ClientService) Patch(ctx context.Context, patch []byte, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Patch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+	var err error
+
+	if client, err = client.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client with ID %s: %v", id, err)
+		return nil, err
+	}
+
+	data := dto.NewClientDto(client)
+	if _, err = util.ApplyMergePatch(data, patch); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to patch client with ID %s: %v", id, err)
+		return nil, err
+	}
+	return s.Update(ctx, data, id)
+}
+
 // Delete deletes this client data.
-func (s *ClientService) Delete(id string) (*models.Client, error) {
+func (s *ClientService) Delete(ctx context.Context, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	client := &models.Client{}
 	var err error
 
 	if client, err = client.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete client: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete client: %v", err)
+		return nil, err
+	}
+	return client, nil
+}
+
+// Restore restores this soft-deleted client data.
+func (s *ClientService) Restore(ctx context.Context, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Restore")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+	var err error
+
+	if client, err = client.Restore(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to restore client with ID %s: %v", id, err)
+		return nil, err
+	}
+	return client, nil
+}
+
+// Purge permanently deletes this client data.
+func (s *ClientService) Purge(ctx context.Context, id string) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Purge")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+	var err error
+
+	if client, err = client.Purge(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to purge client with ID %s: %v", id, err)
 		return nil, err
 	}
 	return client, nil
 }
+
+// FindDuplicates returns the other clients which likely are the same person as this client.
+func (s *ClientService) FindDuplicates(ctx context.Context, id string) ([]*models.DuplicateClient, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.FindDuplicates")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+
+	duplicates, err := client.FindDuplicates(rep, util.ConvertToUint(id))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to find duplicates of client with ID %s: %v", id, err)
+		return nil, err
+	}
+	return duplicates, nil
+}
+
+// Merge merges the duplicate client into this client and returns the audit record of the merge.
+func (s *ClientService) Merge(ctx context.Context, dto *dto.ClientMergeDto, id string,
+	mergedByID uint) (*models.ClientMerge, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.Merge")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+
+	merge, err := client.Merge(rep, util.ConvertToUint(id), dto.SourceID, mergedByID)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to merge client with ID %d into client with ID %s: %v",
+			dto.SourceID, id, err)
+		return nil, err
+	}
+	s.container.Logger().WithContext(ctx).Infof("Merged client with ID %d into client with ID %s", dto.SourceID, id)
+	return merge, nil
+}
+
+// GetMerges returns the audit records of the merges into and from this client.
+func (s *ClientService) GetMerges(ctx context.Context, id string) ([]*models.ClientMerge, error) {
+	ctx, span := tracing.Start(ctx, "ClientService.GetMerges")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	merge := &models.ClientMerge{}
+
+	merges, err := merge.GetAllByClient(rep, util.ConvertToUint(id))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch merges of client with ID %s: %v", id, err)
+		return nil, err
+	}
+	return merges, nil
+}
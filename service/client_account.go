@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"math/big"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// oneTimeCodeLength is the number of digits of the one-time login code.
+const oneTimeCodeLength = 6
+
+// CodeSender delivers the one-time login codes to the clients.
+type CodeSender interface {
+	Send(ctx context.Context, client *models.Client, code string) error
+}
+
+// logCodeSender writes the one-time codes to the debug log.
+// It is used until the codes are delivered by SMS or e-mail.
+type logCodeSender struct {
+	container container.Container
+}
+
+func (s *logCodeSender) Send(ctx context.Context, client *models.Client, code string) error {
+	s.container.Logger().WithContext(ctx).Debugf("One-time code for client with ID %d: %s", client.ID, code)
+	return nil
+}
+
+type ClientAccountService struct {
+	container container.Container
+	sender    CodeSender
+}
+
+// NewClientAccountService is constructor.
+func NewClientAccountService(container container.Container) *ClientAccountService {
+	return NewClientAccountServiceWithSender(container, &logCodeSender{container: container})
+}
+
+// NewClientAccountServiceWithSender is constructor which uses the given sender of the one-time codes.
+func NewClientAccountServiceWithSender(container container.Container, sender CodeSender) *ClientAccountService {
+	return &ClientAccountService{container: container, sender: sender}
+}
+
+// Get returns the portal account of the client with the given client ID.
+func (s *ClientAccountService) Get(ctx context.Context, clientID string) (*models.ClientAccount, error) {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := &models.ClientAccount{}
+	var err error
+
+	if account, err = account.GetByClient(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch account of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return account, nil
+}
+
+// Create creates the portal account of the client with the given client ID.
+func (s *ClientAccountService) Create(ctx context.Context, dto *dto.ClientAccountDto, clientID string) (*models.ClientAccount, error) {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.Create")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := dto.ToModel(util.ConvertToUint(clientID))
+	var err error
+
+	if account, err = account.Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create account of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return account, nil
+}
+
+// Delete deletes the portal account of the client with the given client ID.
+func (s *ClientAccountService) Delete(ctx context.Context, clientID string) (*models.ClientAccount, error) {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := &models.ClientAccount{}
+	var err error
+
+	if account, err = account.DeleteByClient(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete account of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return account, nil
+}
+
+// Login authenticates the client by using the phone or the e-mail and the password.
+func (s *ClientAccountService) Login(ctx context.Context, dto *dto.PortalLoginDto) (*models.ClientAccount, error) {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.Login")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := &models.ClientAccount{}
+	var err error
+
+	if account, err = account.Login(rep, dto.Login, dto.Password); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to login client: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) ||
+			errors.Is(err, bcrypt.ErrHashTooShort) {
+			return nil, apperror.NewInvalidCredentials(err)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// RequestCode generates a new one-time login code and sends it to the client.
+// It succeeds even if there is no account with the given login, so the accounts cannot be enumerated.
+func (s *ClientAccountService) RequestCode(ctx context.Context, dto *dto.PortalCodeRequestDto) error {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.RequestCode")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := &models.ClientAccount{}
+	var err error
+
+	if account, err = account.FindByLogin(rep, dto.Login); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to find client account: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	code, err := generateOneTimeCode()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.container.Config().Portal.OneTimeCodeTTL)
+	if err = account.SetOneTimeCode(rep, account.ID, code, expiresAt); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to save one-time code: %v", err)
+		return err
+	}
+	if err = s.sender.Send(ctx, account.Client, code); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to send one-time code: %v", err)
+		return err
+	}
+	return nil
+}
+
+// LoginWithCode authenticates the client by using the phone or the e-mail and the one-time code.
+func (s *ClientAccountService) LoginWithCode(ctx context.Context, dto *dto.PortalCodeLoginDto) (*models.ClientAccount, error) {
+	ctx, span := tracing.Start(ctx, "ClientAccountService.LoginWithCode")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	account := &models.ClientAccount{}
+	var err error
+
+	maxAttempts := s.container.Config().Portal.OneTimeCodeAttempts
+	if account, err = account.LoginWithCode(rep, dto.Login, dto.Code, time.Now(), maxAttempts); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to login client with one-time code: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, models.ErrInvalidOneTimeCode) {
+			return nil, apperror.NewInvalidCredentials(err)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func generateOneTimeCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < oneTimeCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", oneTimeCodeLength, n), nil
+}
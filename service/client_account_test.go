@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"vet-clinic/apperror"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+// fakeCodeSender keeps the last sent one-time code instead of sending it.
+type fakeCodeSender struct {
+	client *models.Client
+	code   string
+}
+
+func (s *fakeCodeSender) Send(_ context.Context, client *models.Client, code string) error {
+	s.client = client
+	s.code = code
+	return nil
+}
+
+func TestCreateClientAccount_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientAccountService(cont)
+	result, err := s.Create(context.Background(), &dto.ClientAccountDto{Password: "Password1!"}, "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), result.ClientID)
+	assert.True(t, result.Active)
+}
+
+func TestCreateClientAccount_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientAccountService(cont)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+	result, err := s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.Conflict, apperror.From(err, nil).Code)
+}
+
+func TestClientAccountLogin_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientAccountService(cont)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{Password: "Password1!"}, "1")
+	result, err := s.Login(context.Background(), &dto.PortalLoginDto{Login: "mail@mail.su", Password: "Password1!"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), result.ClientID)
+}
+
+func TestClientAccountLogin_WithoutPassword(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientAccountService(cont)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+	result, err := s.Login(context.Background(), &dto.PortalLoginDto{Login: "mail@mail.su", Password: "Password1!"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.InvalidCredentials, apperror.From(err, nil).Code)
+}
+
+func TestClientAccountLoginWithCode_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	sender := &fakeCodeSender{}
+	s := NewClientAccountServiceWithSender(cont, sender)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+
+	err := s.RequestCode(context.Background(), &dto.PortalCodeRequestDto{Login: "+78888888888"})
+	assert.Nil(t, err)
+	assert.Len(t, sender.code, 6)
+	assert.Equal(t, uint(1), sender.client.ID)
+
+	result, err := s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: sender.code})
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), result.ClientID)
+
+	// The code can be used only once.
+	result, err = s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: sender.code})
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+}
+
+func TestClientAccountLoginWithCode_TooManyAttempts(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	cont.Config().Portal.OneTimeCodeAttempts = 2
+
+	sender := &fakeCodeSender{}
+	s := NewClientAccountServiceWithSender(cont, sender)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+	_ = s.RequestCode(context.Background(), &dto.PortalCodeRequestDto{Login: "+78888888888"})
+
+	wrong := "000000"
+	if sender.code == wrong {
+		wrong = "111111"
+	}
+	for i := 0; i < 2; i++ {
+		_, err := s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: wrong})
+		assert.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+	}
+
+	result, err := s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: sender.code})
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+}
+
+func TestClientAccountLoginWithCode_ParallelAttempts(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	maxAttempts := cont.Config().Portal.OneTimeCodeAttempts
+
+	sender := &fakeCodeSender{}
+	s := NewClientAccountServiceWithSender(cont, sender)
+	_, _ = s.Create(context.Background(), &dto.ClientAccountDto{}, "1")
+	_ = s.RequestCode(context.Background(), &dto.PortalCodeRequestDto{Login: "+78888888888"})
+
+	wrong := "000000"
+	if sender.code == wrong {
+		wrong = "111111"
+	}
+	var rejected int32
+	var wg sync.WaitGroup
+	for i := 0; i < maxAttempts*4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: wrong})
+			if apperror.From(err, nil).Code == apperror.InvalidCredentials {
+				atomic.AddInt32(&rejected, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every guess is rejected and counted, so the code is discarded once the limit is reached.
+	assert.Equal(t, int32(maxAttempts*4), rejected)
+	result, err := s.LoginWithCode(context.Background(), &dto.PortalCodeLoginDto{Login: "+78888888888", Code: sender.code})
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, models.ErrInvalidOneTimeCode)
+}
+
+func TestClientAccountRequestCode_UnknownLogin(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	sender := &fakeCodeSender{}
+	s := NewClientAccountServiceWithSender(cont, sender)
+	err := s.RequestCode(context.Background(), &dto.PortalCodeRequestDto{Login: "+78888888888"})
+
+	assert.Nil(t, err)
+	assert.Empty(t, sender.code)
+}
@@ -1,19 +1,21 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
 	"vet-clinic/test"
+	"vet-clinic/util"
 )
 
 func TestFindClientByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -24,7 +26,7 @@ func TestFindClientByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -34,7 +36,7 @@ func TestFindClientByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -44,7 +46,7 @@ func TestFindAllClients_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background(), false)
 
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
@@ -54,12 +56,13 @@ func TestCreateClient_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	_, err := s.Create(createClientForCreate())
+	_, err := s.Create(context.Background(), createClientForCreate())
 	result := createResultClient()
 
-	data, _ := s.Get("2")
+	data, _ := s.Get(context.Background(), "2")
 	data.BaseModel = nil
 	data.BirthDate = data.BirthDate.Local()
+	data.Contacts = nil
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -70,12 +73,13 @@ func TestUpdateClient_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	_, err := s.Update(createClientForCreate(), "1")
+	_, err := s.Update(context.Background(), createClientForCreate(), "1")
 	result := createResultClient()
 
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 	data.BaseModel = nil
 	data.BirthDate = data.BirthDate.Local()
+	data.Contacts = nil
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -86,19 +90,43 @@ func TestUpdateClient_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.Update(createClientForCreate(), "99")
+	result, err := s.Update(context.Background(), createClientForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
 }
 
+func TestPatchClient_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientService(cont)
+	before, _ := s.Get(context.Background(), "1")
+	result, err := s.Patch(context.Background(), []byte(`{"phone":"+71234560000","info":null}`), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "+71234560000", result.Phone)
+	assert.Equal(t, "", result.Info)
+	assert.Equal(t, before.Name, result.Name)
+	assert.Equal(t, before.Email, result.Email)
+}
+
+func TestPatchClient_NotObject(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientService(cont)
+	result, err := s.Patch(context.Background(), []byte(`["phone"]`), "1")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, util.ErrInvalidMergePatch)
+}
+
 func TestDeleteClient_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -108,12 +136,41 @@ func TestDeleteClient_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewClientService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
 }
 
+func TestUpdateClient_Contacts(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientService(cont)
+	clientDto := createClientForCreate()
+	clientDto.Contacts = []*dto.ClientContactDto{
+		{Type: models.ContactPhone, Value: "+79876543210", Label: "Рабочий"},
+		{Type: models.ContactMessenger, Value: "@client"},
+	}
+	_, _ = s.Update(context.Background(), clientDto, "1")
+
+	clientDto.Contacts = nil
+	_, _ = s.Update(context.Background(), clientDto, "1")
+	kept, _ := s.Get(context.Background(), "1")
+
+	clientDto.Contacts = []*dto.ClientContactDto{}
+	_, _ = s.Update(context.Background(), clientDto, "1")
+	cleared, err := s.Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	if assert.Len(t, kept.Contacts, 2) {
+		assert.Equal(t, models.ContactPhone, kept.Contacts[0].Type)
+		assert.Equal(t, "+79876543210", kept.Contacts[0].Value)
+		assert.Equal(t, "Рабочий", kept.Contacts[0].Label)
+		assert.Equal(t, "@client", kept.Contacts[1].Value)
+	}
+	assert.Empty(t, cleared.Contacts)
+}
+
 func createClientForCreate() *dto.ClientDto {
 	return &dto.ClientDto{
 		Surname:    "Фамилия",
@@ -139,3 +196,114 @@ func createResultClient() *models.Client {
 		Info:       "Информация",
 	}
 }
+
+func TestFindClientDuplicates_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	same := &models.Client{Surname: "фамилия", Name: "Имя", Phone: "8 (888) 888-88-88", Email: "MAIL@mail.su"}
+	_, _ = same.Create(rep)
+	typo := &models.Client{Surname: "Фамилиа", Name: "Имя", Patronymic: "Отчество", Phone: "+71234567890"}
+	_, _ = typo.Create(rep)
+	other := &models.Client{Surname: "Петров", Name: "Пётр", Phone: "+70000000000"}
+	_, _ = other.Create(rep)
+
+	s := NewClientService(cont)
+	result, err := s.FindDuplicates(context.Background(), "1")
+
+	assert.Empty(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, same.ID, result[0].Client.ID)
+	assert.Equal(t, []string{models.DuplicatePhone, models.DuplicateEmail, models.DuplicateName}, result[0].Reasons)
+	assert.Equal(t, typo.ID, result[1].Client.ID)
+	assert.Equal(t, []string{models.DuplicateName}, result[1].Reasons)
+}
+
+func TestFindClientDuplicates_EntityNotFound(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientService(cont)
+	result, err := s.FindDuplicates(context.Background(), "99")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record not found", err.Error())
+}
+
+func TestMergeClient_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	target := &models.Client{Surname: "Фамилия", Name: "Имя", Phone: "+78888888888"}
+	_, _ = target.Create(rep)
+	_, _ = models.NewClientAccount(1, "password").Create(rep)
+
+	s := NewClientService(cont)
+	merge, err := s.Merge(context.Background(), &dto.ClientMergeDto{SourceID: 1}, "2", 1)
+
+	assert.Empty(t, err)
+	assert.Equal(t, uint(2), merge.TargetID)
+	assert.Equal(t, uint(1), merge.SourceID)
+	assert.Equal(t, uint(1), merge.MergedByID)
+	assert.Equal(t, "1", merge.PetIDs)
+	assert.Equal(t, "1", merge.VisitIDs)
+	assert.True(t, merge.AccountMoved)
+	assert.Contains(t, merge.Source, `"email":"mail@mail.su"`)
+
+	survivor, _ := s.Get(context.Background(), "2")
+	assert.Equal(t, "Отчество", survivor.Patronymic)
+	assert.Equal(t, "mail@mail.su", survivor.Email)
+	assert.Equal(t, "+78888888888", survivor.Phone)
+
+	pet, _ := (&models.Pet{}).Get(rep, 1)
+	assert.Equal(t, uint(2), pet.ClientID)
+	lead, _ := (&models.Lead{}).Get(rep, 1)
+	assert.Equal(t, uint(2), lead.ClientID)
+	if assert.Len(t, pet.Owners, 1) {
+		assert.Equal(t, uint(2), pet.Owners[0].ClientID)
+		assert.Equal(t, models.PetOwnerPrimary, pet.Owners[0].Role)
+	}
+	visit, _ := (&models.Visit{}).Get(rep, 1)
+	assert.Equal(t, uint(2), visit.ClientID)
+	account, _ := (&models.ClientAccount{}).GetByClient(rep, 2)
+	assert.NotNil(t, account)
+
+	_, err = s.Get(context.Background(), "1")
+	assert.Equal(t, "record not found", err.Error())
+
+	merges, _ := s.GetMerges(context.Background(), "1")
+	assert.Len(t, merges, 1)
+}
+
+func TestMergeClient_KeepsTargetAccount(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	target := &models.Client{Surname: "Фамилия", Name: "Имя"}
+	_, _ = target.Create(rep)
+	_, _ = models.NewClientAccount(1, "password").Create(rep)
+	_, _ = models.NewClientAccount(2, "password").Create(rep)
+
+	s := NewClientService(cont)
+	merge, err := s.Merge(context.Background(), &dto.ClientMergeDto{SourceID: 1}, "2", 1)
+
+	var count int64
+	rep.Unscoped().Model(&models.ClientAccount{}).Count(&count)
+
+	assert.Empty(t, err)
+	assert.False(t, merge.AccountMoved)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMergeClient_Failure(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewClientService(cont)
+	_, err := s.Merge(context.Background(), &dto.ClientMergeDto{SourceID: 1}, "1", 1)
+	assert.Equal(t, "a client cannot be merged into itself", err.Error())
+
+	_, err = s.Merge(context.Background(), &dto.ClientMergeDto{SourceID: 99}, "1", 1)
+	assert.Contains(t, err.Error(), "sourceId refers to a record which does not exist")
+
+	_, err = s.Merge(context.Background(), &dto.ClientMergeDto{SourceID: 1}, "99", 1)
+	assert.Equal(t, "record not found", err.Error())
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
-	"errors"
+	"context"
 	"github.com/gosimple/slug"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -19,51 +21,60 @@ func NewDepartmentService(container container.Container) *DepartmentService {
 }
 
 // Get returns department full matched given department ID or department slug.
-func (s *DepartmentService) Get(param string) (*models.Department, error) {
-	rep := s.container.Repository()
+func (s *DepartmentService) Get(ctx context.Context, param string) (*models.Department, error) {
+	ctx, span := tracing.Start(ctx, "DepartmentService.Get")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	department := &models.Department{}
 	var err error
 
 	if util.IsNumeric(param) {
 		if department, err = department.Get(rep, util.ConvertToUint(param)); err != nil {
-			s.container.Logger().Errorf("Failed to fetch department with ID %s: %v", param, err)
+			s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department with ID %s: %v", param, err)
 			return nil, err
 		}
 		return department, nil
 	}
 	if slug.IsSlug(param) {
 		if department, err = department.GetBySlug(rep, param); err != nil {
-			s.container.Logger().Errorf("Failed to fetch department with slug %s: %v", param, err)
+			s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department with slug %s: %v", param, err)
 			return nil, err
 		}
 		return department, nil
 	}
-	s.container.Logger().Errorf("Failed to fetch department with: %s", param)
-	return nil, errors.New("failed to fetch data")
+	s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department with: %s", param)
+	return nil, apperror.NewInvalidID(param)
 }
 
 // GetAll returns a slice of all departments.
-func (s *DepartmentService) GetAll() ([]*models.Department, error) {
-	rep := s.container.Repository()
+func (s *DepartmentService) GetAll(ctx context.Context) ([]*models.Department, error) {
+	ctx, span := tracing.Start(ctx, "DepartmentService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Department{}
 	var departments []*models.Department
 	var err error
 
 	if departments, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch departments: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch departments: %v", err)
 		return nil, err
 	}
 	return departments, nil
 }
 
 // Create persists this department data.
-func (s *DepartmentService) Create(dto *dto.DepartmentDto) (*models.Department, error) {
-	rep := s.container.Repository()
+func (s *DepartmentService) Create(ctx context.Context, dto *dto.DepartmentDto) (*models.Department, error) {
+	ctx, span := tracing.Start(ctx, "DepartmentService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	department := dto.ToModel()
 	var err error
 
 	if department, err = department.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create department: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create department: %v", err)
 		return nil, err
 	}
 
@@ -71,36 +82,42 @@ func (s *DepartmentService) Create(dto *dto.DepartmentDto) (*models.Department,
 }
 
 // Update updates this department data.
-func (s *DepartmentService) Update(dto *dto.DepartmentDto, id string) (*models.Department, error) {
+func (s *DepartmentService) Update(ctx context.Context, dto *dto.DepartmentDto, id string) (*models.Department, error) {
+	ctx, span := tracing.Start(ctx, "DepartmentService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch department ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	department := dto.ToModel()
 	var err error
 
 	if department, err = department.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update department with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update department with ID %s: %v", id, err)
 		return nil, err
 	}
 	return department, nil
 }
 
 // Delete deletes this department data.
-func (s *DepartmentService) Delete(id string) (*models.Department, error) {
+func (s *DepartmentService) Delete(ctx context.Context, id string) (*models.Department, error) {
+	ctx, span := tracing.Start(ctx, "DepartmentService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch department ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	department := &models.Department{}
 	var err error
 
 	if department, err = department.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete department: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete department: %v", err)
 		return nil, err
 	}
 	return department, nil
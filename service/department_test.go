@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/gosimple/slug"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -13,7 +14,7 @@ func TestFindDepartmentByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -24,8 +25,8 @@ func TestFindDepartmentBySlug_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	resultByID, _ := s.Get("2")
-	resultBySlug, err := s.Get(resultByID.Slug)
+	resultByID, _ := s.Get(context.Background(), "2")
+	resultBySlug, err := s.Get(context.Background(), resultByID.Slug)
 
 	assert.Equal(t, uint(2), resultBySlug.ID)
 	assert.NoError(t, err)
@@ -36,7 +37,7 @@ func TestFindDepartmentByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -46,7 +47,7 @@ func TestFindDepartmentByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -56,7 +57,7 @@ func TestFindAllDepartments_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background())
 
 	assert.Len(t, result, 2)
 	assert.NoError(t, err)
@@ -67,9 +68,9 @@ func TestCreateDepartment_Success(t *testing.T) {
 
 	s := NewDepartmentService(cont)
 	departmentDto := createDepartmentForCreate()
-	_, err := s.Create(departmentDto)
+	_, err := s.Create(context.Background(), departmentDto)
 
-	result, _ := s.Get("3")
+	result, _ := s.Get(context.Background(), "3")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -83,9 +84,9 @@ func TestUpdateDepartment_Success(t *testing.T) {
 
 	s := NewDepartmentService(cont)
 	departmentDto := createDepartmentForCreate()
-	_, err := s.Update(departmentDto, "1")
+	_, err := s.Update(context.Background(), departmentDto, "1")
 
-	result, _ := s.Get("1")
+	result, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -98,7 +99,7 @@ func TestUpdateDepartment_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.Update(createDepartmentForCreate(), "99")
+	result, err := s.Update(context.Background(), createDepartmentForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -108,9 +109,9 @@ func TestDeleteDepartment_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -120,7 +121,7 @@ func TestDeleteDepartment_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewDepartmentService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type DiscountTierService struct {
+	container container.Container
+}
+
+// NewDiscountTierService is constructor.
+func NewDiscountTierService(container container.Container) *DiscountTierService {
+	return &DiscountTierService{container: container}
+}
+
+// Get returns discount tier full matched given discount tier ID.
+func (s *DiscountTierService) Get(ctx context.Context, id string) (*models.DiscountTier, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount tier ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	tier := &models.DiscountTier{}
+	var err error
+
+	if tier, err = tier.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount tier with ID %s: %v", id, err)
+		return nil, err
+	}
+	return tier, nil
+}
+
+// GetAll returns a slice of all discount tiers.
+func (s *DiscountTierService) GetAll(ctx context.Context) ([]*models.DiscountTier, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.DiscountTier{}
+	var tiers []*models.DiscountTier
+	var err error
+
+	if tiers, err = model.GetAll(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount tiers: %v", err)
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// Create persists this discount tier data.
+func (s *DiscountTierService) Create(ctx context.Context, dto *dto.DiscountTierDto) (*models.DiscountTier, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	tier := &models.DiscountTier{}
+	var err error
+
+	if tier, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create discount tier: %v", err)
+		return nil, err
+	}
+	return tier, nil
+}
+
+// Update updates this discount tier data.
+func (s *DiscountTierService) Update(ctx context.Context, dto *dto.DiscountTierDto, id string) (*models.DiscountTier, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount tier ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	tier := &models.DiscountTier{}
+	var err error
+
+	if tier, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update discount tier with ID %s: %v", id, err)
+		return nil, err
+	}
+	return tier, nil
+}
+
+// Delete deletes this discount tier data.
+func (s *DiscountTierService) Delete(ctx context.Context, id string) (*models.DiscountTier, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount tier ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	tier := &models.DiscountTier{}
+	var err error
+
+	if tier, err = tier.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete discount tier: %v", err)
+		return nil, err
+	}
+	return tier, nil
+}
+
+// GetForClient returns the loyalty programme status of the client.
+func (s *DiscountTierService) GetForClient(ctx context.Context, clientID string) (*models.ClientDiscount, error) {
+	ctx, span := tracing.Start(ctx, "DiscountTierService.GetForClient")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.DiscountTier{}
+	var discount *models.ClientDiscount
+	var err error
+
+	if discount, err = model.GetForClient(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch discount of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return discount, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// InvoiceService issues the invoices of the visits.
+type InvoiceService struct {
+	container container.Container
+}
+
+// NewInvoiceService is constructor.
+func NewInvoiceService(container container.Container) *InvoiceService {
+	return &InvoiceService{container: container}
+}
+
+// Get returns invoice full matched given invoice ID.
+func (s *InvoiceService) Get(ctx context.Context, id string) (*models.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch invoice ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	invoice := &models.Invoice{}
+	var err error
+
+	if invoice, err = invoice.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch invoice with ID %s: %v", id, err)
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// GetAllByClient returns the invoices of the client.
+func (s *InvoiceService) GetAllByClient(ctx context.Context, clientID string) ([]*models.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetAllByClient")
+	defer span.End()
+
+	if !util.IsNumeric(clientID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", clientID)
+		return nil, apperror.NewInvalidID(clientID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Invoice{}
+	var invoices []*models.Invoice
+	var err error
+
+	if invoices, err = model.GetAllByClient(rep, util.ConvertToUint(clientID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch invoices of client with ID %s: %v", clientID, err)
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// Issue issues the invoice of the completed visit applying the discounts and the balance of the client.
+func (s *InvoiceService) Issue(ctx context.Context, dto *dto.InvoiceDto, visitID string) (*models.Invoice, error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.Issue")
+	defer span.End()
+
+	if !util.IsNumeric(visitID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", visitID)
+		return nil, apperror.NewInvalidID(visitID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	invoice := &models.Invoice{}
+	var err error
+
+	if invoice, err = invoice.Issue(rep, util.ConvertToUint(visitID), dto.PromoCode, dto.UseBalance, time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to invoice visit with ID %s: %v", visitID, err)
+		return nil, err
+	}
+	return invoice, nil
+}
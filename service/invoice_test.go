@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestIssueInvoice_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{}, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ClientID)
+	assert.Equal(t, float64(2500), result.Subtotal)
+	assert.Equal(t, float64(0), result.Discount)
+	assert.Equal(t, float64(2500), result.Total)
+}
+
+func TestIssueInvoice_NotCompleted(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "only completed visits can be invoiced", err.Error())
+}
+
+func TestIssueInvoice_AlreadyInvoiced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	_, _ = s.Issue(context.Background(), &dto.InvoiceDto{}, "1")
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the visit has already been invoiced", err.Error())
+}
+
+func TestIssueInvoice_PromoCode(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "welcome10"}, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), result.DiscountPercent)
+	assert.Equal(t, float64(250), result.Discount)
+	assert.Equal(t, float64(2250), result.Total)
+	if assert.NotNil(t, result.PromoCode) {
+		assert.Equal(t, 1, result.PromoCode.UsedCount)
+	}
+}
+
+func TestIssueInvoice_LoyaltyBetterThanPromoCode(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewDiscountTierService(cont).Create(context.Background(),
+		&dto.DiscountTierDto{Name: "Сотрудник", Percent: 15, MinSpent: 0})
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "WELCOME10"}, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(15), result.DiscountPercent)
+	assert.Equal(t, float64(375), result.Discount)
+	assert.Equal(t, uint(0), result.PromoCodeID)
+
+	code, _ := NewPromoCodeService(cont).Get(context.Background(), "1")
+	assert.Equal(t, 0, code.UsedCount)
+}
+
+func TestIssueInvoice_PromoCodeExpired(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewPromoCodeService(cont).Create(context.Background(), &dto.PromoCodeDto{Code: "NY2020", Percent: 20,
+		ValidFrom: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local),
+		ValidTo:   time.Date(2020, time.January, 10, 0, 0, 0, 0, time.Local)})
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "NY2020"}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the promo code is not valid on this date", err.Error())
+}
+
+func TestIssueInvoice_PromoCodeUsageLimit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewPromoCodeService(cont).Create(context.Background(), &dto.PromoCodeDto{Code: "ONCE", Percent: 20,
+		ValidFrom: time.Now().AddDate(0, 0, -1), ValidTo: time.Now(), UsageLimit: 1})
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 1
+	_, _ = visits.Create(context.Background(), visitDto)
+	_, _ = visits.Complete(context.Background(), "1", 1)
+	_, _ = visits.Complete(context.Background(), "2", 1)
+
+	s := NewInvoiceService(cont)
+	_, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "ONCE"}, "1")
+	assert.NoError(t, err)
+
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "ONCE"}, "2")
+	assert.Nil(t, result)
+	assert.Equal(t, "the promo code has reached its usage limit", err.Error())
+}
+
+func TestIssueInvoice_UseBalance(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	balances := NewBalanceService(cont)
+	_, _ = balances.TopUp(context.Background(), &dto.BalanceEntryDto{Amount: 1000}, "1", 1)
+	_, _ = NewVisitService(cont).Complete(context.Background(), "1", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{UseBalance: true}, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1000), result.PaidFromBalance)
+	assert.Equal(t, float64(1500), result.Total)
+
+	balance, _ := balances.Get(context.Background(), "1")
+	assert.Equal(t, float64(0), balance.Balance)
+	if assert.Len(t, balance.Entries, 2) {
+		assert.Equal(t, models.BalancePayment, balance.Entries[0].Reason)
+		assert.Equal(t, result.ID, balance.Entries[0].InvoiceID)
+	}
+}
+
+func TestIssueInvoice_Deposit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 6
+	_, _ = visits.Create(context.Background(), visitDto)
+	_, _ = visits.Complete(context.Background(), "2", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{}, "2")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Deposit)
+	assert.Equal(t, float64(30000), result.Total)
+
+	balances := NewBalanceService(cont)
+	balance, _ := balances.Get(context.Background(), "1")
+	assert.Equal(t, float64(30000), balance.Balance)
+
+	discount, _ := NewDiscountTierService(cont).GetForClient(context.Background(), "1")
+	assert.Equal(t, float64(0), discount.Spent)
+
+	balance, err = balances.Refund(context.Background(), &dto.BalanceEntryDto{Amount: 30000, Note: "Прибор возвращён"}, "1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), balance.Balance)
+}
+
+func TestIssueInvoice_DepositPromoCode(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 6
+	_, _ = visits.Create(context.Background(), visitDto)
+	_, _ = visits.Complete(context.Background(), "2", 1)
+
+	s := NewInvoiceService(cont)
+	result, err := s.Issue(context.Background(), &dto.InvoiceDto{PromoCode: "WELCOME10"}, "2")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the discounts do not apply to deposits", err.Error())
+}
+
+func TestRefundBalance_ExceedsBalance(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewBalanceService(cont)
+	_, _ = s.TopUp(context.Background(), &dto.BalanceEntryDto{Amount: 500}, "1", 1)
+	result, err := s.Refund(context.Background(), &dto.BalanceEntryDto{Amount: 501}, "1", 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the amount exceeds the balance of the client", err.Error())
+}
+
+func TestGetClientDiscount_Tier(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 8
+	_, _ = visits.Create(context.Background(), visitDto)
+	_, _ = visits.Complete(context.Background(), "2", 1)
+	_, _ = visits.Create(context.Background(), visitDto)
+	_, _ = visits.Complete(context.Background(), "3", 1)
+
+	invoices := NewInvoiceService(cont)
+	_, _ = invoices.Issue(context.Background(), &dto.InvoiceDto{}, "2")
+	_, _ = invoices.Issue(context.Background(), &dto.InvoiceDto{}, "3")
+
+	s := NewDiscountTierService(cont)
+	result, err := s.GetForClient(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(19000), result.Spent)
+	assert.Nil(t, result.Tier)
+
+	_, _ = visits.Complete(context.Background(), "1", 1)
+	invoice, _ := invoices.Issue(context.Background(), &dto.InvoiceDto{}, "1")
+	assert.Equal(t, float64(0), invoice.Discount)
+
+	result, _ = s.GetForClient(context.Background(), "1")
+	if assert.NotNil(t, result.Tier) {
+		assert.Equal(t, "Серебряный", result.Tier.Name)
+	}
+}
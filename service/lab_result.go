@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type LabResultService struct {
+	container container.Container
+}
+
+// NewLabResultService is constructor.
+func NewLabResultService(container container.Container) *LabResultService {
+	return &LabResultService{container: container}
+}
+
+// Get returns lab result full matched given lab result ID.
+func (s *LabResultService) Get(ctx context.Context, id string) (*models.LabResult, error) {
+	ctx, span := tracing.Start(ctx, "LabResultService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lab result ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	result := &models.LabResult{}
+	var err error
+
+	if result, err = result.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lab result with ID %s: %v", id, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAllByVisit returns the lab results of the visit.
+func (s *LabResultService) GetAllByVisit(ctx context.Context, visitID string) ([]*models.LabResult, error) {
+	ctx, span := tracing.Start(ctx, "LabResultService.GetAllByVisit")
+	defer span.End()
+
+	if !util.IsNumeric(visitID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", visitID)
+		return nil, apperror.NewInvalidID(visitID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.LabResult{}
+	var results []*models.LabResult
+	var err error
+
+	if results, err = model.GetAllByVisit(rep, util.ConvertToUint(visitID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lab results of visit with ID %s: %v", visitID, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetAllByPet returns the lab results of the pet. If test is not empty, only the results of the test are returned.
+func (s *LabResultService) GetAllByPet(ctx context.Context, petID string, test string) ([]*models.LabResult, error) {
+	ctx, span := tracing.Start(ctx, "LabResultService.GetAllByPet")
+	defer span.End()
+
+	if !util.IsNumeric(petID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", petID)
+		return nil, apperror.NewInvalidID(petID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.LabResult{}
+	var results []*models.LabResult
+	var err error
+
+	if results, err = model.GetAllByPet(rep, util.ConvertToUint(petID), test); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lab results of pet with ID %s: %v", petID, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// Create persists this lab result data. The value is flagged according to the reference range.
+func (s *LabResultService) Create(ctx context.Context, dto *dto.LabResultDto) (*models.LabResult, error) {
+	ctx, span := tracing.Start(ctx, "LabResultService.Create")
+	defer span.End()
+
+	if dto.ReferenceMin != nil && dto.ReferenceMax != nil && *dto.ReferenceMin > *dto.ReferenceMax {
+		e := apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed,
+			"referenceMax must be greater than or equal to referenceMin")
+		e.Details = []*apperror.FieldError{{Field: "referenceMax", Tag: "gtefield", Param: "referenceMin", Message: e.Message}}
+		return nil, e
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	result := dto.ToModel()
+	var err error
+
+	if result, err = result.Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create lab result: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete deletes the lab result.
+func (s *LabResultService) Delete(ctx context.Context, id string) (*models.LabResult, error) {
+	ctx, span := tracing.Start(ctx, "LabResultService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lab result ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	result := &models.LabResult{}
+	var err error
+
+	if result, err = result.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete lab result with ID %s: %v", id, err)
+		return nil, err
+	}
+	return result, nil
+}
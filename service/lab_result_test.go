@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestCreateLabResult_Flag(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLabResultService(cont)
+	cases := []struct {
+		value    float64
+		min, max *float64
+		flag     string
+	}{
+		{132, floatPtr(80), floatPtr(150), models.LabResultNormal},
+		{70, floatPtr(80), floatPtr(150), models.LabResultLow},
+		{160, floatPtr(80), floatPtr(150), models.LabResultHigh},
+		{160, floatPtr(80), nil, models.LabResultNormal},
+		{160, nil, nil, ""},
+	}
+	for _, c := range cases {
+		param := createLabResultForCreate()
+		param.Value, param.ReferenceMin, param.ReferenceMax = c.value, c.min, c.max
+		result, err := s.Create(context.Background(), param)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.flag, result.Flag, c.value)
+			assert.Equal(t, uint(1), result.PetID)
+		}
+	}
+}
+
+func TestCreateLabResult_InvalidRange(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLabResultService(cont)
+	param := createLabResultForCreate()
+	param.ReferenceMin, param.ReferenceMax = floatPtr(150), floatPtr(80)
+	result, err := s.Create(context.Background(), param)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "referenceMax must be greater than or equal to referenceMin", err.Error())
+}
+
+func TestCreateLabResult_NotVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLabResultService(cont)
+	param := createLabResultForCreate()
+	param.VisitID = 99
+	result, err := s.Create(context.Background(), param)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "visitId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestFindLabResultsByPet_Test(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLabResultService(cont)
+	_, _ = s.Create(context.Background(), createLabResultForCreate())
+	other := createLabResultForCreate()
+	other.Test, other.Value, other.Unit = "Глюкоза", 5.2, "ммоль/л"
+	_, _ = s.Create(context.Background(), other)
+
+	all, err1 := s.GetAllByPet(context.Background(), "1", "")
+	result, err2 := s.GetAllByPet(context.Background(), "1", "гемоглобин")
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Len(t, all, 2)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Гемоглобин", result[0].Test)
+	}
+}
+
+func TestPurgeVisit_LabResults(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewLabResultService(cont).Create(context.Background(), createLabResultForCreate())
+
+	visits := NewVisitService(cont)
+	_, _ = visits.Delete(context.Background(), "1")
+	result, err := visits.Purge(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
+
+func createLabResultForCreate() *dto.LabResultDto {
+	return &dto.LabResultDto{
+		VisitID:      1,
+		Test:         "Гемоглобин",
+		Value:        132,
+		Unit:         "г/л",
+		ReferenceMin: floatPtr(80),
+		ReferenceMax: floatPtr(150),
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
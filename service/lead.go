@@ -1,99 +1,245 @@
 package service
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
 type LeadService struct {
 	container container.Container
+	captcha   CaptchaVerifier
 }
 
+var (
+	// generatedFormSecret is the key signing the form tokens if none is configured, it is generated once per process.
+	generatedFormSecret     []byte
+	generatedFormSecretOnce sync.Once
+)
+
 // NewLeadService is constructor.
 func NewLeadService(container container.Container) *LeadService {
-	return &LeadService{container: container}
+	return NewLeadServiceWithCaptcha(container, NewCaptchaVerifier(container))
+}
+
+// NewLeadServiceWithCaptcha is constructor which uses the given captcha verifier, nil disables the captcha.
+func NewLeadServiceWithCaptcha(container container.Container, captcha CaptchaVerifier) *LeadService {
+	return &LeadService{container: container, captcha: captcha}
 }
 
 // Get returns lead full matched given lead ID or lead slug.
-func (s *LeadService) Get(id string) (*models.Lead, error) {
+func (s *LeadService) Get(ctx context.Context, id string) (*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	lead := &models.Lead{}
 	var err error
 
 	if lead, err = lead.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch lead with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lead with ID %s: %v", id, err)
 		return nil, err
 	}
 	return lead, nil
 }
 
 // GetAll returns a slice of all leads.
-func (s *LeadService) GetAll() ([]*models.Lead, error) {
-	rep := s.container.Repository()
+func (s *LeadService) GetAll(ctx context.Context) ([]*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Lead{}
 	var leads []*models.Lead
 	var err error
 
 	if leads, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch leads: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch leads: %v", err)
 		return nil, err
 	}
 	return leads, nil
 }
 
-// Create persists this lead data.
-func (s *LeadService) Create(dto *dto.LeadDto) (*models.Lead, error) {
-	rep := s.container.Repository()
+// GetForm returns the data for showing the public lead form, i.e. a new form token.
+func (s *LeadService) GetForm(ctx context.Context) *models.LeadForm {
+	_, span := tracing.Start(ctx, "LeadService.GetForm")
+	defer span.End()
+
+	return &models.LeadForm{FormToken: s.IssueFormToken(time.Now())}
+}
+
+// IssueFormToken returns the form token of the form shown at the given time.
+// The token is the Unix time in milliseconds followed by its HMAC-SHA256 signature.
+func (s *LeadService) IssueFormToken(shownAt time.Time) string {
+	payload := strconv.FormatInt(shownAt.UnixMilli(), 10)
+	return payload + "." + s.formTokenSignature(payload)
+}
+
+// Create persists the lead submitted by the public form from the given IP address.
+// The submissions which fill in the honeypot field, have no valid form token, come too fast after the form
+// was shown or fail the captcha are rejected as spam. The lead with the same phone or e-mail as a recent one
+// is rejected the same way, so the response does not reveal the submissions of others.
+// The lead is assigned to the default assignee regardless of the submitted data.
+func (s *LeadService) Create(ctx context.Context, dto *dto.LeadRequestDto, remoteIP string) (*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.Create")
+	defer span.End()
+
+	conf := s.container.Config().Lead
+	now := time.Now()
+	shownAt, valid := s.verifyFormToken(dto.FormToken)
+	if dto.Website != "" || !valid || now.Sub(shownAt) < conf.MinFillTime || now.Sub(shownAt) > conf.FormTokenTTL {
+		s.container.Logger().WithContext(ctx).Warnf("Rejected lead as spam from %s", remoteIP)
+		return nil, newLeadRejected()
+	}
+	if s.captcha != nil {
+		ok, err := s.captcha.Verify(ctx, dto.CaptchaToken, remoteIP)
+		if err != nil {
+			s.container.Logger().WithContext(ctx).Errorf("Failed to verify captcha: %v", err)
+			return nil, apperror.Wrap(err, http.StatusServiceUnavailable, apperror.Unavailable, "failed to verify the captcha")
+		}
+		if !ok {
+			e := apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed, "captchaToken is not valid")
+			e.Details = []*apperror.FieldError{{Field: "captchaToken", Tag: "captcha", Message: e.Message}}
+			return nil, e
+		}
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
 	lead := dto.ToModel()
 	var err error
 
-	if lead, err = lead.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create lead: %v", err)
+	if lead, err = lead.Submit(rep, conf.DefaultAssigneeID, conf.DuplicateWindow, now); err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code == apperror.Conflict {
+			s.container.Logger().WithContext(ctx).Warnf("Rejected lead as duplicate from %s", remoteIP)
+			return nil, newLeadRejected()
+		}
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create lead: %v", err)
 		return nil, err
 	}
-
 	return lead, nil
 }
 
+// newLeadRejected returns the error of the lead rejected as spam or as a duplicate, which does not tell the reason.
+func newLeadRejected() *apperror.Error {
+	return apperror.New(http.StatusUnprocessableEntity, apperror.ValidationFailed, "the lead has been rejected")
+}
+
+// verifyFormToken returns the time when the form was shown if the token has been issued by IssueFormToken.
+func (s *LeadService) verifyFormToken(token string) (time.Time, bool) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(s.formTokenSignature(payload))) {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+// formTokenSignature returns the signature of the payload of the form token by the configured or generated key.
+func (s *LeadService) formTokenSignature(payload string) string {
+	secret := []byte(s.container.Config().Lead.FormSecret)
+	if len(secret) == 0 {
+		generatedFormSecretOnce.Do(func() {
+			generatedFormSecret = make([]byte, 32)
+			if _, err := rand.Read(generatedFormSecret); err != nil {
+				panic(err)
+			}
+		})
+		secret = generatedFormSecret
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
 // Update updates this lead data.
-func (s *LeadService) Update(dto *dto.LeadDto, id string) (*models.Lead, error) {
+func (s *LeadService) Update(ctx context.Context, dto *dto.LeadDto, id string) (*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch lead ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lead ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	lead := dto.ToModel()
 	var err error
 
 	if lead, err = lead.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update lead with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update lead with ID %s: %v", id, err)
 		return nil, err
 	}
 	return lead, nil
 }
 
+// Patch applies the JSON Merge Patch to this lead data.
+// The fields which are absent in the patch keep their current values.
+func (s *LeadService) Patch(ctx context.Context, patch []byte, id string, updatedByID uint) (*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.Patch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lead ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	lead := &models.Lead{}
+	var err error
+
+	if lead, err = lead.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lead with ID %s: %v", id, err)
+		return nil, err
+	}
+
+	data := dto.NewLeadDto(lead)
+	if _, err = util.ApplyMergePatch(data, patch); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to patch lead with ID %s: %v", id, err)
+		return nil, err
+	}
+	data.LastUpdatedByID = updatedByID
+	return s.Update(ctx, data, id)
+}
+
 // Delete deletes this lead data.
-func (s *LeadService) Delete(id string) (*models.Lead, error) {
+func (s *LeadService) Delete(ctx context.Context, id string) (*models.Lead, error) {
+	ctx, span := tracing.Start(ctx, "LeadService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch lead ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch lead ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	lead := &models.Lead{}
 	var err error
 
 	if lead, err = lead.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete lead: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete lead: %v", err)
 		return nil, err
 	}
 	return lead, nil
@@ -1,8 +1,14 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/models/dto"
 	"vet-clinic/test"
 )
@@ -11,7 +17,7 @@ func TestFindLeadByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.NoError(t, err)
@@ -22,7 +28,7 @@ func TestFindLeadByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -32,7 +38,7 @@ func TestFindLeadByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -42,7 +48,7 @@ func TestFindAllLeads_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background())
 
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
@@ -52,10 +58,10 @@ func TestCreateLead_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	leadDto := createLeadForCreate()
-	_, err := s.Create(leadDto)
+	leadDto := createLeadRequestForCreate(s)
+	_, err := s.Create(context.Background(), leadDto, "127.0.0.1")
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -65,18 +71,100 @@ func TestCreateLead_Success(t *testing.T) {
 	assert.Equal(t, leadDto.Comment, result.Comment)
 	assert.Equal(t, leadDto.Type, result.Type)
 	assert.Equal(t, "open", result.Status)
-	assert.Equal(t, leadDto.DoctorID, result.DoctorID)
+	assert.Equal(t, cont.Config().Lead.DefaultAssigneeID, result.DoctorID)
 	assert.Equal(t, uint(0), result.LastUpdatedByID)
 }
 
+func TestCreateLead_Unassigned(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	cont.Config().Lead.DefaultAssigneeID = 99
+
+	s := NewLeadService(cont)
+	result, err := s.Create(context.Background(), createLeadRequestForCreate(s), "127.0.0.1")
+
+	assert.Empty(t, err)
+	assert.Equal(t, uint(0), result.DoctorID)
+	assert.Nil(t, result.Doctor)
+}
+
+func TestCreateLead_Spam(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLeadService(cont)
+	honeypot := createLeadRequestForCreate(s)
+	honeypot.Website = "http://spam.example.com"
+	tooFast := createLeadRequestForCreate(s)
+	tooFast.FormToken = s.IssueFormToken(time.Now())
+	expired := createLeadRequestForCreate(s)
+	expired.FormToken = s.IssueFormToken(time.Now().Add(-3 * time.Hour))
+	noToken := createLeadRequestForCreate(s)
+	noToken.FormToken = ""
+	forged := createLeadRequestForCreate(s)
+	forged.FormToken = strconv.FormatInt(time.Now().Add(-time.Minute).UnixMilli(), 10) + ".forged"
+
+	for _, leadDto := range []*dto.LeadRequestDto{honeypot, tooFast, expired, noToken, forged} {
+		result, err := s.Create(context.Background(), leadDto, "127.0.0.1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, "the lead has been rejected", err.Error())
+	}
+}
+
+func TestCreateLead_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLeadService(cont)
+	_, _ = s.Create(context.Background(), createLeadRequestForCreate(s), "127.0.0.1")
+
+	leadDto := createLeadRequestForCreate(s)
+	leadDto.Email = ""
+	result, err := s.Create(context.Background(), leadDto, "127.0.0.1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the lead has been rejected", err.Error())
+
+	cont.Config().Lead.DuplicateWindow = 0
+	_, err = s.Create(context.Background(), leadDto, "127.0.0.1")
+	assert.Empty(t, err)
+}
+
+func TestCreateLead_Captcha(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLeadServiceWithCaptcha(cont, &test.FakeCaptchaVerifier{Token: "valid"})
+	leadDto := createLeadRequestForCreate(s)
+	leadDto.CaptchaToken = "invalid"
+	_, err := s.Create(context.Background(), leadDto, "127.0.0.1")
+
+	assert.Equal(t, "captchaToken is not valid", err.Error())
+
+	leadDto.CaptchaToken = "valid"
+	result, err := s.Create(context.Background(), leadDto, "127.0.0.1")
+
+	assert.Empty(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestCreateLead_CaptchaUnavailable(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLeadServiceWithCaptcha(cont, &test.FakeCaptchaVerifier{Err: errors.New("timeout")})
+	result, err := s.Create(context.Background(), createLeadRequestForCreate(s), "127.0.0.1")
+
+	var appErr *apperror.Error
+	assert.Nil(t, result)
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusServiceUnavailable, appErr.Status)
+}
+
 func TestUpdateLead_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
 	leadDto := createLeadForCreate()
-	_, err := s.Update(leadDto, "1")
+	_, err := s.Update(context.Background(), leadDto, "1")
 
-	result, _ := s.Get("1")
+	result, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -90,11 +178,30 @@ func TestUpdateLead_Success(t *testing.T) {
 	assert.Equal(t, leadDto.LastUpdatedByID, result.LastUpdatedByID)
 }
 
+func TestUpdateLead_InvalidClient(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewLeadService(cont)
+	leadDto := createLeadForCreate()
+	leadDto.ClientID = 99
+	result, err := s.Update(context.Background(), leadDto, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "clientId refers to a record which does not exist: record not found", err.Error())
+
+	leadDto.ClientID = 0
+	result, err = s.Update(context.Background(), leadDto, "1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), result.ClientID)
+	assert.Nil(t, result.Client)
+}
+
 func TestUpdateLead_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.Update(createLeadForCreate(), "99")
+	result, err := s.Update(context.Background(), createLeadForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -104,9 +211,9 @@ func TestDeleteLead_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -116,7 +223,7 @@ func TestDeleteLead_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewLeadService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -134,3 +241,14 @@ func createLeadForCreate() *dto.LeadDto {
 		LastUpdatedByID: 1,
 	}
 }
+
+func createLeadRequestForCreate(s *LeadService) *dto.LeadRequestDto {
+	return &dto.LeadRequestDto{
+		Name:      "Клиент",
+		Phone:     "+79998887766",
+		Email:     "client@test.com",
+		Comment:   "Комментарий",
+		Type:      "consult-online",
+		FormToken: s.IssueFormToken(time.Now().Add(-time.Minute)),
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type MedicationService struct {
+	container container.Container
+}
+
+// NewMedicationService is constructor.
+func NewMedicationService(container container.Container) *MedicationService {
+	return &MedicationService{container: container}
+}
+
+// Get returns medication full matched given medication ID.
+func (s *MedicationService) Get(ctx context.Context, id string) (*models.Medication, error) {
+	ctx, span := tracing.Start(ctx, "MedicationService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch medication ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	medication := &models.Medication{}
+	var err error
+
+	if medication, err = medication.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch medication with ID %s: %v", id, err)
+		return nil, err
+	}
+	return medication, nil
+}
+
+// GetAll returns a slice of all medications.
+func (s *MedicationService) GetAll(ctx context.Context) ([]*models.Medication, error) {
+	ctx, span := tracing.Start(ctx, "MedicationService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Medication{}
+	var medications []*models.Medication
+	var err error
+
+	if medications, err = model.GetAll(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch medications: %v", err)
+		return nil, err
+	}
+	return medications, nil
+}
+
+// Create persists this medication data.
+func (s *MedicationService) Create(ctx context.Context, dto *dto.MedicationDto) (*models.Medication, error) {
+	ctx, span := tracing.Start(ctx, "MedicationService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	medication := &models.Medication{}
+	var err error
+
+	if medication, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create medication: %v", err)
+		return nil, err
+	}
+	return medication, nil
+}
+
+// Update updates this medication data.
+func (s *MedicationService) Update(ctx context.Context, dto *dto.MedicationDto, id string) (*models.Medication, error) {
+	ctx, span := tracing.Start(ctx, "MedicationService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch medication ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	medication := &models.Medication{}
+	var err error
+
+	if medication, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update medication with ID %s: %v", id, err)
+		return nil, err
+	}
+	return medication, nil
+}
+
+// Delete deletes this medication data.
+func (s *MedicationService) Delete(ctx context.Context, id string) (*models.Medication, error) {
+	ctx, span := tracing.Start(ctx, "MedicationService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch medication ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	medication := &models.Medication{}
+	var err error
+
+	if medication, err = medication.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete medication: %v", err)
+		return nil, err
+	}
+	return medication, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestFindAllMedications_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewMedicationService(cont)
+	result, err := s.GetAll(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, "Амоксиклав", result[0].Name)
+	}
+}
+
+func TestCreateMedication_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewMedicationService(cont)
+	result, err := s.Create(context.Background(),
+		&dto.MedicationDto{Name: "Синулокс", Form: "таблетки", Strength: 50, Unit: "мг"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestCreateMedication_OtherStrength(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewMedicationService(cont)
+	result, err := s.Create(context.Background(),
+		&dto.MedicationDto{Name: "Синулокс", Form: "таблетки", Strength: 250, Unit: "мг"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(250), result.Strength)
+}
+
+func TestDeleteMedication_Prescribed(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewPrescriptionService(cont).Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	s := NewMedicationService(cont)
+	result, err := s.Delete(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
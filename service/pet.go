@@ -1,10 +1,13 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,45 +21,59 @@ func NewPetService(container container.Container) *PetService {
 }
 
 // Get returns pet full matched given pet ID or pet slug.
-func (s *PetService) Get(id string) (*models.Pet, error) {
+func (s *PetService) Get(ctx context.Context, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	pet := &models.Pet{}
 	var err error
 
 	if pet, err = pet.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch pet with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet with ID %s: %v", id, err)
 		return nil, err
 	}
 	return pet, nil
 }
 
-// GetAll returns a slice of all pets.
-func (s *PetService) GetAll() ([]*models.Pet, error) {
-	rep := s.container.Repository()
+// GetAll returns a slice of all pets. If includeDeleted is true, the soft-deleted pets are included.
+func (s *PetService) GetAll(ctx context.Context, includeDeleted bool) ([]*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Pet{}
 	var pets []*models.Pet
 	var err error
 
-	if pets, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch pets: %v", err)
+	if includeDeleted {
+		pets, err = model.GetAllWithDeleted(rep)
+	} else {
+		pets, err = model.GetAll(rep)
+	}
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pets: %v", err)
 		return nil, err
 	}
 	return pets, nil
 }
 
 // Create persists this pet data.
-func (s *PetService) Create(dto *dto.PetDto) (*models.Pet, error) {
-	rep := s.container.Repository()
+func (s *PetService) Create(ctx context.Context, dto *dto.PetDto) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	pet := dto.ToModel()
 	var err error
 
 	if pet, err = pet.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create pet: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create pet: %v", err)
 		return nil, err
 	}
 
@@ -64,37 +81,176 @@ func (s *PetService) Create(dto *dto.PetDto) (*models.Pet, error) {
 }
 
 // Update updates this pet data.
-func (s *PetService) Update(dto *dto.PetDto, id string) (*models.Pet, error) {
+func (s *PetService) Update(ctx context.Context, dto *dto.PetDto, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch pet ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	pet := dto.ToModel()
 	var err error
 
 	if pet, err = pet.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update pet with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update pet with ID %s: %v", id, err)
 		return nil, err
 	}
 	return pet, nil
 }
 
+// Patch applies the JSON Merge Patch to this pet data.
+// The fields which are absent in the patch keep their current values.
+func (s *PetService) Patch(ctx context.Context, patch []byte, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Patch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	pet := &models.Pet{}
+	var err error
+
+	if pet, err = pet.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet with ID %s: %v", id, err)
+		return nil, err
+	}
+
+	data := dto.NewPetDto(pet)
+	if _, err = util.ApplyMergePatch(data, patch); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to patch pet with ID %s: %v", id, err)
+		return nil, err
+	}
+	return s.Update(ctx, data, id)
+}
+
 // Delete deletes this pet data.
-func (s *PetService) Delete(id string) (*models.Pet, error) {
+func (s *PetService) Delete(ctx context.Context, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch pet ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	pet := &models.Pet{}
 	var err error
 
 	if pet, err = pet.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete pet: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete pet: %v", err)
+		return nil, err
+	}
+	return pet, nil
+}
+
+// Restore restores this soft-deleted pet data.
+func (s *PetService) Restore(ctx context.Context, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Restore")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	pet := &models.Pet{}
+	var err error
+
+	if pet, err = pet.Restore(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to restore pet with ID %s: %v", id, err)
 		return nil, err
 	}
 	return pet, nil
 }
+
+// Purge permanently deletes this pet data.
+func (s *PetService) Purge(ctx context.Context, id string) (*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PetService.Purge")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	pet := &models.Pet{}
+	var err error
+
+	if pet, err = pet.Purge(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to purge pet with ID %s: %v", id, err)
+		return nil, err
+	}
+	return pet, nil
+}
+
+// RecordWeight records the weight measurement of this pet.
+func (s *PetService) RecordWeight(ctx context.Context, dto *dto.PetWeightDto, id string) (*models.PetWeight, error) {
+	ctx, span := tracing.Start(ctx, "PetService.RecordWeight")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	weight := dto.ToModel()
+	var err error
+
+	if weight, err = weight.Record(rep, util.ConvertToUint(id), time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to record weight of pet with ID %s: %v", id, err)
+		return nil, err
+	}
+	return weight, nil
+}
+
+// GetWeights returns the weight history of this pet.
+func (s *PetService) GetWeights(ctx context.Context, id string) ([]*models.PetWeight, error) {
+	ctx, span := tracing.Start(ctx, "PetService.GetWeights")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	weight := &models.PetWeight{}
+
+	weights, err := weight.GetAllByPet(rep, util.ConvertToUint(id))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch weights of pet with ID %s: %v", id, err)
+		return nil, err
+	}
+	return weights, nil
+}
+
+// GetWeightTrend returns the summary of the weight history of this pet.
+func (s *PetService) GetWeightTrend(ctx context.Context, id string) (*models.WeightTrend, error) {
+	ctx, span := tracing.Start(ctx, "PetService.GetWeightTrend")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	weight := &models.PetWeight{}
+
+	trend, err := weight.GetTrend(rep, util.ConvertToUint(id))
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch weight trend of pet with ID %s: %v", id, err)
+		return nil, err
+	}
+	return trend, nil
+}
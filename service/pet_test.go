@@ -1,8 +1,13 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"strconv"
 	"testing"
+	"time"
+	"vet-clinic/models"
 	"vet-clinic/models/dto"
 	"vet-clinic/test"
 )
@@ -11,7 +16,7 @@ func TestFindPetByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -22,7 +27,7 @@ func TestFindPetByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -32,7 +37,7 @@ func TestFindPetByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -42,7 +47,7 @@ func TestFindAllPets_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background(), false)
 
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
@@ -53,11 +58,13 @@ func TestCreatePet_Success(t *testing.T) {
 
 	s := NewPetService(cont)
 	petDto := createPetForCreate()
-	_, err := s.Create(petDto)
+	_, err := s.Create(context.Background(), petDto)
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 	result.BaseModel = nil
 	result.Client = nil
+	result.Owners = nil
+	result.BirthDate = result.BirthDate.Local()
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -68,10 +75,10 @@ func TestCreatePet_NotClient(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Create(createPetForNotClient())
+	result, err := s.Create(context.Background(), createPetForNotClient())
 
 	assert.Nil(t, result)
-	assert.Equal(t, "record not found", err.Error())
+	assert.Equal(t, "clientId refers to a record which does not exist: record not found", err.Error())
 }
 
 func TestUpdatePet_Success(t *testing.T) {
@@ -79,11 +86,13 @@ func TestUpdatePet_Success(t *testing.T) {
 
 	s := NewPetService(cont)
 	petDto := createPetForCreate()
-	_, err := s.Update(petDto, "1")
+	_, err := s.Update(context.Background(), petDto, "1")
 
-	result, _ := s.Get("1")
+	result, _ := s.Get(context.Background(), "1")
 	result.BaseModel = nil
 	result.Client = nil
+	result.Owners = nil
+	result.BirthDate = result.BirthDate.Local()
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -94,7 +103,7 @@ func TestUpdatePet_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Update(createPetForCreate(), "99")
+	result, err := s.Update(context.Background(), createPetForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -104,9 +113,9 @@ func TestDeletePet_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -116,20 +125,249 @@ func TestDeletePet_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewPetService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
 }
 
+func TestCreatePet_WithOwners(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	coOwner := &models.Client{Surname: "Петров", Name: "Пётр", Phone: "+71234560001"}
+	_, _ = coOwner.Create(rep)
+
+	s := NewPetService(cont)
+	petDto := createPetForCreate()
+	petDto.Owners = []*dto.PetOwnerDto{{ClientID: coOwner.ID, Role: models.PetOwnerCoOwner}}
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Owners, 2) {
+		assert.Equal(t, uint(1), result.Owners[0].ClientID)
+		assert.Equal(t, models.PetOwnerPrimary, result.Owners[0].Role)
+		assert.Equal(t, coOwner.ID, result.Owners[1].ClientID)
+		assert.Equal(t, models.PetOwnerCoOwner, result.Owners[1].Role)
+		assert.Equal(t, "Петров", result.Owners[1].Client.Surname)
+	}
+}
+
+func TestCreatePet_OwnerNotClient(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForCreate()
+	petDto.Owners = []*dto.PetOwnerDto{{ClientID: 99, Role: models.PetOwnerEmergency}}
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "owners refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestUpdatePet_KeepsOwners(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	emergency := &models.Client{Surname: "Петров", Name: "Пётр", Phone: "+71234560001"}
+	_, _ = emergency.Create(rep)
+	primary := &models.Client{Surname: "Сидоров", Name: "Иван", Phone: "+71234560002"}
+	_, _ = primary.Create(rep)
+
+	s := NewPetService(cont)
+	petDto := createPetForCreate()
+	petDto.Owners = []*dto.PetOwnerDto{{ClientID: emergency.ID, Role: models.PetOwnerEmergency}}
+	_, _ = s.Update(context.Background(), petDto, "1")
+
+	petDto.ClientID = primary.ID
+	petDto.Owners = nil
+	result, err := s.Update(context.Background(), petDto, "1")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result.Owners, 2) {
+		assert.Equal(t, primary.ID, result.Owners[0].ClientID)
+		assert.Equal(t, models.PetOwnerPrimary, result.Owners[0].Role)
+		assert.Equal(t, emergency.ID, result.Owners[1].ClientID)
+		assert.Equal(t, models.PetOwnerEmergency, result.Owners[1].Role)
+	}
+}
+
+func TestCreatePet_ResolvesSpecies(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForNotClient()
+	petDto.ClientID = 1
+	petDto.Type, petDto.Breed = "Dog", "такса"
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), result.SpeciesID)
+	assert.Equal(t, "Собака", result.Type)
+	assert.Equal(t, uint(7), result.BreedID)
+	assert.Equal(t, "Такса", result.Breed)
+	assert.False(t, result.BreedUnverified)
+}
+
+func TestCreatePet_UnknownBreed(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForNotClient()
+	petDto.ClientID = 1
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), result.SpeciesID)
+	assert.Equal(t, uint(0), result.BreedID)
+	assert.Equal(t, "Дворняга", result.Breed)
+	assert.True(t, result.BreedUnverified)
+}
+
+func TestCreatePet_UnknownSpecies(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForNotClient()
+	petDto.ClientID = 1
+	petDto.Type = "Енот"
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "type refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestCreatePet_BreedOfAnotherSpecies(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForCreate()
+	petDto.SpeciesID = 1
+	result, err := s.Create(context.Background(), petDto)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "breedId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestCreatePet_DuplicateMicrochip(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	_, _ = s.Create(context.Background(), createPetForCreate())
+	result, err := s.Create(context.Background(), createPetForCreate())
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a pet with the same microchip already exists", err.Error())
+}
+
+func TestCreatePet_MicrochipUniqueIndex(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	_, _ = NewPetService(cont).Create(context.Background(), createPetForCreate())
+
+	err := rep.Select("name", "client_id", "microchip").
+		Create(&models.Pet{Name: "Копия", ClientID: 1, Microchip: "643094100123456"}).Error
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+	for _, name := range []string{"Первый", "Второй"} {
+		err = rep.Select("name", "client_id", "microchip").Create(&models.Pet{Name: name, ClientID: 1}).Error
+		assert.NoError(t, err)
+	}
+}
+
+func TestUpdatePet_SameMicrochip(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	petDto := createPetForCreate()
+	_, _ = s.Update(context.Background(), petDto, "1")
+	petDto.Status = models.PetDeceased
+	result, err := s.Update(context.Background(), petDto, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "643094100123456", result.Microchip)
+	assert.Equal(t, models.PetDeceased, result.Status)
+}
+
+func TestRecordWeight_AtVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	_, _ = s.RecordWeight(context.Background(), &dto.PetWeightDto{Weight: 4.2, VisitID: 1}, "1")
+	result, err := s.RecordWeight(context.Background(), &dto.PetWeightDto{Weight: 4.5, VisitID: 1}, "1")
+
+	visit, _ := (&models.Visit{}).Get(cont.Repository(), 1)
+	weights, _ := s.GetWeights(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4.5, result.Weight)
+	assert.True(t, visit.DateTime.Equal(result.MeasuredAt))
+	assert.Len(t, weights, 1)
+}
+
+func TestRecordWeight_NotVisitOfPet(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	pet, _ := s.Create(context.Background(), createPetForCreate())
+	result, err := s.RecordWeight(context.Background(), &dto.PetWeightDto{Weight: 4.2, VisitID: 1},
+		strconv.Itoa(int(pet.ID)))
+
+	assert.Nil(t, result)
+	assert.Equal(t, "visitId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestGetWeightTrend_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+	for i, weight := range []float64{4.0, 4.2, 4.4, 4.6} {
+		_, _ = s.RecordWeight(context.Background(),
+			&dto.PetWeightDto{Weight: weight, MeasuredAt: start.AddDate(0, 0, 10*i)}, "1")
+	}
+	result, err := s.GetWeightTrend(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Count)
+	assert.Equal(t, 4.0, result.First.Weight)
+	assert.Equal(t, 4.6, result.Latest.Weight)
+	assert.Equal(t, 4.0, result.Min)
+	assert.Equal(t, 4.6, result.Max)
+	assert.Equal(t, 0.6, result.Change)
+	assert.Equal(t, 15.0, result.ChangePercent)
+	assert.Equal(t, 0.02, result.DailyChange)
+	assert.Equal(t, models.WeightGaining, result.Direction)
+}
+
+func TestGetWeightTrend_NoWeights(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPetService(cont)
+	result, err := s.GetWeightTrend(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Count)
+	assert.Nil(t, result.Latest)
+	assert.Equal(t, models.WeightStable, result.Direction)
+}
+
 func createPetForCreate() *dto.PetDto {
 	return &dto.PetDto{
-		Name:     "Шарик",
-		Type:     "Пёс",
-		Breed:    "Дворняга",
-		Colour:   "Коричневый",
-		Sex:      "Самец",
-		ClientID: 1,
+		Name:      "Шарик",
+		Type:      "Собака",
+		Breed:     "Такса",
+		SpeciesID: 2,
+		BreedID:   7,
+		Colour:    "Коричневый",
+		Sex:       "Самец",
+		ClientID:  1,
+		BirthDate: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local),
+		Neutered:  true,
+		Microchip: "643094100123456",
+		Allergies: "Пенициллин",
+		Status:    models.PetActive,
 	}
 }
 
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+const (
+	// PeriodUpcoming selects the visits which have not taken place yet.
+	PeriodUpcoming = "upcoming"
+	// PeriodPast selects the visits which have taken place.
+	PeriodPast = "past"
+)
+
+// PortalService provides the data of the logged-in client in the client portal.
+// All methods are restricted to the records of the given client.
+type PortalService struct {
+	container container.Container
+}
+
+// NewPortalService is constructor.
+func NewPortalService(container container.Container) *PortalService {
+	return &PortalService{container: container}
+}
+
+// GetProfile returns the data of the client.
+func (s *PortalService) GetProfile(ctx context.Context, clientID uint) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.GetProfile")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	client := &models.Client{}
+	var err error
+
+	if client, err = client.Get(rep, clientID); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client with ID %d: %v", clientID, err)
+		return nil, err
+	}
+	return client, nil
+}
+
+// GetPets returns a slice of the pets of the client.
+func (s *PortalService) GetPets(ctx context.Context, clientID uint) ([]*models.Pet, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.GetPets")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	pet := &models.Pet{}
+
+	pets, err := pet.GetAllByClient(rep, clientID)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pets of client with ID %d: %v", clientID, err)
+		return nil, err
+	}
+	return pets, nil
+}
+
+// GetVisits returns a slice of the visits of the client.
+// The period is either empty for all visits, PeriodUpcoming or PeriodPast.
+func (s *PortalService) GetVisits(ctx context.Context, clientID uint, period string) ([]*models.PortalVisit, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.GetVisits")
+	defer span.End()
+
+	var upcoming *bool
+	switch period {
+	case "":
+	case PeriodUpcoming, PeriodPast:
+		value := period == PeriodUpcoming
+		upcoming = &value
+	default:
+		return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid period: %s", period))
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+
+	visits, err := visit.GetAllByClient(rep, clientID, upcoming, time.Now())
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visits of client with ID %d: %v", clientID, err)
+		return nil, err
+	}
+	return models.NewPortalVisits(visits), nil
+}
+
+// GetInvoices returns a slice of the invoices of the client, the latest first.
+func (s *PortalService) GetInvoices(ctx context.Context, clientID uint) ([]*models.PortalInvoice, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.GetInvoices")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	invoice := &models.Invoice{}
+
+	invoices, err := invoice.GetAllByClient(rep, clientID)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch invoices of client with ID %d: %v", clientID, err)
+		return nil, err
+	}
+	return models.NewPortalInvoices(invoices), nil
+}
+
+// GetRecord returns the medical record of the pet of the client.
+func (s *PortalService) GetRecord(ctx context.Context, clientID uint, petID string) (*models.PortalRecord, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.GetRecord")
+	defer span.End()
+
+	if !util.IsNumeric(petID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", petID)
+		return nil, apperror.NewInvalidID(petID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	pet := &models.Pet{}
+
+	record, err := pet.GetPortalRecord(rep, util.ConvertToUint(petID), clientID)
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch record of pet with ID %s: %v", petID, err)
+		return nil, err
+	}
+	return record, nil
+}
+
+// RequestVisit persists the visit requested by the client. The clinic schedules it later.
+func (s *PortalService) RequestVisit(ctx context.Context, clientID uint, dto *dto.PortalVisitDto) (*models.PortalVisit, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.RequestVisit")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := dto.ToModel(clientID)
+	var err error
+
+	if visit, err = visit.Request(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to request visit: %v", err)
+		return nil, err
+	}
+	return models.NewPortalVisit(visit), nil
+}
+
+// CancelVisit cancels the upcoming visit of the client.
+func (s *PortalService) CancelVisit(ctx context.Context, clientID uint, id string) (*models.PortalVisit, error) {
+	ctx, span := tracing.Start(ctx, "PortalService.CancelVisit")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+	var err error
+
+	if visit, err = visit.Cancel(rep, util.ConvertToUint(id), clientID, time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to cancel visit with ID %s: %v", id, err)
+		return nil, err
+	}
+	return models.NewPortalVisit(visit), nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// prescriptionTemplate renders the printable prescription.
+var prescriptionTemplate = template.Must(template.New("prescription").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("02.01.2006") },
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Рецепт № {{.ID}}</title>
+</head>
+<body>
+<h1>Рецепт № {{.ID}}</h1>
+<p>Дата: {{date .CreatedAt}}</p>
+{{with .Pet}}<p>Пациент: {{.Name}}{{if .Type}}, {{.Type}}{{end}}{{if .Breed}}, {{.Breed}}{{end}}</p>
+{{with .Client}}<p>Владелец: {{.Surname}} {{.Name}} {{.Patronymic}}</p>
+{{end}}{{end}}{{with .Medication}}<p>Rp.: {{.Name}}, {{.Form}}{{if .Strength}} {{.Strength}} {{.Unit}}{{end}}</p>
+{{end}}<p>Доза: {{.Dosage}}</p>
+<p>Кратность: {{.Frequency}}</p>
+<p>Курс: с {{date .StartDate}}{{if .DurationDays}}, {{.DurationDays}} дн.{{else}} до отмены{{end}}</p>
+{{if .Instructions}}<p>Указания: {{.Instructions}}</p>
+{{end}}{{with .Prescriber}}<p>Врач: {{.Surname}} {{.Name}} {{.Patronymic}}</p>
+{{end}}</body>
+</html>
+`))
+
+type PrescriptionService struct {
+	container container.Container
+}
+
+// NewPrescriptionService is constructor.
+func NewPrescriptionService(container container.Container) *PrescriptionService {
+	return &PrescriptionService{container: container}
+}
+
+// Get returns prescription full matched given prescription ID.
+func (s *PrescriptionService) Get(ctx context.Context, id string) (*models.Prescription, error) {
+	ctx, span := tracing.Start(ctx, "PrescriptionService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch prescription ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	prescription := &models.Prescription{}
+	var err error
+
+	if prescription, err = prescription.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch prescription with ID %s: %v", id, err)
+		return nil, err
+	}
+	return prescription, nil
+}
+
+// GetAllByPet returns the prescriptions of the pet. If current is true, only the medications to be taken now are returned.
+func (s *PrescriptionService) GetAllByPet(ctx context.Context, petID string, current bool) ([]*models.Prescription, error) {
+	ctx, span := tracing.Start(ctx, "PrescriptionService.GetAllByPet")
+	defer span.End()
+
+	if !util.IsNumeric(petID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch pet ID: %s", petID)
+		return nil, apperror.NewInvalidID(petID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Prescription{}
+	var prescriptions []*models.Prescription
+	var err error
+
+	if current {
+		prescriptions, err = model.GetCurrentByPet(rep, util.ConvertToUint(petID), time.Now())
+	} else {
+		prescriptions, err = model.GetAllByPet(rep, util.ConvertToUint(petID))
+	}
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch prescriptions of pet with ID %s: %v", petID, err)
+		return nil, err
+	}
+	return prescriptions, nil
+}
+
+// Create persists this prescription data written by the given prescriber.
+func (s *PrescriptionService) Create(ctx context.Context, dto *dto.PrescriptionDto,
+	prescriberID uint) (*models.Prescription, error) {
+	ctx, span := tracing.Start(ctx, "PrescriptionService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	prescription := dto.ToModel()
+	prescription.PrescriberID = prescriberID
+	var err error
+
+	if prescription, err = prescription.Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create prescription: %v", err)
+		return nil, err
+	}
+	return prescription, nil
+}
+
+// Cancel cancels this prescription.
+func (s *PrescriptionService) Cancel(ctx context.Context, id string) (*models.Prescription, error) {
+	ctx, span := tracing.Start(ctx, "PrescriptionService.Cancel")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch prescription ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	prescription := &models.Prescription{}
+	var err error
+
+	if prescription, err = prescription.Cancel(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to cancel prescription with ID %s: %v", id, err)
+		return nil, err
+	}
+	return prescription, nil
+}
+
+// Print returns this prescription as an HTML document to be printed.
+func (s *PrescriptionService) Print(ctx context.Context, id string) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "PrescriptionService.Print")
+	defer span.End()
+
+	prescription, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = prescriptionTemplate.Execute(&buf, prescription); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to print prescription with ID %s: %v", id, err)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestCreatePrescription_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	result, err := s.Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.PetID)
+	assert.Equal(t, uint(1), result.PrescriberID)
+	assert.Equal(t, "Синулокс", result.Medication.Name)
+	assert.Equal(t, models.PrescriptionActive, result.Status)
+	assert.True(t, result.StartDate.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)))
+}
+
+func TestCreatePrescription_NotVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	param := createPrescriptionForCreate()
+	param.VisitID = 99
+	result, err := s.Create(context.Background(), param, 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "visitId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestCreatePrescription_NotMedication(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	param := createPrescriptionForCreate()
+	param.MedicationID = 99
+	result, err := s.Create(context.Background(), param, 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "medicationId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestGetCurrentMedications_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	// The course prescribed at the visit has ended.
+	_, _ = s.Create(context.Background(), createPrescriptionForCreate(), 1)
+	current := createPrescriptionForCreate()
+	current.MedicationID = 2
+	current.StartDate = time.Now().AddDate(0, 0, -1)
+	current.DurationDays = 0
+	_, _ = s.Create(context.Background(), current, 1)
+
+	all, err1 := s.GetAllByPet(context.Background(), "1", false)
+	result, err2 := s.GetAllByPet(context.Background(), "1", true)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Len(t, all, 2)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Мелоксикам", result[0].Medication.Name)
+	}
+}
+
+func TestGetCurrentMedications_NotPet(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	result, err := s.GetAllByPet(context.Background(), "99", true)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record not found", err.Error())
+}
+
+func TestCancelPrescription_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	param := createPrescriptionForCreate()
+	param.StartDate = time.Now().AddDate(0, 0, -1)
+	_, _ = s.Create(context.Background(), param, 1)
+
+	result, err := s.Cancel(context.Background(), "1")
+	current, _ := s.GetAllByPet(context.Background(), "1", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PrescriptionCancelled, result.Status)
+	assert.Empty(t, current)
+}
+
+func TestCancelPrescription_AlreadyCancelled(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	_, _ = s.Create(context.Background(), createPrescriptionForCreate(), 1)
+	_, _ = s.Cancel(context.Background(), "1")
+
+	result, err := s.Cancel(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the prescription has already been cancelled", err.Error())
+}
+
+func TestPrintPrescription_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewPrescriptionService(cont)
+	_, _ = s.Create(context.Background(), createPrescriptionForCreate(), 1)
+
+	result, err := s.Print(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "Рецепт № 1")
+	assert.Contains(t, string(result), "Китти")
+	assert.Contains(t, string(result), "Синулокс, таблетки 50 мг")
+	assert.Contains(t, string(result), "7 дн.")
+}
+
+func createPrescriptionForCreate() *dto.PrescriptionDto {
+	return &dto.PrescriptionDto{
+		VisitID:      1,
+		MedicationID: 1,
+		Dosage:       "1 таблетка",
+		Frequency:    "2 раза в день",
+		DurationDays: 7,
+		Instructions: "Давать во время еды",
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+const (
+	// defaultExpiringDays is the period of the expiring-soon report in days if it is not given.
+	defaultExpiringDays = 30
+	// maxExpiringDays is the longest period of the expiring-soon report in days.
+	maxExpiringDays = 3650
+)
+
+type ProductService struct {
+	container container.Container
+}
+
+// NewProductService is constructor.
+func NewProductService(container container.Container) *ProductService {
+	return &ProductService{container: container}
+}
+
+// Get returns product full matched given product ID.
+func (s *ProductService) Get(ctx context.Context, id string) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	product := &models.Product{}
+	var err error
+
+	if product, err = product.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product with ID %s: %v", id, err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetAll returns a slice of all products.
+func (s *ProductService) GetAll(ctx context.Context) ([]*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Product{}
+	var products []*models.Product
+	var err error
+
+	if products, err = model.GetAll(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch products: %v", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// Create persists this product data.
+func (s *ProductService) Create(ctx context.Context, dto *dto.ProductDto) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	product := &models.Product{}
+	var err error
+
+	if product, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create product: %v", err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// Update updates this product data.
+func (s *ProductService) Update(ctx context.Context, dto *dto.ProductDto, id string) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	product := &models.Product{}
+	var err error
+
+	if product, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update product with ID %s: %v", id, err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// Delete deletes this product data.
+func (s *ProductService) Delete(ctx context.Context, id string) (*models.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	product := &models.Product{}
+	var err error
+
+	if product, err = product.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete product: %v", err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetLowStock returns the products whose quantity in stock is below the minimum.
+func (s *ProductService) GetLowStock(ctx context.Context) ([]*models.ProductStock, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetLowStock")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Product{}
+	var products []*models.ProductStock
+	var err error
+
+	if products, err = model.GetLowStock(rep, time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch low stock products: %v", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetBatches returns the batches of the product.
+func (s *ProductService) GetBatches(ctx context.Context, id string) ([]*models.ProductBatch, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetBatches")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ProductBatch{}
+	var batches []*models.ProductBatch
+	var err error
+
+	if batches, err = model.GetAllByProduct(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch batches of product with ID %s: %v", id, err)
+		return nil, err
+	}
+	return batches, nil
+}
+
+// ReceiveBatch persists the batch of the product received in stock.
+func (s *ProductService) ReceiveBatch(ctx context.Context, dto *dto.ProductBatchDto, id string) (*models.ProductBatch, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.ReceiveBatch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	batch := dto.ToModel()
+	var err error
+
+	if batch, err = batch.Receive(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to receive batch of product with ID %s: %v", id, err)
+		return nil, err
+	}
+	return batch, nil
+}
+
+// WriteOff decreases the quantity of the batch.
+func (s *ProductService) WriteOff(ctx context.Context, dto *dto.WriteOffDto, batchID string) (*models.ProductBatch, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.WriteOff")
+	defer span.End()
+
+	if !util.IsNumeric(batchID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch batch ID: %s", batchID)
+		return nil, apperror.NewInvalidID(batchID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	batch := &models.ProductBatch{}
+	var err error
+
+	if batch, err = batch.WriteOff(rep, util.ConvertToUint(batchID), dto.Quantity, dto.Note); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to write off batch with ID %s: %v", batchID, err)
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetExpiring returns the batches left in stock which expire within the given number of days, including the expired ones.
+func (s *ProductService) GetExpiring(ctx context.Context, days string) ([]*models.ProductBatch, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetExpiring")
+	defer span.End()
+
+	period := defaultExpiringDays
+	if days != "" {
+		var err error
+		if period, err = strconv.Atoi(days); err != nil || period < 0 || period > maxExpiringDays {
+			return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid days: %s", days))
+		}
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ProductBatch{}
+	var batches []*models.ProductBatch
+	var err error
+
+	now := time.Now()
+	// The batches expiring on the last day of the period are included.
+	before := time.Date(now.Year(), now.Month(), now.Day()+period+1, 0, 0, 0, 0, now.Location())
+	if batches, err = model.GetExpiring(rep, before); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch expiring batches: %v", err)
+		return nil, err
+	}
+	return batches, nil
+}
+
+// GetMovements returns the stock movements of the product.
+func (s *ProductService) GetMovements(ctx context.Context, id string) ([]*models.StockMovement, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetMovements")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch product ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.StockMovement{}
+	var movements []*models.StockMovement
+	var err error
+
+	if movements, err = model.GetAllByProduct(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch stock movements of product with ID %s: %v", id, err)
+		return nil, err
+	}
+	return movements, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestGetLowStock_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.GetLowStock(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Гель для УЗИ", result[0].Product.Name)
+		assert.Equal(t, float64(0), result[0].Quantity)
+	}
+}
+
+func TestGetLowStock_ExpiredBatch(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	expired := &models.ProductBatch{ProductID: 3, BatchNumber: "G-1", ExpiryDate: time.Now().AddDate(0, 0, -1), Quantity: 500}
+	cont.Repository().Create(expired)
+
+	s := NewProductService(cont)
+	result, err := s.GetLowStock(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Гель для УЗИ", result[0].Product.Name)
+	}
+}
+
+func TestGetExpiring_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	_, _ = s.ReceiveBatch(context.Background(),
+		&dto.ProductBatchDto{BatchNumber: "G-1", ExpiryDate: time.Now().AddDate(0, 0, 10), Quantity: 500}, "3")
+
+	result, err := s.GetExpiring(context.Background(), "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "G-1", result[0].BatchNumber)
+	}
+
+	result, err = s.GetExpiring(context.Background(), "5")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetExpiring_InvalidDays(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.GetExpiring(context.Background(), "soon")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid days: soon", err.Error())
+}
+
+func TestReceiveBatch_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.ReceiveBatch(context.Background(),
+		&dto.ProductBatchDto{BatchNumber: "A123B45", ExpiryDate: time.Now().AddDate(1, 0, 0), Quantity: 10}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a batch with the same number already exists for the product", err.Error())
+}
+
+func TestWriteOff_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.WriteOff(context.Background(), &dto.WriteOffDto{Quantity: 2, Note: "Разбит флакон"}, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(18), result.Quantity)
+
+	movements, _ := s.GetMovements(context.Background(), "1")
+	if assert.NotEmpty(t, movements) {
+		assert.Equal(t, float64(-2), movements[0].Quantity)
+		assert.Equal(t, models.StockWrittenOff, movements[0].Reason)
+	}
+}
+
+func TestWriteOff_ExceedsStock(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.WriteOff(context.Background(), &dto.WriteOffDto{Quantity: 21}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the quantity exceeds the stock of the batch", err.Error())
+}
+
+func TestDeleteProduct_Referenced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewProductService(cont)
+	result, err := s.Delete(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type PromoCodeService struct {
+	container container.Container
+}
+
+// NewPromoCodeService is constructor.
+func NewPromoCodeService(container container.Container) *PromoCodeService {
+	return &PromoCodeService{container: container}
+}
+
+// Get returns promo code full matched given promo code ID.
+func (s *PromoCodeService) Get(ctx context.Context, id string) (*models.PromoCode, error) {
+	ctx, span := tracing.Start(ctx, "PromoCodeService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch promo code ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	code := &models.PromoCode{}
+	var err error
+
+	if code, err = code.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch promo code with ID %s: %v", id, err)
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetAll returns a slice of all promo codes.
+func (s *PromoCodeService) GetAll(ctx context.Context) ([]*models.PromoCode, error) {
+	ctx, span := tracing.Start(ctx, "PromoCodeService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.PromoCode{}
+	var codes []*models.PromoCode
+	var err error
+
+	if codes, err = model.GetAll(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch promo codes: %v", err)
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Create persists this promo code data.
+func (s *PromoCodeService) Create(ctx context.Context, dto *dto.PromoCodeDto) (*models.PromoCode, error) {
+	ctx, span := tracing.Start(ctx, "PromoCodeService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	code := &models.PromoCode{}
+	var err error
+
+	if code, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create promo code: %v", err)
+		return nil, err
+	}
+	return code, nil
+}
+
+// Update updates this promo code data.
+func (s *PromoCodeService) Update(ctx context.Context, dto *dto.PromoCodeDto, id string) (*models.PromoCode, error) {
+	ctx, span := tracing.Start(ctx, "PromoCodeService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch promo code ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	code := &models.PromoCode{}
+	var err error
+
+	if code, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update promo code with ID %s: %v", id, err)
+		return nil, err
+	}
+	return code, nil
+}
+
+// Delete deletes this promo code data.
+func (s *PromoCodeService) Delete(ctx context.Context, id string) (*models.PromoCode, error) {
+	ctx, span := tracing.Start(ctx, "PromoCodeService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch promo code ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	code := &models.PromoCode{}
+	var err error
+
+	if code, err = code.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete promo code: %v", err)
+		return nil, err
+	}
+	return code, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type ResourceService struct {
+	container container.Container
+}
+
+// NewResourceService is constructor.
+func NewResourceService(container container.Container) *ResourceService {
+	return &ResourceService{container: container}
+}
+
+// Get returns resource full matched given resource ID.
+func (s *ResourceService) Get(ctx context.Context, id string) (*models.Resource, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resource ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	resource := &models.Resource{}
+	var err error
+
+	if resource, err = resource.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resource with ID %s: %v", id, err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll returns a slice of the resources, optionally only of the given department.
+func (s *ResourceService) GetAll(ctx context.Context, departmentID string) ([]*models.Resource, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.GetAll")
+	defer span.End()
+
+	if departmentID != "" && !util.IsNumeric(departmentID) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch department ID: %s", departmentID)
+		return nil, apperror.NewInvalidID(departmentID)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Resource{}
+	var resources []*models.Resource
+	var err error
+
+	if resources, err = model.GetAll(rep, util.ConvertToUint(departmentID)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resources: %v", err)
+		return nil, err
+	}
+	return resources, nil
+}
+
+// Create persists this resource data.
+func (s *ResourceService) Create(ctx context.Context, dto *dto.ResourceDto) (*models.Resource, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	resource := &models.Resource{}
+	var err error
+
+	if resource, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create resource: %v", err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates this resource data.
+func (s *ResourceService) Update(ctx context.Context, dto *dto.ResourceDto, id string) (*models.Resource, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resource ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	resource := &models.Resource{}
+	var err error
+
+	if resource, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update resource with ID %s: %v", id, err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes this resource data.
+func (s *ResourceService) Delete(ctx context.Context, id string) (*models.Resource, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resource ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	resource := &models.Resource{}
+	var err error
+
+	if resource, err = resource.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete resource: %v", err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetReservations returns the reservations of the resource held by the upcoming visits on the given date.
+func (s *ResourceService) GetReservations(ctx context.Context, id, date string) ([]*models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ResourceService.GetReservations")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resource ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+	day, err := parsePriceDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Reservation{}
+	var reservations []*models.Reservation
+
+	if reservations, err = model.GetAllByResource(rep, util.ConvertToUint(id), day, day.AddDate(0, 0, 1)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch reservations of resource with ID %s: %v", id, err)
+		return nil, err
+	}
+	return reservations, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestFindAllResources_Department(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewResourceService(cont)
+	result, err := s.GetAll(context.Background(), "2")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "Кабинет УЗИ", result[0].Name)
+		assert.Equal(t, "Холтеровский монитор", result[1].Name)
+	}
+}
+
+func TestCreateResource_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewResourceService(cont)
+	result, err := s.Create(context.Background(),
+		&dto.ResourceDto{Name: "Кабинет УЗИ", Type: "room", DepartmentID: 2})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the department already has a resource with the same name", err.Error())
+}
+
+func TestCreateResource_OtherDepartment(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewResourceService(cont)
+	result, err := s.Create(context.Background(),
+		&dto.ResourceDto{Name: "Кабинет УЗИ", Type: "room", DepartmentID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.DepartmentID)
+}
+
+func TestDeleteResource_Required(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewResourceService(cont)
+	result, err := s.Delete(context.Background(), "3")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
+
+func TestCreateVisit_ResourceReserved(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 8
+	_, _ = visits.Create(context.Background(), visitDto)
+	visitDto.DateTime = visitDto.DateTime.Add(12 * time.Hour)
+	result, err := visits.Create(context.Background(), visitDto)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the resource Холтеровский монитор is already reserved for this time", err.Error())
+
+	visitDto.DateTime = visitDto.DateTime.Add(12 * time.Hour)
+	result, err = visits.Create(context.Background(), visitDto)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestDeleteVisit_ReleasesResource(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 8
+	_, _ = visits.Create(context.Background(), visitDto)
+
+	s := NewResourceService(cont)
+	result, err := s.GetReservations(context.Background(), "3", "2024-01-02")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, uint(2), result[0].VisitID)
+		assert.Equal(t, visitDto.DateTime.Add(24*time.Hour), result[0].EndTime.In(time.Local))
+	}
+
+	_, _ = visits.Delete(context.Background(), "2")
+	result, _ = s.GetReservations(context.Background(), "3", "2024-01-02")
+	assert.Empty(t, result)
+
+	_, err = visits.Create(context.Background(), visitDto)
+	assert.NoError(t, err)
+}
+
+func TestGetReservations_InvalidDate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewResourceService(cont)
+	result, err := s.GetReservations(context.Background(), "3", "02.01.2024")
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
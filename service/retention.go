@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/repository"
+	"vet-clinic/tracing"
+)
+
+// purger is implemented by the models whose soft-deleted records are purged after the retention period.
+type purger interface {
+	PurgeDeletedBefore(rep repository.Repository, before time.Time) (int64, error)
+}
+
+type RetentionService struct {
+	container container.Container
+}
+
+// NewRetentionService is constructor.
+func NewRetentionService(container container.Container) *RetentionService {
+	return &RetentionService{container: container}
+}
+
+// Start purges the expired soft-deleted records every configured interval until the context is done.
+// It does nothing if the retention is disabled.
+func (s *RetentionService) Start(ctx context.Context) {
+	conf := s.container.Config().Retention
+	if !conf.Enabled || conf.Interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(conf.Interval)
+		defer ticker.Stop()
+		for {
+			_, _ = s.PurgeExpired(ctx, time.Now().Add(-conf.Period))
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// PurgeExpired permanently deletes the records soft-deleted before the given time and returns their number.
+// The records which are still referred to by other records are kept.
+func (s *RetentionService) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "RetentionService.PurgeExpired")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	logger := s.container.Logger().WithContext(ctx)
+
+	// The referring records go first, so the records they refer to can be purged in the same run.
+	var total int64
+	for _, model := range []purger{&models.Visit{}, &models.Pet{}, &models.Client{}, &models.User{}} {
+		purged, err := model.PurgeDeletedBefore(rep, before)
+		total += purged
+		if err != nil {
+			logger.Errorf("Failed to purge the expired records: %v", err)
+			return total, err
+		}
+	}
+	logger.Infof("Purged %d records deleted before %s", total, before.Format(time.RFC3339))
+	return total, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models"
+	"vet-clinic/test"
+)
+
+func TestPurgeExpired_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	visit := &models.Visit{}
+	_, _ = visit.Delete(rep, 1)
+	pet := &models.Pet{}
+	_, _ = pet.Delete(rep, 1)
+	client := &models.Client{}
+	_, _ = client.Delete(rep, 1)
+
+	s := NewRetentionService(cont)
+	purged, err := s.PurgeExpired(context.Background(), time.Now().Add(time.Minute))
+
+	clients, _ := client.GetAllWithDeleted(rep)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.Empty(t, clients)
+}
+
+func TestPurgeExpired_NotExpired(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	visit := &models.Visit{}
+	_, _ = visit.Delete(rep, 1)
+
+	s := NewRetentionService(cont)
+	purged, err := s.PurgeExpired(context.Background(), time.Now().Add(-time.Hour))
+
+	visits, _ := visit.GetAllWithDeleted(rep)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), purged)
+	assert.Len(t, visits, 1)
+}
+
+func TestPurgeExpired_Referenced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	client := &models.Client{}
+	_, _ = client.Delete(rep, 1)
+
+	s := NewRetentionService(cont)
+	purged, err := s.PurgeExpired(context.Background(), time.Now().Add(time.Minute))
+
+	clients, _ := client.GetAllWithDeleted(rep)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), purged)
+	assert.Len(t, clients, 1)
+}
+
+func TestPurgeExpired_KeepsWeightOfVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+	rep := cont.Repository()
+
+	weight := &models.PetWeight{VisitID: 1, Weight: 4.2}
+	_, _ = weight.Record(rep, 1, time.Now())
+	visit := &models.Visit{}
+	_, _ = visit.Delete(rep, 1)
+
+	s := NewRetentionService(cont)
+	purged, err := s.PurgeExpired(context.Background(), time.Now().Add(time.Minute))
+
+	weights, _ := weight.GetAllByPet(rep, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), purged)
+	if assert.Len(t, weights, 1) {
+		assert.Equal(t, uint(0), weights[0].VisitID)
+	}
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,81 +20,96 @@ func NewRoleService(container container.Container) *RoleService {
 }
 
 // Get returns role full matched given role ID.
-func (s *RoleService) Get(id string) (*models.Role, error) {
+func (s *RoleService) Get(ctx context.Context, id string) (*models.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch role ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch role ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	role := &models.Role{}
 	var err error
 
 	if role, err = role.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch role with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch role with ID %s: %v", id, err)
 		return nil, err
 	}
 	return role, nil
 }
 
 // GetAll returns a slice of all roles.
-func (s *RoleService) GetAll() ([]*models.Role, error) {
-	rep := s.container.Repository()
+func (s *RoleService) GetAll(ctx context.Context) ([]*models.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Role{}
 	var roles []*models.Role
 	var err error
 
 	if roles, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch roles: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch roles: %v", err)
 		return nil, err
 	}
 	return roles, nil
 }
 
 // Create persists this role data.
-func (s *RoleService) Create(dto *dto.RoleDto) (*models.Role, error) {
-	rep := s.container.Repository()
+func (s *RoleService) Create(ctx context.Context, dto *dto.RoleDto) (*models.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	role := dto.ToModel()
 	var err error
 
 	if role, err = role.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create role: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create role: %v", err)
 		return nil, err
 	}
 	return role, nil
 }
 
 // Update updates this role data.
-func (s *RoleService) Update(dto *dto.RoleDto, id string) (*models.Role, error) {
+func (s *RoleService) Update(ctx context.Context, dto *dto.RoleDto, id string) (*models.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch role ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch role ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	role := dto.ToModel()
 	var err error
 
 	if role, err = role.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update role with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update role with ID %s: %v", id, err)
 		return nil, err
 	}
 	return role, nil
 }
 
 // Delete deletes this role data.
-func (s *RoleService) Delete(id string) (*models.Role, error) {
+func (s *RoleService) Delete(ctx context.Context, id string) (*models.Role, error) {
+	ctx, span := tracing.Start(ctx, "RoleService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch role ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch role ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	role := &models.Role{}
 	var err error
 
 	if role, err = role.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete role: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete role: %v", err)
 		return nil, err
 	}
 	return role, nil
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"vet-clinic/models"
@@ -12,7 +13,7 @@ func TestFindRoleByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -23,7 +24,7 @@ func TestFindRoleByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -33,7 +34,7 @@ func TestFindRoleByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -43,7 +44,7 @@ func TestFindAllRoles_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background())
 
 	assert.Len(t, result, 4)
 	assert.NoError(t, err)
@@ -53,11 +54,11 @@ func TestCreateRole_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	_, err := s.Create(createRoleForCreate())
+	_, err := s.Create(context.Background(), createRoleForCreate())
 	result := createResultRole()
 	result.ID = 5
 
-	data, _ := s.Get("5")
+	data, _ := s.Get(context.Background(), "5")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -68,11 +69,11 @@ func TestUpdateRole_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	_, err := s.Update(createRoleForCreate(), "1")
+	_, err := s.Update(context.Background(), createRoleForCreate(), "1")
 	result := createResultRole()
 	result.ID = 1
 
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -83,7 +84,7 @@ func TestUpdateRole_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.Update(createRoleForCreate(), "99")
+	result, err := s.Update(context.Background(), createRoleForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -93,9 +94,9 @@ func TestDeleteRole_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -105,7 +106,7 @@ func TestDeleteRole_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewRoleService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/tracing"
+)
+
+const (
+	// searchMinQueryLength is the shortest query in characters, shorter ones match too many records.
+	searchMinQueryLength = 2
+	// searchDefaultLimit is the number of the results of each type returned by default.
+	searchDefaultLimit = 10
+	// searchMaxLimit is the largest number of the results of each type.
+	searchMaxLimit = 50
+)
+
+// searchTypes are the types of the search results in the order they are returned.
+var searchTypes = []string{models.SearchClient, models.SearchPet, models.SearchUser, models.SearchLead}
+
+// SearchService searches clients, pets, users and leads.
+type SearchService struct {
+	container container.Container
+}
+
+// NewSearchService is constructor.
+func NewSearchService(container container.Container) *SearchService {
+	return &SearchService{container: container}
+}
+
+// Search returns the records whose names, phones or e-mails start with the query, case-insensitively.
+// The types is a comma-separated list of the result types, empty for all types.
+// The limit is the largest number of the results of each type, empty for the default.
+func (s *SearchService) Search(ctx context.Context, query, types, limit string) ([]*models.SearchResult, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer span.End()
+
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < searchMinQueryLength {
+		return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest,
+			fmt.Sprintf("the query must be at least %d characters long", searchMinQueryLength))
+	}
+	selected, err := parseSearchTypes(types)
+	if err != nil {
+		return nil, err
+	}
+	max := searchDefaultLimit
+	if limit != "" {
+		if max, err = strconv.Atoi(limit); err != nil || max < 1 || max > searchMaxLimit {
+			return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid limit: %s", limit))
+		}
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	results := []*models.SearchResult{}
+	for _, t := range searchTypes {
+		if !selected[t] {
+			continue
+		}
+		switch t {
+		case models.SearchClient:
+			clients, err := (&models.Client{}).Search(rep, query, max)
+			if err != nil {
+				s.container.Logger().WithContext(ctx).Errorf("Failed to search clients: %v", err)
+				return nil, err
+			}
+			for _, client := range clients {
+				results = append(results, client.ToSearchResult())
+			}
+		case models.SearchPet:
+			pets, err := (&models.Pet{}).Search(rep, query, max)
+			if err != nil {
+				s.container.Logger().WithContext(ctx).Errorf("Failed to search pets: %v", err)
+				return nil, err
+			}
+			for _, pet := range pets {
+				results = append(results, pet.ToSearchResult())
+			}
+		case models.SearchUser:
+			users, err := (&models.User{}).Search(rep, query, max)
+			if err != nil {
+				s.container.Logger().WithContext(ctx).Errorf("Failed to search users: %v", err)
+				return nil, err
+			}
+			for _, user := range users {
+				results = append(results, user.ToSearchResult())
+			}
+		case models.SearchLead:
+			leads, err := (&models.Lead{}).Search(rep, query, max)
+			if err != nil {
+				s.container.Logger().WithContext(ctx).Errorf("Failed to search leads: %v", err)
+				return nil, err
+			}
+			for _, lead := range leads {
+				results = append(results, lead.ToSearchResult())
+			}
+		}
+	}
+	return results, nil
+}
+
+// parseSearchTypes returns the set of the result types in the comma-separated list, all types if it is empty.
+func parseSearchTypes(types string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	if types == "" {
+		for _, t := range searchTypes {
+			selected[t] = true
+		}
+		return selected, nil
+	}
+	for _, t := range strings.Split(types, ",") {
+		t = strings.TrimSpace(t)
+		valid := false
+		for _, known := range searchTypes {
+			valid = valid || t == known
+		}
+		if !valid {
+			return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid type: %s", t))
+		}
+		selected[t] = true
+	}
+	return selected, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"vet-clinic/models"
+	"vet-clinic/test"
+)
+
+func TestSearch_CyrillicCaseInsensitive(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	for _, query := range []string{"Китти", "китти", "КИТ", "дворн"} {
+		results, err := s.Search(context.Background(), query, "", "")
+
+		assert.Empty(t, err)
+		assert.Equal(t, []*models.SearchResult{{Type: models.SearchPet, ID: 1, Title: "Китти",
+			Subtitle: "Кошка, Дворняга, Фамилия Имя"}}, results)
+	}
+}
+
+func TestSearch_Prefix(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	results, err := s.Search(context.Background(), "итти", "", "")
+
+	assert.Empty(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSearch_Phone(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	for _, query := range []string{"+7888", "7 (888) 888-88", "78888"} {
+		results, err := s.Search(context.Background(), query, "", "")
+
+		assert.Empty(t, err)
+		assert.Equal(t, []*models.SearchResult{{Type: models.SearchClient, ID: 1, Title: "Фамилия Имя Отчество",
+			Subtitle: "+78888888888, mail@mail.su"}}, results)
+	}
+}
+
+func TestSearch_AllTypes(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	results, err := s.Search(context.Background(), "фамилия имя", "", "")
+
+	assert.Empty(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, models.SearchClient, results[0].Type)
+	assert.Equal(t, models.SearchUser, results[1].Type)
+
+	results, err = s.Search(context.Background(), "алекс", "", "")
+
+	assert.Empty(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, models.SearchLead, results[0].Type)
+}
+
+func TestSearch_TypesAndLimit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	results, err := s.Search(context.Background(), "фамилия", "user", "")
+
+	assert.Empty(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, models.SearchUser, results[0].Type)
+
+	pet := &models.Pet{Name: "Китти Вторая", ClientID: 1}
+	_, _ = pet.Create(cont.Repository())
+	results, err = s.Search(context.Background(), "кит", "pet", "1")
+
+	assert.Empty(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestSearch_Wildcards(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	results, err := s.Search(context.Background(), "%_", "", "")
+
+	assert.Empty(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSearch_InvalidParams(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSearchService(cont)
+	_, err := s.Search(context.Background(), " к ", "", "")
+	assert.Equal(t, "the query must be at least 2 characters long", err.Error())
+
+	_, err = s.Search(context.Background(), "кит", "pet,visit", "")
+	assert.Equal(t, "invalid type: visit", err.Error())
+
+	_, err = s.Search(context.Background(), "кит", "", "100")
+	assert.Equal(t, "invalid limit: 100", err.Error())
+}
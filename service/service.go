@@ -1,10 +1,15 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,45 +23,70 @@ func NewServiceService(container container.Container) *ServiceService {
 }
 
 // Get returns service full matched given service ID or service slug.
-func (s *ServiceService) Get(id string) (*models.Service, error) {
+func (s *ServiceService) Get(ctx context.Context, id string) (*models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	service := &models.Service{}
 	var err error
 
 	if service, err = service.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch service with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service with ID %s: %v", id, err)
 		return nil, err
 	}
 	return service, nil
 }
 
-// GetAll returns a slice of all services.
-func (s *ServiceService) GetAll() ([]*models.Service, error) {
-	rep := s.container.Repository()
+// GetAll returns a slice of the services filtered by the category along with its subcategories,
+// the department and the archived flag. Empty filters mean any.
+func (s *ServiceService) GetAll(ctx context.Context, categoryID, departmentID, archived string) ([]*models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.GetAll")
+	defer span.End()
+
+	for _, id := range []string{categoryID, departmentID} {
+		if id != "" && !util.IsNumeric(id) {
+			s.container.Logger().WithContext(ctx).Errorf("Failed to fetch ID: %s", id)
+			return nil, apperror.NewInvalidID(id)
+		}
+	}
+	var archivedFilter *bool
+	if archived != "" {
+		value, err := strconv.ParseBool(archived)
+		if err != nil {
+			return nil, apperror.Wrap(err, http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid archived flag: %s", archived))
+		}
+		archivedFilter = &value
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Service{}
 	var services []*models.Service
 	var err error
 
-	if services, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch services: %v", err)
+	if services, err = model.GetAll(rep, util.ConvertToUint(categoryID), util.ConvertToUint(departmentID), archivedFilter); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch services: %v", err)
 		return nil, err
 	}
 	return services, nil
 }
 
 // Create persists this service data.
-func (s *ServiceService) Create(dto *dto.ServiceDto) (*models.Service, error) {
-	rep := s.container.Repository()
+func (s *ServiceService) Create(ctx context.Context, dto *dto.ServiceDto) (*models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	service := dto.ToModel()
 	var err error
 
 	if service, err = service.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create service: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create service: %v", err)
 		return nil, err
 	}
 
@@ -64,37 +94,133 @@ func (s *ServiceService) Create(dto *dto.ServiceDto) (*models.Service, error) {
 }
 
 // Update updates this service data.
-func (s *ServiceService) Update(dto *dto.ServiceDto, id string) (*models.Service, error) {
+func (s *ServiceService) Update(ctx context.Context, dto *dto.ServiceDto, id string) (*models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch service ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	service := dto.ToModel()
 	var err error
 
 	if service, err = service.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update service with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update service with ID %s: %v", id, err)
 		return nil, err
 	}
 	return service, nil
 }
 
 // Delete deletes this service data.
-func (s *ServiceService) Delete(id string) (*models.Service, error) {
+func (s *ServiceService) Delete(ctx context.Context, id string) (*models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch service ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	service := &models.Service{}
 	var err error
 
 	if service, err = service.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete service: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete service: %v", err)
 		return nil, err
 	}
 	return service, nil
 }
+
+// GetConsumables returns the consumables of the service.
+func (s *ServiceService) GetConsumables(ctx context.Context, id string) ([]*models.ServiceConsumable, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.GetConsumables")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServiceConsumable{}
+	var consumables []*models.ServiceConsumable
+	var err error
+
+	if consumables, err = model.GetAllByService(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch consumables of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return consumables, nil
+}
+
+// SetConsumables replaces the consumables of the service.
+func (s *ServiceService) SetConsumables(ctx context.Context, dto *dto.ServiceConsumablesDto,
+	id string) ([]*models.ServiceConsumable, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.SetConsumables")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServiceConsumable{}
+	var consumables []*models.ServiceConsumable
+	var err error
+
+	if consumables, err = model.SetForService(rep, util.ConvertToUint(id), dto.ToModel()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to set consumables of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return consumables, nil
+}
+
+// GetResources returns the resources required by the service.
+func (s *ServiceService) GetResources(ctx context.Context, id string) ([]*models.ServiceResource, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.GetResources")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServiceResource{}
+	var resources []*models.ServiceResource
+	var err error
+
+	if resources, err = model.GetAllByService(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch resources of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return resources, nil
+}
+
+// SetResources replaces the resources required by the service.
+func (s *ServiceService) SetResources(ctx context.Context, dto *dto.ServiceResourcesDto,
+	id string) ([]*models.ServiceResource, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.SetResources")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServiceResource{}
+	var resources []*models.ServiceResource
+	var err error
+
+	if resources, err = model.SetForService(rep, util.ConvertToUint(id), dto.ResourceIDs); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to set resources of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return resources, nil
+}
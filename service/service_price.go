@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+// PriceDateFormat is the format of the date the prices are resolved for.
+const PriceDateFormat = "2006-01-02"
+
+// ServicePriceService manages the price history of the services and the price list.
+type ServicePriceService struct {
+	container container.Container
+}
+
+// NewServicePriceService is constructor.
+func NewServicePriceService(container container.Container) *ServicePriceService {
+	return &ServicePriceService{container: container}
+}
+
+// Get returns the service price matched given ID.
+func (s *ServicePriceService) Get(ctx context.Context, id string) (*models.ServicePrice, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service price ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	price := &models.ServicePrice{}
+	var err error
+
+	if price, err = price.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service price with ID %s: %v", id, err)
+		return nil, err
+	}
+	return price, nil
+}
+
+// GetAllByService returns the price history of the service.
+func (s *ServicePriceService) GetAllByService(ctx context.Context, id string) ([]*models.ServicePrice, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.GetAllByService")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServicePrice{}
+	var prices []*models.ServicePrice
+	var err error
+
+	if prices, err = model.GetAllByService(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch prices of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return prices, nil
+}
+
+// Create adds the price of the service effective from the given date.
+func (s *ServicePriceService) Create(ctx context.Context, dto *dto.ServicePriceDto, id string) (*models.ServicePrice, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.Create")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	price := dto.ToModel()
+	var err error
+
+	if price, err = price.Create(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create price of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return price, nil
+}
+
+// Delete deletes the price which is not yet effective.
+func (s *ServicePriceService) Delete(ctx context.Context, id string) (*models.ServicePrice, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service price ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	price := &models.ServicePrice{}
+	var err error
+
+	if price, err = price.Delete(rep, util.ConvertToUint(id), time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete service price with ID %s: %v", id, err)
+		return nil, err
+	}
+	return price, nil
+}
+
+// Resolve returns the price of the service in the department as of the given date, today by default.
+// The department ID is optional, the base price is returned without it.
+func (s *ServicePriceService) Resolve(ctx context.Context, id, departmentID, date string) (*models.ResolvedPrice, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.Resolve")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch service ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+	if departmentID != "" && !util.IsNumeric(departmentID) {
+		return nil, apperror.NewInvalidID(departmentID)
+	}
+	day, err := parsePriceDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServicePrice{}
+	var price *models.ResolvedPrice
+
+	if price, err = model.Resolve(rep, util.ConvertToUint(id), util.ConvertToUint(departmentID), day); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to resolve price of service with ID %s: %v", id, err)
+		return nil, err
+	}
+	return price, nil
+}
+
+// GetPriceList returns the prices of the services as of the given date, today by default.
+// The category and the department IDs are optional filters.
+func (s *ServicePriceService) GetPriceList(ctx context.Context, categoryID, departmentID, date string) ([]*models.PriceListItem, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.GetPriceList")
+	defer span.End()
+
+	for _, id := range []string{categoryID, departmentID} {
+		if id != "" && !util.IsNumeric(id) {
+			return nil, apperror.NewInvalidID(id)
+		}
+	}
+	day, err := parsePriceDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.ServicePrice{}
+	var items []*models.PriceListItem
+
+	if items, err = model.GetPriceList(rep, util.ConvertToUint(categoryID), util.ConvertToUint(departmentID), day); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch price list: %v", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExportPriceList returns the price list as a CSV document.
+func (s *ServicePriceService) ExportPriceList(ctx context.Context, categoryID, departmentID, date string) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "ServicePriceService.ExportPriceList")
+	defer span.End()
+
+	items, err := s.GetPriceList(ctx, categoryID, departmentID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"Категория", "Услуга", "Цена"})
+	for _, item := range items {
+		_ = w.Write([]string{item.Category, item.ServiceName, strconv.FormatFloat(item.Price, 'f', 2, 64)})
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to export price list: %v", err)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parsePriceDate(date string) (time.Time, error) {
+	if date == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	day, err := time.ParseInLocation(PriceDateFormat, date, time.Local)
+	if err != nil {
+		return time.Time{}, apperror.Wrap(err, http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid date: %s", date))
+	}
+	return day, nil
+}
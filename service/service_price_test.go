@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestResolvePrice_Department(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+
+	result, err := s.Resolve(context.Background(), "1", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1000), result.Price)
+
+	result, err = s.Resolve(context.Background(), "1", "2", "")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1500), result.Price)
+
+	result, err = s.Resolve(context.Background(), "1", "2", "2023-12-31")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1000), result.Price)
+}
+
+func TestResolvePrice_InvalidDate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	result, err := s.Resolve(context.Background(), "1", "", "31.12.2023")
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid date: 31.12.2023")
+}
+
+func TestCreateServicePrice_Scheduled(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	_, err := s.Create(context.Background(), &dto.ServicePriceDto{Price: 3000, EffectiveFrom: tomorrow}, "5")
+
+	assert.NoError(t, err)
+
+	result, _ := s.Resolve(context.Background(), "5", "", "")
+	assert.Equal(t, float64(2500), result.Price)
+
+	result, _ = s.Resolve(context.Background(), "5", "", tomorrow.Format(PriceDateFormat))
+	assert.Equal(t, float64(3000), result.Price)
+}
+
+func TestCreateServicePrice_Duplicate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	result, err := s.Create(context.Background(), &dto.ServicePriceDto{DepartmentID: 2, Price: 1700,
+		EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)}, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a price effective from the same date already exists for the service and department", err.Error())
+}
+
+func TestDeleteServicePrice_Effective(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	prices, _ := s.GetAllByService(context.Background(), "1")
+	result, err := s.Delete(context.Background(), fmt.Sprint(prices[0].ID))
+
+	assert.Nil(t, result)
+	assert.Equal(t, "only the prices which are not yet effective can be deleted", err.Error())
+}
+
+func TestUpdateService_RecordsPrice(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	services := NewServiceService(cont)
+	_, err := services.Update(context.Background(), &dto.ServiceDto{Name: "Вакцинация", Price: 2700, CategoryID: 2}, "5")
+	assert.NoError(t, err)
+
+	s := NewServicePriceService(cont)
+	result, _ := s.Resolve(context.Background(), "5", "", "")
+	assert.Equal(t, float64(2700), result.Price)
+
+	prices, _ := s.GetAllByService(context.Background(), "5")
+	assert.Len(t, prices, 1)
+}
+
+func TestGetVisit_PriceAsOfVisitDate(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	_, _ = s.Create(context.Background(), &dto.ServicePriceDto{Price: 2000,
+		EffectiveFrom: time.Date(2023, time.June, 1, 0, 0, 0, 0, time.Local)}, "5")
+
+	visit, err := NewVisitService(cont).Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2000), visit.Price)
+	assert.Equal(t, float64(2500), visit.Service.Price)
+}
+
+func TestGetPriceList_Category(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	result, err := s.GetPriceList(context.Background(), "2", "", "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, "Вакцинация", result[0].ServiceName)
+		assert.Equal(t, "Процедуры", result[0].Category)
+		assert.Equal(t, float64(2500), result[0].Price)
+	}
+}
+
+func TestGetPriceList_Department(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	result, err := s.GetPriceList(context.Background(), "", "2", "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 4) {
+		assert.Equal(t, "Консультация", result[3].ServiceName)
+		assert.Equal(t, float64(1500), result[3].Price)
+	}
+}
+
+func TestExportPriceList_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServicePriceService(cont)
+	result, err := s.ExportPriceList(context.Background(), "1", "", "")
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(result)), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "Категория,Услуга,Цена", lines[0])
+		assert.Equal(t, "Консультация,Консультация,1000.00", lines[1])
+	}
+}
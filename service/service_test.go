@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"vet-clinic/models/dto"
@@ -11,7 +12,7 @@ func TestFindServiceByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -22,7 +23,7 @@ func TestFindServiceByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -32,7 +33,7 @@ func TestFindServiceByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -42,7 +43,7 @@ func TestFindAllServices_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background(), "", "", "")
 
 	assert.Len(t, result, 8)
 	assert.NoError(t, err)
@@ -53,9 +54,9 @@ func TestCreateService_Success(t *testing.T) {
 
 	s := NewServiceService(cont)
 	serviceDto := createServiceForCreate()
-	_, err := s.Create(serviceDto)
+	_, err := s.Create(context.Background(), serviceDto)
 
-	result, _ := s.Get("9")
+	result, _ := s.Get(context.Background(), "9")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -69,9 +70,9 @@ func TestUpdateService_Success(t *testing.T) {
 
 	s := NewServiceService(cont)
 	serviceDto := createServiceForCreate()
-	_, err := s.Update(serviceDto, "1")
+	_, err := s.Update(context.Background(), serviceDto, "1")
 
-	result, _ := s.Get("1")
+	result, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -84,7 +85,7 @@ func TestUpdateService_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.Update(createServiceForCreate(), "99")
+	result, err := s.Update(context.Background(), createServiceForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -94,9 +95,9 @@ func TestDeleteService_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -106,7 +107,7 @@ func TestDeleteService_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewServiceService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -119,3 +120,103 @@ func createServiceForCreate() *dto.ServiceDto {
 		CategoryID: 2,
 	}
 }
+
+func TestSetConsumables_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	data := &dto.ServiceConsumablesDto{Consumables: []*dto.ServiceConsumableDto{{ProductID: 2, Quantity: 3}}}
+	_, err := s.SetConsumables(context.Background(), data, "5")
+
+	assert.NoError(t, err)
+
+	result, _ := s.GetConsumables(context.Background(), "5")
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Шприц 2 мл", result[0].Product.Name)
+		assert.Equal(t, float64(3), result[0].Quantity)
+	}
+}
+
+func TestSetConsumables_DuplicateProduct(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	data := &dto.ServiceConsumablesDto{Consumables: []*dto.ServiceConsumableDto{
+		{ProductID: 2, Quantity: 1}, {ProductID: 2, Quantity: 2}}}
+	result, err := s.SetConsumables(context.Background(), data, "5")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the product is listed more than once", err.Error())
+}
+
+func TestSetResources_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	_, err := s.SetResources(context.Background(), &dto.ServiceResourcesDto{ResourceIDs: []uint{2, 3}}, "7")
+
+	assert.NoError(t, err)
+
+	result, _ := s.GetResources(context.Background(), "7")
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "Кабинет УЗИ", result[0].Resource.Name)
+		assert.Equal(t, "Холтеровский монитор", result[1].Resource.Name)
+	}
+}
+
+func TestSetResources_InvalidResource(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	result, err := s.SetResources(context.Background(), &dto.ServiceResourcesDto{ResourceIDs: []uint{9}}, "7")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "resourceIds refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestFindAllServices_CategorySubtree(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	category, _ := NewCategoryService(cont).Create(context.Background(),
+		&dto.CategoryDto{Name: "Ультразвуковая диагностика", ParentID: 4})
+	data := createServiceForCreate()
+	data.CategoryID = category.ID
+	_, _ = NewServiceService(cont).Create(context.Background(), data)
+
+	s := NewServiceService(cont)
+	result, err := s.GetAll(context.Background(), "4", "", "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, "ЭхоКГ скрининг", result[0].Name)
+		assert.Equal(t, "Общий анализ крови", result[2].Name)
+	}
+}
+
+func TestFindAllServices_DepartmentAndArchived(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	data := &dto.ServiceDto{Name: "ЭхоКГ скрининг", Price: 3500, Archived: true, CategoryID: 4}
+	_, _ = s.Update(context.Background(), data, "7")
+
+	result, err := s.GetAll(context.Background(), "", "2", "false")
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+
+	result, err = s.GetAll(context.Background(), "", "", "true")
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, uint(7), result[0].ID)
+	}
+}
+
+func TestFindAllServices_InvalidArchivedFlag(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewServiceService(cont)
+	result, err := s.GetAll(context.Background(), "", "", "maybe")
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid archived flag: maybe")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type SpeciesService struct {
+	container container.Container
+}
+
+// NewSpeciesService is constructor.
+func NewSpeciesService(container container.Container) *SpeciesService {
+	return &SpeciesService{container: container}
+}
+
+// Get returns species full matched given species ID.
+func (s *SpeciesService) Get(ctx context.Context, id string) (*models.Species, error) {
+	ctx, span := tracing.Start(ctx, "SpeciesService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	species := &models.Species{}
+	var err error
+
+	if species, err = species.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species with ID %s: %v", id, err)
+		return nil, err
+	}
+	return species, nil
+}
+
+// GetAll returns a slice of all species.
+func (s *SpeciesService) GetAll(ctx context.Context) ([]*models.Species, error) {
+	ctx, span := tracing.Start(ctx, "SpeciesService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.Species{}
+	var species []*models.Species
+	var err error
+
+	if species, err = model.GetAll(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species: %v", err)
+		return nil, err
+	}
+	return species, nil
+}
+
+// Create persists this species data.
+func (s *SpeciesService) Create(ctx context.Context, dto *dto.SpeciesDto) (*models.Species, error) {
+	ctx, span := tracing.Start(ctx, "SpeciesService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	species := &models.Species{}
+	var err error
+
+	if species, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create species: %v", err)
+		return nil, err
+	}
+	return species, nil
+}
+
+// Update updates this species data.
+func (s *SpeciesService) Update(ctx context.Context, dto *dto.SpeciesDto, id string) (*models.Species, error) {
+	ctx, span := tracing.Start(ctx, "SpeciesService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	species := &models.Species{}
+	var err error
+
+	if species, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update species with ID %s: %v", id, err)
+		return nil, err
+	}
+	return species, nil
+}
+
+// Delete deletes this species data.
+func (s *SpeciesService) Delete(ctx context.Context, id string) (*models.Species, error) {
+	ctx, span := tracing.Start(ctx, "SpeciesService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch species ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	species := &models.Species{}
+	var err error
+
+	if species, err = species.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete species: %v", err)
+		return nil, err
+	}
+	return species, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestFindSpeciesByID_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Кошка", result.Name)
+}
+
+func TestFindAllSpecies_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.GetAll(context.Background())
+
+	assert.Len(t, result, 7)
+	assert.NoError(t, err)
+}
+
+func TestCreateSpecies_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.Create(context.Background(), &dto.SpeciesDto{Name: "Черепаха", Aliases: "turtle"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Черепаха", result.Name)
+	assert.Equal(t, "turtle", result.Aliases)
+}
+
+func TestCreateSpecies_DuplicateAlias(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.Create(context.Background(), &dto.SpeciesDto{Name: "Котик", Aliases: "Cat"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "a species with the same name or alias already exists", err.Error())
+}
+
+func TestUpdateSpecies_RenamesPets(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	_, err := s.Update(context.Background(), &dto.SpeciesDto{Name: "Кот", Aliases: "кошка,cat"}, "1")
+	pet, _ := NewPetService(cont).Get(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Кот", pet.Type)
+}
+
+func TestDeleteSpecies_Referenced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.Delete(context.Background(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
+
+func TestDeleteSpecies_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewSpeciesService(cont)
+	result, err := s.Delete(context.Background(), "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Рептилия", result.Name)
+}
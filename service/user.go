@@ -1,11 +1,16 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"github.com/gosimple/slug"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -19,51 +24,65 @@ func NewUserService(container container.Container) *UserService {
 }
 
 // Get returns user full matched given user ID or user slug.
-func (s *UserService) Get(param string) (*models.User, error) {
-	rep := s.container.Repository()
+func (s *UserService) Get(ctx context.Context, param string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Get")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	user := &models.User{}
 	var err error
 
 	if util.IsNumeric(param) {
 		if user, err = user.Get(rep, util.ConvertToUint(param)); err != nil {
-			s.container.Logger().Debugf("Failed to fetch user with ID %s: %v", param, err)
+			s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user with ID %s: %v", param, err)
 			return nil, err
 		}
 		return user, nil
 	}
 	if slug.IsSlug(param) {
 		if user, err = user.GetBySlug(rep, param); err != nil {
-			s.container.Logger().Debugf("Failed to fetch user with slug %s: %v", param, err)
+			s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user with slug %s: %v", param, err)
 			return nil, err
 		}
 		return user, nil
 	}
-	s.container.Logger().Debugf("Failed to fetch user with: %s", param)
-	return nil, errors.New("failed to fetch data")
+	s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user with: %s", param)
+	return nil, apperror.NewInvalidID(param)
 }
 
-// GetAll returns a slice of all users.
-func (s *UserService) GetAll() ([]*models.User, error) {
-	rep := s.container.Repository()
+// GetAll returns a slice of all users. If includeDeleted is true, the soft-deleted users are included.
+func (s *UserService) GetAll(ctx context.Context, includeDeleted bool) ([]*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.User{}
 	var users []*models.User
 	var err error
 
-	if users, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Debugf("Failed to fetch users: %v", err)
+	if includeDeleted {
+		users, err = model.GetAllWithDeleted(rep)
+	} else {
+		users, err = model.GetAll(rep)
+	}
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch users: %v", err)
 		return nil, err
 	}
 	return users, nil
 }
 
 // Create persists this user data.
-func (s *UserService) Create(dto *dto.UserCreateDto) (*models.User, error) {
-	rep := s.container.Repository()
+func (s *UserService) Create(ctx context.Context, dto *dto.UserCreateDto) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	user := dto.ToModel()
 	var err error
 
 	if user, err = user.Create(rep); err != nil {
-		s.container.Logger().Debugf("Failed to create user: %v", err)
+		s.container.Logger().WithContext(ctx).Debugf("Failed to create user: %v", err)
 		return nil, err
 	}
 
@@ -71,68 +90,198 @@ func (s *UserService) Create(dto *dto.UserCreateDto) (*models.User, error) {
 }
 
 // Update updates this user data.
-func (s *UserService) Update(dto *dto.UserUpdateDto, id string, owner bool) (*models.User, error) {
+func (s *UserService) Update(ctx context.Context, dto *dto.UserUpdateDto, id string, owner bool) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Debugf("Failed to fetch user ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	user := dto.ToModel(owner)
 	var err error
 
 	if user, err = user.Update(rep, util.ConvertToUint(id), owner); err != nil {
-		s.container.Logger().Debugf("Failed to update user with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Debugf("Failed to update user with ID %s: %v", id, err)
 		return nil, err
 	}
 	return user, nil
 }
 
+// Patch applies the JSON Merge Patch to this user data.
+// The fields which are absent in the patch keep their current values.
+// If the owner value is true, then fields are included that can only be changed by a user with the owner role.
+func (s *UserService) Patch(ctx context.Context, patch []byte, id string, owner bool) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Patch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	user := &models.User{}
+	var err error
+
+	if user, err = user.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user with ID %s: %v", id, err)
+		return nil, err
+	}
+
+	data := dto.NewUserUpdateDto(user)
+	if _, err = util.ApplyMergePatch(data, patch); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to patch user with ID %s: %v", id, err)
+		return nil, err
+	}
+	return s.Update(ctx, data, id, owner)
+}
+
 // UpdatePassword updates this user data.
-func (s *UserService) UpdatePassword(dto *dto.UpdatePasswordDto, id string) error {
+func (s *UserService) UpdatePassword(ctx context.Context, dto *dto.UpdatePasswordDto, id string) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdatePassword")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Debugf("Failed to fetch user ID: %s", id)
-		return errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	user := &models.User{}
 
 	if err := user.UpdatePassword(rep, util.ConvertToUint(id), dto.OldPassword, dto.NewPassword); err != nil {
-		s.container.Logger().Debugf("Failed to update user with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Debugf("Failed to update user with ID %s: %v", id, err)
 		return err
 	}
 	return nil
 }
 
 // Delete deletes this user data.
-func (s *UserService) Delete(id string) (*models.User, error) {
+func (s *UserService) Delete(ctx context.Context, id string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Debugf("Failed to fetch user ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	user := &models.User{}
 	var err error
 
 	if user, err = user.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Debugf("Failed to delete user: %v", err)
+		s.container.Logger().WithContext(ctx).Debugf("Failed to delete user: %v", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// Restore restores this soft-deleted user data.
+func (s *UserService) Restore(ctx context.Context, id string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Restore")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	user := &models.User{}
+	var err error
+
+	if user, err = user.Restore(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to restore user with ID %s: %v", id, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// Purge permanently deletes this user data.
+func (s *UserService) Purge(ctx context.Context, id string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Purge")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	user := &models.User{}
+	var err error
+
+	if user, err = user.Purge(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Debugf("Failed to purge user with ID %s: %v", id, err)
 		return nil, err
 	}
 	return user, nil
 }
 
 // Login authenticates by using login DTO.
-func (s *UserService) Login(dto *dto.LoginDto) (*models.User, error) {
-	rep := s.container.Repository()
+func (s *UserService) Login(ctx context.Context, dto *dto.LoginDto) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	user := &models.User{}
 	var err error
 
 	if user, err = user.Login(rep, dto.Login, dto.Password); err != nil {
-		s.container.Logger().Debugf("Failed to login: %v", err)
+		s.container.Logger().WithContext(ctx).Debugf("Failed to login: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, apperror.NewInvalidCredentials(err)
+		}
 		return nil, err
 	}
 
 	return user, nil
 }
+
+// GetWorkingHours returns the working hours of the user.
+func (s *UserService) GetWorkingHours(ctx context.Context, id string) ([]*models.WorkingHours, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetWorkingHours")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.WorkingHours{}
+	var hours []*models.WorkingHours
+	var err error
+
+	if hours, err = model.GetAllByUser(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch working hours of user with ID %s: %v", id, err)
+		return nil, err
+	}
+	return hours, nil
+}
+
+// SetWorkingHours replaces the working hours of the user.
+func (s *UserService) SetWorkingHours(ctx context.Context, dto *dto.WorkingScheduleDto, id string) ([]*models.WorkingHours, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetWorkingHours")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch user ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.WorkingHours{}
+	var hours []*models.WorkingHours
+	var err error
+
+	if hours, err = model.SetForUser(rep, util.ConvertToUint(id), dto.ToModel()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to set working hours of user with ID %s: %v", id, err)
+		return nil, err
+	}
+	return hours, nil
+}
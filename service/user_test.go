@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"github.com/gosimple/slug"
 	"github.com/stretchr/testify/assert"
@@ -20,7 +21,7 @@ func TestFindUserByID_Success(t *testing.T) {
 	setUpUserTestFullData(cont, true)
 
 	s := NewUserService(cont)
-	result, err := s.Get("2")
+	result, err := s.Get(context.Background(), "2")
 
 	assert.Equal(t, uint(2), result.ID)
 	assert.NoError(t, err)
@@ -33,8 +34,8 @@ func TestFindUserBySlug_Success(t *testing.T) {
 	setUpUserTestFullData(cont, true)
 
 	s := NewUserService(cont)
-	resultByID, _ := s.Get("2")
-	resultBySlug, err := s.Get(resultByID.Slug)
+	resultByID, _ := s.Get(context.Background(), "2")
+	resultBySlug, err := s.Get(context.Background(), resultByID.Slug)
 
 	assert.Equal(t, uint(2), resultBySlug.ID)
 	assert.NoError(t, err)
@@ -45,7 +46,7 @@ func TestFindUserByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -55,7 +56,7 @@ func TestFindUserByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -67,7 +68,7 @@ func TestFindAllUsers_Success(t *testing.T) {
 	setUpUserTestData(cont)
 
 	s := NewUserService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background(), false)
 
 	assert.Len(t, result, 2)
 	assert.NoError(t, err)
@@ -78,9 +79,9 @@ func TestCreateUser_Success(t *testing.T) {
 
 	s := NewUserService(cont)
 	userDto := createUserForCreate()
-	_, err := s.Create(userDto)
+	_, err := s.Create(context.Background(), userDto)
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -94,10 +95,10 @@ func TestCreateUser_NotRole(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
-	result, err := s.Create(createUserForNotRole())
+	result, err := s.Create(context.Background(), createUserForNotRole())
 
 	assert.Nil(t, result)
-	assert.Equal(t, "record not found", err.Error())
+	assert.Equal(t, "roleId refers to a record which does not exist: record not found", err.Error())
 }
 
 func TestUpdateUser_Success(t *testing.T) {
@@ -107,9 +108,9 @@ func TestUpdateUser_Success(t *testing.T) {
 
 	s := NewUserService(cont)
 	userDto := createUserForUpdate()
-	_, err := s.Update(userDto, "2", true)
+	_, err := s.Update(context.Background(), userDto, "2", true)
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -135,9 +136,9 @@ func TestUpdateUserNotOwner_Success(t *testing.T) {
 
 	s := NewUserService(cont)
 	userDto := createUserForUpdate()
-	_, err := s.Update(userDto, "2", false)
+	_, err := s.Update(context.Background(), userDto, "2", false)
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -160,7 +161,7 @@ func TestUpdateUser_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
-	result, err := s.Update(createUserForUpdate(), "99", true)
+	result, err := s.Update(context.Background(), createUserForUpdate(), "99", true)
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -172,9 +173,9 @@ func TestDeleteUser_Success(t *testing.T) {
 	setUpUserTestData(cont)
 
 	s := NewUserService(cont)
-	data, _ := s.Get("2")
+	data, _ := s.Get(context.Background(), "2")
 
-	result, err := s.Delete("2")
+	result, err := s.Delete(context.Background(), "2")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -184,20 +185,33 @@ func TestDeleteUser_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
 }
 
+func TestPurgeUser_Referenced(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewUserService(cont)
+	_, _ = s.Create(context.Background(), createUserForCreate())
+	_, _ = NewPrescriptionService(cont).Create(context.Background(), createPrescriptionForCreate(), 2)
+	_, _ = s.Delete(context.Background(), "2")
+	result, err := s.Purge(context.Background(), "2")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "record is referenced by other records", err.Error())
+}
+
 func TestCompareHashAndPassword_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewUserService(cont)
 	userDto := createUserForCreate()
 
-	_, _ = s.Create(userDto)
-	data, _ := s.Get("2")
+	_, _ = s.Create(context.Background(), userDto)
+	data, _ := s.Get(context.Background(), "2")
 
 	err := bcrypt.CompareHashAndPassword([]byte(data.Password), []byte(userDto.Password))
 
@@ -211,9 +225,9 @@ func TestUpdatePassword_Success(t *testing.T) {
 
 	s := NewUserService(cont)
 	userDto := createUpdatePasswordDto()
-	updateErr := s.UpdatePassword(userDto, "2")
+	updateErr := s.UpdatePassword(context.Background(), userDto, "2")
 
-	data, _ := s.Get("2")
+	data, _ := s.Get(context.Background(), "2")
 	compareErr := bcrypt.CompareHashAndPassword([]byte(data.Password), []byte(userDto.NewPassword))
 
 	assert.Empty(t, updateErr)
@@ -228,9 +242,9 @@ func TestUpdatePassword_WrongPassword(t *testing.T) {
 	s := NewUserService(cont)
 	userDto := createUpdatePasswordDto()
 	userDto.OldPassword = "wrong_password"
-	err := s.UpdatePassword(userDto, "2")
+	err := s.UpdatePassword(context.Background(), userDto, "2")
 
-	assert.Equal(t, "crypto/bcrypt: hashedPassword is not the hash of the given password", err.Error())
+	assert.Equal(t, "the old password is wrong: crypto/bcrypt: hashedPassword is not the hash of the given password", err.Error())
 }
 
 func TestLoginUserByUsername_Success(t *testing.T) {
@@ -239,7 +253,7 @@ func TestLoginUserByUsername_Success(t *testing.T) {
 	setUpUserTestFullData(cont, true)
 
 	s := NewUserService(cont)
-	result, err := s.Login(createLoginDto("Test2"))
+	result, err := s.Login(context.Background(), createLoginDto("Test2"))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -251,7 +265,7 @@ func TestLoginUserByEmail_Success(t *testing.T) {
 	setUpUserTestFullData(cont, true)
 
 	s := NewUserService(cont)
-	result, err := s.Login(createLoginDto("test@test.com"))
+	result, err := s.Login(context.Background(), createLoginDto("test@test.com"))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -263,7 +277,7 @@ func TestLoginUserByPhone_Success(t *testing.T) {
 	setUpUserTestFullData(cont, true)
 
 	s := NewUserService(cont)
-	result, err := s.Login(createLoginDto("+79999999999"))
+	result, err := s.Login(context.Background(), createLoginDto("+79999999999"))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -275,10 +289,10 @@ func TestLoginUser_EntityNotFound(t *testing.T) {
 	setUpUserTestData(cont)
 
 	s := NewUserService(cont)
-	result, err := s.Login(createLoginDto("ABCD"))
+	result, err := s.Login(context.Background(), createLoginDto("ABCD"))
 
 	assert.Nil(t, result)
-	assert.Equal(t, "record not found", err.Error())
+	assert.Equal(t, "invalid login or password: record not found", err.Error())
 }
 
 func TestLoginUser_WrongPassword(t *testing.T) {
@@ -289,10 +303,10 @@ func TestLoginUser_WrongPassword(t *testing.T) {
 	s := NewUserService(cont)
 	userDto := createLoginDto("Test1")
 	userDto.Password = "pass1234"
-	result, err := s.Login(userDto)
+	result, err := s.Login(context.Background(), userDto)
 
 	assert.Nil(t, result)
-	assert.Equal(t, "crypto/bcrypt: hashedPassword is not the hash of the given password", err.Error())
+	assert.Equal(t, "invalid login or password: crypto/bcrypt: hashedPassword is not the hash of the given password", err.Error())
 }
 
 func setUpUserTestData(container container.Container) {
@@ -371,3 +385,43 @@ func makeSlugForUser(user *models.User) string {
 		return strconv.Itoa(int(user.ID))
 	}
 }
+
+func TestSetWorkingHours_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewUserService(cont)
+	data := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: 1, Start: "09:00", End: "18:00"}, {Weekday: 6, Start: "10:00", End: "14:00"}}}
+	_, err := s.SetWorkingHours(context.Background(), data, "1")
+
+	assert.NoError(t, err)
+
+	result, _ := s.GetWorkingHours(context.Background(), "1")
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, 1, result[0].Weekday)
+		assert.Equal(t, "14:00", result[1].End)
+	}
+}
+
+func TestSetWorkingHours_EndBeforeStart(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewUserService(cont)
+	data := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{{Weekday: 1, Start: "18:00", End: "09:00"}}}
+	result, err := s.SetWorkingHours(context.Background(), data, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the working hours must end after they start", err.Error())
+}
+
+func TestSetWorkingHours_DuplicateDay(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewUserService(cont)
+	data := &dto.WorkingScheduleDto{Hours: []*dto.WorkingHoursDto{
+		{Weekday: 1, Start: "09:00", End: "13:00"}, {Weekday: 1, Start: "14:00", End: "18:00"}}}
+	result, err := s.SetWorkingHours(context.Background(), data, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the day of the week is listed more than once", err.Error())
+}
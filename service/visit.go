@@ -1,10 +1,13 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"time"
+	"vet-clinic/apperror"
 	"vet-clinic/container"
 	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
 	"vet-clinic/util"
 )
 
@@ -18,45 +21,59 @@ func NewVisitService(container container.Container) *VisitService {
 }
 
 // Get returns visit full matched given visit ID or visit slug.
-func (s *VisitService) Get(id string) (*models.Visit, error) {
+func (s *VisitService) Get(ctx context.Context, id string) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Get")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch client ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch client ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	visit := &models.Visit{}
 	var err error
 
 	if visit, err = visit.Get(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to fetch visit with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit with ID %s: %v", id, err)
 		return nil, err
 	}
 	return visit, nil
 }
 
-// GetAll returns a slice of all visits.
-func (s *VisitService) GetAll() ([]*models.Visit, error) {
-	rep := s.container.Repository()
+// GetAll returns a slice of all visits. If includeDeleted is true, the soft-deleted visits are included.
+func (s *VisitService) GetAll(ctx context.Context, includeDeleted bool) ([]*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.GetAll")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	model := &models.Visit{}
 	var visits []*models.Visit
 	var err error
 
-	if visits, err = model.GetAll(rep); err != nil {
-		s.container.Logger().Errorf("Failed to fetch visits: %v", err)
+	if includeDeleted {
+		visits, err = model.GetAllWithDeleted(rep)
+	} else {
+		visits, err = model.GetAll(rep)
+	}
+	if err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visits: %v", err)
 		return nil, err
 	}
 	return visits, nil
 }
 
 // Create persists this visit data.
-func (s *VisitService) Create(dto *dto.VisitDto) (*models.Visit, error) {
-	rep := s.container.Repository()
+func (s *VisitService) Create(ctx context.Context, dto *dto.VisitDto) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
 	visit := dto.ToModel()
 	var err error
 
 	if visit, err = visit.Create(rep); err != nil {
-		s.container.Logger().Errorf("Failed to create visit: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create visit: %v", err)
 		return nil, err
 	}
 
@@ -64,36 +81,134 @@ func (s *VisitService) Create(dto *dto.VisitDto) (*models.Visit, error) {
 }
 
 // Update updates this visit data.
-func (s *VisitService) Update(dto *dto.VisitDto, id string) (*models.Visit, error) {
+func (s *VisitService) Update(ctx context.Context, dto *dto.VisitDto, id string) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Update")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch visit ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	visit := dto.ToModel()
 	var err error
 
 	if visit, err = visit.Update(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to update visit with ID %s: %v", id, err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update visit with ID %s: %v", id, err)
 		return nil, err
 	}
 	return visit, nil
 }
 
+// Patch applies the JSON Merge Patch to this visit data.
+// The fields which are absent in the patch keep their current values.
+func (s *VisitService) Patch(ctx context.Context, patch []byte, id string, updatedByID uint) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Patch")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+	var err error
+
+	if visit, err = visit.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit with ID %s: %v", id, err)
+		return nil, err
+	}
+
+	data := dto.NewVisitDto(visit)
+	if _, err = util.ApplyMergePatch(data, patch); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to patch visit with ID %s: %v", id, err)
+		return nil, err
+	}
+	data.LastUpdatedByID = updatedByID
+	return s.Update(ctx, data, id)
+}
+
 // Delete deletes this visit data.
-func (s *VisitService) Delete(id string) (*models.Visit, error) {
+func (s *VisitService) Delete(ctx context.Context, id string) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Delete")
+	defer span.End()
+
 	if !util.IsNumeric(id) {
-		s.container.Logger().Errorf("Failed to fetch visit ID: %s", id)
-		return nil, errors.New("failed to fetch data")
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
 	}
 
-	rep := s.container.Repository()
+	rep := s.container.Repository().WithContext(ctx)
 	visit := &models.Visit{}
 	var err error
 
 	if visit, err = visit.Delete(rep, util.ConvertToUint(id)); err != nil {
-		s.container.Logger().Errorf("Failed to delete visit: %v", err)
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete visit: %v", err)
+		return nil, err
+	}
+	return visit, nil
+}
+
+// Restore restores this soft-deleted visit data.
+func (s *VisitService) Restore(ctx context.Context, id string) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Restore")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+	var err error
+
+	if visit, err = visit.Restore(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to restore visit with ID %s: %v", id, err)
+		return nil, err
+	}
+	return visit, nil
+}
+
+// Purge permanently deletes this visit data.
+func (s *VisitService) Purge(ctx context.Context, id string) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Purge")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+	var err error
+
+	if visit, err = visit.Purge(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to purge visit with ID %s: %v", id, err)
+		return nil, err
+	}
+	return visit, nil
+}
+
+// Complete completes the scheduled visit and takes the consumables of its service from stock.
+func (s *VisitService) Complete(ctx context.Context, id string, updatedByID uint) (*models.Visit, error) {
+	ctx, span := tracing.Start(ctx, "VisitService.Complete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch visit ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	visit := &models.Visit{}
+	var err error
+
+	if visit, err = visit.Complete(rep, util.ConvertToUint(id), updatedByID, time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to complete visit with ID %s: %v", id, err)
 		return nil, err
 	}
 	return visit, nil
@@ -1,10 +1,15 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"testing"
 	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/models"
 	"vet-clinic/models/dto"
+	"vet-clinic/repository"
 	"vet-clinic/test"
 )
 
@@ -12,7 +17,7 @@ func TestFindVisitByID_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.Get("1")
+	result, err := s.Get(context.Background(), "1")
 
 	assert.Equal(t, uint(1), result.ID)
 	assert.NoError(t, err)
@@ -23,7 +28,7 @@ func TestFindVisitByID_IdNotNumeric(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.Get("ABCD")
+	result, err := s.Get(context.Background(), "ABCD")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -33,7 +38,7 @@ func TestFindVisitByID_EntityNotFound(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.Get("9999")
+	result, err := s.Get(context.Background(), "9999")
 
 	assert.Nil(t, result)
 	assert.Error(t, err, "failed to fetch data")
@@ -43,20 +48,91 @@ func TestFindAllVisits_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.GetAll()
+	result, err := s.GetAll(context.Background(), false)
 
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
 }
 
+func TestFindAllVisits_ContextCanceled(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewVisitService(cont)
+	result, err := s.GetAll(ctx, false)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestUpdateVisit_ContextDeadlineExceeded(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	s := NewVisitService(cont)
+	result, err := s.Update(ctx, createVisitForCreate(), "1")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	visit, _ := s.Get(context.Background(), "1")
+	assert.Equal(t, "Вакцинация", visit.Info)
+}
+
+func TestFindAllVisits_StatementDeadlineExceeded(t *testing.T) {
+	cont := test.PrepareForDeadlineTest(time.Nanosecond)
+
+	s := NewVisitService(cont)
+	result, err := s.GetAll(context.Background(), false)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var count int
+	err = cont.Repository().Raw("SELECT COUNT(*) FROM visit_master").Scan(&count).Error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFindAllVisits_Tracing(t *testing.T) {
+	cont, recorder := test.PrepareForTracingTest(t)
+
+	s := NewVisitService(cont)
+	_, err := s.GetAll(context.Background(), false)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	var serviceSpan sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		if span.Name() == "VisitService.GetAll" {
+			serviceSpan = span
+		}
+	}
+	assert.NotNil(t, serviceSpan)
+
+	queries := 0
+	for _, span := range spans {
+		if span.Name() == "gorm.query" {
+			assert.Equal(t, serviceSpan.SpanContext().TraceID(), span.SpanContext().TraceID())
+			queries++
+		}
+	}
+	// The visits query, the preloads of its five associations and the price history of the services.
+	assert.Equal(t, 7, queries)
+}
+
 func TestCreateVisit_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
 	visitDto := createVisitForCreate()
-	_, err := s.Create(visitDto)
+	_, err := s.Create(context.Background(), visitDto)
 
-	result, _ := s.Get("2")
+	result, _ := s.Get(context.Background(), "2")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -74,9 +150,9 @@ func TestUpdateVisit_Success(t *testing.T) {
 
 	s := NewVisitService(cont)
 	visitDto := createVisitForCreate()
-	_, err := s.Update(visitDto, "1")
+	_, err := s.Update(context.Background(), visitDto, "1")
 
-	result, _ := s.Get("1")
+	result, _ := s.Get(context.Background(), "1")
 
 	assert.NotEmpty(t, result)
 	assert.Empty(t, err)
@@ -88,11 +164,38 @@ func TestUpdateVisit_Success(t *testing.T) {
 	assert.Equal(t, visitDto.LastUpdatedByID, result.LastUpdatedByID)
 }
 
+func TestUpdateVisit_Precondition(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	before, _ := s.Get(context.Background(), "1")
+	ctx := repository.WithPrecondition(context.Background(), before, before.Version())
+	result, err := s.Update(ctx, createVisitForCreate(), "1")
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, before.ETag(), result.ETag())
+}
+
+func TestUpdateVisit_PreconditionFailed(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	before, _ := s.Get(context.Background(), "1")
+	ctx := repository.WithPrecondition(context.Background(), before, before.Version())
+	_, err := s.Update(context.Background(), createVisitForCreate(), "1")
+	assert.NoError(t, err)
+
+	result, err := s.Update(ctx, createVisitForCreate(), "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, apperror.NewPreconditionFailed(), err)
+}
+
 func TestUpdateVisit_NotEntity(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.Update(createVisitForCreate(), "99")
+	result, err := s.Update(context.Background(), createVisitForCreate(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -102,9 +205,9 @@ func TestDeleteVisit_Success(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	data, _ := s.Get("1")
+	data, _ := s.Get(context.Background(), "1")
 
-	result, err := s.Delete("1")
+	result, err := s.Delete(context.Background(), "1")
 
 	assert.Equal(t, data, result)
 	assert.Empty(t, err)
@@ -114,7 +217,7 @@ func TestDeleteVisit_Error(t *testing.T) {
 	cont := test.PrepareForServiceTest()
 
 	s := NewVisitService(cont)
-	result, err := s.Delete("99")
+	result, err := s.Delete(context.Background(), "99")
 
 	assert.Nil(t, result)
 	assert.Equal(t, "record not found", err.Error())
@@ -131,3 +234,89 @@ func createVisitForCreate() *dto.VisitDto {
 		LastUpdatedByID: 1,
 	}
 }
+
+func TestCompleteVisit_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	result, err := s.Complete(context.Background(), "1", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VisitCompleted, result.Status)
+
+	products := NewProductService(cont)
+	vaccines, _ := products.GetBatches(context.Background(), "1")
+	syringes, _ := products.GetBatches(context.Background(), "2")
+	assert.Equal(t, float64(19), vaccines[0].Quantity)
+	assert.Equal(t, float64(199), syringes[0].Quantity)
+}
+
+func TestCompleteVisit_AlreadyCompleted(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	_, _ = s.Complete(context.Background(), "1", 1)
+	result, err := s.Complete(context.Background(), "1", 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "only scheduled visits can be completed", err.Error())
+
+	vaccines, _ := NewProductService(cont).GetBatches(context.Background(), "1")
+	assert.Equal(t, float64(19), vaccines[0].Quantity)
+}
+
+func TestCompleteVisit_OutOfStock(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 7
+	_, _ = s.Create(context.Background(), visitDto)
+	result, err := s.Complete(context.Background(), "2", 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "not enough stock of Гель для УЗИ", err.Error())
+}
+
+func TestUpdateVisit_CompletedStatus(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewVisitService(cont)
+	visitDto := createVisitForCreate()
+	visitDto.Status = models.VisitCompleted
+	result, err := s.Update(context.Background(), visitDto, "1")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the visit can be completed only by the completion, which cannot be undone", err.Error())
+}
+
+func TestCreateVisit_ArchivedService(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewServiceService(cont).Update(context.Background(),
+		&dto.ServiceDto{Name: "Глюкометрия", Price: 400, Archived: true, CategoryID: 2}, "4")
+
+	s := NewVisitService(cont)
+	result, err := s.Create(context.Background(), createVisitForCreate())
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the service is archived", err.Error())
+}
+
+func TestCreateVisit_ProfessionRequired(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	_, _ = NewServiceService(cont).Update(context.Background(),
+		&dto.ServiceDto{Name: "Глюкометрия", Price: 400, Profession: "Кардиолог", CategoryID: 2}, "4")
+
+	s := NewVisitService(cont)
+	result, err := s.Create(context.Background(), createVisitForCreate())
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the doctor does not have the profession required by the service", err.Error())
+
+	visitDto := createVisitForCreate()
+	visitDto.ServiceID = 2
+	_, err = s.Create(context.Background(), visitDto)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"vet-clinic/apperror"
+	"vet-clinic/container"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/tracing"
+	"vet-clinic/util"
+)
+
+type WaitlistService struct {
+	container container.Container
+}
+
+// NewWaitlistService is constructor.
+func NewWaitlistService(container container.Container) *WaitlistService {
+	return &WaitlistService{container: container}
+}
+
+// Get returns waitlist entry full matched given waitlist entry ID.
+func (s *WaitlistService) Get(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.Get")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist entry ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := &models.WaitlistEntry{}
+	var err error
+
+	if entry, err = entry.Get(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist entry with ID %s: %v", id, err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetAll returns a slice of the waitlist entries, optionally only with the given status.
+func (s *WaitlistService) GetAll(ctx context.Context, status string) ([]*models.WaitlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.GetAll")
+	defer span.End()
+
+	switch status {
+	case "", models.WaitlistWaiting, models.WaitlistBooked, models.WaitlistCancelled:
+	default:
+		return nil, apperror.New(http.StatusBadRequest, apperror.BadRequest, fmt.Sprintf("invalid status: %s", status))
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.WaitlistEntry{}
+	var entries []*models.WaitlistEntry
+	var err error
+
+	if entries, err = model.GetAll(rep, status); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist entries: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Create persists this waitlist entry data.
+func (s *WaitlistService) Create(ctx context.Context, dto *dto.WaitlistEntryDto) (*models.WaitlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.Create")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := &models.WaitlistEntry{}
+	var err error
+
+	if entry, err = dto.ToModel().Create(rep); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to create waitlist entry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Update updates this waitlist entry data.
+func (s *WaitlistService) Update(ctx context.Context, dto *dto.WaitlistEntryDto, id string) (*models.WaitlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.Update")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist entry ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := &models.WaitlistEntry{}
+	var err error
+
+	if entry, err = dto.ToModel().Update(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to update waitlist entry with ID %s: %v", id, err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Delete deletes this waitlist entry data.
+func (s *WaitlistService) Delete(ctx context.Context, id string) (*models.WaitlistEntry, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.Delete")
+	defer span.End()
+
+	if !util.IsNumeric(id) {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist entry ID: %s", id)
+		return nil, apperror.NewInvalidID(id)
+	}
+
+	rep := s.container.Repository().WithContext(ctx)
+	entry := &models.WaitlistEntry{}
+	var err error
+
+	if entry, err = entry.Delete(rep, util.ConvertToUint(id)); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to delete waitlist entry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetMatches returns the upcoming slots freed by the cancelled or moved visits which are still free,
+// each with a waiting entry it suits.
+func (s *WaitlistService) GetMatches(ctx context.Context) ([]*models.WaitlistMatch, error) {
+	ctx, span := tracing.Start(ctx, "WaitlistService.GetMatches")
+	defer span.End()
+
+	rep := s.container.Repository().WithContext(ctx)
+	model := &models.WaitlistMatch{}
+	var matches []*models.WaitlistMatch
+	var err error
+
+	if matches, err = model.GetOpen(rep, time.Now()); err != nil {
+		s.container.Logger().WithContext(ctx).Errorf("Failed to fetch waitlist matches: %v", err)
+		return nil, err
+	}
+	return matches, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vet-clinic/models"
+	"vet-clinic/models/dto"
+	"vet-clinic/test"
+)
+
+func TestCreateWaitlistEntry_Success(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewWaitlistService(cont)
+	result, err := s.Create(context.Background(), createWaitlistEntryForCreate(tomorrowAt(9)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.WaitlistWaiting, result.Status)
+	assert.Equal(t, uint(0), result.DoctorID)
+	if assert.Len(t, result.Windows, 1) {
+		assert.Equal(t, tomorrowAt(12), result.Windows[0].EndTime.In(time.Local))
+	}
+}
+
+func TestCreateWaitlistEntry_InvalidPet(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	data := createWaitlistEntryForCreate(tomorrowAt(9))
+	data.PetID = 2
+
+	s := NewWaitlistService(cont)
+	result, err := s.Create(context.Background(), data)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "petId refers to a record which does not exist: record not found", err.Error())
+}
+
+func TestCreateWaitlistEntry_WindowEndsBeforeStart(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	data := createWaitlistEntryForCreate(tomorrowAt(9))
+	data.Windows[0].EndTime = tomorrowAt(8)
+
+	s := NewWaitlistService(cont)
+	result, err := s.Create(context.Background(), data)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "the time window must end after it starts", err.Error())
+}
+
+func TestFindAllWaitlistEntries_InvalidStatus(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	s := NewWaitlistService(cont)
+	result, err := s.GetAll(context.Background(), "done")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid status: done", err.Error())
+}
+
+func TestGetWaitlistMatches_DeletedVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10)))
+
+	s := NewWaitlistService(cont)
+	_, _ = s.Create(context.Background(), createWaitlistEntryForCreate(tomorrowAt(9)))
+	result, _ := s.GetMatches(context.Background())
+	assert.Empty(t, result)
+
+	_, _ = visits.Delete(context.Background(), "2")
+	result, err := s.GetMatches(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, uint(1), result[0].EntryID)
+		assert.Equal(t, uint(2), result[0].VisitID)
+		assert.Equal(t, uint(1), result[0].DoctorID)
+		assert.Equal(t, tomorrowAt(10), result[0].StartTime.In(time.Local))
+		assert.Equal(t, "Имя", result[0].Entry.Client.Name)
+	}
+}
+
+func TestGetWaitlistMatches_MovedVisit(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	visitDto := createVisitForWaitlist(tomorrowAt(10))
+	_, _ = visits.Create(context.Background(), visitDto)
+
+	s := NewWaitlistService(cont)
+	_, _ = s.Create(context.Background(), createWaitlistEntryForCreate(tomorrowAt(9)))
+
+	visitDto.Status = models.VisitScheduled
+	_, _ = visits.Update(context.Background(), visitDto, "2")
+	result, _ := s.GetMatches(context.Background())
+	assert.Empty(t, result)
+
+	visitDto.DateTime = tomorrowAt(15)
+	_, _ = visits.Update(context.Background(), visitDto, "2")
+	result, err := s.GetMatches(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10)))
+	result, _ = s.GetMatches(context.Background())
+	assert.Empty(t, result)
+}
+
+func TestGetWaitlistMatches_SlotTakenPartly(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10)))
+
+	s := NewWaitlistService(cont)
+	_, _ = s.Create(context.Background(), createWaitlistEntryForCreate(tomorrowAt(9)))
+	_, _ = visits.Delete(context.Background(), "2")
+	result, _ := s.GetMatches(context.Background())
+	assert.Len(t, result, 1)
+
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10).Add(-15*time.Minute)))
+	result, err := s.GetMatches(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetWaitlistMatches_NotSuitable(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10)))
+
+	s := NewWaitlistService(cont)
+	_, _ = s.Create(context.Background(), createWaitlistEntryForCreate(tomorrowAt(13)))
+	other := createWaitlistEntryForCreate(tomorrowAt(9))
+	other.ServiceID = 6
+	_, _ = s.Create(context.Background(), other)
+
+	_, _ = visits.Delete(context.Background(), "2")
+	result, err := s.GetMatches(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestGetWaitlistMatches_EntryBooked(t *testing.T) {
+	cont := test.PrepareForServiceTest()
+
+	visits := NewVisitService(cont)
+	_, _ = visits.Create(context.Background(), createVisitForWaitlist(tomorrowAt(10)))
+
+	s := NewWaitlistService(cont)
+	data := createWaitlistEntryForCreate(tomorrowAt(9))
+	_, _ = s.Create(context.Background(), data)
+	_, _ = visits.Delete(context.Background(), "2")
+
+	data.Status = models.WaitlistBooked
+	_, _ = s.Update(context.Background(), data, "1")
+	result, err := s.GetMatches(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func tomorrowAt(hour int) time.Time {
+	day := time.Now().AddDate(0, 0, 1)
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+}
+
+func createVisitForWaitlist(start time.Time) *dto.VisitDto {
+	visit := createVisitForCreate()
+	visit.DateTime = start
+	visit.ServiceID = 1
+	return visit
+}
+
+func createWaitlistEntryForCreate(from time.Time) *dto.WaitlistEntryDto {
+	return &dto.WaitlistEntryDto{
+		ClientID:        1,
+		PetID:           1,
+		ServiceID:       1,
+		Comment:         "Позвонить, если освободится время",
+		Windows:         []*dto.WaitlistWindowDto{{StartTime: from, EndTime: from.Add(3 * time.Hour)}},
+		LastUpdatedByID: 1,
+	}
+}
@@ -16,6 +16,8 @@ const (
 	Auth = "Authorization"
 	// User is the key of account data in the session.
 	User = "User"
+	// ClientAccount is the key of the portal account data of a client in the session.
+	ClientAccount = "ClientAccount"
 )
 
 // Session represents an interface for accessing the session within the application.
@@ -29,6 +31,8 @@ type Session interface {
 	GetValue(c echo.Context, key string) string
 	SetUser(c echo.Context, user *models.User) error
 	GetUser(c echo.Context) *models.User
+	SetClientAccount(c echo.Context, account *models.ClientAccount) error
+	GetClientAccount(c echo.Context) *models.ClientAccount
 }
 
 type GorillaSession struct {
@@ -129,3 +133,18 @@ func (s *GorillaSession) GetUser(c echo.Context) *models.User {
 	}
 	return nil
 }
+
+// SetClientAccount saves the portal account of a client in the session.
+func (s *GorillaSession) SetClientAccount(c echo.Context, account *models.ClientAccount) error {
+	return s.SetValue(c, ClientAccount, account)
+}
+
+// GetClientAccount returns the portal account of a client saved in the session.
+func (s *GorillaSession) GetClientAccount(c echo.Context) *models.ClientAccount {
+	if v := s.GetValue(c, ClientAccount); v != "" {
+		a := &models.ClientAccount{}
+		_ = json.Unmarshal([]byte(v), a)
+		return a
+	}
+	return nil
+}
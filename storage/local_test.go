@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorageSave_Open(t *testing.T) {
+	s := NewLocalStorage(t.TempDir())
+
+	err := s.Save(context.Background(), "2024/01/report", strings.NewReader("ЭхоКГ"))
+	assert.NoError(t, err)
+
+	file, err := s.Open(context.Background(), "2024/01/report")
+	if assert.NoError(t, err) {
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "ЭхоКГ", string(content))
+	}
+}
+
+func TestLocalStorageDelete_Success(t *testing.T) {
+	s := NewLocalStorage(t.TempDir())
+	_ = s.Save(context.Background(), "report", strings.NewReader("ЭхоКГ"))
+
+	assert.NoError(t, s.Delete(context.Background(), "report"))
+	assert.NoError(t, s.Delete(context.Background(), "report"))
+
+	_, err := s.Open(context.Background(), "report")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	s := NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", "../report", "2024/../../report", "/report", "2024//report"} {
+		assert.ErrorIs(t, s.Save(context.Background(), key, strings.NewReader("")), ErrInvalidKey, key)
+	}
+}
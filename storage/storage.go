@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"vet-clinic/config"
+	"vet-clinic/logging"
+)
+
+// ErrInvalidKey is returned for a key which would refer to a file outside of the storage.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage keeps the contents of the files attached to the records.
+// The files are identified by keys, which are relative slash-separated paths, e.g. "2024/01/5f0c...".
+type Storage interface {
+	// Save writes the content to the file with the given key, which is replaced if it exists.
+	Save(ctx context.Context, key string, content io.Reader) error
+	// Open returns the content of the file with the given key. The caller must close it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the file with the given key. It does nothing if the file does not exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage creates the storage selected by the configuration.
+// The unknown backends fall back to the local storage.
+func NewStorage(logger logging.Logger, conf *config.Config) Storage {
+	if conf.Attachment.Storage != "local" {
+		logger.Warnf("unknown storage %q for attachments, use local storage", conf.Attachment.Storage)
+	}
+	logger.Infof("use local storage in %s for attachments", conf.Attachment.Path)
+	return NewLocalStorage(conf.Attachment.Path)
+}
@@ -0,0 +1,17 @@
+package test
+
+import "context"
+
+// FakeCaptchaVerifier accepts only the given token without calling the captcha provider.
+type FakeCaptchaVerifier struct {
+	Token string
+	Err   error
+}
+
+// Verify returns true if the token is the accepted one, or the configured error.
+func (v *FakeCaptchaVerifier) Verify(_ context.Context, token, _ string) (bool, error) {
+	if v.Err != nil {
+		return false, v.Err
+	}
+	return token == v.Token, nil
+}
@@ -1,15 +1,26 @@
 package test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/go-playground/validator"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	gormLogger "gorm.io/gorm/logger"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"testing"
+	"time"
 	"vet-clinic/config"
 	"vet-clinic/container"
 	"vet-clinic/logging"
@@ -39,6 +50,24 @@ func PrepareForControllerTest() (*echo.Echo, container.Container) {
 	return e, cont
 }
 
+// PrepareForLoggingTest func prepares the controllers for testing with the entries logged at the info level
+// and above recorded by the returned observer.
+func PrepareForLoggingTest() (*echo.Echo, container.Container, *observer.ObservedLogs) {
+	e := echo.New()
+	e.Validator = validate.NewValidator(validator.New())
+
+	conf := createConfig()
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := logging.NewLogger(zap.New(core).Sugar(), &gormLogger.Config{})
+	cont := initContainer(conf, logger)
+
+	middleware.Init(e, cont)
+
+	migration.CreateTables(cont)
+	migration.InitMasterData(cont)
+	return e, cont, logs
+}
+
 // PrepareForServiceTest func prepares the services for testing.
 func PrepareForServiceTest() container.Container {
 	conf := createConfig()
@@ -51,12 +80,67 @@ func PrepareForServiceTest() container.Container {
 	return cont
 }
 
+// PrepareForTracingTest func prepares the services for testing with the tracing enabled.
+// The finished spans are recorded by the returned span recorder. The previous tracer provider
+// is restored when the test finishes, so the spans do not leak into other tests.
+func PrepareForTracingTest(t testing.TB) (container.Container, *tracetest.SpanRecorder) {
+	conf := createConfig()
+	conf.Tracing.Enabled = true
+	logger := initTestLogger(false)
+	cont := initContainer(conf, logger)
+
+	migration.CreateTables(cont)
+	migration.InitMasterData(cont)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return cont, recorder
+}
+
+// PrepareForDeadlineTest func prepares the services for testing with the given deadline of each database statement.
+func PrepareForDeadlineTest(timeout time.Duration) container.Container {
+	// The master data is created without the deadline, the in-memory database is shared by the containers.
+	cont := PrepareForServiceTest()
+
+	conf := createConfig()
+	conf.Database.RequestTimeout = timeout
+	return initContainer(conf, cont.Logger())
+}
+
 func createConfig() *config.Config {
 	conf := &config.Config{}
 	conf.Database.Dialect = "sqlite3"
 	conf.Database.Host = "file::memory:?cache=shared"
 	conf.Database.Migration = true
 	conf.Extension.MasterGenerator = true
+	conf.Portal.OneTimeCodeTTL = 10 * time.Minute
+	conf.Portal.OneTimeCodeAttempts = 5
+	conf.Booking.SlotDuration = 30 * time.Minute
+	conf.Booking.DayStart = 9 * time.Hour
+	conf.Booking.DayEnd = 18 * time.Hour
+	conf.Booking.Horizon = 14 * 24 * time.Hour
+	conf.Lead.DefaultAssigneeID = 1
+	conf.Lead.MinFillTime = 3 * time.Second
+	conf.Lead.DuplicateWindow = 24 * time.Hour
+	conf.Lead.FormSecret = "test-form-secret"
+	conf.Lead.FormTokenTTL = 2 * time.Hour
+	conf.Lead.Captcha.Enabled = false
+	conf.Attachment.Storage = "local"
+	conf.Attachment.Path = filepath.Join(os.TempDir(), "vet-clinic-test-attachments")
+	conf.Attachment.MaxSize = 1024 * 1024
+	conf.Attachment.AllowedTypes = []string{"application/pdf", "image/jpeg", "image/png", "text/plain"}
+	conf.RateLimit.Enabled = false
+	conf.RateLimit.Store = "memory"
+	conf.RateLimit.Groups = map[string]config.RateLimitRule{
+		"auth":   {Limit: 10, Period: time.Minute},
+		"public": {Limit: 5, Period: time.Minute},
+	}
 
 	return conf
 }
@@ -98,6 +182,26 @@ func NewJSONRequest(method string, target string, param interface{}) *http.Reque
 	return req
 }
 
+// NewMultipartRequest func creates a new request which uploads the file with the given name and content along with the form fields.
+func NewMultipartRequest(method string, target string, fileName string, content string,
+	fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
+	}
+	if fileName != "" {
+		part, _ := writer.CreateFormFile("file", fileName)
+		_, _ = part.Write([]byte(content))
+	}
+	_ = writer.Close()
+
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+	req.Header.Add("Accept", "application/json")
+	return req
+}
+
 // LoginUser saves the user in the session.
 func LoginUser(e *echo.Echo, container container.Container,
 	r *http.Request, w http.ResponseWriter, user *models.User) {
@@ -105,6 +209,13 @@ func LoginUser(e *echo.Echo, container container.Container,
 	_ = container.Session().SetUser(ctx, user)
 }
 
+// LoginClient saves the portal account of a client in the session.
+func LoginClient(e *echo.Echo, container container.Container,
+	r *http.Request, w http.ResponseWriter, account *models.ClientAccount) {
+	ctx := e.NewContext(r, w)
+	_ = container.Session().SetClientAccount(ctx, account)
+}
+
 // SetParam sets the parameter in the URI.
 func SetParam(s string, param string) string {
 	return strings.Replace(s, ":id", param, 1)
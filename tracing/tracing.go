@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"vet-clinic/config"
+	"vet-clinic/logging"
+)
+
+// TracerName is the instrumentation name of the spans created by this application.
+const TracerName = "vet-clinic"
+
+const (
+	// OTLP exports spans to an OpenTelemetry collector using OTLP over HTTP.
+	OTLP = "otlp"
+	// STDOUT writes spans to the standard output.
+	STDOUT = "stdout"
+	// FILE writes spans to the file specified by the configuration.
+	FILE = "file"
+)
+
+// Init configures the global tracer provider and propagator according to the configuration.
+// It returns a function that flushes the remaining spans and stops the exporter.
+func Init(conf *config.Config, logger logging.Logger) func(ctx context.Context) error {
+	if !conf.Tracing.Enabled {
+		return func(context.Context) error { return nil }
+	}
+
+	exporter, err := newExporter(conf)
+	if err != nil {
+		logger.Errorf("Failed to create trace exporter: %v", err)
+		os.Exit(config.ErrExitStatus)
+	}
+
+	serviceName := conf.Tracing.ServiceName
+	if serviceName == "" {
+		serviceName = TracerName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.Tracing.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+	logger.Infof("Tracing enabled, exporter: %s, sample ratio: %v", conf.Tracing.Exporter, conf.Tracing.SampleRatio)
+
+	return provider.Shutdown
+}
+
+func newExporter(conf *config.Config) (sdktrace.SpanExporter, error) {
+	switch conf.Tracing.Exporter {
+	case OTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Tracing.Endpoint)}
+		if conf.Tracing.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	case STDOUT:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case FILE:
+		file, err := os.OpenFile(conf.Tracing.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, errors.New("trace exporter configuration not found")
+	}
+}
+
+// Start creates a span and a context containing the newly-created span.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
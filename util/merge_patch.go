@@ -0,0 +1,48 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// MergePatchMIMEType is the media type of JSON Merge Patch documents.
+const MergePatchMIMEType = "application/merge-patch+json"
+
+// ErrInvalidMergePatch is returned if a merge patch is not a JSON object.
+var ErrInvalidMergePatch = errors.New("merge patch must be a JSON object")
+
+// ApplyMergePatch applies the JSON Merge Patch (RFC 7396) to the struct pointed to by target.
+// The members of the patch overwrite the fields with the same JSON name, a null member resets the field
+// to its zero value and the fields which are absent in the patch are left unchanged.
+// It returns the names of the struct fields which are present in the patch.
+func ApplyMergePatch(target interface{}, patch []byte) ([]string, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return nil, ErrInvalidMergePatch
+	}
+	if err := json.Unmarshal(patch, target); err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(target).Elem()
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" {
+			name = field.Name
+		}
+		member, ok := members[name]
+		if name == "-" || !ok {
+			continue
+		}
+		if bytes.Equal(member, []byte("null")) {
+			v.Field(i).Set(reflect.Zero(field.Type))
+		}
+		fields = append(fields, field.Name)
+	}
+	return fields, nil
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mergePatchTarget struct {
+	Name   string `json:"name"`
+	Phone  string `json:"phone"`
+	Active bool   `json:"active"`
+	IDs    []uint `json:"ids"`
+	Secret string `json:"-"`
+}
+
+func TestApplyMergePatch_Success(t *testing.T) {
+	target := &mergePatchTarget{Name: "Name", Phone: "+79876543210", Active: true, IDs: []uint{1, 2}, Secret: "Secret"}
+
+	fields, err := ApplyMergePatch(target, []byte(`{"phone":null,"ids":[3],"Secret":"X","unknown":1}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Phone", "IDs"}, fields)
+	assert.Equal(t, &mergePatchTarget{Name: "Name", Active: true, IDs: []uint{3}, Secret: "Secret"}, target)
+}
+
+func TestApplyMergePatch_NotObject(t *testing.T) {
+	target := &mergePatchTarget{Name: "Name"}
+
+	_, err := ApplyMergePatch(target, []byte(`["name"]`))
+
+	assert.ErrorIs(t, err, ErrInvalidMergePatch)
+	assert.Equal(t, &mergePatchTarget{Name: "Name"}, target)
+}
@@ -1,6 +1,10 @@
 package util
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
 
 // IsNumeric judges whether given string is numeric or not.
 func IsNumeric(number string) bool {
@@ -21,3 +25,46 @@ func ConvertToInt(number string) int {
 func ConvertToUint(number string) uint {
 	return uint(ConvertToInt(number))
 }
+
+// NormalizePhone returns the digits of given phone number, so that differently formatted numbers can be compared.
+// The Russian trunk prefix 8 of an 11-digit number is replaced with the country code 7.
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if len(digits) == 11 && digits[0] == '8' {
+		return "7" + digits[1:]
+	}
+	return digits
+}
+
+// NormalizeName returns given name in lower case without surrounding spaces and with 'ё' replaced with 'е'.
+func NormalizeName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "ё", "е")
+}
+
+// Levenshtein returns the edit distance between given strings in characters.
+func Levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
@@ -30,3 +30,22 @@ func TestConvertToUint_Number(t *testing.T) {
 	result := ConvertToUint("123")
 	assert.Exactly(t, uint(123), result)
 }
+
+func TestNormalizePhone(t *testing.T) {
+	assert.Equal(t, "79876543210", NormalizePhone("+7 (987) 654-32-10"))
+	assert.Equal(t, "79876543210", NormalizePhone("8 987 654 32 10"))
+	assert.Equal(t, "12025550123", NormalizePhone("+1-202-555-0123"))
+	assert.Equal(t, "", NormalizePhone("none"))
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "семенов", NormalizeName(" Сёменов "))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, Levenshtein("Иванов", "Иванов"))
+	assert.Equal(t, 1, Levenshtein("Иванов", "Иваново"))
+	assert.Equal(t, 1, Levenshtein("Иванов", "Ивонов"))
+	assert.Equal(t, 2, Levenshtein("Иванова", "Ивонов"))
+	assert.Equal(t, 6, Levenshtein("", "Иванов"))
+}
@@ -0,0 +1,221 @@
+package validate
+
+import (
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator"
+	"golang.org/x/text/language"
+	"reflect"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// invalidKey is the key of the message used for the tags without their own translation.
+	invalidKey = "invalid"
+	// characterKey is the key of the plural form of the word "character".
+	characterKey = "character"
+	// itemKey is the key of the plural form of the word "item".
+	itemKey = "item"
+	// numberSuffix is appended to the key of the message for the size of a number rather than of a length.
+	numberSuffix = "-number"
+	// itemsSuffix is appended to the key of the message for the size of a list rather than of a string.
+	itemsSuffix = "-items"
+)
+
+// messages defines the validation messages for each supported language.
+// {0} is replaced with the field name, {1} with the parameter of the tag.
+var messages = map[string]map[string]string{
+	"en": {
+		invalidKey:     "{0} is invalid",
+		"required":     "{0} is a required field",
+		"min":          "{0} must be at least {1} in length",
+		"max":          "{0} must be a maximum of {1} in length",
+		"email":        "{0} must be a valid email address",
+		"e164":         "{0} must be a valid E.164 formatted phone number",
+		"min-number":   "{0} must be {1} or greater",
+		"max-number":   "{0} must be {1} or less",
+		"gt-number":    "{0} must be greater than {1}",
+		"lt-number":    "{0} must be less than {1}",
+		"min-items":    "{0} must contain at least {1}",
+		"max-items":    "{0} must contain a maximum of {1}",
+		"eqfield":      "{0} must be equal to {1}",
+		"nefield":      "{0} cannot be equal to {1}",
+		"gtfield":      "{0} must be greater than {1}",
+		"gtefield":     "{0} must be greater than or equal to {1}",
+		"ltfield":      "{0} must be less than {1}",
+		"ltefield":     "{0} must be less than or equal to {1}",
+		"oneof":        "{0} must be one of [{1}]",
+		"rualpha":      "{0} can only contain Russian and Latin letters",
+		"rualphanum":   "{0} can only contain Russian and Latin letters and digits",
+		"ruprintascii": "{0} can only contain Russian letters, Latin letters, digits, spaces and punctuation",
+		"username":     "{0} must start with a Latin letter and can only contain Latin letters, digits and the symbols _ . -",
+		"password":     "{0} must contain a lowercase and an uppercase Latin letter, a digit and a punctuation symbol",
+		"clock":        "{0} must be a time of day in the format HH:MM",
+	},
+	"ru": {
+		invalidKey:     "{0} имеет недопустимое значение",
+		"required":     "{0} является обязательным полем",
+		"min":          "{0} должно содержать минимум {1}",
+		"max":          "{0} должно содержать максимум {1}",
+		"email":        "{0} должно быть корректным адресом электронной почты",
+		"e164":         "{0} должно быть номером телефона в формате E.164",
+		"min-number":   "{0} должно быть не меньше {1}",
+		"max-number":   "{0} должно быть не больше {1}",
+		"gt-number":    "{0} должно быть больше {1}",
+		"lt-number":    "{0} должно быть меньше {1}",
+		"min-items":    "{0} должно содержать минимум {1}",
+		"max-items":    "{0} должно содержать максимум {1}",
+		"eqfield":      "{0} должно совпадать с {1}",
+		"nefield":      "{0} не должно совпадать с {1}",
+		"gtfield":      "{0} должно быть больше {1}",
+		"gtefield":     "{0} должно быть больше или равно {1}",
+		"ltfield":      "{0} должно быть меньше {1}",
+		"ltefield":     "{0} должно быть меньше или равно {1}",
+		"oneof":        "{0} должно быть одним из [{1}]",
+		"rualpha":      "{0} может содержать только русские и латинские буквы",
+		"rualphanum":   "{0} может содержать только русские и латинские буквы и цифры",
+		"ruprintascii": "{0} может содержать только русские и латинские буквы, цифры, пробелы и знаки препинания",
+		"username":     "{0} должно начинаться с латинской буквы и может содержать только латинские буквы, цифры и символы _ . -",
+		"password":     "{0} должно содержать строчную и заглавную латинские буквы, цифру и знак препинания",
+		"clock":        "{0} должно быть временем суток в формате ЧЧ:ММ",
+	},
+}
+
+// characters defines the plural forms of the word "character" for each supported language.
+var characters = map[string]map[locales.PluralRule]string{
+	"en": {
+		locales.PluralRuleOne:   "{0} character",
+		locales.PluralRuleOther: "{0} characters",
+	},
+	"ru": {
+		locales.PluralRuleOne:   "{0} символ",
+		locales.PluralRuleFew:   "{0} символа",
+		locales.PluralRuleMany:  "{0} символов",
+		locales.PluralRuleOther: "{0} символа",
+	},
+}
+
+// items defines the plural forms of the word "item" for each supported language.
+var items = map[string]map[locales.PluralRule]string{
+	"en": {
+		locales.PluralRuleOne:   "{0} item",
+		locales.PluralRuleOther: "{0} items",
+	},
+	"ru": {
+		locales.PluralRuleOne:   "{0} элемент",
+		locales.PluralRuleFew:   "{0} элемента",
+		locales.PluralRuleMany:  "{0} элементов",
+		locales.PluralRuleOther: "{0} элемента",
+	},
+}
+
+// newUniversalTranslator creates the translator of the validation messages. English is the fallback language.
+func newUniversalTranslator() *ut.UniversalTranslator {
+	uni := ut.New(en.New(), en.New(), ru.New())
+	for lang, texts := range messages {
+		trans, _ := uni.GetTranslator(lang)
+		for key, text := range texts {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+		for rule, text := range characters[lang] {
+			if err := trans.AddCardinal(characterKey, text, rule, false); err != nil {
+				panic(err)
+			}
+		}
+		for rule, text := range items[lang] {
+			if err := trans.AddCardinal(itemKey, text, rule, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+	if err := uni.VerifyTranslations(); err != nil {
+		panic(err)
+	}
+	return uni
+}
+
+// Translator returns the function which translates the validation errors
+// to the most preferred language of the given Accept-Language header.
+func (v *Validator) Translator(acceptLanguage string) func(fe validator.FieldError) string {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	langs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		langs = append(langs, base.String())
+	}
+	trans, _ := v.translator.FindTranslator(langs...)
+
+	return func(fe validator.FieldError) string {
+		return translate(trans, fe)
+	}
+}
+
+func translate(trans ut.Translator, fe validator.FieldError) string {
+	key, param := fe.Tag(), fe.Param()
+	switch key {
+	case "min", "max", "gt", "gte", "lt", "lte":
+		key, param = sizeMessage(trans, fe)
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		param = lowerFirst(param)
+	}
+
+	if msg, err := trans.T(key, fe.Field(), param); err == nil {
+		return msg
+	}
+	msg, _ := trans.T(invalidKey, fe.Field())
+	return msg
+}
+
+// sizeMessage returns the key and the parameter of the message for a size tag.
+// A number is compared with the parameter itself, while a string or a list is compared by its length,
+// which is a whole number, so the strict comparisons are given as the nearest inclusive bound,
+// e.g. gt=2 as "at least 3 characters".
+func sizeMessage(trans ut.Translator, fe validator.FieldError) (string, string) {
+	key, param := fe.Tag(), fe.Param()
+	switch fe.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch key {
+		case "gte":
+			key = "min"
+		case "lte":
+			key = "max"
+		}
+		return key + numberSuffix, param
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return key, param
+		}
+		switch key {
+		case "gt":
+			key, n = "min", n+1
+		case "gte":
+			key = "min"
+		case "lt":
+			key, n = "max", n-1
+		case "lte":
+			key = "max"
+		}
+		if fe.Kind() == reflect.String {
+			param, _ = trans.C(characterKey, float64(n), 0, strconv.Itoa(n))
+			return key, param
+		}
+		param, _ = trans.C(itemKey, float64(n), 0, strconv.Itoa(n))
+		return key + itemsSuffix, param
+	}
+	return key, param
+}
+
+// lowerFirst converts the name of a struct field to its JSON name, as all JSON names in this application are lower camel case.
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
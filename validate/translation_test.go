@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type translationTag struct {
+	Name     string `json:"name" validate:"required"`
+	Password string `json:"password" validate:"min=8"`
+	Login    string `json:"login" validate:"username|email"`
+}
+
+func translateAll(v *Validator, acceptLanguage string, i interface{}) []string {
+	translate := v.Translator(acceptLanguage)
+	var messages []string
+	for _, fe := range v.Validate(i).(validator.ValidationErrors) {
+		messages = append(messages, translate(fe))
+	}
+	return messages
+}
+
+func TestTranslator(t *testing.T) {
+	v := NewValidator(validator.New())
+	param := translationTag{Password: "pass", Login: "1"}
+
+	assert.Equal(t, []string{
+		"name is a required field",
+		"password must be at least 8 characters in length",
+		"login is invalid",
+	}, translateAll(v, "", param))
+
+	assert.Equal(t, []string{
+		"name является обязательным полем",
+		"password должно содержать минимум 8 символов",
+		"login имеет недопустимое значение",
+	}, translateAll(v, "ru-RU,ru;q=0.9", param))
+
+	assert.Equal(t, []string{
+		"name is a required field",
+		"password must be at least 8 characters in length",
+		"login is invalid",
+	}, translateAll(v, "de-DE,en;q=0.5,ru;q=0.3", param))
+}
+
+type pluralTag struct {
+	One  string `json:"one" validate:"min=21"`
+	Few  string `json:"few" validate:"max=2"`
+	Many string `json:"many" validate:"min=255"`
+}
+
+func TestTranslator_RuPlural(t *testing.T) {
+	v := NewValidator(validator.New())
+	param := pluralTag{Few: "abc"}
+
+	assert.Equal(t, []string{
+		"one должно содержать минимум 21 символ",
+		"few должно содержать максимум 2 символа",
+		"many должно содержать минимум 255 символов",
+	}, translateAll(v, "ru", param))
+}
+
+type sizeTag struct {
+	Price    float64  `json:"price" validate:"gt=0"`
+	Discount int      `json:"discount" validate:"lte=100"`
+	Weekday  int      `json:"weekday" validate:"min=0,max=6"`
+	Code     string   `json:"code" validate:"gt=2"`
+	Windows  []string `json:"windows" validate:"min=1"`
+}
+
+func TestTranslator_Size(t *testing.T) {
+	v := NewValidator(validator.New())
+	param := sizeTag{Discount: 150, Weekday: 7, Code: "ab"}
+
+	assert.Equal(t, []string{
+		"price must be greater than 0",
+		"discount must be 100 or less",
+		"weekday must be 6 or less",
+		"code must be at least 3 characters in length",
+		"windows must contain at least 1 item",
+	}, translateAll(v, "", param))
+
+	assert.Equal(t, []string{
+		"price должно быть больше 0",
+		"discount должно быть не больше 100",
+		"weekday должно быть не больше 6",
+		"code должно содержать минимум 3 символа",
+		"windows должно содержать минимум 1 элемент",
+	}, translateAll(v, "ru", param))
+}
+
+type fieldComparisonTag struct {
+	ValidFrom int `json:"validFrom"`
+	ValidTo   int `json:"validTo" validate:"gtefield=ValidFrom"`
+}
+
+func TestTranslator_FieldComparison(t *testing.T) {
+	v := NewValidator(validator.New())
+	param := fieldComparisonTag{ValidFrom: 2, ValidTo: 1}
+
+	assert.Equal(t, []string{"validTo must be greater than or equal to validFrom"}, translateAll(v, "", param))
+	assert.Equal(t, []string{"validTo должно быть больше или равно validFrom"}, translateAll(v, "ru", param))
+}
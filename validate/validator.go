@@ -1,8 +1,11 @@
 package validate
 
 import (
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator"
+	"reflect"
 	"regexp"
+	"strings"
 )
 
 const (
@@ -15,6 +18,7 @@ const (
 	containsUppercaseLetterRegexString = `[A-Z]`
 	containsDigitRegexString           = `\d`
 	containsSymbolRegexString          = "[ !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~]"
+	clockRegexString                   = `^([01][0-9]|2[0-3]):[0-5][0-9]$`
 )
 
 var (
@@ -27,6 +31,7 @@ var (
 	containsUppercaseLetterRegex = regexp.MustCompile(containsUppercaseLetterRegexString)
 	containsDigitRegex           = regexp.MustCompile(containsDigitRegexString)
 	containsSymbolRegex          = regexp.MustCompile(containsSymbolRegexString)
+	clockRegex                   = regexp.MustCompile(clockRegexString)
 )
 
 var customValidators = map[string]validator.Func{
@@ -35,10 +40,12 @@ var customValidators = map[string]validator.Func{
 	"ruprintascii": isRuPrintableASCII,
 	"username":     isUsername,
 	"password":     isPassword,
+	"clock":        isClock,
 }
 
 type Validator struct {
-	validator *validator.Validate
+	validator  *validator.Validate
+	translator *ut.UniversalTranslator
 }
 
 // NewValidator is constructor.
@@ -48,7 +55,20 @@ func NewValidator(validator *validator.Validate) *Validator {
 			panic(err)
 		}
 	}
-	return &Validator{validator: validator}
+	validator.RegisterTagNameFunc(jsonTagName)
+	return &Validator{validator: validator, translator: newUniversalTranslator()}
+}
+
+// jsonTagName returns the JSON name of the field, so validation errors refer to the fields as the clients see them.
+func jsonTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
 }
 
 func (v *Validator) Validate(i interface{}) error {
@@ -58,6 +78,14 @@ func (v *Validator) Validate(i interface{}) error {
 	return nil
 }
 
+// ValidatePartial validates only the given fields of the struct, e.g. the fields present in a merge patch.
+func (v *Validator) ValidatePartial(i interface{}, fields ...string) error {
+	if err := v.validator.StructPartial(i, fields...); err != nil {
+		return err
+	}
+	return nil
+}
+
 func isRuAlpha(fl validator.FieldLevel) bool {
 	return ruAlphaRegex.MatchString(fl.Field().String())
 }
@@ -84,3 +112,7 @@ func isPassword(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+func isClock(fl validator.FieldLevel) bool {
+	return clockRegex.MatchString(fl.Field().String())
+}
@@ -34,6 +34,10 @@ type passwordTag struct {
 	S string `validate:"password"`
 }
 
+type clockTag struct {
+	S string `validate:"clock"`
+}
+
 func TestValidator(t *testing.T) {
 	v := NewValidator(validator.New())
 	validationErrs := &validator.ValidationErrors{}
@@ -61,4 +65,10 @@ func TestValidator(t *testing.T) {
 	assert.ErrorAs(t, v.Validate(passwordTag{S: "Pass!"}), validationErrs)
 	assert.ErrorAs(t, v.Validate(passwordTag{S: "Pass1"}), validationErrs)
 	assert.ErrorAs(t, v.Validate(passwordTag{S: ruAlphasTestSting + "Pass1!"}), validationErrs)
+
+	assert.Empty(t, v.Validate(clockTag{S: "00:00"}))
+	assert.Empty(t, v.Validate(clockTag{S: "23:59"}))
+	assert.ErrorAs(t, v.Validate(clockTag{S: "24:00"}), validationErrs)
+	assert.ErrorAs(t, v.Validate(clockTag{S: "9:00"}), validationErrs)
+	assert.ErrorAs(t, v.Validate(clockTag{S: "09:60"}), validationErrs)
 }